@@ -0,0 +1,211 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ActivationPolicy is the Schema for the ActivationPolicies API. It configures how the activator
+// brings the InferencePools referencing it up from zero replicas.
+//
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=actpolicy
+// +kubebuilder:storageversion
+// +genclient
+type ActivationPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired activation behavior.
+	//
+	// +required
+	Spec ActivationPolicySpec `json:"spec,omitzero"`
+}
+
+// ActivationPolicyList contains a list of ActivationPolicies.
+//
+// +kubebuilder:object:root=true
+type ActivationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ActivationPolicy `json:"items"`
+}
+
+// ActivationPolicySpec defines the desired activation behavior.
+type ActivationPolicySpec struct {
+	// Placement holds scheduling hints applied to the target workload while the activator wakes it up
+	// from zero replicas, e.g. to land the first replica on cheaper spot accelerators.
+	//
+	// +optional
+	Placement *PlacementSpec `json:"placement,omitempty"`
+
+	// Group makes the InferencePools referencing the policy and matching its selector a pool group, brought
+	// up from zero together and scaled down to zero once the whole group is idle, e.g. a router pool and the
+	// model pool it routes to.
+	//
+	// +optional
+	Group *PoolGroupSpec `json:"group,omitempty"`
+
+	// Fallback lists alternate target workloads activated when the target workload of the InferencePools
+	// referencing the policy fails to activate for a reason retrying would not fix, e.g. a CPU deployment or a
+	// smaller GPU profile of the model.
+	//
+	// +optional
+	Fallback *FallbackSpec `json:"fallback,omitempty"`
+
+	// Priorities vary how long the requests held for a scale up from zero of the InferencePools referencing the
+	// policy wait with the priority of their InferenceObjective, e.g. to fail interactive requests fast while
+	// batch requests wait for the whole scale up.
+	//
+	// +optional
+	Priorities *PrioritiesSpec `json:"priorities,omitempty"`
+}
+
+// PrioritiesSpec configures the waits of the requests held for the scale up from zero of an InferencePool by
+// priority class.
+//
+// A request belongs to the first class whose minimum priority its InferenceObjective, as named by its
+// x-gateway-inference-objective header, reaches. Requests without an InferenceObjective have priority 0.
+// Requests of no class wait as long as the scale from zero grace period of the pool. A request whose wait
+// expires is failed as unavailable, for the gateway to fall back to another backend or the client to retry
+// later, while the scale up carries on for the requests of the other classes. The scale up itself lasts as
+// long as the longest grace period of the classes, if longer than the scale from zero grace period of the pool.
+type PrioritiesSpec struct {
+	// Classes are the priority classes of the requests, matched in order.
+	//
+	// +required
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	Classes []PriorityClass `json:"classes"`
+}
+
+// PriorityClass configures the waits of the requests of a priority class.
+type PriorityClass struct {
+	// Name is the name of the class, e.g. "interactive" or "batch".
+	//
+	// +required
+	Name string `json:"name"`
+
+	// MinPriority is the minimum priority of the InferenceObjectives of the requests of the class. Unset
+	// matches every request.
+	//
+	// +optional
+	MinPriority *int `json:"minPriority,omitempty"`
+
+	// GracePeriod is how long the requests of the class are held for the scale up from zero of the pool they
+	// trigger or join. Defaults to the scale from zero grace period of the pool.
+	//
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+
+	// QueueTimeout is how long the requests of the class arriving while the pool is already scaling up from
+	// zero wait for the scale up to be done. Defaults to the default scale from zero grace period, after which
+	// the requests are released to the pool whether it is ready or not.
+	//
+	// +optional
+	QueueTimeout *metav1.Duration `json:"queueTimeout,omitempty"`
+}
+
+// FallbackSpec configures the alternate target workloads of an InferencePool.
+//
+// When the target workload of the pool fails to activate for one of the given reasons, the activator scales
+// it back down and tries the fallback targets in order, the requests held being released on the first one
+// that becomes ready. A fallback target serves the pool until the pool is idle and scaled down to zero, the
+// next activation trying the target workload again. The pods of fallback targets must be selected by the
+// pool for the requests to reach them.
+type FallbackSpec struct {
+	// Targets are the alternate target workloads, tried in order.
+	//
+	// +required
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	Targets []FallbackTarget `json:"targets"`
+
+	// Reasons are the activation failure reasons of the target workload the fallback targets are tried for,
+	// among ImagePull, Scheduling, OOM, CrashLoop, ModelLoadTimeout, PodsNotCreated and Unknown. Defaults to
+	// ImagePull, Scheduling, OOM, CrashLoop and PodsNotCreated.
+	//
+	// +optional
+	// +listType=set
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// FallbackTarget references an alternate target workload in the namespace of the InferencePool.
+type FallbackTarget struct {
+	// APIVersion is the API version of the workload, e.g. "apps/v1".
+	//
+	// +required
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the kind of the workload, e.g. "Deployment".
+	//
+	// +required
+	Kind string `json:"kind"`
+
+	// Name is the name of the workload.
+	//
+	// +required
+	Name string `json:"name"`
+}
+
+// PoolGroupSpec selects the InferencePools activated and deactivated as a unit.
+//
+// Scaling up any pool of the group from zero scales up the other pools of the group from zero as well,
+// without waiting for them to become ready. A pool of the group is only scaled down once no pool of the
+// group received a request for the scale down delay of the group, as recorded by the idle clocks of their
+// activators.
+type PoolGroupSpec struct {
+	// Selector selects the InferencePools of the group by their labels, in the namespace of the policy.
+	//
+	// +required
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// ScaleDownDelay is how long every pool of the group must have received no request for before any pool
+	// of the group is scaled down to zero. Defaults to the scale down delay of each pool.
+	//
+	// +optional
+	ScaleDownDelay *metav1.Duration `json:"scaleDownDelay,omitempty"`
+}
+
+// PlacementSpec holds scheduling constraints overlaid on the pod template of the target workload.
+//
+// The overlay is applied with server-side apply under its own field manager before the workload is
+// scaled up from zero, and removed once the workload is scaled back down to zero, so that it never
+// causes a rollout of running replicas. As every replica started in between shares the overlay,
+// preferred rather than required affinities let regular autoscaling place subsequent replicas freely.
+type PlacementSpec struct {
+	// NodeSelector is merged into the node selector of the pod template.
+	//
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Affinity is merged into the affinity of the pod template.
+	//
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// Tolerations are added to the tolerations of the pod template.
+	//
+	// +optional
+	// +listType=atomic
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
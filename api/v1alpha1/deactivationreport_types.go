@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DeactivationReport is the Schema for the DeactivationReports API. An activator whose scale downs run in
+// dry-run mode records in it, named after its InferencePool, the last scale down to zero it would have
+// applied and the capacity it would have freed, for operators to review before enabling the scale downs.
+//
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=deactreport
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.status.target`
+// +kubebuilder:printcolumn:name="Replicas",type=integer,JSONPath=`.status.replicas`
+// +kubebuilder:printcolumn:name="Accelerators",type=integer,JSONPath=`.status.accelerators`
+// +kubebuilder:printcolumn:name="Last Report",type=date,JSONPath=`.status.lastReportTime`
+// +genclient
+type DeactivationReport struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Status holds the last scale down to zero the activator would have applied to the InferencePool.
+	//
+	// +optional
+	Status DeactivationReportStatus `json:"status,omitzero"`
+}
+
+// DeactivationReportList contains a list of DeactivationReports.
+//
+// +kubebuilder:object:root=true
+type DeactivationReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DeactivationReport `json:"items"`
+}
+
+// DeactivationReportStatus describes the last scale down to zero the activator would have applied.
+type DeactivationReportStatus struct {
+	// Target is the kind and name of the target workload of the InferencePool, e.g. Deployment/llama.
+	//
+	// +optional
+	Target string `json:"target,omitempty"`
+
+	// Replicas is the number of replicas of the target workload the scale down would have freed.
+	//
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Accelerators is the number of accelerators, e.g. GPUs, the scale down would have freed.
+	//
+	// +optional
+	Accelerators int64 `json:"accelerators,omitempty"`
+
+	// LastReportTime is when the InferencePool was last found idle for its scale down delay.
+	//
+	// +optional
+	LastReportTime metav1.Time `json:"lastReportTime,omitzero"`
+}
@@ -27,6 +27,9 @@ import (
 func main() {
 	// For adding out-of-tree plugins to the plugins registry, use the following:
 	// plugins.Register(my-out-of-tree-plugin-name, my-out-of-tree-plugin-factory-function)
+	//
+	// For reacting to activations programmatically, subscribe to their lifecycle events:
+	// requestcontrol.DefaultEventBus.Subscribe(my-buffered-activation-event-channel)
 
 	if err := runner.Run(ctrl.SetupSignalHandler()); err != nil {
 		os.Exit(1)
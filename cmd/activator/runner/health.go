@@ -28,12 +28,15 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/requestcontrol"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
 
 type healthServer struct {
-	logger                logr.Logger
-	datastore             datastore.Datastore
+	logger    logr.Logger
+	datastore datastore.Datastore
+	// soak fails every check once the soak test mode found an invariant violated. Optional.
+	soak                  *requestcontrol.Soak
 	isLeader              *atomic.Bool
 	leaderElectionEnabled bool
 }
@@ -44,6 +47,11 @@ const (
 )
 
 func (s *healthServer) Check(ctx context.Context, in *healthPb.HealthCheckRequest) (*healthPb.HealthCheckResponse, error) {
+	if s.soak != nil && !s.soak.Healthy() {
+		s.logger.V(logutil.DEFAULT).Info("gRPC health check not serving, soak invariants violated", "service", in.Service, "violations", s.soak.Violations())
+		return &healthPb.HealthCheckResponse{Status: healthPb.HealthCheckResponse_NOT_SERVING}, nil
+	}
+
 	isLive := s.datastore.PoolHasSynced()
 
 	// If leader election is disabled, use current logic: all checks are based on whether the pool has synced.
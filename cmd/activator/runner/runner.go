@@ -17,30 +17,45 @@ limitations under the License.
 package runner
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
+	"math"
+	"net/http"
 	"os"
+	"slices"
+	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/go-logr/logr"
 	uberzap "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 	healthPb "google.golang.org/grpc/health/grpc_health_v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/common"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 	"sigs.k8s.io/gateway-api-inference-extension/version"
 
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/attribution"
 	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/features"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
 	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/requestcontrol"
 	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/runnable"
 	runserver "github.com/llm-d-incubation/llm-d-activator/pkg/activator/server"
@@ -59,7 +74,61 @@ var (
 	certPath       = flag.String("cert-path", runserver.DefaultCertPath, "The path to the certificate for secure serving. The certificate and private key files "+
 		"are assumed to be named tls.crt and tls.key, respectively. If not set, and secureServing is enabled, "+
 		"then a self-signed certificate is used.")
-	haEnableLeaderElection = flag.Bool("ha-enable-leader-election", false, "Enables leader election for high availability. When enabled, readiness probes will only pass on the leader.")
+	pipelineMaxConcurrency  = flag.Int("pipeline-max-concurrency", runserver.DefaultPipelineMaxConcurrency, "Maximum number of requests admitted concurrently into the activation pipeline of a pool.")
+	breakerFailureThreshold = flag.Int("breaker-failure-threshold", runserver.DefaultBreakerFailureThreshold, "Number of consecutive activation failures after which the pool pipeline fails fast. Zero disables the circuit breaker.")
+	breakerCooldown         = flag.Duration("breaker-cooldown", runserver.DefaultBreakerCooldown, "Amount of time the pool pipeline fails fast once its circuit breaker opens.")
+	deactivationDryRun      = flag.Bool("deactivation-dry-run", false, "Report the scale downs the deactivator would perform on idle pools instead of applying them, once per idle period.")
+	dryRunReport            = flag.Bool("deactivation-dry-run-report", false, "Record the scale downs reported in dry-run mode in the DeactivationReport named after the pool as well. Requires the DeactivationReport CRD.")
+	benchmarkPassthrough    = flag.Bool("benchmark-passthrough", false, "Let every request through without activation, recording the decisions the activator would have made, to benchmark the gateway without the activation logic. Pools override it at runtime with the activator.llm-d.ai/benchmark-passthrough annotation.")
+	debugBypassHeader       = flag.Bool("debug-bypass-header", false, "Let the requests carrying the x-llmd-activator-bypass header through without activation check, to debug the data path. Any client may set that header unless the gateway strips it.")
+	recommendationWindow    = flag.Duration("recommendation-window", requestcontrol.DefaultRecommendationWindow, "Amount of traffic history right-sizing recommendations are computed from. Zero disables recommendations.")
+	batchPaths              = flag.String("batch-paths", "", "Comma separated path prefixes of long-running batch requests, which exempt the pool from scale down until they complete.")
+	batchHeader             = flag.String("batch-header", "", "Name of a request header marking long-running batch requests, which exempt the pool from scale down until they complete.")
+	batchCompletionBuffer   = flag.Duration("batch-completion-buffer", requestcontrol.DefaultBatchCompletionBuffer, "Amount of time the pool stays exempt from scale down after its last batch request completed.")
+	excludedPaths           = flag.String("excluded-paths", "", "Comma separated path prefixes of monitoring requests, e.g. health checks, which neither activate the pool nor keep it warm. They are rejected while the pool is scaled to zero.")
+	excludedHeader          = flag.String("excluded-header", "", "Name of a request header marking monitoring requests, which neither activate the pool nor keep it warm.")
+	excludedUserAgents      = flag.String("excluded-user-agents", "", "Comma separated substrings of the user agents of monitoring requests, e.g. kube-probe, which neither activate the pool nor keep it warm.")
+	attributionHeader       = flag.String("attribution-header", "", "Name of the request header carrying the API key or team that activations and accelerator time are attributed to. Values are reported by the first 16 hex digits of their SHA-256, never in clear. Empty disables attribution.")
+	attributionMaxKeys      = flag.Int("attribution-max-keys", attribution.DefaultMaxKeys, "Maximum number of distinct attribution keys tracked per pool, further keys are reported as \"other\".")
+	pipelineRetryReserve    = flag.Int("pipeline-retry-reserve", runserver.DefaultPipelineRetryReserve, "Number of requests admitted beyond the pipeline max concurrency for retries of failed requests. Zero disables retry prioritization.")
+	idempotencyKeyHeader    = flag.String("idempotency-key-header", requestcontrol.DefaultIdempotencyKeyHeader, "Name of the request header identifying the attempts of the same request, used with the request ID to recognize retries.")
+	scaleUpPrecheck         = flag.Bool("scale-up-precheck", true, "Check that the images of a target workload exist and that its volume claims are usable before scaling it up from zero, failing activations that would never become ready.")
+	responseCachePaths      = flag.String("response-cache-paths", "", "Comma separated path prefixes of idempotent requests whose responses are cached to answer repeated requests while the pool is cold. Empty disables the response cache.")
+	responseCacheSize       = flag.Int("response-cache-size", requestcontrol.DefaultResponseCacheSize, "Maximum number of responses kept by the response cache.")
+	responseCacheTTL        = flag.Duration("response-cache-ttl", requestcontrol.DefaultResponseCacheTTL, "Amount of time a cached response may be served while the pool is cold.")
+	overloadMaxGoroutines   = flag.Int("overload-max-goroutines", 0, "Number of goroutines past which the activator is overloaded and sheds new requests. Zero disables the threshold.")
+	overloadMaxMemory       = flag.Uint64("overload-max-memory", 0, "Size in bytes of the live heap past which the activator is overloaded and sheds new requests. Zero disables the threshold.")
+	overloadMaxCPU          = flag.Float64("overload-max-cpu", 0, "CPU usage in cores past which the activator is overloaded and sheds new requests. Zero disables the threshold.")
+	bypassSecretFile        = flag.String("bypass-secret-file", "", "File holding the secret signing the x-activator-bypass header of trusted internal clients, whose requests bypass the activator while the pool is warm. The header is \"<unix seconds>.<hex HMAC-SHA256 of the unix seconds, method, authority and path joined by new lines>\" and expires after 30s. Empty disables signed bypass headers.")
+	bypassIdentities        = flag.String("bypass-identities", "", "Comma-separated client certificate URIs, e.g. SPIFFE IDs, forwarded by the gateway in the x-forwarded-client-cert header, whose requests bypass the activator while the pool is warm. The gateway must sanitize that header. Empty disables identity based bypass.")
+	gatewayRateLimits       = flag.String("gateway-rate-limits", "", "Comma-separated rate limits in requests per second of the gateways sharing the activator, e.g. \"gw-a=100,gw-b=20\". Gateways are named by the x-activator-gateway initial metadata of their ext_proc filter. Gateways without rate limit are not limited.")
+	cancelAbandoned         = flag.Bool("cancel-abandoned-activations", false, "Cancel the scale up from zero in progress once the clients of every request held for it disconnected. The replicas already requested are left to the deactivator.")
+	idleClockInterval       = flag.Duration("idle-clock-interval", requestcontrol.DefaultIdleClockInterval, "Minimum interval between two writes of the time of the last request of the pool, persisted for a restarted activator or a new leader to rebuild the idle timer of the pool. Zero disables the persistence.")
+	budgetInterval          = flag.Duration("activation-budget-interval", requestcontrol.DefaultActivationBudgetInterval, "Interval between two writes of the accelerator time consumed by the pool, persisted for the replicas to share the monthly activation budget of pools setting the activator.llm-d.ai/monthly-activation-budget annotation. Zero disables the activation budgets.")
+	featureGates            = flag.String("feature-gates", "", "Comma-separated Feature=bool pairs enabling or disabling experimental behaviors, overriding the feature gates file. Known features: "+strings.Join(features.Gate.KnownFeatures(), ", "))
+	featureGatesFile        = flag.String("feature-gates-file", "", "File holding Feature=bool pairs, one per line, e.g. mounted from a ConfigMap.")
+	activationSlots         = flag.Int("activation-slots", 0, "Number of scale ups from zero allowed in flight at once in the namespace of the pool, across all activators, so that many pools waking simultaneously do not overload the API server and the scheduler. Zero disables the bound.")
+	activationBatchInterval = flag.Duration("activation-batch-interval", requestcontrol.DefaultActivationBatchInterval, "Interval between the attempts of the pools waiting for an activation slot, pools of activation priority p retrying every p+1 intervals.")
+	activationJitter        = flag.Duration("activation-jitter", requestcontrol.DefaultActivationJitter, "Maximum random delay added to every attempt to get an activation slot, spreading the pools waking at once.")
+	deactivationRate        = flag.Int("deactivation-rate", 0, "Number of scale downs to zero allowed per minute in the namespace of the pool, across all activators, so that the API server and the cluster autoscaler see a smooth drop when many pools are idle at once. Pools setting a lower activator.llm-d.ai/deactivation-priority annotation are scaled down first. Zero disables the rate limit.")
+	deactivationInterval    = flag.Duration("deactivation-batch-interval", requestcontrol.DefaultDeactivationBatchInterval, "Interval between the attempts of the idle pools waiting for a scale down slot, pools of deactivation priority p retrying every p+1 intervals.")
+	simulateHerd            = flag.Int("simulate-thundering-herd", 0, "Test mode simulating the given number of pools waking at once against the activation slot flags, logging when they scale up, then exiting.")
+	simulateHerdActivation  = flag.Duration("simulate-thundering-herd-activation", 30*time.Second, "Time each simulated scale up from zero holds its activation slot.")
+	soakNamespace           = flag.String("soak-namespace", "", "Test mode continuously activating and deactivating synthetic pools, whose target workloads are created in the given test namespace, and failing the health checks once a goroutine leaked, a request or a scale up got stuck or a counter decreased. Requires creating Deployments in the test namespace. Empty disables the soak test mode.")
+	soakPools               = flag.Int("soak-pools", requestcontrol.DefaultSoakPools, "Number of synthetic pools of the soak test mode, activated and deactivated concurrently.")
+	soakRequests            = flag.Int("soak-requests", requestcontrol.DefaultSoakRequests, "Number of requests held for each activation of a synthetic pool of the soak test mode.")
+	soakInterval            = flag.Duration("soak-interval", requestcontrol.DefaultSoakInterval, "Interval between the activate/deactivate cycles of the soak test mode.")
+	soakGoroutineSlack      = flag.Int("soak-goroutine-slack", requestcontrol.DefaultSoakGoroutineSlack, "Number of goroutines the activator may run after a soak cycle above the number after the first cycle before it is considered leaking goroutines.")
+	initialScale            = flag.Int("initial-scale", requestcontrol.DefaultInitialScale, "Number of replicas pools are scaled up to from zero, unless they set the activator.llm-d.ai/initial-scale annotation.")
+	zone                    = flag.String("zone", "", "Zone of the activator, that is of the gateway traffic it serves, e.g. the topology.kubernetes.io/zone label of its node. Pools setting the activator.llm-d.ai/zone-aware-activation annotation prefer waking replicas in this zone, and the zone of the replicas woken is reported. Requires reading nodes.")
+	discoverTarget          = flag.Bool("discover-target", false, "Discover the target workload of pools setting no target annotations, as the Deployment or StatefulSet of their namespace whose pod template matches their selector. The target annotations override the discovery.")
+	externalConfig          = flag.Bool("external-config", false, "Fill in the activator annotations pools do not set from their companion ConfigMap, named after the pool with the -activator suffix, then from the annotations of their target workload, for pools that cannot be annotated.")
+	namespaceOptIn          = flag.Bool("namespace-opt-in", false, "Only consider the pools of namespaces labeled activator.llm-d.ai/enabled=true for activation and deactivation, leaving the other pools alone.")
+	killSwitchConfigMap     = flag.String("kill-switch-configmap", "", "Namespace/name of a ConfigMap shared by the activators of the fleet whose deactivation-disabled key set to true disables all scale downs to zero, leaving activations alone. The kill switch of a single activator is also turned through its /admin/kill-switch endpoint.")
+	poolStateMetadata       = flag.Bool("pool-state-metadata", false, "Emit the activation state of the pool, cold, activating or ready, and the number of requests held for its activation as dynamic metadata of the llm-d.activator namespace, for Envoy filters to react to. The ext_proc filter must accept that namespace.")
+	handoffPeer             = flag.String("handoff-peer", "", "Address of the ext_proc gRPC server of a peer activator replica of the same pool, e.g. its headless Service, the requests held by this replica are handed off to when it shuts down during an activation, so that the peer starts the activation for their retries at once. Setting it also receives the requests held by the peers. Empty disables the handoff.")
+	handoffCodec            = flag.String("handoff-codec", requestcontrol.JSONHeldRequestCodecName, "Name of the registered codec the held requests are handed off with. Every replica must register it.")
+	haEnableLeaderElection  = flag.Bool("ha-enable-leader-election", false, "Enables leader election for high availability. When enabled, readiness probes will only pass on the leader.")
 
 	setupLog = ctrl.Log.WithName("setup")
 )
@@ -70,7 +139,7 @@ func Run(ctx context.Context) error {
 	}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
-	initLogging(&opts)
+	logLevel := initLogging(&opts)
 
 	setupLog.Info("GIE build", "commit-sha", version.CommitSHA, "build-ref", version.BuildRef)
 
@@ -80,6 +149,25 @@ func Run(ctx context.Context) error {
 		return err
 	}
 
+	// Set feature gates, the flag taking precedence over the file
+	if *featureGatesFile != "" {
+		if err := features.SetFromFile(*featureGatesFile); err != nil {
+			setupLog.Error(err, "Failed to set feature gates from file")
+			return err
+		}
+	}
+	if *featureGates != "" {
+		if err := features.Gate.Set(*featureGates); err != nil {
+			setupLog.Error(err, "Failed to set feature gates")
+			return err
+		}
+	}
+	enabledFeatures := make(map[string]bool)
+	for feature := range features.Gate.GetAll() {
+		enabledFeatures[string(feature)] = features.Enabled(feature)
+	}
+	setupLog.Info("Feature gates set", "features", enabledFeatures)
+
 	// Print all flag values
 	flags := make(map[string]any)
 	flag.VisitAll(func(f *flag.Flag) {
@@ -87,6 +175,12 @@ func Run(ctx context.Context) error {
 	})
 	setupLog.Info("Flags processed", "flags", flags)
 
+	herdConfig := requestcontrol.HerdConfig{Slots: *activationSlots, BatchInterval: *activationBatchInterval, Jitter: *activationJitter}
+	if *simulateHerd > 0 {
+		logHerdSimulation(herdConfig, *simulateHerd, *simulateHerdActivation)
+		return nil
+	}
+
 	// --- Get Kubernetes Config ---
 	cfg, err := ctrl.GetConfig()
 	if err != nil {
@@ -111,13 +205,153 @@ func Run(ctx context.Context) error {
 		return err
 	}
 
-	//Start Deactivator
-	go deactivator.MonitorInferencePoolIdleness(ctx)
+	// --- Setup Director ---
+	pipelineConfig := requestcontrol.NewPipelineConfig()
+	pipelineConfig.MaxConcurrency = *pipelineMaxConcurrency
+	pipelineConfig.RetryReserve = *pipelineRetryReserve
+	pipelineConfig.BreakerFailureThreshold = *breakerFailureThreshold
+	pipelineConfig.BreakerCooldown = *breakerCooldown
+	director := requestcontrol.NewDirectorWithConfig(datastore, activator, pipelineConfig)
+	if *pipelineRetryReserve > 0 {
+		director.Retries = requestcontrol.NewRetryTracker(strings.ToLower(*idempotencyKeyHeader), requestcontrol.DefaultRetryMemory)
+	}
+
+	// --- Setup Batch Tracking ---
+	batchConfig := requestcontrol.NewBatchConfig()
+	if *batchPaths != "" {
+		batchConfig.Paths = strings.Split(*batchPaths, ",")
+	}
+	batchConfig.Header = *batchHeader
+	batchConfig.CompletionBuffer = *batchCompletionBuffer
+	if batchConfig.Enabled() {
+		batches := requestcontrol.NewBatchTracker(batchConfig)
+		director.Batches = batches
+		deactivator.Batches = batches
+	}
+
+	exclusionConfig := requestcontrol.NewExclusionConfig()
+	if *excludedPaths != "" {
+		exclusionConfig.Paths = strings.Split(*excludedPaths, ",")
+	}
+	exclusionConfig.Header = *excludedHeader
+	if *excludedUserAgents != "" {
+		exclusionConfig.UserAgents = strings.Split(*excludedUserAgents, ",")
+	}
+	if exclusionConfig.Enabled() {
+		director.Exclusions = requestcontrol.NewExclusions(exclusionConfig)
+	}
+
+	responses := requestcontrol.NewResponseTracker()
+	director.Responses = responses
+	deactivator.Responses = responses
+
+	// --- Setup Response Cache ---
+	cacheConfig := requestcontrol.NewResponseCacheConfig()
+	if *responseCachePaths != "" {
+		cacheConfig.Paths = strings.Split(*responseCachePaths, ",")
+	}
+	cacheConfig.Size = *responseCacheSize
+	cacheConfig.TTL = *responseCacheTTL
+	if cacheConfig.Enabled() && features.Enabled(features.ResponseCache) {
+		director.Cache = requestcontrol.NewResponseCache(cacheConfig)
+	}
+
+	// --- Setup Overload Protection ---
+	overloadConfig := &requestcontrol.OverloadConfig{
+		MaxGoroutines: *overloadMaxGoroutines,
+		MaxHeapBytes:  *overloadMaxMemory,
+		MaxCPU:        *overloadMaxCPU,
+	}
+	if overloadConfig.Enabled() {
+		director.Overload = requestcontrol.NewOverloadMonitor(overloadConfig)
+		go director.Overload.Run(ctx, requestcontrol.DefaultOverloadInterval)
+	}
+
+	// --- Setup Gateway Rate Limits ---
+	if limits, _ := requestcontrol.ParseGatewayRateLimits(*gatewayRateLimits); len(limits) > 0 {
+		director.Gateways = requestcontrol.NewGatewayLimiter(limits)
+	}
+
+	// --- Setup Trusted Client Bypass ---
+	bypassConfig := &requestcontrol.BypassConfig{MaxSkew: requestcontrol.DefaultBypassMaxSkew}
+	if *bypassSecretFile != "" {
+		secret, err := os.ReadFile(*bypassSecretFile)
+		if err != nil {
+			setupLog.Error(err, "Failed to read bypass secret")
+			return err
+		}
+		bypassConfig.Secret = bytes.TrimSpace(secret)
+	}
+	if *bypassIdentities != "" {
+		bypassConfig.Identities = strings.Split(*bypassIdentities, ",")
+	}
+	if bypassConfig.Enabled() && features.Enabled(features.TrustedClientBypass) {
+		director.Bypass = requestcontrol.NewBypass(bypassConfig)
+	}
+	director.BenchmarkPassthrough = *benchmarkPassthrough
+	director.DebugBypass = *debugBypassHeader
+	director.PoolStateMetadata = *poolStateMetadata
+
+	// --- Setup Activation Attribution ---
+	var ledger *attribution.Ledger
+	if *attributionHeader != "" {
+		ledger = attribution.NewLedger(*attributionMaxKeys)
+		director.AttributionHeader = strings.ToLower(*attributionHeader)
+		activator.Attribution = ledger
+		deactivator.Attribution = ledger
+	}
+
+	// --- Setup Activation Lifecycle Events ---
+	activator.Events = requestcontrol.DefaultEventBus
+	deactivator.Events = requestcontrol.DefaultEventBus
+	activator.Verifier = requestcontrol.NewActivationVerifier()
+
+	// --- Setup Activation Slots ---
+	if herdConfig.Enabled() {
+		activator.Slots = requestcontrol.NewActivationSlots(activator.DynamicClient, herdConfig)
+	}
+
+	// --- Setup Deactivation Slots ---
+	if *deactivationRate > 0 {
+		deactivator.Slots = requestcontrol.NewDeactivationSlots(activator.DynamicClient, *deactivationRate, *deactivationInterval)
+	}
+
+	// --- Setup Activation Claims ---
+	claims := requestcontrol.NewActivationClaims(activator.DynamicClient)
+	activator.Claims = claims
+	deactivator.Claims = claims
+
+	activator.CancelAbandoned = *cancelAbandoned && features.Enabled(features.AbandonedActivationCancellation)
+	activator.InitialScale = int32(*initialScale)
+	activator.Zone = *zone
+
+	// --- Setup Scale Up Pre-check ---
+	if *scaleUpPrecheck {
+		activator.Precheck = requestcontrol.NewPrecheck(requestcontrol.DefaultPrecheckTimeout)
+	}
 
 	// --- Setup Metrics Server ---
+	metrics.Register()
+
+	// --- Setup Deactivation Kill Switch ---
+	killSwitchNamespace, killSwitchName, _ := strings.Cut(*killSwitchConfigMap, "/")
+	killSwitch := requestcontrol.NewKillSwitch(activator.DynamicClient, types.NamespacedName{Namespace: killSwitchNamespace, Name: killSwitchName})
+	deactivator.KillSwitch = killSwitch
+	go killSwitch.Run(ctx, requestcontrol.DefaultKillSwitchInterval)
+
 	metricsServerOptions := metricsserver.Options{
 		BindAddress:    fmt.Sprintf(":%d", *metricsPort),
 		FilterProvider: filters.WithAuthenticationAndAuthorization,
+		ExtraHandlers: map[string]http.Handler{
+			"/metrics/openmetrics":     metrics.OpenMetricsHandler(),
+			"/activation/progress":     activator.ProgressHandler(),
+			"/activation/verification": activator.VerificationHandler(),
+			"/admin/state":             requestcontrol.NewStateTransfer(datastore, activator, deactivator).Handler(),
+			"/admin/kill-switch":       killSwitch.Handler(),
+		},
+	}
+	if ledger != nil {
+		metricsServerOptions.ExtraHandlers["/attribution"] = ledger.Handler()
 	}
 
 	// Determine pool namespace: if --pool-namespace is non-empty, use it; else NAMESPACE env var; else default
@@ -153,6 +387,58 @@ func Run(ctx context.Context) error {
 		return err
 	}
 
+	activator.Recorder = mgr.GetEventRecorderFor("activator")
+	activator.Pods = mgr.GetClient()
+	deactivator.Recorder = mgr.GetEventRecorderFor("deactivator")
+	deactivator.DryRun = *deactivationDryRun
+	deactivator.DryRunReport = *dryRunReport
+	if *deactivationDryRun {
+		setupLog.Info("Deactivation dry-run enabled, idle pools will be reported instead of scaled down")
+	}
+
+	// With leader election, only the leader scales down
+	if *haEnableLeaderElection {
+		deactivator.Elected = mgr.Elected()
+	}
+
+	// Persist the time of the last request so that neither a restart nor a failover restarts the idle period
+	if *idleClockInterval > 0 {
+		idleClock := requestcontrol.NewIdleClock(activator.DynamicClient, datastore)
+		activator.IdleClock = idleClock
+		deactivator.IdleClock = idleClock
+		go idleClock.Run(ctx, *idleClockInterval)
+	}
+
+	// Charge the accelerator time of the scale ups from zero to the monthly activation budget of the pool
+	if *budgetInterval > 0 {
+		budget := requestcontrol.NewActivationBudget(activator.DynamicClient, datastore)
+		activator.Budget = budget
+		deactivator.Budget = budget
+		go budget.Run(ctx, *budgetInterval)
+	}
+
+	//Start Deactivator
+	go deactivator.MonitorInferencePoolIdleness(ctx)
+
+	// --- Setup Soak Test Mode ---
+	var soak *requestcontrol.Soak
+	if *soakNamespace != "" {
+		soak = requestcontrol.NewSoak(ctx, activator, ctrlmetrics.Registry, requestcontrol.SoakConfig{
+			Namespace:      *soakNamespace,
+			Pools:          *soakPools,
+			Requests:       *soakRequests,
+			Interval:       *soakInterval,
+			GoroutineSlack: *soakGoroutineSlack,
+		})
+		go soak.Run(ctx)
+	}
+
+	// --- Setup Recommender ---
+	if *recommendationWindow > 0 {
+		activator.Recommender = requestcontrol.NewRecommender(datastore, *recommendationWindow)
+		go activator.Recommender.Run(ctx, requestcontrol.DefaultRecommendationInterval)
+	}
+
 	if *haEnableLeaderElection {
 		setupLog.Info("Leader election enabled")
 		go func() {
@@ -174,8 +460,56 @@ func Run(ctx context.Context) error {
 		SecureServing:      *secureServing,
 		HealthChecking:     *healthChecking,
 		CertPath:           *certPath,
-		Activator:          activator,
+		Director:           director,
+		PoolValidator:      activator.ValidatePool,
+		PoolChecker:        activator.CheckTarget,
+	}
+	// The activator configuration of the pool is filled in from, by precedence, the pool annotations, its
+	// companion ConfigMap, the discovery of its target and the annotations of its target
+	var defaulters []func(ctx context.Context, pool *v1.InferencePool) error
+	if *namespaceOptIn {
+		defaulters = append(defaulters, activator.ApplyNamespaceOptIn)
+		serverRunner.PoolResync = requestcontrol.ExternalConfigResync
 	}
+	if *externalConfig {
+		defaulters = append(defaulters, activator.ApplyConfigMap)
+		serverRunner.PoolResync = requestcontrol.ExternalConfigResync
+		activator.ExternalConfig = true
+		deactivator.ExternalConfig = true
+	}
+	if *discoverTarget {
+		defaulters = append(defaulters, activator.DiscoverTarget)
+	}
+	if *externalConfig {
+		defaulters = append(defaulters, activator.ApplyWorkloadAnnotations)
+	}
+	defaulters = append(defaulters, activator.ApplyRollover)
+	serverRunner.PoolDefaulter = func(ctx context.Context, pool *v1.InferencePool) error {
+		for _, defaulter := range defaulters {
+			if err := defaulter(ctx, pool); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if logLevel != nil {
+		serverRunner.PoolObserver = requestcontrol.NewLogVerbosity(*logLevel).Apply
+	}
+
+	// --- Setup Held Request Handoff ---
+	if *handoffPeer != "" {
+		handoff, err := requestcontrol.NewRequestHandoff(activator, *handoffCodec)
+		if err != nil {
+			setupLog.Error(err, "Failed to setup the held request handoff")
+			return err
+		}
+		activator.Handoff = handoff
+		serverRunner.Handoff = handoff
+		if err := registerHandoff(mgr, handoff, *handoffPeer, *secureServing); err != nil {
+			return err
+		}
+	}
+
 	if err := serverRunner.SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "Failed to setup Activator controllers")
 		return err
@@ -183,7 +517,7 @@ func Run(ctx context.Context) error {
 
 	// --- Add Runnables to Manager ---
 	// Register health server.
-	if err := registerHealthServer(mgr, ctrl.Log.WithName("health"), datastore, *grpcHealthPort, isLeader, *haEnableLeaderElection); err != nil {
+	if err := registerHealthServer(mgr, ctrl.Log.WithName("health"), datastore, soak, *grpcHealthPort, isLeader, *haEnableLeaderElection); err != nil {
 		return err
 	}
 
@@ -203,7 +537,9 @@ func Run(ctx context.Context) error {
 	return nil
 }
 
-func initLogging(opts *zap.Options) {
+// initLogging sets up the logger and returns its level, to be adjusted to the log verbosity of the pool,
+// if the level can be changed at runtime.
+func initLogging(opts *zap.Options) *uberzap.AtomicLevel {
 	// Unless -zap-log-level is explicitly set, use -v
 	useV := true
 	flag.Visit(func(f *flag.Flag) {
@@ -219,6 +555,11 @@ func initLogging(opts *zap.Options) {
 
 	logger := zap.New(zap.UseFlagOptions(opts), zap.RawZapOpts(uberzap.AddCaller()))
 	ctrl.SetLogger(logger)
+
+	if level, ok := opts.Level.(uberzap.AtomicLevel); ok {
+		return &level
+	}
+	return nil
 }
 
 // registerExtProcServer adds the ExtProcServerRunner as a Runnable to the manager.
@@ -231,12 +572,35 @@ func registerExtProcServer(mgr manager.Manager, runner *runserver.ExtProcServerR
 	return nil
 }
 
+// registerHandoff adds a Runnable handing the held requests off to the given peer once the manager stops.
+func registerHandoff(mgr manager.Manager, handoff *requestcontrol.RequestHandoff, peer string, secure bool) error {
+	creds := insecure.NewCredentials()
+	if secure {
+		// The ext_proc servers of the peers serve self-signed certificates unless given one
+		creds = credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})
+	}
+	if err := mgr.Add(runnable.NoLeaderElection(manager.RunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		sendCtx, cancel := context.WithTimeout(log.IntoContext(context.Background(), ctrl.Log.WithName("handoff")), requestcontrol.DefaultHandoffTimeout)
+		defer cancel()
+		if err := handoff.Send(sendCtx, peer, creds); err != nil {
+			setupLog.Error(err, "Failed to hand the held requests off", "peer", peer)
+		}
+		return nil
+	}))); err != nil {
+		setupLog.Error(err, "Failed to register the held request handoff")
+		return err
+	}
+	return nil
+}
+
 // registerHealthServer adds the Health gRPC server as a Runnable to the given manager.
-func registerHealthServer(mgr manager.Manager, logger logr.Logger, ds datastore.Datastore, port int, isLeader *atomic.Bool, leaderElectionEnabled bool) error {
+func registerHealthServer(mgr manager.Manager, logger logr.Logger, ds datastore.Datastore, soak *requestcontrol.Soak, port int, isLeader *atomic.Bool, leaderElectionEnabled bool) error {
 	srv := grpc.NewServer()
 	healthPb.RegisterHealthServer(srv, &healthServer{
 		logger:                logger,
 		datastore:             ds,
+		soak:                  soak,
 		isLeader:              isLeader,
 		leaderElectionEnabled: leaderElectionEnabled,
 	})
@@ -253,5 +617,77 @@ func validateFlags() error {
 		return fmt.Errorf("required %q flag not set", "poolName")
 	}
 
+	if *pipelineMaxConcurrency <= 0 {
+		return fmt.Errorf("%q flag must be positive", "pipeline-max-concurrency")
+	}
+
+	if *pipelineRetryReserve < 0 {
+		return fmt.Errorf("%q flag must not be negative", "pipeline-retry-reserve")
+	}
+
+	if *attributionMaxKeys <= 0 {
+		return fmt.Errorf("%q flag must be positive", "attribution-max-keys")
+	}
+
+	if *overloadMaxGoroutines < 0 || *overloadMaxCPU < 0 {
+		return fmt.Errorf("%q and %q flags must not be negative", "overload-max-goroutines", "overload-max-cpu")
+	}
+
+	if _, err := requestcontrol.ParseGatewayRateLimits(*gatewayRateLimits); err != nil {
+		return fmt.Errorf("invalid %q flag: %w", "gateway-rate-limits", err)
+	}
+
+	if *idleClockInterval < 0 {
+		return fmt.Errorf("%q flag must not be negative", "idle-clock-interval")
+	}
+
+	if *budgetInterval < 0 {
+		return fmt.Errorf("%q flag must not be negative", "activation-budget-interval")
+	}
+
+	if *activationSlots < 0 || *activationBatchInterval < 0 || *activationJitter < 0 {
+		return fmt.Errorf("%q, %q and %q flags must not be negative", "activation-slots", "activation-batch-interval", "activation-jitter")
+	}
+
+	if *deactivationRate < 0 || *deactivationInterval < 0 {
+		return fmt.Errorf("%q and %q flags must not be negative", "deactivation-rate", "deactivation-batch-interval")
+	}
+
+	if *initialScale < 1 || *initialScale > math.MaxInt32 {
+		return fmt.Errorf("%q flag must be a positive 32-bit integer", "initial-scale")
+	}
+
+	if !slices.Contains(requestcontrol.RegisteredHeldRequestCodecs(), *handoffCodec) {
+		return fmt.Errorf("%q flag must name a registered codec, one of: %s", "handoff-codec", strings.Join(requestcontrol.RegisteredHeldRequestCodecs(), ", "))
+	}
+
+	if namespace, name, found := strings.Cut(*killSwitchConfigMap, "/"); *killSwitchConfigMap != "" && (!found || namespace == "" || name == "") {
+		return fmt.Errorf("%q flag must be a namespace/name, got %q", "kill-switch-configmap", *killSwitchConfigMap)
+	}
+
+	if *soakNamespace != "" && (*soakPools < 1 || *soakRequests < 1) {
+		return fmt.Errorf("%q and %q flags must be positive in the soak test mode", "soak-pools", "soak-requests")
+	}
+
 	return nil
 }
+
+// logHerdSimulation logs when the given number of pools waking at once, of activation priorities 0 to 2 in
+// turn, scale up under the given activation slots configuration.
+func logHerdSimulation(config requestcontrol.HerdConfig, pools int, activation time.Duration) {
+	priorities := make([]int, pools)
+	for i := range priorities {
+		priorities[i] = i % 3
+	}
+	starts := requestcontrol.SimulateHerd(config, priorities, activation, uint64(time.Now().UnixNano()))
+
+	lastStart := make(map[int]time.Duration)
+	for i, start := range starts {
+		lastStart[priorities[i]] = max(lastStart[priorities[i]], start)
+	}
+	for priority := 0; priority < 3 && priority < pools; priority++ {
+		setupLog.Info("Thundering herd simulation", "priority", priority, "lastScaleUpStart", lastStart[priority].String())
+	}
+	setupLog.Info("Thundering herd simulation done", "pools", pools, "slots", config.Slots, "activation", activation.String(),
+		"lastScaleUpStart", slices.Max(starts).String())
+}
@@ -26,7 +26,7 @@ echo "$SCRIPT_ROOT script"
 CODEGEN_PKG=${2:-bin}
 echo $CODEGEN_PKG
 source "${CODEGEN_PKG}/kube_codegen.sh"
-THIS_PKG="sigs.k8s.io/gateway-api-inference-extension"
+THIS_PKG="github.com/llm-d-incubation/llm-d-activator"
 
 
 kube::codegen::gen_helpers \
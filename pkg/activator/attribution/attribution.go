@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package attribution attributes the activations of the activator, and the accelerator time they
+// caused, to the API key or team of the request that triggered them, for internal chargeback.
+package attribution
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+)
+
+const (
+	// UnknownKey is the key of activations triggered by requests without attribution header.
+	UnknownKey = "unknown"
+	// OverflowKey is the key of activations triggered by keys beyond the maximum number of tracked keys.
+	OverflowKey = "other"
+
+	// DefaultMaxKeys bounds the number of distinct keys tracked, and the cardinality of the attribution metrics.
+	DefaultMaxKeys = 100
+
+	// keyPrefix marks the keys derived from the attribution header of requests.
+	keyPrefix = "sha256:"
+)
+
+// KeyOf returns the attribution key of a request from the value of its attribution header. The header
+// typically carries an API key or a bearer token, which must not end up in metric labels or in the
+// attribution report: the key is a truncated SHA-256 of the value instead, which operators match with the
+// digests of the credentials they issued, e.g. with `printf %s "$KEY" | sha256sum | cut -c1-16`.
+func KeyOf(value string) string {
+	if value == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(value))
+	return keyPrefix + hex.EncodeToString(sum[:8])
+}
+
+// Entry is the attribution report of a single key.
+type Entry struct {
+	Pool        string `json:"pool"`
+	Key         string `json:"key"`
+	Activations int64  `json:"activations"`
+	// ActiveSeconds is the time the pool stayed active after activations triggered by the key.
+	ActiveSeconds float64 `json:"activeSeconds"`
+	// AcceleratorSeconds is the accelerator time of the pool after activations triggered by the key.
+	AcceleratorSeconds float64 `json:"acceleratorSeconds"`
+}
+
+// activation is an activation of a pool that was not deactivated yet.
+type activation struct {
+	key          string
+	accelerators int64
+	start        time.Time
+}
+
+// Ledger accumulates the activations of each pool and the time until their deactivation, attributed to
+// the key of the request that triggered them.
+type Ledger struct {
+	maxKeys int
+
+	mu      sync.Mutex
+	entries map[string]map[string]*Entry // pool -> key -> entry
+	active  map[string]*activation       // pool -> activation in progress
+}
+
+func NewLedger(maxKeys int) *Ledger {
+	return &Ledger{
+		maxKeys: maxKeys,
+		entries: make(map[string]map[string]*Entry),
+		active:  make(map[string]*activation),
+	}
+}
+
+// Activated records the activation of the pool, triggered by a request with the given key, bringing the
+// given number of accelerators into use.
+func (l *Ledger) Activated(pool, key string, accelerators int64, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// The pool was activated again without deactivation being observed, e.g. by an external scale down
+	l.settleLocked(pool, now)
+
+	entry := l.entryLocked(pool, key)
+	entry.Activations++
+	metrics.RecordAttributedActivation(pool, entry.Key)
+	l.active[pool] = &activation{key: entry.Key, accelerators: accelerators, start: now}
+}
+
+// Deactivated records the deactivation of the pool, charging the time it was active to the key that activated it.
+func (l *Ledger) Deactivated(pool string, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.settleLocked(pool, now)
+}
+
+// Report returns the attribution of every tracked key, including the time of activations in progress.
+func (l *Ledger) Report(now time.Time) []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var report []Entry
+	for pool, keys := range l.entries {
+		for key, entry := range keys {
+			e := *entry
+			if a, ok := l.active[pool]; ok && a.key == key {
+				elapsed := now.Sub(a.start).Seconds()
+				e.ActiveSeconds += elapsed
+				e.AcceleratorSeconds += elapsed * float64(a.accelerators)
+			}
+			report = append(report, e)
+		}
+	}
+	slices.SortFunc(report, func(a, b Entry) int {
+		return cmp.Or(strings.Compare(a.Pool, b.Pool), strings.Compare(a.Key, b.Key))
+	})
+	return report
+}
+
+// Handler returns a handler serving the attribution report as JSON.
+func (l *Ledger) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"entries": l.Report(time.Now())})
+	})
+}
+
+// settleLocked charges the activation in progress of the pool, if any, to its key.
+func (l *Ledger) settleLocked(pool string, now time.Time) {
+	a, ok := l.active[pool]
+	if !ok {
+		return
+	}
+	delete(l.active, pool)
+
+	elapsed := now.Sub(a.start).Seconds()
+	entry := l.entryLocked(pool, a.key)
+	entry.ActiveSeconds += elapsed
+	entry.AcceleratorSeconds += elapsed * float64(a.accelerators)
+	metrics.RecordAttributedAcceleratorSeconds(pool, a.key, elapsed*float64(a.accelerators))
+}
+
+// entryLocked returns the entry of the given key, folding new keys into the overflow key once the
+// maximum number of keys is tracked.
+func (l *Ledger) entryLocked(pool, key string) *Entry {
+	if key == "" {
+		key = UnknownKey
+	}
+	keys, ok := l.entries[pool]
+	if !ok {
+		keys = make(map[string]*Entry)
+		l.entries[pool] = keys
+	}
+	if _, ok := keys[key]; !ok && len(keys) >= l.maxKeys {
+		key = OverflowKey
+	}
+	entry, ok := keys[key]
+	if !ok {
+		entry = &Entry{Pool: pool, Key: key}
+		keys[key] = entry
+	}
+	return entry
+}
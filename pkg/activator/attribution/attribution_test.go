@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attribution
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestKeyOf(t *testing.T) {
+	if got := KeyOf(""); got != "" {
+		t.Errorf("KeyOf(\"\") = %q, want empty", got)
+	}
+	key := KeyOf("Bearer sk-secret")
+	if !strings.HasPrefix(key, keyPrefix) || len(key) != len(keyPrefix)+16 {
+		t.Errorf("KeyOf() = %q, want %s followed by 16 hex digits", key, keyPrefix)
+	}
+	if strings.Contains(key, "sk-secret") {
+		t.Errorf("KeyOf() = %q, leaks the header value", key)
+	}
+	if KeyOf("Bearer sk-secret") != key {
+		t.Errorf("KeyOf() is not deterministic")
+	}
+	if KeyOf("Bearer sk-other") == key {
+		t.Errorf("KeyOf() maps distinct values to the same key")
+	}
+}
+
+func TestLedger(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+
+	type step struct {
+		at           int
+		activated    bool
+		key          string
+		accelerators int64
+	}
+	tests := []struct {
+		name     string
+		maxKeys  int
+		steps    []step
+		reportAt int
+		want     []Entry
+	}{
+		{
+			name:     "deactivation charges the activating key",
+			maxKeys:  DefaultMaxKeys,
+			steps:    []step{{at: 0, activated: true, key: "a", accelerators: 4}, {at: 10}},
+			reportAt: 100,
+			want:     []Entry{{Pool: "pool", Key: "a", Activations: 1, ActiveSeconds: 10, AcceleratorSeconds: 40}},
+		},
+		{
+			name:     "activation in progress is reported up to now",
+			maxKeys:  DefaultMaxKeys,
+			steps:    []step{{at: 0, activated: true, key: "a", accelerators: 2}},
+			reportAt: 30,
+			want:     []Entry{{Pool: "pool", Key: "a", Activations: 1, ActiveSeconds: 30, AcceleratorSeconds: 60}},
+		},
+		{
+			name:    "reactivation settles the previous activation",
+			maxKeys: DefaultMaxKeys,
+			steps: []step{
+				{at: 0, activated: true, key: "a", accelerators: 1},
+				{at: 5, activated: true, key: "b", accelerators: 2},
+				{at: 15},
+			},
+			reportAt: 100,
+			want: []Entry{
+				{Pool: "pool", Key: "a", Activations: 1, ActiveSeconds: 5, AcceleratorSeconds: 5},
+				{Pool: "pool", Key: "b", Activations: 1, ActiveSeconds: 10, AcceleratorSeconds: 20},
+			},
+		},
+		{
+			name:     "deactivation without activation is ignored",
+			maxKeys:  DefaultMaxKeys,
+			steps:    []step{{at: 0}, {at: 5, activated: true, key: "a", accelerators: 1}, {at: 10}, {at: 20}},
+			reportAt: 100,
+			want:     []Entry{{Pool: "pool", Key: "a", Activations: 1, ActiveSeconds: 5, AcceleratorSeconds: 5}},
+		},
+		{
+			name:     "requests without key are unknown",
+			maxKeys:  DefaultMaxKeys,
+			steps:    []step{{at: 0, activated: true, accelerators: 1}, {at: 10}},
+			reportAt: 100,
+			want:     []Entry{{Pool: "pool", Key: UnknownKey, Activations: 1, ActiveSeconds: 10, AcceleratorSeconds: 10}},
+		},
+		{
+			name:    "keys beyond the maximum fold into the overflow key",
+			maxKeys: 2,
+			steps: []step{
+				{at: 0, activated: true, key: "a", accelerators: 1},
+				{at: 10, activated: true, key: "b", accelerators: 1},
+				{at: 20, activated: true, key: "c", accelerators: 1},
+				{at: 30, activated: true, key: "d", accelerators: 1},
+				{at: 40, activated: true, key: "a", accelerators: 1},
+				{at: 50},
+			},
+			reportAt: 100,
+			want: []Entry{
+				{Pool: "pool", Key: "a", Activations: 2, ActiveSeconds: 20, AcceleratorSeconds: 20},
+				{Pool: "pool", Key: "b", Activations: 1, ActiveSeconds: 10, AcceleratorSeconds: 10},
+				{Pool: "pool", Key: OverflowKey, Activations: 2, ActiveSeconds: 20, AcceleratorSeconds: 20},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ledger := NewLedger(test.maxKeys)
+			for _, s := range test.steps {
+				if s.activated {
+					ledger.Activated("pool", s.key, s.accelerators, at(s.at))
+				} else {
+					ledger.Deactivated("pool", at(s.at))
+				}
+			}
+			if got := ledger.Report(at(test.reportAt)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Report() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLedgerHandler(t *testing.T) {
+	ledger := NewLedger(DefaultMaxKeys)
+	ledger.Activated("default/pool", KeyOf("sk-secret"), 1, time.Now())
+
+	recorder := httptest.NewRecorder()
+	ledger.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/attribution", nil))
+	if strings.Contains(recorder.Body.String(), "sk-secret") {
+		t.Errorf("attribution report %s leaks the header value", recorder.Body.String())
+	}
+	var report struct {
+		Entries []Entry `json:"entries"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &report); err != nil {
+		t.Fatalf("invalid attribution report: %v", err)
+	}
+	if len(report.Entries) != 1 || report.Entries[0].Key != KeyOf("sk-secret") || report.Entries[0].Activations != 1 {
+		t.Errorf("attribution report = %+v, want a single activation of %s", report.Entries, KeyOf("sk-secret"))
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records the scale actions taken by the activator so they can be traced back
+// to the request that triggered them.
+package audit
+
+import (
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Action is the kind of scale action recorded in the audit trail.
+type Action string
+
+const (
+	ActionScaleUp   Action = "ScaleUp"
+	ActionScaleDown Action = "ScaleDown"
+	// ActionRollover is recorded when a pool is cut over, or fails to cut over, to a new target workload.
+	ActionRollover Action = "Rollover"
+)
+
+// Outcome is the result of a recorded scale action.
+type Outcome string
+
+const (
+	OutcomeSucceeded Outcome = "Succeeded"
+	OutcomeFailed    Outcome = "Failed"
+	// OutcomeDryRun is recorded for actions that were evaluated but not applied.
+	OutcomeDryRun Outcome = "DryRun"
+)
+
+// Record is a single entry of the audit trail.
+type Record struct {
+	Timestamp time.Time
+	Action    Action
+	Outcome   Outcome
+	// Pool is the namespaced name of the InferencePool the action applies to.
+	Pool string
+	// Target is the kind/name of the scaled workload.
+	Target   string
+	Replicas int32
+	// RequestID is the gateway request ID of the request that triggered the action, if any.
+	RequestID string
+	// TraceID is the ID of the distributed trace of the request that triggered the action, if any.
+	TraceID string
+	// ActivationKey identifies the activation across activator replicas and restarts, if claimed.
+	ActivationKey string
+	// Reason classifies why a failed action failed, if known.
+	Reason  string
+	Message string
+	// ResourceVersionBefore and ResourceVersionAfter are the resource versions of the target workload before
+	// and after the action mutated it, if known, to tell which writes changed its replicas.
+	ResourceVersionBefore string
+	ResourceVersionAfter  string
+}
+
+var auditLog = ctrl.Log.WithName("audit")
+
+// Log writes the given record to the audit trail.
+func Log(record Record) {
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now()
+	}
+	auditLog.Info("Scale action",
+		"timestamp", record.Timestamp.UTC().Format(time.RFC3339Nano),
+		"action", record.Action,
+		"outcome", record.Outcome,
+		"pool", record.Pool,
+		"target", record.Target,
+		"replicas", record.Replicas,
+		"x-request-id", record.RequestID,
+		"trace-id", record.TraceID,
+		"activation-key", record.ActivationKey,
+		"reason", record.Reason,
+		"message", record.Message,
+		"resource-version-before", record.ResourceVersionBefore,
+		"resource-version-after", record.ResourceVersionAfter)
+}
@@ -19,8 +19,11 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -32,6 +35,10 @@ import (
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
 
+// defaultingRetryInterval is the interval the pools whose activator configuration could not be filled in are
+// reconciled again at, e.g. until the target workload they discover is created.
+const defaultingRetryInterval = 30 * time.Second
+
 // InferencePoolReconciler utilizes the controller runtime to reconcile Instance Gateway resources
 // This implementation is just used for reading & maintaining data sync. The Gateway implementation
 // will have the proper controller that will create/manage objects on behalf of the server pool.
@@ -39,6 +46,20 @@ type InferencePoolReconciler struct {
 	client.Reader
 	Datastore datastore.Datastore
 	PoolGKNN  common.GKNN
+	// Default fills in the activator configuration the pool does not set, e.g. its discovered target. Optional.
+	Default func(ctx context.Context, pool *v1.InferencePool) error
+	// Validate checks the activator configuration of the pool. Optional.
+	Validate func(pool *v1.InferencePool) error
+	// Recorder emits Kubernetes events on the InferencePool. Optional.
+	Recorder record.EventRecorder
+	// Check verifies the activator configuration of the pool against the cluster, e.g. that its target
+	// workload runs the pods it selects. Optional.
+	Check func(ctx context.Context, pool *v1.InferencePool) error
+	// Observe is called with the pool after each reconcile, or with nil once the pool is deleted. Optional.
+	Observe func(ctx context.Context, pool *v1.InferencePool)
+	// Resync is the interval the pool is reconciled again at, e.g. to pick up the activator configuration
+	// filled in from other objects. Optional.
+	Resync time.Duration
 }
 
 func (c *InferencePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -64,6 +85,7 @@ func (c *InferencePoolReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		if errors.IsNotFound(err) {
 			logger.Info("InferencePool not found. Clearing the datastore")
 			c.Datastore.Clear()
+			c.observe(ctx, nil)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, fmt.Errorf("unable to get InferencePool - %w", err)
@@ -73,6 +95,7 @@ func (c *InferencePoolReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	if !obj.GetDeletionTimestamp().IsZero() {
 		logger.Info("InferencePool is marked for deletion. Clearing the datastore")
 		c.Datastore.Clear()
+		c.observe(ctx, nil)
 		return ctrl.Result{}, nil
 	}
 
@@ -93,9 +116,52 @@ func (c *InferencePoolReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, fmt.Errorf("unsupported API group: %s", c.PoolGKNN.Group)
 	}
 
+	// 5. Fill in the defaults of the activator configuration, which are never written back to the pool.
+	var result ctrl.Result
+	if c.Default != nil {
+		if err := c.Default(ctx, v1infPool); err != nil {
+			result.RequeueAfter = defaultingRetryInterval
+			logger.Error(err, "Failed to fill in the activator configuration of the InferencePool")
+			if c.Recorder != nil {
+				c.Recorder.Event(obj, corev1.EventTypeWarning, "ActivatorConfigurationDefaultingFailed", err.Error())
+			}
+		}
+	}
+
+	// 6. Validate the activator configuration. An invalid configuration is reported but does not
+	// prevent the pool from being stored, so that requests keep flowing while it gets fixed.
+	if c.Validate != nil {
+		if err := c.Validate(v1infPool); err != nil {
+			logger.Error(err, "InferencePool has an invalid activator configuration")
+			if c.Recorder != nil {
+				c.Recorder.Event(obj, corev1.EventTypeWarning, "InvalidActivatorConfiguration", err.Error())
+			}
+		}
+	}
+
+	// 7. Check the activator configuration against the cluster, reporting the inconsistencies likewise.
+	if c.Check != nil {
+		if err := c.Check(ctx, v1infPool); err != nil {
+			logger.Error(err, "InferencePool has an activator configuration inconsistent with the cluster")
+			if c.Recorder != nil {
+				c.Recorder.Event(obj, corev1.EventTypeWarning, "InconsistentActivatorConfiguration", err.Error())
+			}
+		}
+	}
+
 	c.Datastore.PoolSet(v1infPool)
+	c.observe(ctx, v1infPool)
 
-	return ctrl.Result{}, nil
+	if result.RequeueAfter == 0 {
+		result.RequeueAfter = c.Resync
+	}
+	return result, nil
+}
+
+func (c *InferencePoolReconciler) observe(ctx context.Context, pool *v1.InferencePool) {
+	if c.Observe != nil {
+		c.Observe(ctx, pool)
+	}
 }
 
 func (c *InferencePoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -43,6 +43,17 @@ type Datastore interface {
 
 	// Clears the store state, happens when the pool gets deleted.
 	Clear()
+	// PoolDeleted returns a channel closed when the pool currently stored gets deleted.
+	PoolDeleted() <-chan struct{}
+
+	// PoolState returns the activation state of the pool.
+	PoolState() PoolState
+	// PoolTransition moves the pool to the given state if it is in one of the given from states, and reports
+	// whether it did.
+	PoolTransition(to PoolState, from ...PoolState) bool
+	// PoolActiveSince returns when the pool last became active after being idle or scaled up from zero, or
+	// zero when it did not since the activator started.
+	PoolActiveSince() time.Time
 }
 
 func NewDatastore(parentCtx context.Context) Datastore {
@@ -50,6 +61,8 @@ func NewDatastore(parentCtx context.Context) Datastore {
 		parentCtx: parentCtx,
 		poolMu:    sync.RWMutex{},
 		ticker:    time.NewTicker(60 * time.Second),
+		deleted:   make(chan struct{}),
+		state:     PoolActive,
 	}
 	return store
 }
@@ -61,6 +74,12 @@ type datastore struct {
 	poolMu sync.RWMutex
 	pool   *v1.InferencePool
 	ticker *time.Ticker
+	// deleted is closed when the pool gets deleted, and replaced when a pool is stored again.
+	deleted chan struct{}
+	// state is the activation state of the pool, guarded by poolMu.
+	state PoolState
+	// activeSince is when the pool last became active after being idle or scaled up from zero, guarded by poolMu.
+	activeSince time.Time
 }
 
 // /// InferencePool APIs ///
@@ -69,6 +88,9 @@ func (ds *datastore) PoolSet(pool *v1.InferencePool) {
 	defer ds.poolMu.Unlock()
 
 	ds.pool = pool
+	if pool != nil && isClosed(ds.deleted) {
+		ds.deleted = make(chan struct{})
+	}
 }
 
 func (ds *datastore) PoolGet() (*v1.InferencePool, error) {
@@ -87,7 +109,30 @@ func (ds *datastore) PoolHasSynced() bool {
 }
 
 func (ds *datastore) Clear() {
-	ds.PoolSet(nil)
+	ds.poolMu.Lock()
+	defer ds.poolMu.Unlock()
+
+	ds.setStateLocked(PoolActive)
+	ds.activeSince = time.Time{}
+	ds.pool = nil
+	if !isClosed(ds.deleted) {
+		close(ds.deleted)
+	}
+}
+
+func (ds *datastore) PoolDeleted() <-chan struct{} {
+	ds.poolMu.RLock()
+	defer ds.poolMu.RUnlock()
+	return ds.deleted
+}
+
+func isClosed(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
 }
 
 func (ds *datastore) ResetTicker(t time.Duration) {
@@ -81,3 +81,84 @@ func TestPool(t *testing.T) {
 		})
 	}
 }
+
+func TestPoolDeleted(t *testing.T) {
+	pool := testutil.MakeInferencePool("pool1").Namespace("default").ObjRef()
+	datastore := NewDatastore(context.Background())
+	datastore.PoolSet(pool)
+
+	deleted := datastore.PoolDeleted()
+	select {
+	case <-deleted:
+		t.Fatal("PoolDeleted() closed before the pool was deleted")
+	default:
+	}
+
+	datastore.Clear()
+	datastore.Clear()
+	select {
+	case <-deleted:
+	default:
+		t.Fatal("PoolDeleted() not closed after the pool was deleted")
+	}
+
+	datastore.PoolSet(pool)
+	select {
+	case <-datastore.PoolDeleted():
+		t.Fatal("PoolDeleted() still closed after the pool was created again")
+	default:
+	}
+}
+
+func TestPoolTransition(t *testing.T) {
+	datastore := NewDatastore(context.Background())
+	datastore.PoolSet(testutil.MakeInferencePool("pool1").Namespace("default").ObjRef())
+
+	steps := []struct {
+		name      string
+		to        PoolState
+		from      []PoolState
+		wantOK    bool
+		wantState PoolState
+	}{
+		{name: "scale down of an active pool", to: PoolDeactivating, from: []PoolState{PoolIdle, PoolActive}, wantOK: true, wantState: PoolDeactivating},
+		{name: "scale up during the scale down", to: PoolActivating, from: []PoolState{PoolIdle, PoolActive}, wantOK: false, wantState: PoolDeactivating},
+		{name: "scale down done", to: PoolIdle, from: []PoolState{PoolDeactivating}, wantOK: true, wantState: PoolIdle},
+		{name: "scale up of the idle pool", to: PoolActivating, from: []PoolState{PoolIdle, PoolActive}, wantOK: true, wantState: PoolActivating},
+		{name: "scale down during the scale up", to: PoolDeactivating, from: []PoolState{PoolIdle, PoolActive}, wantOK: false, wantState: PoolActivating},
+		{name: "scale up done", to: PoolActive, from: []PoolState{PoolActivating}, wantOK: true, wantState: PoolActive},
+	}
+	for _, step := range steps {
+		if ok := datastore.PoolTransition(step.to, step.from...); ok != step.wantOK {
+			t.Errorf("%s: PoolTransition() = %t, want %t", step.name, ok, step.wantOK)
+		}
+		if state := datastore.PoolState(); state != step.wantState {
+			t.Errorf("%s: PoolState() = %s, want %s", step.name, state, step.wantState)
+		}
+	}
+}
+
+func TestPoolActiveSince(t *testing.T) {
+	datastore := NewDatastore(context.Background())
+	if since := datastore.PoolActiveSince(); !since.IsZero() {
+		t.Fatalf("PoolActiveSince() = %v on startup, want zero", since)
+	}
+
+	datastore.PoolTransition(PoolDeactivating, PoolActive)
+	datastore.PoolTransition(PoolActive, PoolDeactivating)
+	if since := datastore.PoolActiveSince(); !since.IsZero() {
+		t.Errorf("PoolActiveSince() = %v after a scale down called off, want zero", since)
+	}
+
+	datastore.PoolTransition(PoolIdle, PoolActive)
+	datastore.PoolTransition(PoolActivating, PoolIdle)
+	datastore.PoolTransition(PoolActive, PoolActivating)
+	if since := datastore.PoolActiveSince(); since.IsZero() {
+		t.Errorf("PoolActiveSince() is zero after a scale up from zero")
+	}
+
+	datastore.Clear()
+	if since := datastore.PoolActiveSince(); !since.IsZero() {
+		t.Errorf("PoolActiveSince() = %v after the pool was deleted, want zero", since)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datastore
+
+import (
+	"slices"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// PoolState is the activation state of the pool, consulted by the Activator and the Deactivator so that a
+// scale to zero never races with a scale from zero in progress.
+type PoolState string
+
+const (
+	// PoolIdle is the state of a pool scaled to zero, or whose scale from zero failed.
+	PoolIdle PoolState = "Idle"
+	// PoolActivating is the state of a pool the Activator is scaling up from zero.
+	PoolActivating PoolState = "Activating"
+	// PoolActive is the state of a pool serving requests, the initial state of a pool until proven otherwise.
+	PoolActive PoolState = "Active"
+	// PoolDeactivating is the state of a pool the Deactivator is scaling down to zero.
+	PoolDeactivating PoolState = "Deactivating"
+)
+
+// PoolStates lists the states of a pool.
+var PoolStates = []PoolState{PoolIdle, PoolActivating, PoolActive, PoolDeactivating}
+
+func (ds *datastore) PoolState() PoolState {
+	ds.poolMu.RLock()
+	defer ds.poolMu.RUnlock()
+	return ds.state
+}
+
+func (ds *datastore) PoolActiveSince() time.Time {
+	ds.poolMu.RLock()
+	defer ds.poolMu.RUnlock()
+	return ds.activeSince
+}
+
+func (ds *datastore) PoolTransition(to PoolState, from ...PoolState) bool {
+	ds.poolMu.Lock()
+	defer ds.poolMu.Unlock()
+
+	if !slices.Contains(from, ds.state) {
+		return false
+	}
+	ds.setStateLocked(to)
+	return true
+}
+
+// setStateLocked moves the pool to the given state. poolMu must be held.
+func (ds *datastore) setStateLocked(to PoolState) {
+	from := ds.state
+	ds.state = to
+	if from == to {
+		return
+	}
+	// A scale down called off leaves the pool active rather than making it active again
+	if to == PoolActive && (from == PoolIdle || from == PoolActivating) {
+		ds.activeSince = time.Now()
+	}
+
+	poolName := ""
+	if ds.pool != nil {
+		poolName = ds.pool.Namespace + "/" + ds.pool.Name
+	}
+	log.FromContext(ds.parentCtx).V(logutil.DEBUG).Info("InferencePool state transition", "pool", poolName, "from", from, "to", to)
+	metrics.RecordPoolStateTransition(poolName, string(from), string(to))
+	for _, state := range PoolStates {
+		metrics.SetPoolState(poolName, string(state), state == to)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package features gates the experimental behaviors of the activator, so that operators can enable them
+// incrementally and partial features can ship disabled.
+package features
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/component-base/featuregate"
+)
+
+const (
+	// PanicMode scales pools up beyond one replica during request bursts, for pools setting a target request rate.
+	PanicMode featuregate.Feature = "PanicMode"
+
+	// ResponseCache answers repeated cacheable requests from a cache of recent responses while the pool is cold.
+	ResponseCache featuregate.Feature = "ResponseCache"
+
+	// TrustedClientBypass lets the requests of trusted internal clients bypass the activator while the pool is warm.
+	TrustedClientBypass featuregate.Feature = "TrustedClientBypass"
+
+	// AbandonedActivationCancellation cancels, and optionally reverts, the scale ups from zero whose held
+	// requests all disconnected.
+	AbandonedActivationCancellation featuregate.Feature = "AbandonedActivationCancellation"
+
+	// RolloutAwareActivation pauses the replica changes of the activator while the target workload rolls out.
+	RolloutAwareActivation featuregate.Feature = "RolloutAwareActivation"
+
+	// ReleaseGates lets pools hold or reject their requests with operator provided release gates once they are active.
+	ReleaseGates featuregate.Feature = "ReleaseGates"
+)
+
+var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
+	PanicMode:                       {Default: true, PreRelease: featuregate.Beta},
+	ResponseCache:                   {Default: true, PreRelease: featuregate.Beta},
+	TrustedClientBypass:             {Default: true, PreRelease: featuregate.Beta},
+	AbandonedActivationCancellation: {Default: true, PreRelease: featuregate.Beta},
+	RolloutAwareActivation:          {Default: true, PreRelease: featuregate.Beta},
+	ReleaseGates:                    {Default: false, PreRelease: featuregate.Alpha},
+}
+
+// Gate holds the state of the feature gates of the activator.
+var Gate featuregate.MutableFeatureGate = featuregate.NewFeatureGate()
+
+func init() {
+	utilruntime.Must(Gate.Add(defaultFeatureGates))
+}
+
+// Enabled reports whether the given feature is enabled.
+func Enabled(feature featuregate.Feature) bool {
+	return Gate.Enabled(feature)
+}
+
+// SetFromFile sets feature gates from the given file, e.g. mounted from a ConfigMap, holding "Feature=bool"
+// entries separated by commas or new lines. Empty lines and lines starting with '#' are ignored.
+func SetFromFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read feature gates file: %w", err)
+	}
+
+	var entries []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries = append(entries, line)
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	return Gate.Set(strings.Join(entries, ","))
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/component-base/featuregate"
+)
+
+func TestSetFromFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		wantErr  bool
+		disabled []string
+	}{
+		{name: "empty file", content: ""},
+		{
+			name:     "one feature per line",
+			content:  "# experimental behaviors\nPanicMode=false\n\nResponseCache=false\n",
+			disabled: []string{"PanicMode", "ResponseCache"},
+		},
+		{name: "comma separated features", content: "PanicMode=false, RolloutAwareActivation=false", disabled: []string{"PanicMode", "RolloutAwareActivation"}},
+		{name: "unknown feature", content: "Teleport=true", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defaults := Gate.DeepCopy()
+			t.Cleanup(func() { Gate = defaults })
+
+			path := filepath.Join(t.TempDir(), "feature-gates")
+			if err := os.WriteFile(path, []byte(test.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if err := SetFromFile(path); (err != nil) != test.wantErr {
+				t.Fatalf("SetFromFile() error = %v, wantErr %t", err, test.wantErr)
+			}
+			for _, feature := range test.disabled {
+				if Enabled(featuregate.Feature(feature)) {
+					t.Errorf("feature %s is enabled, want disabled", feature)
+				}
+			}
+		})
+	}
+}
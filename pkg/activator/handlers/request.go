@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	filterPb "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metadata"
+)
+
+// buildRequestHeadersResponse builds the response to a request headers message. When the request was held
+// by the activator, the time already spent waiting is subtracted from the timeout hints of the request,
+// so that the backend does not work against a budget that has already partially expired.
+//
+// When the request asked for an alias of the model served by the InferencePool, the request is routed
+// again for the served model, and the Endpoint Picker is asked to rewrite the model of the request body.
+//
+// When only some replicas were ready when the request was released, their endpoints are emitted as the
+// endpoint subset hint of the Endpoint Picker, which must forward the "envoy.lb.subset_hint" metadata
+// namespace to consider it.
+//
+// Cacheable requests ask Envoy to send their request and response bodies, which requires the activator
+// filter to allow mode overrides.
+//
+// The state of the InferencePool when the request arrived is emitted as dynamic metadata, when known.
+func buildRequestHeadersResponse(reqCtx *RequestContext) *extProcPb.ProcessingResponse {
+	mutation := timeoutHeaderMutation(reqCtx.Request.Headers, reqCtx.ActivationWait)
+	if reqCtx.ModelRewrite != "" {
+		if mutation == nil {
+			mutation = &extProcPb.HeaderMutation{}
+		}
+		for _, key := range []string{ModelNameHeaderKey, ModelNameRewriteHeaderKey} {
+			mutation.SetHeaders = append(mutation.SetHeaders, &configPb.HeaderValueOption{
+				Header: &configPb.HeaderValue{Key: key, RawValue: []byte(reqCtx.ModelRewrite)},
+			})
+		}
+	}
+	for _, key := range []string{BypassHeaderKey, DebugBypassHeaderKey} {
+		if _, ok := reqCtx.Request.Headers[key]; ok {
+			if mutation == nil {
+				mutation = &extProcPb.HeaderMutation{}
+			}
+			mutation.RemoveHeaders = append(mutation.RemoveHeaders, key)
+		}
+	}
+	dynamicMetadata := mergeMetadata(endpointSubsetMetadata(reqCtx.EndpointSubset), poolStateMetadata(reqCtx))
+	if mutation == nil && dynamicMetadata == nil && !reqCtx.Cacheable {
+		return continueHeadersResponse
+	}
+
+	var modeOverride *filterPb.ProcessingMode
+	if reqCtx.Cacheable {
+		modeOverride = &filterPb.ProcessingMode{
+			RequestHeaderMode:  filterPb.ProcessingMode_SEND,
+			ResponseHeaderMode: filterPb.ProcessingMode_SEND,
+			RequestBodyMode:    filterPb.ProcessingMode_BUFFERED,
+			ResponseBodyMode:   filterPb.ProcessingMode_BUFFERED,
+		}
+	}
+
+	return &extProcPb.ProcessingResponse{
+		DynamicMetadata: dynamicMetadata,
+		ModeOverride:    modeOverride,
+		Response: &extProcPb.ProcessingResponse_RequestHeaders{
+			RequestHeaders: &extProcPb.HeadersResponse{
+				Response: &extProcPb.CommonResponse{
+					Status:          extProcPb.CommonResponse_CONTINUE,
+					HeaderMutation:  mutation,
+					ClearRouteCache: reqCtx.ModelRewrite != "",
+				},
+			},
+		},
+	}
+}
+
+// endpointSubsetMetadata returns the dynamic metadata restricting the Endpoint Picker to the given
+// endpoints, or nil if there are none.
+func endpointSubsetMetadata(endpoints []string) *structpb.Struct {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	subset := make([]any, len(endpoints))
+	for i, endpoint := range endpoints {
+		subset[i] = endpoint
+	}
+	hint, err := structpb.NewStruct(map[string]any{
+		metadata.SubsetFilterNamespace: map[string]any{
+			metadata.SubsetFilterKey: subset,
+		},
+	})
+	if err != nil {
+		return nil
+	}
+	return hint
+}
+
+// poolStateMetadata returns the dynamic metadata describing the state of the InferencePool when the request
+// arrived, or nil if it is not known.
+func poolStateMetadata(reqCtx *RequestContext) *structpb.Struct {
+	if reqCtx.PoolState == "" {
+		return nil
+	}
+	state, err := structpb.NewStruct(map[string]any{
+		PoolStateMetadataNamespace: map[string]any{
+			PoolStateMetadataKey:  reqCtx.PoolState,
+			QueueDepthMetadataKey: reqCtx.QueueDepth,
+		},
+	})
+	if err != nil {
+		return nil
+	}
+	return state
+}
+
+// mergeMetadata returns the namespaces of all the given dynamic metadata, or nil if there are none.
+func mergeMetadata(all ...*structpb.Struct) *structpb.Struct {
+	var merged *structpb.Struct
+	for _, metadata := range all {
+		if metadata == nil {
+			continue
+		}
+		if merged == nil {
+			merged = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+		}
+		for namespace, value := range metadata.Fields {
+			merged.Fields[namespace] = value
+		}
+	}
+	return merged
+}
+
+// buildRequestBodyResponse builds the response letting the body of a cacheable request through.
+func buildRequestBodyResponse() *extProcPb.ProcessingResponse {
+	return &extProcPb.ProcessingResponse{
+		Response: &extProcPb.ProcessingResponse_RequestBody{
+			RequestBody: &extProcPb.BodyResponse{
+				Response: &extProcPb.CommonResponse{
+					Status: extProcPb.CommonResponse_CONTINUE,
+				},
+			},
+		},
+	}
+}
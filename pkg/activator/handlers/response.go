@@ -0,0 +1,236 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+)
+
+const (
+	// ActivationStatusHeaderKey is added to responses of requests that were held during a scale from zero.
+	ActivationStatusHeaderKey = "x-activator-status"
+	// ActivationWaitHeaderKey reports the amount of time, in milliseconds, the request was held by the activator.
+	ActivationWaitHeaderKey = "x-activator-wait-ms"
+	// ActivationStagesHeaderKey breaks the latency of a cold-started request down by stage, in milliseconds,
+	// e.g. "parse=0,queue=2,scale=45,pod_readiness=41250,epp_sync=800,release=1".
+	ActivationStagesHeaderKey = "x-activator-stages-ms"
+	// ActivationRemainingHeaderKey reports the wait budget left for the scale from zero in progress, in
+	// milliseconds, to requests not held for it.
+	ActivationRemainingHeaderKey = "x-activator-remaining-ms"
+	// ActivationReplicasHeaderKey reports the ready and desired replicas of the scale from zero in progress,
+	// e.g. "1/2", to requests not held for it.
+	ActivationReplicasHeaderKey = "x-activator-replicas"
+	// PreferHeaderKey carries the preferences of the client (RFC 7240). Clients preferring "respond-async" on
+	// routes reporting the activation progress are answered with the progress of a scale from zero instead of
+	// being held for it.
+	PreferHeaderKey = "prefer"
+	// FailureReasonHeaderKey is added to error responses of requests whose activation failed, to classify the failure.
+	FailureReasonHeaderKey = "x-activator-failure-reason"
+
+	activationStatusColdStart         = "cold-start"
+	activationStatusCached            = "cached"
+	activationStatusOverloaded        = "overloaded"
+	activationStatusQueuedForCapacity = "queued-for-capacity"
+	activationStatusRateLimited       = "rate-limited"
+	activationStatusWarmingUp         = "warming-up"
+
+	// preferRespondAsync is the preference of clients asking not to wait for the scale from zero.
+	preferRespondAsync = "respond-async"
+
+	// maxWarmingUpRetryAfter is the most number of seconds clients answered with the progress of a scale from
+	// zero are advised to wait before asking again, for them to follow its progress.
+	maxWarmingUpRetryAfter = 5
+
+	// queuedForCapacityRetryAfter is the number of seconds clients are advised to wait before retrying
+	// requests that gave up while their InferencePool was queued for capacity.
+	queuedForCapacityRetryAfter = "60"
+
+	// overloadedRetryAfter is the number of seconds clients are advised to wait before retrying requests
+	// shed while the activator was overloaded.
+	overloadedRetryAfter = "1"
+
+	// rateLimitedRetryAfter is the number of seconds clients are advised to wait before retrying requests
+	// rejected by the rate limit of their gateway.
+	rateLimitedRetryAfter = "1"
+)
+
+// buildResponseHeadersResponse builds the response to a response headers message. Envoy cannot relay
+// interim (1xx) responses from an external processor, so the activation progress of cold-started
+// requests is reported to clients as headers of the final response. Clients wanting the progress before the
+// pool is ready prefer "respond-async", see setWarmingUpHeaders.
+func buildResponseHeadersResponse(reqCtx *RequestContext) *extProcPb.ProcessingResponse {
+	commonResponse := &extProcPb.CommonResponse{
+		Status: extProcPb.CommonResponse_CONTINUE,
+	}
+	if reqCtx.ColdStart {
+		commonResponse.HeaderMutation = &extProcPb.HeaderMutation{
+			SetHeaders: []*configPb.HeaderValueOption{
+				{
+					Header: &configPb.HeaderValue{
+						Key:      ActivationStatusHeaderKey,
+						RawValue: []byte(activationStatusColdStart),
+					},
+				},
+				{
+					Header: &configPb.HeaderValue{
+						Key:      ActivationWaitHeaderKey,
+						RawValue: []byte(strconv.FormatInt(reqCtx.ActivationWait.Milliseconds(), 10)),
+					},
+				},
+			},
+		}
+		if len(reqCtx.ActivationStages) > 0 {
+			stages := make([]string, 0, len(reqCtx.ActivationStages))
+			for _, stage := range reqCtx.ActivationStages {
+				stages = append(stages, stage.Stage+"="+strconv.FormatInt(stage.Duration.Milliseconds(), 10))
+			}
+			commonResponse.HeaderMutation.SetHeaders = append(commonResponse.HeaderMutation.SetHeaders, &configPb.HeaderValueOption{
+				Header: &configPb.HeaderValue{
+					Key:      ActivationStagesHeaderKey,
+					RawValue: []byte(strings.Join(stages, ",")),
+				},
+			})
+		}
+	}
+
+	return &extProcPb.ProcessingResponse{
+		Response: &extProcPb.ProcessingResponse_ResponseHeaders{
+			ResponseHeaders: &extProcPb.HeadersResponse{
+				Response: commonResponse,
+			},
+		},
+	}
+}
+
+// buildResponseBodyResponse builds the response letting the response body of a cacheable request through.
+func buildResponseBodyResponse() *extProcPb.ProcessingResponse {
+	return &extProcPb.ProcessingResponse{
+		Response: &extProcPb.ProcessingResponse_ResponseBody{
+			ResponseBody: &extProcPb.BodyResponse{
+				Response: &extProcPb.CommonResponse{
+					Status: extProcPb.CommonResponse_CONTINUE,
+				},
+			},
+		},
+	}
+}
+
+// buildCachedResponse builds the immediate response answering a request from the response cache while its
+// InferencePool is cold. The response is marked as cached, along with its age in seconds.
+func buildCachedResponse(cached *CachedResponse) *extProcPb.ProcessingResponse {
+	resp := &extProcPb.ProcessingResponse{
+		Response: &extProcPb.ProcessingResponse_ImmediateResponse{
+			ImmediateResponse: &extProcPb.ImmediateResponse{
+				Status: &envoyTypePb.HttpStatus{
+					Code: envoyTypePb.StatusCode_OK,
+				},
+				Body: cached.Body,
+			},
+		},
+	}
+	headers := map[string]string{
+		ActivationStatusHeaderKey: activationStatusCached,
+		"age":                     strconv.FormatInt(int64(time.Since(cached.StoredAt).Seconds()), 10),
+	}
+	if cached.ContentType != "" {
+		headers["content-type"] = cached.ContentType
+	}
+	addImmediateResponseHeaders(resp, headers)
+	return resp
+}
+
+// setQueuedForCapacityHeaders marks the immediate response of a request that gave up while its InferencePool
+// was queued for capacity, so that clients can tell it apart from a failed activation.
+func setQueuedForCapacityHeaders(resp *extProcPb.ProcessingResponse) {
+	addImmediateResponseHeaders(resp, map[string]string{
+		ActivationStatusHeaderKey: activationStatusQueuedForCapacity,
+		"retry-after":             queuedForCapacityRetryAfter,
+	})
+}
+
+// setOverloadedHeaders marks the immediate response of a request shed while the activator was overloaded,
+// so that clients can tell it apart from a pool being out of capacity.
+func setOverloadedHeaders(resp *extProcPb.ProcessingResponse) {
+	addImmediateResponseHeaders(resp, map[string]string{
+		ActivationStatusHeaderKey: activationStatusOverloaded,
+		"retry-after":             overloadedRetryAfter,
+	})
+}
+
+// setRateLimitedHeaders marks the immediate response of a request rejected by the rate limit of its gateway.
+func setRateLimitedHeaders(resp *extProcPb.ProcessingResponse) {
+	addImmediateResponseHeaders(resp, map[string]string{
+		ActivationStatusHeaderKey: activationStatusRateLimited,
+		"retry-after":             rateLimitedRetryAfter,
+	})
+}
+
+// setWarmingUpHeaders reports the progress of the scale from zero of the InferencePool in the immediate response
+// of a request that preferred not to be held for it, advising the client when to ask again.
+func setWarmingUpHeaders(resp *extProcPb.ProcessingResponse, warmUp *WarmUp) {
+	retryAfter := min(max(int64(math.Ceil(warmUp.Remaining.Seconds())), 1), maxWarmingUpRetryAfter)
+	addImmediateResponseHeaders(resp, map[string]string{
+		ActivationStatusHeaderKey:    activationStatusWarmingUp,
+		ActivationRemainingHeaderKey: strconv.FormatInt(warmUp.Remaining.Milliseconds(), 10),
+		ActivationReplicasHeaderKey:  fmt.Sprintf("%d/%d", warmUp.ReadyReplicas, warmUp.DesiredReplicas),
+		"preference-applied":         preferRespondAsync,
+		"retry-after":                strconv.FormatInt(retryAfter, 10),
+	})
+}
+
+// PrefersRespondAsync reports whether the client of the request with the given headers prefers an immediate
+// response over waiting for the scale from zero of its InferencePool.
+func PrefersRespondAsync(headers map[string]string) bool {
+	for _, preference := range strings.Split(headers[PreferHeaderKey], ",") {
+		token, _, _ := strings.Cut(preference, ";")
+		if strings.EqualFold(strings.TrimSpace(token), preferRespondAsync) {
+			return true
+		}
+	}
+	return false
+}
+
+// setFailureReasonHeader adds the reason of the failed activation to the immediate response of a request.
+func setFailureReasonHeader(resp *extProcPb.ProcessingResponse, reason string) {
+	addImmediateResponseHeaders(resp, map[string]string{FailureReasonHeaderKey: reason})
+}
+
+func addImmediateResponseHeaders(resp *extProcPb.ProcessingResponse, headers map[string]string) {
+	immediate, ok := resp.Response.(*extProcPb.ProcessingResponse_ImmediateResponse)
+	if !ok {
+		return
+	}
+	if immediate.ImmediateResponse.Headers == nil {
+		immediate.ImmediateResponse.Headers = &extProcPb.HeaderMutation{}
+	}
+	mutation := immediate.ImmediateResponse.Headers
+	for _, key := range slices.Sorted(maps.Keys(headers)) {
+		mutation.SetHeaders = append(mutation.SetHeaders, &configPb.HeaderValueOption{
+			Header: &configPb.HeaderValue{Key: key, RawValue: []byte(headers[key])},
+		})
+	}
+}
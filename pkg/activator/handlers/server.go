@@ -19,10 +19,13 @@ package handlers
 import (
 	"context"
 	"io"
+	"strings"
+	"time"
 
 	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
 	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -41,15 +44,23 @@ var continueHeadersResponse = &extProcPb.ProcessingResponse{
 	},
 }
 
-func NewStreamingServer(datastore Datastore, activator Activator) *StreamingServer {
+func NewStreamingServer(datastore Datastore, director Director) *StreamingServer {
 	return &StreamingServer{
-		activator: activator,
+		director:  director,
 		datastore: datastore,
 	}
 }
 
-type Activator interface {
-	MayActivate(ctx context.Context) error
+type Director interface {
+	HandleRequest(ctx context.Context, reqCtx *RequestContext) (*RequestContext, error)
+	// HandleRequestBody is called with the request body of cacheable requests. It returns the cached
+	// response to answer the request with, if any.
+	HandleRequestBody(ctx context.Context, reqCtx *RequestContext, body []byte) (*CachedResponse, error)
+	// HandleResponseBody is called with the response body of cacheable requests.
+	HandleResponseBody(ctx context.Context, reqCtx *RequestContext, body []byte)
+	// HandleRequestCompletion is called once the HTTP request is over, when Envoy closes the processing stream,
+	// that is after the end of the response, streamed or not. It is called concurrently for different requests.
+	HandleRequestCompletion(ctx context.Context, reqCtx *RequestContext)
 }
 
 type Datastore interface {
@@ -60,7 +71,125 @@ type Datastore interface {
 // https://www.envoyproxy.io/docs/envoy/latest/api-v3/service/ext_proc/v3/external_processor.proto
 type StreamingServer struct {
 	datastore Datastore
-	activator Activator
+	director  Director
+}
+
+// StageDuration is the time a request spent in a stage of its cold start.
+type StageDuration struct {
+	Stage    string
+	Duration time.Duration
+}
+
+// WarmUp is the progress of the scale up from zero of the InferencePool, reported to a client that preferred not
+// to be held for it.
+type WarmUp struct {
+	// Remaining is the wait budget left before the requests held for the scale up are failed.
+	Remaining       time.Duration
+	DesiredReplicas int32
+	ReadyReplicas   int64
+}
+
+// RequestContext stores context information during the life time of an HTTP request.
+type RequestContext struct {
+	RequestReceivedTimestamp time.Time
+	// ActivationProgress is set when the route of the request reports the activation progress to clients.
+	ActivationProgress bool
+	// WarmingUp is set when the request preferred not to be held while its InferencePool was scaled up from
+	// zero, and was answered with the progress of the scale up instead.
+	WarmingUp *WarmUp
+	// ColdStart is set when the request was held while its InferencePool was scaled up from zero.
+	ColdStart bool
+	// ActivationWait is the amount of time the request was held by the activator.
+	ActivationWait time.Duration
+	// ActivationStages breaks the latency of a cold-started request down by stage, in order.
+	ActivationStages []StageDuration
+	// QueuedForCapacity is set when the request gave up while its InferencePool was waiting for capacity.
+	QueuedForCapacity bool
+	// Overloaded is set when the request was shed because the activator itself is overloaded.
+	Overloaded bool
+	// RateLimited is set when the request was rejected by the rate limit of its gateway.
+	RateLimited bool
+	// Gateway names the gateway the request came through, when the activator serves several gateways.
+	Gateway string
+	// FailureReason classifies why the activation of the InferencePool failed, if it did.
+	FailureReason string
+	// EndpointSubset lists the endpoints the Endpoint Picker should pick from, when only some replicas
+	// of the InferencePool were ready when the request was released.
+	EndpointSubset []string
+	// ModelRewrite is the model served by the InferencePool when the request asked for an alias of it.
+	ModelRewrite string
+	// Batch is set when the request is a long-running batch request.
+	Batch bool
+	// Admitted is set once the request was released to the InferencePool.
+	Admitted bool
+	// Cacheable is set when the response to the request may be cached, in which case Envoy is asked to
+	// send the request and response bodies.
+	Cacheable bool
+	// ServeStale is set when the cacheable request arrived while its InferencePool was cold, and its
+	// activation is deferred until the request body shows whether it can be answered from the cache.
+	ServeStale bool
+	// CacheKey identifies the response to the request in the response cache.
+	CacheKey string
+	// PoolState is the activation state of the InferencePool when the request arrived, one of PoolStateCold,
+	// PoolStateActivating and PoolStateReady, emitted as dynamic metadata when set.
+	PoolState string
+	// QueueDepth is the number of requests held for the activation of the InferencePool when the request arrived.
+	QueueDepth int32
+	Request    *Request
+	Response   *Response
+}
+
+const (
+	// ModelNameHeaderKey carries the requested model, as set by body based routing in front of the activator.
+	ModelNameHeaderKey = "x-gateway-model-name"
+	// ModelNameRewriteHeaderKey asks the Endpoint Picker to rewrite the model of the request body before
+	// forwarding it to the model server.
+	ModelNameRewriteHeaderKey = "x-gateway-model-name-rewrite"
+	// BypassHeaderKey carries the signature of trusted internal clients bypassing the activator. It is
+	// removed before the request is forwarded.
+	BypassHeaderKey = "x-activator-bypass"
+	// DebugBypassHeaderKey lets the request through without activation check when the debug bypass is enabled,
+	// to debug the data path. It is removed before the request is forwarded.
+	DebugBypassHeaderKey = "x-llmd-activator-bypass"
+	// GatewayMetadataKey is the gRPC metadata of the processing stream naming the gateway the request came
+	// through, set by the ext_proc filter of each gateway sharing the activator with initial_metadata.
+	GatewayMetadataKey = "x-activator-gateway"
+
+	// ActivationProgressMetadataKey is the gRPC metadata of the processing stream set to "true" by the ext_proc
+	// filter of the routes reporting the activation progress to clients.
+	ActivationProgressMetadataKey = "x-activator-progress"
+
+	// PoolStateMetadataNamespace is the dynamic metadata namespace the state of the InferencePool is emitted in,
+	// for Envoy filters and access logs to react to the activation state, e.g.
+	// %DYNAMIC_METADATA(llm-d.activator:pool_state)%. The ext_proc filter must accept that namespace.
+	PoolStateMetadataNamespace = "llm-d.activator"
+	// PoolStateMetadataKey is the activation state of the InferencePool in the pool state metadata namespace.
+	PoolStateMetadataKey = "pool_state"
+	// QueueDepthMetadataKey is the number of requests held for the activation of the InferencePool in the pool
+	// state metadata namespace.
+	QueueDepthMetadataKey = "queue_depth"
+
+	// PoolStateCold is the state of an InferencePool scaled to zero, or being scaled down to zero.
+	PoolStateCold = "cold"
+	// PoolStateActivating is the state of an InferencePool being scaled up from zero.
+	PoolStateActivating = "activating"
+	// PoolStateReady is the state of an InferencePool serving requests.
+	PoolStateReady = "ready"
+)
+
+type Request struct {
+	Headers map[string]string
+}
+
+type Response struct {
+	Headers map[string]string
+}
+
+// CachedResponse is a response served from the response cache.
+type CachedResponse struct {
+	ContentType string
+	Body        []byte
+	StoredAt    time.Time
 }
 
 func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer) error {
@@ -69,6 +198,23 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 	loggerTrace := logger.V(logutil.TRACE)
 	loggerTrace.Info("Processing")
 
+	// Create request context to share states during life time of an HTTP request.
+	// See https://github.com/envoyproxy/envoy/issues/17540.
+	reqCtx := &RequestContext{
+		Gateway:            gatewayFromMetadata(ctx),
+		ActivationProgress: activationProgressFromMetadata(ctx),
+		Request: &Request{
+			Headers: make(map[string]string),
+		},
+		Response: &Response{
+			Headers: make(map[string]string),
+		},
+	}
+
+	defer func() {
+		s.director.HandleRequestCompletion(ctx, reqCtx)
+	}()
+
 	var err error
 	for {
 		select {
@@ -85,10 +231,11 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 			return status.Errorf(codes.Unknown, "cannot receive stream request: %v", err)
 		}
 
-		switch req.Request.(type) {
+		switch v := req.Request.(type) {
 		case *extProcPb.ProcessingRequest_RequestHeaders:
+			s.HandleRequestHeaders(reqCtx, v)
 
-			if err := s.activator.MayActivate(ctx); err != nil {
+			if reqCtx, err = s.director.HandleRequest(ctx, reqCtx); err != nil {
 				if logger.V(logutil.DEBUG).Enabled() {
 					logger.V(logutil.DEBUG).Error(err, "Failed to process request", "request", req)
 				} else {
@@ -98,6 +245,22 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 				if err != nil {
 					return err
 				}
+				if reqCtx.QueuedForCapacity {
+					setQueuedForCapacityHeaders(resp)
+				}
+				if reqCtx.Overloaded {
+					setOverloadedHeaders(resp)
+				}
+				if reqCtx.RateLimited {
+					setRateLimitedHeaders(resp)
+				}
+				if reqCtx.WarmingUp != nil {
+					setWarmingUpHeaders(resp, reqCtx.WarmingUp)
+				}
+				if reqCtx.FailureReason != "" {
+					setFailureReasonHeader(resp, reqCtx.FailureReason)
+				}
+				resp.DynamicMetadata = poolStateMetadata(reqCtx)
 				if err := srv.Send(resp); err != nil {
 					logger.V(logutil.DEFAULT).Error(err, "Send failed")
 					return status.Errorf(codes.Unknown, "failed to send response back to Envoy: %v", err)
@@ -105,20 +268,61 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 				return nil
 			}
 
-			loggerTrace.Info("Sending request header response")
-			if err := srv.Send(continueHeadersResponse); err != nil {
+			loggerTrace.Info("Sending request header response", "activationWait", reqCtx.ActivationWait)
+			if err := srv.Send(buildRequestHeadersResponse(reqCtx)); err != nil {
 				logger.V(logutil.DEFAULT).Error(err, "error sending response")
 				return status.Errorf(codes.Unknown, "failed to send response back to Envoy: %v", err)
 			}
 
 		case *extProcPb.ProcessingRequest_RequestBody:
-			logger.V(logutil.DEBUG).Info("Error: ProcessingRequest_RequestBody received")
+			if !reqCtx.Cacheable {
+				logger.V(logutil.DEBUG).Info("Error: ProcessingRequest_RequestBody received")
+				break
+			}
+			// Bodies are only sent for cacheable requests, as asked in the request headers response.
+			cached, err := s.director.HandleRequestBody(ctx, reqCtx, v.RequestBody.GetBody())
+			if err != nil {
+				logger.V(logutil.DEFAULT).Error(err, "Failed to process request body")
+				resp, err := buildErrResponse(err)
+				if err != nil {
+					return err
+				}
+				if err := srv.Send(resp); err != nil {
+					logger.V(logutil.DEFAULT).Error(err, "Send failed")
+					return status.Errorf(codes.Unknown, "failed to send response back to Envoy: %v", err)
+				}
+				return nil
+			}
+			resp := buildRequestBodyResponse()
+			if cached != nil {
+				loggerTrace.Info("Sending cached response", "age", time.Since(cached.StoredAt))
+				resp = buildCachedResponse(cached)
+			}
+			if err := srv.Send(resp); err != nil {
+				logger.V(logutil.DEFAULT).Error(err, "error sending response")
+				return status.Errorf(codes.Unknown, "failed to send response back to Envoy: %v", err)
+			}
 		case *extProcPb.ProcessingRequest_RequestTrailers:
 			logger.V(logutil.DEBUG).Info("Error: ProcessingRequest_RequestTrailers received")
 		case *extProcPb.ProcessingRequest_ResponseHeaders:
-			logger.V(logutil.DEBUG).Info("Error: ProcessingRequest_ResponseHeaders received")
+			// Response headers are only sent by Envoy on routes that enabled activation progress reporting,
+			// and for cacheable requests.
+			s.HandleResponseHeaders(reqCtx, v)
+			loggerTrace.Info("Sending response header response", "coldStart", reqCtx.ColdStart)
+			if err := srv.Send(buildResponseHeadersResponse(reqCtx)); err != nil {
+				logger.V(logutil.DEFAULT).Error(err, "error sending response")
+				return status.Errorf(codes.Unknown, "failed to send response back to Envoy: %v", err)
+			}
 		case *extProcPb.ProcessingRequest_ResponseBody:
-			logger.V(logutil.DEBUG).Info("Error: ProcessingRequest_ResponseBody received")
+			if !reqCtx.Cacheable {
+				logger.V(logutil.DEBUG).Info("Error: ProcessingRequest_ResponseBody received")
+				break
+			}
+			s.director.HandleResponseBody(ctx, reqCtx, v.ResponseBody.GetBody())
+			if err := srv.Send(buildResponseBodyResponse()); err != nil {
+				logger.V(logutil.DEFAULT).Error(err, "error sending response")
+				return status.Errorf(codes.Unknown, "failed to send response back to Envoy: %v", err)
+			}
 		case *extProcPb.ProcessingRequest_ResponseTrailers:
 			logger.V(logutil.DEBUG).Info("Error: ProcessingRequest_ResponseTrailers received")
 		}
@@ -126,6 +330,51 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 	}
 }
 
+// gatewayFromMetadata returns the gateway named by the gRPC metadata of the processing stream, if any.
+func gatewayFromMetadata(ctx context.Context) string {
+	if values := metadata.ValueFromIncomingContext(ctx, GatewayMetadataKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// activationProgressFromMetadata reports whether the gRPC metadata of the processing stream enables the
+// activation progress reporting of the route.
+func activationProgressFromMetadata(ctx context.Context) bool {
+	values := metadata.ValueFromIncomingContext(ctx, ActivationProgressMetadataKey)
+	return len(values) > 0 && values[0] == "true"
+}
+
+// HandleRequestHeaders extracts the request headers into the request context.
+func (s *StreamingServer) HandleRequestHeaders(reqCtx *RequestContext, req *extProcPb.ProcessingRequest_RequestHeaders) {
+	reqCtx.RequestReceivedTimestamp = time.Now()
+
+	if req.RequestHeaders == nil || req.RequestHeaders.Headers == nil {
+		return
+	}
+	for _, header := range req.RequestHeaders.Headers.Headers {
+		value := string(header.RawValue)
+		if value == "" {
+			value = header.Value
+		}
+		reqCtx.Request.Headers[strings.ToLower(header.Key)] = value
+	}
+}
+
+// HandleResponseHeaders extracts the response headers into the request context.
+func (s *StreamingServer) HandleResponseHeaders(reqCtx *RequestContext, resp *extProcPb.ProcessingRequest_ResponseHeaders) {
+	if resp.ResponseHeaders == nil || resp.ResponseHeaders.Headers == nil {
+		return
+	}
+	for _, header := range resp.ResponseHeaders.Headers.Headers {
+		value := string(header.RawValue)
+		if value == "" {
+			value = header.Value
+		}
+		reqCtx.Response.Headers[strings.ToLower(header.Key)] = value
+	}
+}
+
 func buildErrResponse(err error) (*extProcPb.ProcessingResponse, error) {
 	var resp *extProcPb.ProcessingResponse
 
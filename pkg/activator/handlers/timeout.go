@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+)
+
+const (
+	// GRPCTimeoutHeaderKey carries the deadline of gRPC requests.
+	GRPCTimeoutHeaderKey = "grpc-timeout"
+
+	// maxGRPCTimeoutValue is the largest grpc-timeout value, which the gRPC spec limits to 8 digits.
+	maxGRPCTimeoutValue = 99999999
+)
+
+// grpcTimeoutUnits are the grpc-timeout units the rewritten timeouts are expressed in, finest first.
+var grpcTimeoutUnits = []struct {
+	unit   time.Duration
+	symbol string
+}{
+	{time.Millisecond, "m"},
+	{time.Second, "S"},
+	{time.Minute, "M"},
+	{time.Hour, "H"},
+}
+
+// timeoutHeaderKeys are the headers carrying a timeout in milliseconds that downstream components
+// measure from the moment they receive the request.
+var timeoutHeaderKeys = []string{
+	"x-envoy-expected-rq-timeout-ms",
+	"x-envoy-upstream-rq-timeout-ms",
+}
+
+// timeoutHeaderMutation returns the mutation rewriting the timeout hints of the given headers after the
+// given wait, or nil if there is nothing to rewrite. Timeouts never go below one millisecond, as a zero
+// timeout disables the timeout altogether for Envoy.
+func timeoutHeaderMutation(headers map[string]string, wait time.Duration) *extProcPb.HeaderMutation {
+	waitMs := wait.Milliseconds()
+	if waitMs <= 0 {
+		return nil
+	}
+
+	var setHeaders []*configPb.HeaderValueOption
+	setHeader := func(key, value string) {
+		setHeaders = append(setHeaders, &configPb.HeaderValueOption{
+			Header: &configPb.HeaderValue{Key: key, RawValue: []byte(value)},
+		})
+	}
+
+	for _, key := range timeoutHeaderKeys {
+		timeoutMs, err := strconv.ParseInt(headers[key], 10, 64)
+		if err != nil || timeoutMs <= 0 {
+			continue
+		}
+		setHeader(key, strconv.FormatInt(max(timeoutMs-waitMs, 1), 10))
+	}
+
+	if timeout, ok := parseGRPCTimeout(headers[GRPCTimeoutHeaderKey]); ok {
+		setHeader(GRPCTimeoutHeaderKey, formatGRPCTimeout(max(timeout-wait, time.Millisecond)))
+	}
+
+	if len(setHeaders) == 0 {
+		return nil
+	}
+	return &extProcPb.HeaderMutation{SetHeaders: setHeaders}
+}
+
+// RequestTimeout returns the shortest timeout of the given request headers, measured from the moment the
+// request was received, or false if they carry none.
+func RequestTimeout(headers map[string]string) (time.Duration, bool) {
+	var timeout time.Duration
+	found := false
+	shortest := func(t time.Duration) {
+		if !found || t < timeout {
+			timeout, found = t, true
+		}
+	}
+
+	for _, key := range timeoutHeaderKeys {
+		timeoutMs, err := strconv.ParseInt(headers[key], 10, 64)
+		if err != nil || timeoutMs <= 0 {
+			continue
+		}
+		shortest(time.Duration(timeoutMs) * time.Millisecond)
+	}
+	if t, ok := parseGRPCTimeout(headers[GRPCTimeoutHeaderKey]); ok {
+		shortest(t)
+	}
+	return timeout, found
+}
+
+// parseGRPCTimeout parses a grpc-timeout header value, e.g. "1500m" or "2S".
+func parseGRPCTimeout(value string) (time.Duration, bool) {
+	if len(value) < 2 {
+		return 0, false
+	}
+	amount, err := strconv.ParseInt(value[:len(value)-1], 10, 64)
+	if err != nil || amount <= 0 {
+		return 0, false
+	}
+	units := map[byte]time.Duration{
+		'H': time.Hour,
+		'M': time.Minute,
+		'S': time.Second,
+		'm': time.Millisecond,
+		'u': time.Microsecond,
+		'n': time.Nanosecond,
+	}
+	unit, ok := units[value[len(value)-1]]
+	if !ok {
+		return 0, false
+	}
+	if amount > math.MaxInt64/int64(unit) {
+		return math.MaxInt64, true
+	}
+	return time.Duration(amount) * unit, true
+}
+
+// formatGRPCTimeout formats the given timeout as a grpc-timeout header value in the finest unit that fits
+// in 8 digits, truncating it to that unit.
+func formatGRPCTimeout(timeout time.Duration) string {
+	for _, unit := range grpcTimeoutUnits {
+		if value := int64(timeout / unit.unit); value <= maxGRPCTimeoutValue {
+			return strconv.FormatInt(value, 10) + unit.symbol
+		}
+	}
+	return strconv.FormatInt(maxGRPCTimeoutValue, 10) + "H"
+}
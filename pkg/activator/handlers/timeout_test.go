@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"maps"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTimeoutHeaderMutation(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		wait    time.Duration
+		want    map[string]string
+	}{
+		{
+			name:    "no wait",
+			headers: map[string]string{"x-envoy-expected-rq-timeout-ms": "5000"},
+			wait:    0,
+		},
+		{
+			name:    "wait below a millisecond",
+			headers: map[string]string{"x-envoy-expected-rq-timeout-ms": "5000"},
+			wait:    500 * time.Microsecond,
+		},
+		{
+			name:    "no timeout headers",
+			headers: map[string]string{"content-type": "application/json"},
+			wait:    time.Second,
+		},
+		{
+			name:    "envoy timeouts shortened by the wait",
+			headers: map[string]string{"x-envoy-expected-rq-timeout-ms": "5000", "x-envoy-upstream-rq-timeout-ms": "3000"},
+			wait:    time.Second,
+			want:    map[string]string{"x-envoy-expected-rq-timeout-ms": "4000", "x-envoy-upstream-rq-timeout-ms": "2000"},
+		},
+		{
+			name:    "envoy timeout clamped to one millisecond",
+			headers: map[string]string{"x-envoy-expected-rq-timeout-ms": "500"},
+			wait:    time.Second,
+			want:    map[string]string{"x-envoy-expected-rq-timeout-ms": "1"},
+		},
+		{
+			name:    "non-positive and invalid envoy timeouts left as is",
+			headers: map[string]string{"x-envoy-expected-rq-timeout-ms": "0", "x-envoy-upstream-rq-timeout-ms": "soon"},
+			wait:    time.Second,
+		},
+		{
+			name:    "grpc timeout in seconds",
+			headers: map[string]string{GRPCTimeoutHeaderKey: "5S"},
+			wait:    1500 * time.Millisecond,
+			want:    map[string]string{GRPCTimeoutHeaderKey: "3500m"},
+		},
+		{
+			name:    "grpc timeout clamped to one millisecond",
+			headers: map[string]string{GRPCTimeoutHeaderKey: "100m"},
+			wait:    time.Second,
+			want:    map[string]string{GRPCTimeoutHeaderKey: "1m"},
+		},
+		{
+			name:    "grpc timeout capped to 8 digits",
+			headers: map[string]string{GRPCTimeoutHeaderKey: "48H"},
+			wait:    time.Second,
+			want:    map[string]string{GRPCTimeoutHeaderKey: "172799S"},
+		},
+		{
+			name:    "non-positive grpc timeout left as is",
+			headers: map[string]string{GRPCTimeoutHeaderKey: "0S"},
+			wait:    time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mutation := timeoutHeaderMutation(test.headers, test.wait)
+			got := map[string]string{}
+			for _, header := range mutation.GetSetHeaders() {
+				got[header.GetHeader().GetKey()] = string(header.GetHeader().GetRawValue())
+			}
+			if test.want == nil && mutation != nil {
+				t.Fatalf("timeoutHeaderMutation() = %v, want nil", got)
+			}
+			if test.want != nil && !maps.Equal(got, test.want) {
+				t.Errorf("timeoutHeaderMutation() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseGRPCTimeout(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{value: "2H", want: 2 * time.Hour, wantOk: true},
+		{value: "3M", want: 3 * time.Minute, wantOk: true},
+		{value: "5S", want: 5 * time.Second, wantOk: true},
+		{value: "1500m", want: 1500 * time.Millisecond, wantOk: true},
+		{value: "250u", want: 250 * time.Microsecond, wantOk: true},
+		{value: "100n", want: 100 * time.Nanosecond, wantOk: true},
+		{value: "99999999H", want: math.MaxInt64, wantOk: true},
+		{value: ""},
+		{value: "S"},
+		{value: "0S"},
+		{value: "-5S"},
+		{value: "5s"},
+		{value: "5"},
+		{value: "fiveS"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			got, ok := parseGRPCTimeout(test.value)
+			if ok != test.wantOk || got != test.want {
+				t.Errorf("parseGRPCTimeout(%q) = %v, %t, want %v, %t", test.value, got, ok, test.want, test.wantOk)
+			}
+		})
+	}
+}
+
+func TestFormatGRPCTimeout(t *testing.T) {
+	tests := []struct {
+		timeout time.Duration
+		want    string
+	}{
+		{timeout: time.Millisecond, want: "1m"},
+		{timeout: 3500 * time.Millisecond, want: "3500m"},
+		{timeout: 99999999 * time.Millisecond, want: "99999999m"},
+		{timeout: 100000000 * time.Millisecond, want: "100000S"},
+		{timeout: 100000000 * time.Minute, want: "1666666H"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			if got := formatGRPCTimeout(test.timeout); got != test.want {
+				t.Errorf("formatGRPCTimeout(%v) = %q, want %q", test.timeout, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,857 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	compbasemetrics "k8s.io/component-base/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	metricsutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/metrics"
+)
+
+const (
+	ActivatorComponent = "activator"
+)
+
+var (
+	// Pipeline Metrics
+	requestCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "request_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of requests handled by the activator broken out for each inference pool and gateway.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool", "gateway"},
+	)
+
+	requestErrCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "request_error_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of requests errors broken out for each inference pool, gateway and error code.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool", "gateway", "error_code"},
+	)
+
+	pipelineInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "pipeline_in_flight_requests",
+			Help:      metricsutil.HelpMsgWithStability("Number of requests currently admitted into the activation pipeline of each inference pool.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	pipelinePanics = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "pipeline_panic_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of recovered panics in the activation pipeline of each inference pool.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	retryAdmittedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "pipeline_retry_admitted_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of retries of failed requests admitted with priority into the activation pipeline of each inference pool.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	// Activation Metrics
+	activationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "activation_duration_seconds",
+			Help:      metricsutil.HelpMsgWithStability("Scale from zero duration distribution in seconds for each inference pool, outcome and failure reason.", compbasemetrics.ALPHA),
+			Buckets: []float64{
+				1, 2, 5, 10, 15, 20, 30, 45, 60, 90, 120, 180, 240, 300, 450, 600, 900,
+			},
+		},
+		[]string{"pool", "outcome", "reason"},
+	)
+
+	endpointPickerPropagation = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "endpoint_picker_propagation_seconds",
+			Help:      metricsutil.HelpMsgWithStability("Distribution of the time in seconds the Endpoint Picker took to report the pods ready after a scale from zero for each inference pool.", compbasemetrics.ALPHA),
+			Buckets: []float64{
+				0.25, 0.5, 1, 2, 3, 5, 7.5, 10, 15, 20, 30,
+			},
+		},
+		[]string{"pool"},
+	)
+
+	coldStartStageDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "cold_start_stage_duration_seconds",
+			Help:      metricsutil.HelpMsgWithStability("Distribution of the time in seconds cold-started requests spent in each stage of their cold start for each inference pool: parse, queue, scale, pod_readiness, epp_sync or release.", compbasemetrics.ALPHA),
+			Buckets: []float64{
+				0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10, 20, 30, 60, 120, 300, 600,
+			},
+		},
+		[]string{"pool", "stage"},
+	)
+
+	warmUps = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "warm_ups_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of warm-ups of the pods woken by a scale from zero for each inference pool and outcome.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool", "outcome"},
+	)
+
+	panicMode = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "panic_mode",
+			Help:      metricsutil.HelpMsgWithStability("Whether each warm inference pool is in panic mode (1) following a request burst or not (0).", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	panicScaleUpCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "panic_scale_up_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of scale ups applied in panic mode to each inference pool.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	// Overload Metrics
+	overloaded = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "overloaded",
+			Help:      metricsutil.HelpMsgWithStability("Whether the activator is overloaded and sheds new requests (1) or not (0).", compbasemetrics.ALPHA),
+		},
+	)
+
+	overloadRejected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "overload_rejected_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of requests shed while the activator was overloaded for each inference pool.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	gatewayRateLimited = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "gateway_rate_limited_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of requests rejected by the rate limit of their gateway for each inference pool and gateway.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool", "gateway"},
+	)
+
+	abandonedRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "abandoned_requests_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of held requests whose client disconnected for each inference pool.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	poolDeletedRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "pool_deleted_requests_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of held requests failed because their inference pool was deleted.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	releaseGateDecisions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "release_gate_decisions_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of release gate decisions for each inference pool, gate and decision.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool", "gate", "decision"},
+	)
+
+	activationEventsDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "activation_events_dropped_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of activation lifecycle events dropped because a subscriber was not ready to receive them for each event type.", compbasemetrics.ALPHA),
+		},
+		[]string{"type"},
+	)
+
+	sharedReadinessChecks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "shared_readiness_checks_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of requests that shared the readiness check, and scale up, of a concurrent request for each inference pool.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	poolState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "pool_state",
+			Help:      metricsutil.HelpMsgWithStability("Activation state of each inference pool, 1 for the current state and 0 for the others.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool", "state"},
+	)
+
+	poolStateTransitions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "pool_state_transitions_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of activation state transitions for each inference pool.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool", "from", "to"},
+	)
+
+	scaleUpdateRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "scale_update_retries_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of scale subresource updates retried after a transient error for each inference pool.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	activationZonePlacements = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "activation_zone_placements_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of scale ups from zero for each inference pool by whether a replica was woken in the zone of the traffic (same-zone) or none was (cross-zone).", compbasemetrics.ALPHA),
+		},
+		[]string{"pool", "zone", "placement"},
+	)
+
+	// Startup Metrics
+	startupReconciliations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "startup_reconciliations_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of startup reconciliations for each inference pool and the state they found the pool in.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool", "state"},
+	)
+
+	// Bypass Metrics
+	bypassedRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "bypassed_requests_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of requests of trusted internal clients bypassing the activator for each inference pool.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	excludedRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "excluded_requests_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of requests excluded from activation, e.g. health checks, for each inference pool and whether they were passed or rejected.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool", "outcome"},
+	)
+
+	benchmarkPassthroughRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "benchmark_passthrough_requests_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of requests passed through in benchmark mode for each inference pool and the decision the activator would have made.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool", "decision"},
+	)
+
+	// Idle Clock Metrics
+	idleClockWrites = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "idle_clock_writes_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of writes persisting the time of the last request for each inference pool.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	// Response Cache Metrics
+	responseCacheHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "response_cache_hits_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of requests answered from the response cache while each inference pool was cold.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	// Attribution Metrics
+	attributedActivations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "attributed_activations_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of activations of each inference pool broken out by the attribution key of the triggering request.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool", "key"},
+	)
+
+	attributedAcceleratorSeconds = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "attributed_accelerator_seconds_total",
+			Help:      metricsutil.HelpMsgWithStability("Accelerator time of each inference pool from activation to deactivation broken out by the attribution key of the triggering request.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool", "key"},
+	)
+
+	// Recommendation Metrics
+	recommendationAverageConcurrency = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "recommendation_average_concurrency",
+			Help:      metricsutil.HelpMsgWithStability("Average estimated number of concurrent requests of each inference pool over the recommendation window.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	recommendationP99Concurrency = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "recommendation_p99_concurrency",
+			Help:      metricsutil.HelpMsgWithStability("99th percentile of the estimated number of concurrent requests of each inference pool over the recommendation window.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	recommendationSuggestedReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "recommendation_suggested_replicas",
+			Help:      metricsutil.HelpMsgWithStability("Suggested steady-state number of replicas of each inference pool for its p99 concurrency.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	// Deactivation Metrics
+	deactivationDryRunCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "deactivation_dry_run_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of scale downs that would have been applied to each inference pool in dry-run mode.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	deactivationDryRunReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "deactivation_dry_run_reclaimable_replicas",
+			Help:      metricsutil.HelpMsgWithStability("Number of replicas that would have been freed by scaling each idle inference pool to zero.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	deactivationDryRunAccelerators = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "deactivation_dry_run_reclaimable_accelerators",
+			Help:      metricsutil.HelpMsgWithStability("Number of accelerators that would have been freed by scaling each idle inference pool to zero.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	activationBudgetConsumed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "activation_budget_consumed_minutes",
+			Help:      metricsutil.HelpMsgWithStability("Accelerator minutes consumed this month after the scale ups from zero of each inference pool setting a monthly activation budget.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	activationBudgetRemaining = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "activation_budget_remaining_minutes",
+			Help:      metricsutil.HelpMsgWithStability("Accelerator minutes left this month in the monthly activation budget of each inference pool setting one.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	unmanagedRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "unmanaged_requests_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of requests let through without activation check for each inference pool, by reason: disabled or debug-bypass.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool", "reason"},
+	)
+
+	releaseRechecksFailed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "release_rechecks_failed_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of held requests waiting for the activation again as their inference pool lost its ready pods right before their release.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+
+	deactivationKillSwitch = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "deactivation_kill_switch",
+			Help:      metricsutil.HelpMsgWithStability("Whether the kill switch disabling the scale downs to zero is on (1) or off (0), by source: fleet or local.", compbasemetrics.ALPHA),
+		},
+		[]string{"source"},
+	)
+
+	priorityWaitsExpired = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "priority_waits_expired_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of held requests failed once the wait of their priority class expired for each inference pool, by priority class.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool", "class"},
+	)
+
+	handedOffRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "handed_off_requests_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of held requests handed off between activator replicas for each inference pool, by direction: sent, received or resumed.", compbasemetrics.ALPHA),
+		},
+		[]string{"pool", "direction"},
+	)
+
+	circuitBreakerOpen = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: ActivatorComponent,
+			Name:      "circuit_breaker_open",
+			Help:      metricsutil.HelpMsgWithStability("Whether the circuit breaker of the activation pipeline of each inference pool is open (1) or closed (0).", compbasemetrics.ALPHA),
+		},
+		[]string{"pool"},
+	)
+)
+
+var registerMetrics sync.Once
+
+// Register all metrics.
+func Register(customCollectors ...prometheus.Collector) {
+	registerMetrics.Do(func() {
+		metrics.Registry.MustRegister(requestCounter)
+		metrics.Registry.MustRegister(requestErrCounter)
+		metrics.Registry.MustRegister(pipelineInFlight)
+		metrics.Registry.MustRegister(pipelinePanics)
+		metrics.Registry.MustRegister(retryAdmittedCounter)
+		metrics.Registry.MustRegister(circuitBreakerOpen)
+		metrics.Registry.MustRegister(activationDuration)
+		metrics.Registry.MustRegister(endpointPickerPropagation)
+		metrics.Registry.MustRegister(coldStartStageDuration)
+		metrics.Registry.MustRegister(warmUps)
+		metrics.Registry.MustRegister(panicMode)
+		metrics.Registry.MustRegister(panicScaleUpCounter)
+		metrics.Registry.MustRegister(overloaded)
+		metrics.Registry.MustRegister(overloadRejected)
+		metrics.Registry.MustRegister(gatewayRateLimited)
+		metrics.Registry.MustRegister(abandonedRequests)
+		metrics.Registry.MustRegister(poolDeletedRequests)
+		metrics.Registry.MustRegister(releaseGateDecisions)
+		metrics.Registry.MustRegister(activationEventsDropped)
+		metrics.Registry.MustRegister(sharedReadinessChecks)
+		metrics.Registry.MustRegister(poolState)
+		metrics.Registry.MustRegister(poolStateTransitions)
+		metrics.Registry.MustRegister(scaleUpdateRetries)
+		metrics.Registry.MustRegister(activationZonePlacements)
+		metrics.Registry.MustRegister(startupReconciliations)
+		metrics.Registry.MustRegister(bypassedRequests)
+		metrics.Registry.MustRegister(excludedRequests)
+		metrics.Registry.MustRegister(benchmarkPassthroughRequests)
+		metrics.Registry.MustRegister(idleClockWrites)
+		metrics.Registry.MustRegister(responseCacheHits)
+		metrics.Registry.MustRegister(attributedActivations)
+		metrics.Registry.MustRegister(attributedAcceleratorSeconds)
+		metrics.Registry.MustRegister(recommendationAverageConcurrency)
+		metrics.Registry.MustRegister(recommendationP99Concurrency)
+		metrics.Registry.MustRegister(recommendationSuggestedReplicas)
+		metrics.Registry.MustRegister(deactivationDryRunCounter)
+		metrics.Registry.MustRegister(deactivationDryRunReplicas)
+		metrics.Registry.MustRegister(deactivationDryRunAccelerators)
+		metrics.Registry.MustRegister(handedOffRequests)
+		metrics.Registry.MustRegister(activationBudgetConsumed)
+		metrics.Registry.MustRegister(activationBudgetRemaining)
+		metrics.Registry.MustRegister(priorityWaitsExpired)
+		metrics.Registry.MustRegister(unmanagedRequests)
+		metrics.Registry.MustRegister(releaseRechecksFailed)
+		metrics.Registry.MustRegister(deactivationKillSwitch)
+		for _, collector := range customCollectors {
+			metrics.Registry.MustRegister(collector)
+		}
+	})
+}
+
+// Just for integration test
+func Reset() {
+	requestCounter.Reset()
+	requestErrCounter.Reset()
+	pipelineInFlight.Reset()
+	pipelinePanics.Reset()
+	retryAdmittedCounter.Reset()
+	circuitBreakerOpen.Reset()
+	activationDuration.Reset()
+	endpointPickerPropagation.Reset()
+	coldStartStageDuration.Reset()
+	warmUps.Reset()
+	panicMode.Reset()
+	panicScaleUpCounter.Reset()
+	overloaded.Set(0)
+	overloadRejected.Reset()
+	gatewayRateLimited.Reset()
+	abandonedRequests.Reset()
+	poolDeletedRequests.Reset()
+	releaseGateDecisions.Reset()
+	activationEventsDropped.Reset()
+	sharedReadinessChecks.Reset()
+	poolState.Reset()
+	poolStateTransitions.Reset()
+	scaleUpdateRetries.Reset()
+	activationZonePlacements.Reset()
+	startupReconciliations.Reset()
+	bypassedRequests.Reset()
+	excludedRequests.Reset()
+	benchmarkPassthroughRequests.Reset()
+	idleClockWrites.Reset()
+	responseCacheHits.Reset()
+	attributedActivations.Reset()
+	attributedAcceleratorSeconds.Reset()
+	recommendationAverageConcurrency.Reset()
+	recommendationP99Concurrency.Reset()
+	recommendationSuggestedReplicas.Reset()
+	deactivationDryRunCounter.Reset()
+	deactivationDryRunReplicas.Reset()
+	deactivationDryRunAccelerators.Reset()
+	handedOffRequests.Reset()
+	activationBudgetConsumed.Reset()
+	activationBudgetRemaining.Reset()
+	priorityWaitsExpired.Reset()
+	unmanagedRequests.Reset()
+	releaseRechecksFailed.Reset()
+	deactivationKillSwitch.Reset()
+}
+
+// RecordRequestCounter records the number of requests.
+func RecordRequestCounter(pool, gateway string) {
+	requestCounter.WithLabelValues(pool, gateway).Inc()
+}
+
+// RecordRequestErrCounter records the number of error requests.
+func RecordRequestErrCounter(pool, gateway, code string) {
+	if code != "" {
+		requestErrCounter.WithLabelValues(pool, gateway, code).Inc()
+	}
+}
+
+// IncPipelineInFlight increases the number of requests admitted into the pool pipeline.
+func IncPipelineInFlight(pool string) {
+	pipelineInFlight.WithLabelValues(pool).Inc()
+}
+
+// DecPipelineInFlight decreases the number of requests admitted into the pool pipeline.
+func DecPipelineInFlight(pool string) {
+	pipelineInFlight.WithLabelValues(pool).Dec()
+}
+
+// RecordPipelinePanic records a recovered panic in the pool pipeline.
+func RecordPipelinePanic(pool string) {
+	pipelinePanics.WithLabelValues(pool).Inc()
+}
+
+// RecordRetryAdmitted records a retry of a failed request admitted into a pool pipeline.
+func RecordRetryAdmitted(pool string) {
+	retryAdmittedCounter.WithLabelValues(pool).Inc()
+}
+
+// RecordCircuitBreakerOpen records the state of the pool pipeline circuit breaker.
+func RecordCircuitBreakerOpen(pool string, open bool) {
+	value := 0.0
+	if open {
+		value = 1.0
+	}
+	circuitBreakerOpen.WithLabelValues(pool).Set(value)
+}
+
+// RecordActivationDuration records the duration of a scale from zero, along with the reason of its failure
+// if it failed. The ID of the request and the trace that triggered the activation, when known, are attached
+// to the observation as an exemplar. Both come from client headers, so they are cut down to the exemplar
+// limits rather than failing the observation.
+func RecordActivationDuration(pool, outcome, reason, requestID, traceID string, duration time.Duration) {
+	observer := activationDuration.WithLabelValues(pool, outcome, reason)
+	exemplar := exemplarLabels("trace_id", traceID, "request_id", requestID)
+	if len(exemplar) == 0 {
+		observer.Observe(duration.Seconds())
+		return
+	}
+	observer.(prometheus.ExemplarObserver).ObserveWithExemplar(duration.Seconds(), exemplar)
+}
+
+// exemplarLabels returns the exemplar labels of the given name and value pairs, in order of precedence. Empty
+// values and values that are not valid UTF-8 are left out, and the values are truncated to keep the labels
+// within ExemplarMaxRunes, which ObserveWithExemplar panics beyond.
+func exemplarLabels(pairs ...string) prometheus.Labels {
+	labels := prometheus.Labels{}
+	left := prometheus.ExemplarMaxRunes
+	for i := 0; i+1 < len(pairs); i += 2 {
+		name, value := pairs[i], pairs[i+1]
+		if value == "" || !utf8.ValidString(value) {
+			continue
+		}
+		runes := []rune(value)
+		room := left - utf8.RuneCountInString(name)
+		if room <= 0 {
+			break
+		}
+		if len(runes) > room {
+			runes = runes[:room]
+		}
+		labels[name] = string(runes)
+		left = room - len(runes)
+	}
+	return labels
+}
+
+// RecordEndpointPickerPropagation records the time the Endpoint Picker took to report the pods of the pool
+// ready after a scale from zero.
+func RecordEndpointPickerPropagation(pool string, propagation time.Duration) {
+	endpointPickerPropagation.WithLabelValues(pool).Observe(propagation.Seconds())
+}
+
+// RecordColdStartStage records the time a cold-started request spent in the given stage of its cold start.
+func RecordColdStartStage(pool, stage string, duration time.Duration) {
+	coldStartStageDuration.WithLabelValues(pool, stage).Observe(duration.Seconds())
+}
+
+// RecordWarmUp records the outcome of the warm-up of the pods woken by a scale from zero of the pool.
+func RecordWarmUp(pool, outcome string) {
+	warmUps.WithLabelValues(pool, outcome).Inc()
+}
+
+// RecordPanicMode records whether the pool is in panic mode.
+func RecordPanicMode(pool string, panicking bool) {
+	value := 0.0
+	if panicking {
+		value = 1.0
+	}
+	panicMode.WithLabelValues(pool).Set(value)
+}
+
+// RecordPanicScaleUp records a scale up applied in panic mode.
+func RecordPanicScaleUp(pool string) {
+	panicScaleUpCounter.WithLabelValues(pool).Inc()
+}
+
+// RecordOverloaded records whether the activator is overloaded.
+func RecordOverloaded(isOverloaded bool) {
+	value := 0.0
+	if isOverloaded {
+		value = 1.0
+	}
+	overloaded.Set(value)
+}
+
+// RecordOverloadRejected records a request shed while the activator was overloaded.
+func RecordOverloadRejected(pool string) {
+	overloadRejected.WithLabelValues(pool).Inc()
+}
+
+// RecordGatewayRateLimited records a request rejected by the rate limit of its gateway.
+func RecordGatewayRateLimited(pool, gateway string) {
+	gatewayRateLimited.WithLabelValues(pool, gateway).Inc()
+}
+
+// RecordAbandonedRequest records a held request whose client disconnected.
+func RecordAbandonedRequest(pool string) {
+	abandonedRequests.WithLabelValues(pool).Inc()
+}
+
+// RecordPoolDeletedRequest records a held request failed because its pool was deleted.
+func RecordPoolDeletedRequest(pool string) {
+	poolDeletedRequests.WithLabelValues(pool).Inc()
+}
+
+// RecordReleaseGateDecision records the decision of a release gate on a request, one of "release", "delay",
+// "veto" or "error".
+func RecordReleaseGateDecision(pool, gate, decision string) {
+	releaseGateDecisions.WithLabelValues(pool, gate, decision).Inc()
+}
+
+// RecordActivationEventDropped records an activation lifecycle event a subscriber missed.
+func RecordActivationEventDropped(eventType string) {
+	activationEventsDropped.WithLabelValues(eventType).Inc()
+}
+
+// RecordSharedReadinessCheck records a request that shared the readiness check of a concurrent request.
+func RecordSharedReadinessCheck(pool string) {
+	sharedReadinessChecks.WithLabelValues(pool).Inc()
+}
+
+// SetPoolState sets whether the pool is in the given activation state.
+func SetPoolState(pool, state string, current bool) {
+	value := 0.0
+	if current {
+		value = 1
+	}
+	poolState.WithLabelValues(pool, state).Set(value)
+}
+
+// RecordPoolStateTransition records an activation state transition of the pool.
+func RecordPoolStateTransition(pool, from, to string) {
+	poolStateTransitions.WithLabelValues(pool, from, to).Inc()
+}
+
+// RecordScaleUpdateRetry records a retried update of the scale subresource of the pool's target workload.
+func RecordScaleUpdateRetry(pool string) {
+	scaleUpdateRetries.WithLabelValues(pool).Inc()
+}
+
+// RecordActivationZonePlacement records whether a scale up from zero of the pool woke a replica in the
+// zone of its traffic.
+func RecordActivationZonePlacement(pool, zone string, sameZone bool) {
+	placement := "cross-zone"
+	if sameZone {
+		placement = "same-zone"
+	}
+	activationZonePlacements.WithLabelValues(pool, zone, placement).Inc()
+}
+
+// RecordStartupReconciliation records the state the startup reconciliation found the pool in.
+func RecordStartupReconciliation(pool, state string) {
+	startupReconciliations.WithLabelValues(pool, state).Inc()
+}
+
+// RecordBypassedRequest records a request of a trusted internal client bypassing the activator.
+func RecordBypassedRequest(pool string) {
+	bypassedRequests.WithLabelValues(pool).Inc()
+}
+
+// RecordExcludedRequest records a request excluded from activation, passed while the pool was warm or
+// rejected while it was cold.
+func RecordExcludedRequest(pool, outcome string) {
+	excludedRequests.WithLabelValues(pool, outcome).Inc()
+}
+
+// RecordBenchmarkPassthrough records a request passed through in benchmark mode and the decision the
+// activator would have made for it.
+func RecordBenchmarkPassthrough(pool, decision string) {
+	benchmarkPassthroughRequests.WithLabelValues(pool, decision).Inc()
+}
+
+// RecordIdleClockWrite records a write persisting the time of the last request of the pool.
+func RecordIdleClockWrite(pool string) {
+	idleClockWrites.WithLabelValues(pool).Inc()
+}
+
+// RecordResponseCacheHit records a request answered from the response cache while the pool was cold.
+func RecordResponseCacheHit(pool string) {
+	responseCacheHits.WithLabelValues(pool).Inc()
+}
+
+// RecordAttributedActivation records an activation of a pool triggered by a request with the given attribution key.
+func RecordAttributedActivation(pool, key string) {
+	attributedActivations.WithLabelValues(pool, key).Inc()
+}
+
+// RecordAttributedAcceleratorSeconds records the accelerator time of a pool attributed to the given key.
+func RecordAttributedAcceleratorSeconds(pool, key string, seconds float64) {
+	attributedAcceleratorSeconds.WithLabelValues(pool, key).Add(seconds)
+}
+
+// RecordRecommendation records the right-sizing recommendation of a pool. A negative number of suggested
+// replicas means no suggestion could be made.
+func RecordRecommendation(pool string, averageConcurrency, p99Concurrency float64, suggestedReplicas int32) {
+	recommendationAverageConcurrency.WithLabelValues(pool).Set(averageConcurrency)
+	recommendationP99Concurrency.WithLabelValues(pool).Set(p99Concurrency)
+	if suggestedReplicas >= 0 {
+		recommendationSuggestedReplicas.WithLabelValues(pool).Set(float64(suggestedReplicas))
+	}
+}
+
+// RecordDeactivationDryRun records a scale down evaluated in dry-run mode and the capacity it would have freed.
+func RecordDeactivationDryRun(pool string, replicas int32, accelerators int64) {
+	if replicas > 0 {
+		deactivationDryRunCounter.WithLabelValues(pool).Inc()
+	}
+	deactivationDryRunReplicas.WithLabelValues(pool).Set(float64(replicas))
+	deactivationDryRunAccelerators.WithLabelValues(pool).Set(float64(accelerators))
+}
+
+// RecordHandedOffRequests records held requests handed off to or received from a peer activator replica, or
+// resumed once retried against the replica they were handed off to.
+func RecordHandedOffRequests(pool, direction string, count int) {
+	handedOffRequests.WithLabelValues(pool, direction).Add(float64(count))
+}
+
+// RecordActivationBudget records the accelerator minutes consumed this month by a pool and left in its budget.
+func RecordActivationBudget(pool string, consumed, remaining float64) {
+	activationBudgetConsumed.WithLabelValues(pool).Set(consumed)
+	activationBudgetRemaining.WithLabelValues(pool).Set(remaining)
+}
+
+// RecordPriorityWaitExpired records a held request failed once the wait of its priority class expired.
+func RecordPriorityWaitExpired(pool, class string) {
+	priorityWaitsExpired.WithLabelValues(pool, class).Inc()
+}
+
+// RecordUnmanagedRequest records a request let through without activation check for the given reason.
+func RecordUnmanagedRequest(pool, reason string) {
+	unmanagedRequests.WithLabelValues(pool, reason).Inc()
+}
+
+// RecordReleaseRecheckFailed records a held request waiting for the activation again as its pool lost its
+// ready pods right before its release.
+func RecordReleaseRecheckFailed(pool string) {
+	releaseRechecksFailed.WithLabelValues(pool).Inc()
+}
+
+// RecordDeactivationKillSwitch records whether the kill switch disabling the scale downs to zero is on, as
+// turned by the given source.
+func RecordDeactivationKillSwitch(source string, on bool) {
+	value := 0.0
+	if on {
+		value = 1.0
+	}
+	deactivationKillSwitch.WithLabelValues(source).Set(value)
+}
+
+// OpenMetricsHandler returns a handler serving the activator metrics in the OpenMetrics format,
+// which unlike the default metrics endpoint exposes exemplars.
+func OpenMetricsHandler() http.Handler {
+	return promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{
+		ErrorHandling:     promhttp.HTTPErrorOnError,
+		EnableOpenMetrics: true,
+	})
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestRecordActivationDurationExemplar(t *testing.T) {
+	tests := []struct {
+		name          string
+		requestID     string
+		traceID       string
+		wantExemplar  bool
+		wantRequestID bool
+	}{
+		{name: "no IDs"},
+		{name: "request ID", requestID: "abc", wantExemplar: true, wantRequestID: true},
+		{name: "200 character request ID", requestID: strings.Repeat("r", 200), wantExemplar: true, wantRequestID: true},
+		{name: "200 character request and trace IDs", requestID: strings.Repeat("r", 200), traceID: strings.Repeat("t", 200), wantExemplar: true},
+		{name: "invalid UTF-8 request ID", requestID: "abc\xff", wantExemplar: false},
+		{name: "invalid UTF-8 request ID with a trace ID", requestID: "\xc3\x28", traceID: "4bf92f3577b34da6a3ce929d0e0e4736", wantExemplar: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			activationDuration.Reset()
+			RecordActivationDuration("default/pool", "succeeded", "", test.requestID, test.traceID, time.Second)
+
+			metric := &dto.Metric{}
+			observer := activationDuration.WithLabelValues("default/pool", "succeeded", "")
+			if err := observer.(prometheus.Metric).Write(metric); err != nil {
+				t.Fatalf("failed to write the histogram: %v", err)
+			}
+			if got := metric.GetHistogram().GetSampleCount(); got != 1 {
+				t.Fatalf("sample count = %d, want 1", got)
+			}
+
+			var exemplar *dto.Exemplar
+			for _, bucket := range metric.GetHistogram().GetBucket() {
+				if bucket.GetExemplar() != nil {
+					exemplar = bucket.GetExemplar()
+				}
+			}
+			if (exemplar != nil) != test.wantExemplar {
+				t.Fatalf("exemplar = %v, want one %t", exemplar, test.wantExemplar)
+			}
+			if exemplar == nil {
+				return
+			}
+			runes, requestID := 0, false
+			for _, label := range exemplar.GetLabel() {
+				if !utf8.ValidString(label.GetValue()) {
+					t.Errorf("exemplar label %s is not valid UTF-8", label.GetName())
+				}
+				runes += utf8.RuneCountInString(label.GetName()) + utf8.RuneCountInString(label.GetValue())
+				requestID = requestID || label.GetName() == "request_id"
+			}
+			if runes > prometheus.ExemplarMaxRunes {
+				t.Errorf("exemplar labels have %d runes, want at most %d", runes, prometheus.ExemplarMaxRunes)
+			}
+			if requestID != test.wantRequestID {
+				t.Errorf("request_id exemplar label present = %t, want %t", requestID, test.wantRequestID)
+			}
+		})
+	}
+}
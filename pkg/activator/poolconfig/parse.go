@@ -0,0 +1,406 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/jsonpath"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+// parser parses the annotations of a pool, recording the error of each annotation set to an invalid value.
+type parser struct {
+	pool *v1.InferencePool
+	errs map[string]error
+}
+
+func (p *parser) value(key string) (string, bool) {
+	value, ok := p.pool.Annotations[key]
+	return value, ok
+}
+
+// fail records the error of the given annotation, unless one was already recorded.
+func (p *parser) fail(key, format string, args ...any) {
+	if _, found := p.errs[key]; !found {
+		p.errs[key] = fmt.Errorf(format, args...)
+	}
+}
+
+// err joins the errors recorded, ordered by annotation.
+func (p *parser) err() error {
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(p.errs)) {
+		errs = append(errs, p.errs[key])
+	}
+	return errors.Join(errs...)
+}
+
+// duration returns the duration of the given annotation, or the given default if it is not set or invalid.
+func (p *parser) duration(key string, defaults time.Duration) time.Duration {
+	duration, err := Duration(p.pool, key)
+	if err != nil {
+		p.errs[key] = err
+	}
+	if duration == 0 {
+		return defaults
+	}
+	return duration
+}
+
+// integer returns the integer of the given annotation, of the given bit size and no less than least, or the
+// given default if it is not set or invalid.
+func (p *parser) integer(key string, bits int, least, defaults int64, requirement string) int64 {
+	value, ok := p.value(key)
+	if !ok {
+		return defaults
+	}
+	n, err := strconv.ParseInt(value, 10, bits)
+	if err != nil || n < least {
+		p.fail(key, "annotation %s of inferencePool %s must be %s, got %q", key, p.pool.Name, requirement, value)
+		return defaults
+	}
+	return n
+}
+
+// number returns the number of the given annotation greater than above, or the given default if it is not
+// set, empty or invalid.
+func (p *parser) number(key string, above, defaults float64, requirement string) float64 {
+	value, ok := p.value(key)
+	if !ok || value == "" {
+		return defaults
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n <= above {
+		p.fail(key, "annotation %s of inferencePool %s must be %s, got %q", key, p.pool.Name, requirement, value)
+		return defaults
+	}
+	return n
+}
+
+// boolean returns the boolean of the given annotation, and whether it is set to a valid one.
+func (p *parser) boolean(key string) (bool, bool) {
+	value, ok := p.value(key)
+	if !ok {
+		return false, false
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		p.fail(key, "annotation %s of inferencePool %s must be a boolean, got %q", key, p.pool.Name, value)
+		return false, false
+	}
+	return b, true
+}
+
+// url returns the absolute http(s) URL of the given annotation, or an empty string if it is not set or invalid.
+func (p *parser) url(key string) string {
+	value, ok := p.value(key)
+	if !ok {
+		return ""
+	}
+	if u, err := url.Parse(value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		p.fail(key, "annotation %s of inferencePool %s must be an absolute http or https URL, got %q", key, p.pool.Name, value)
+		return ""
+	}
+	return value
+}
+
+// path returns the absolute path of the given annotation, or an empty string if it is not set or invalid.
+func (p *parser) path(key, example string) string {
+	value, ok := p.value(key)
+	if ok && !strings.HasPrefix(value, "/") {
+		p.fail(key, "annotation %s of inferencePool %s must be an absolute path, e.g. %q, got %q", key, p.pool.Name, example, value)
+		return ""
+	}
+	return value
+}
+
+// secretName returns the Secret named by the given annotation, or an empty string if it is not set or empty.
+func (p *parser) secretName(key string) string {
+	value, ok := p.value(key)
+	if ok && value == "" {
+		p.fail(key, "annotation %s of inferencePool %s must name a Secret, got an empty name", key, p.pool.Name)
+	}
+	return value
+}
+
+// list returns the comma separated items of the given annotation, without the empty ones.
+func (p *parser) list(key string) []string {
+	var items []string
+	for item := range strings.SplitSeq(p.pool.Annotations[key], ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseSwitches parses the annotations turning the activator features of the pool on or off.
+func (p *parser) parseSwitches(config *Config) {
+	config.Enabled = true
+	if enabled, ok := p.boolean(ActivatorEnabledKey); ok {
+		config.Enabled = enabled
+	}
+	if passthrough, ok := p.boolean(BenchmarkPassthroughKey); ok {
+		config.BenchmarkPassthrough = &passthrough
+	}
+	config.ReplicasFallback, _ = p.boolean(ReplicasFallbackKey)
+	config.ZoneAwareActivation, _ = p.boolean(ZoneAwareActivationKey)
+
+	if value, ok := p.value(LogVerbosityKey); ok {
+		if verbosity, err := strconv.ParseUint(value, 10, 7); err != nil {
+			p.fail(LogVerbosityKey, "annotation %s of inferencePool %s must be an integer between 0 and 127, got %q", LogVerbosityKey, p.pool.Name, value)
+		} else {
+			level := int8(verbosity)
+			config.LogVerbosity = &level
+		}
+	}
+}
+
+// parseStrategy parses the activation strategy of the pool and its options. Whether the options required by
+// the strategy are set is checked by the strategy itself.
+func (p *parser) parseStrategy(config *Config) {
+	config.Strategy = p.pool.Annotations[StrategyKey]
+	config.HPAName = p.pool.Annotations[HPANameKey]
+	config.KEDAScaledObjectName = p.pool.Annotations[KEDAScaledObjectNameKey]
+
+	config.WebhookURL = p.url(WebhookURLKey)
+	config.WebhookSecretName = p.secretName(WebhookSecretNameKey)
+	config.WebhookHealthURL = p.url(WebhookHealthURLKey)
+
+	config.ManagedEndpointProvider = p.pool.Annotations[ManagedEndpointProviderKey]
+	config.ManagedEndpoint = p.pool.Annotations[ManagedEndpointKey]
+	config.ManagedEndpointSecretName = p.secretName(ManagedEndpointSecretKey)
+	config.ManagedEndpointModels = p.list(ManagedEndpointModelsKey)
+
+	config.VLLMSleepLevel = DefaultVLLMSleepLevel
+	if level, ok := p.value(VLLMSleepLevelKey); ok {
+		if level != "1" && level != "2" {
+			p.fail(VLLMSleepLevelKey, "annotation %s of inferencePool %s must be 1 or 2, got %q", VLLMSleepLevelKey, p.pool.Name, level)
+		} else {
+			config.VLLMSleepLevel = level
+		}
+	}
+}
+
+// parseScaling parses the replicas the pool is scaled to, its panic mode and the limits of its scale ups and
+// scale downs.
+func (p *parser) parseScaling(config *Config) {
+	config.InitialScale = int32(p.integer(InitialScaleKey, 32, 1, 0, "a positive integer"))
+	config.InitialScaleRequestsPerReplica = int32(p.integer(InitialScaleRequestsPerReplicaKey, 32, 1, 0, "a positive integer"))
+	config.MinReplicas = int32(p.integer(MinReplicasKey, 32, 0, 0, "a non-negative integer"))
+	config.MaxReplicas = int32(p.integer(MaxReplicasKey, 32, 1, 0, "a positive integer"))
+	if config.MaxReplicas > 0 && config.MinReplicas > config.MaxReplicas {
+		p.fail(MinReplicasKey, "annotation %s of inferencePool %s must not exceed its %s of %d, got %d", MinReplicasKey, p.pool.Name, MaxReplicasKey, config.MaxReplicas, config.MinReplicas)
+		config.MinReplicas = 0
+	}
+
+	config.TargetRequestRate = p.number(TargetRequestRateKey, 0, 0, "a positive number")
+	config.PanicThreshold = p.number(PanicThresholdKey, 1, DefaultPanicThreshold, "a number greater than 1")
+	if config.TargetRequestRate > 0 && config.MaxReplicas == 0 {
+		p.fail(MaxReplicasKey, "annotation %s of inferencePool %s must be a positive integer when %s is set", MaxReplicasKey, p.pool.Name, TargetRequestRateKey)
+	}
+
+	config.ActivationPriority = int(p.integer(ActivationPriorityKey, 0, 0, DefaultActivationPriority, "a non-negative integer"))
+	config.DeactivationPriority = int(p.integer(DeactivationPriorityKey, 0, 0, DefaultDeactivationPriority, "a non-negative integer"))
+	config.MaxColdStartsPerHour = int(p.integer(MaxColdStartsPerHourKey, 0, 1, 0, "a positive integer"))
+	config.MonthlyActivationBudget = p.integer(MonthlyActivationBudgetKey, 64, 1, 0, "a positive number of accelerator minutes")
+}
+
+// parseTargets parses the workloads activated along or instead of the target workload of the pool.
+func (p *parser) parseTargets(config *Config) {
+	config.ActivationPolicy = p.pool.Annotations[ActivationPolicyKey]
+	config.ActivationHooks = p.list(ActivationHooksKey)
+
+	prefill, hasPrefill := p.value(PrefillTargetKey)
+	if hasPrefill && (prefill == "" || prefill == config.Target.Name) {
+		p.fail(PrefillTargetKey, "annotation %s of inferencePool %s must name a workload other than its target, got %q", PrefillTargetKey, p.pool.Name, prefill)
+	} else {
+		config.PrefillTarget = prefill
+	}
+	ratio, ok := p.value(PrefillDecodeRatioKey)
+	if !ok {
+		ratio = DefaultPrefillDecodeRatio
+	}
+	prefillPart, decodePart, found := strings.Cut(ratio, ":")
+	prefillReplicas, perr := strconv.ParseInt(strings.TrimSpace(prefillPart), 10, 32)
+	decodeReplicas, derr := strconv.ParseInt(strings.TrimSpace(decodePart), 10, 32)
+	if !found || perr != nil || derr != nil || prefillReplicas < 1 || decodeReplicas < 1 {
+		p.fail(PrefillDecodeRatioKey, "annotation %s of inferencePool %s must be a ratio of positive integers, e.g. \"1:2\", got %q", PrefillDecodeRatioKey, p.pool.Name, ratio)
+		prefillReplicas, decodeReplicas = 1, 1
+	}
+	config.PrefillDecodeRatio = Ratio{Prefill: int32(prefillReplicas), Decode: int32(decodeReplicas)}
+
+	if value, ok := p.value(AdditionalTargetsKey); ok {
+		config.AdditionalTargets = p.additionalTargets(value, config.Target, prefill, hasPrefill)
+	}
+
+	if name, ok := p.value(RolloverTargetKey); ok && (name == "" || name == config.Target.Name) {
+		p.fail(RolloverTargetKey, "annotation %s of inferencePool %s must name a workload other than its target, got %q", RolloverTargetKey, p.pool.Name, name)
+	} else {
+		config.RolloverTarget = name
+	}
+}
+
+// additionalTargets parses the given additional targets of the pool, none of them being the given target or
+// prefill target of the pool.
+func (p *parser) additionalTargets(value string, target Target, prefill string, hasPrefill bool) []AdditionalTarget {
+	var targets []AdditionalTarget
+	if err := json.Unmarshal([]byte(value), &targets); err != nil {
+		p.fail(AdditionalTargetsKey, "annotation %s of inferencePool %s must be a JSON list of targets: %w", AdditionalTargetsKey, p.pool.Name, err)
+		return nil
+	}
+	seen := make(map[string]bool, len(targets)+1)
+	seen[target.Kind+"/"+target.Name] = true
+	if hasPrefill {
+		seen[target.Kind+"/"+prefill] = true
+	}
+	for i, additional := range targets {
+		if additional.APIVersion == "" || additional.Kind == "" || additional.Name == "" {
+			p.fail(AdditionalTargetsKey, "annotation %s of inferencePool %s must set the apiVersion, kind and name of every target, got %q", AdditionalTargetsKey, p.pool.Name, value)
+			return nil
+		}
+		if additional.Replicas == nil {
+			replicas := int32(1)
+			targets[i].Replicas = &replicas
+		} else if *additional.Replicas < 1 {
+			p.fail(AdditionalTargetsKey, "annotation %s of inferencePool %s must scale target %s/%s up to at least one replica, got %d", AdditionalTargetsKey, p.pool.Name, additional.Kind, additional.Name, *additional.Replicas)
+			return nil
+		}
+		key := additional.Kind + "/" + additional.Name
+		if seen[key] {
+			p.fail(AdditionalTargetsKey, "annotation %s of inferencePool %s lists target %s more than once or along the target or prefill target of the pool", AdditionalTargetsKey, p.pool.Name, key)
+			return nil
+		}
+		seen[key] = true
+	}
+	return targets
+}
+
+// parseRelease parses how the requests held for the pool are released once it is active.
+func (p *parser) parseRelease(config *Config) {
+	config.ReleaseStrategy = ReleaseAllAtOnce
+	switch strategy, ok := p.value(ReleaseStrategyKey); {
+	case !ok:
+	case strategy == ReleaseAllAtOnce, strategy == ReleaseRateLimited, strategy == ReleaseJittered:
+		config.ReleaseStrategy = strategy
+	default:
+		p.fail(ReleaseStrategyKey, "annotation %s of inferencePool %s must be one of %s, %s or %s, got %q",
+			ReleaseStrategyKey, p.pool.Name, ReleaseAllAtOnce, ReleaseRateLimited, ReleaseJittered, strategy)
+	}
+	config.ReleaseRate = int(p.integer(ReleaseRateKey, 0, 1, DefaultReleaseRate, "a positive number of requests per second"))
+	config.ReleaseGates = p.list(ReleaseGatesKey)
+	config.ReleaseGateURL = p.pool.Annotations[ReleaseGateURLKey]
+	config.ReleaseGatesFailOpen, _ = p.boolean(ReleaseGatesFailOpenKey)
+}
+
+// parseReadiness parses how the readiness of the pool is checked after a scale up from zero.
+func (p *parser) parseReadiness(config *Config) {
+	if value, ok := p.value(ReadyConditionKey); ok {
+		conditionType, status, found := strings.Cut(value, "=")
+		if !found {
+			status = string(metav1.ConditionTrue)
+		}
+		conditionType, status = strings.TrimSpace(conditionType), strings.TrimSpace(status)
+		switch metav1.ConditionStatus(status) {
+		case metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown:
+		default:
+			conditionType = ""
+		}
+		if conditionType == "" {
+			p.fail(ReadyConditionKey, "annotation %s of inferencePool %s must be a condition type optionally followed by =True, =False or =Unknown, got %q",
+				ReadyConditionKey, p.pool.Name, value)
+		} else {
+			config.ReadyCondition = Condition{Type: conditionType, Status: status}
+		}
+	}
+
+	if value, ok := p.value(ReadyReplicasJSONPathKey); ok {
+		expression := value
+		if !strings.HasPrefix(expression, "{") {
+			expression = "{" + expression + "}"
+		}
+		// JSONPaths are not safe for concurrent use, only the expression is kept
+		if err := jsonpath.New("ready-replicas").Parse(expression); err != nil {
+			p.fail(ReadyReplicasJSONPathKey, "annotation %s of inferencePool %s must be a JSONPath, got %q: %w", ReadyReplicasJSONPathKey, p.pool.Name, value, err)
+		} else {
+			config.ReadyReplicasJSONPath = expression
+		}
+	}
+
+	config.ReadinessProbePath = p.path(ReadinessProbePathKey, "/health")
+	config.WarmUpPath = p.path(WarmUpPathKey, "/v1/completions")
+	config.WarmUpBody = DefaultWarmUpBody
+	if body, ok := p.value(WarmUpBodyKey); ok {
+		if !json.Valid([]byte(body)) {
+			p.fail(WarmUpBodyKey, "annotation %s of inferencePool %s must be a JSON document, got %q", WarmUpBodyKey, p.pool.Name, body)
+		} else {
+			config.WarmUpBody = body
+		}
+	}
+
+	if check, ok := p.value(ModelCacheCheckKey); ok {
+		if claim, found := strings.CutPrefix(check, ModelCacheCheckClaimPrefix); check != ModelCacheCheckPods && (!found || claim == "") {
+			p.fail(ModelCacheCheckKey, "annotation %s of inferencePool %s must be %q or \"%s<name>\", got %q", ModelCacheCheckKey, p.pool.Name, ModelCacheCheckPods, ModelCacheCheckClaimPrefix, check)
+		} else {
+			config.ModelCacheCheck = check
+		}
+	}
+
+	config.EndpointPickerMetricsURL = p.url(EndpointPickerMetricsURLKey)
+	config.VerificationWebhookURL = p.url(VerificationWebhookURLKey)
+}
+
+// parseIdleness parses when the pool is idle and the model aliases it serves. Whether the idleness predicates
+// are registered is checked by the activator.
+func (p *parser) parseIdleness(config *Config) {
+	config.Idleness = strings.TrimSpace(p.pool.Annotations[IdlenessKey])
+	config.InFlightMetric = p.pool.Annotations[InFlightMetricKey]
+	config.QueueMetric = p.pool.Annotations[QueueMetricKey]
+	config.IdlenessURL = p.pool.Annotations[IdlenessWebhookKey]
+	config.EndpointPickerQueueMetric = p.pool.Annotations[EndpointPickerQueueMetricKey]
+
+	value := p.pool.Annotations[ModelAliasesKey]
+	if value == "" {
+		return
+	}
+	aliases := make(map[string]string)
+	for pair := range strings.SplitSeq(value, ",") {
+		alias, model, found := strings.Cut(strings.TrimSpace(pair), "=")
+		alias, model = strings.TrimSpace(alias), strings.TrimSpace(model)
+		if !found || alias == "" || model == "" {
+			p.fail(ModelAliasesKey, "invalid model alias %q in annotation %s of inferencePool %s, expected alias=model", pair, ModelAliasesKey, p.pool.Name)
+			return
+		}
+		if _, dup := aliases[alias]; dup {
+			p.fail(ModelAliasesKey, "duplicate model alias %q in annotation %s of inferencePool %s", alias, ModelAliasesKey, p.pool.Name)
+			return
+		}
+		aliases[alias] = model
+	}
+	config.ModelAliases = aliases
+}
@@ -0,0 +1,410 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package poolconfig parses the core activator annotations of an InferencePool into a typed configuration,
+// once per version of the pool.
+package poolconfig
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+const (
+	TargetAPIVersionKey = "activator.llm-d.ai/target-apiversion"
+	TargetKindKey       = "activator.llm-d.ai/target-kind"
+	TargetNameKey       = "activator.llm-d.ai/target-name"
+
+	ScaleFromZeroGracePeriodKey = "activator.llm-d.ai/scale-from-zero-grace-period" // Optional annotation
+	ScaleDownDelayKey           = "activator.llm-d.ai/scale-down-delay"             // Optional annotation
+	ScaleToZeroGracePeriodKey   = "activator.llm-d.ai/scale-to-zero-grace-period"   // Optional annotation
+	QueuedTimeoutKey            = "activator.llm-d.ai/queued-timeout"               // Optional annotation
+	NodeProvisioningTimeoutKey  = "activator.llm-d.ai/node-provisioning-timeout"    // Optional annotation
+	AbandonedLingerKey          = "activator.llm-d.ai/abandoned-activation-linger"  // Optional annotation
+	MeanRequestDurationKey      = "activator.llm-d.ai/mean-request-duration"        // Optional annotation
+	PropagationDelayKey         = "activator.llm-d.ai/propagation-delay"            // Optional annotation
+	ActivationSLOKey            = "activator.llm-d.ai/activation-slo"               // Optional annotation
+
+	ActivatorEnabledKey     = "activator.llm-d.ai/enabled"               // Optional annotation
+	BenchmarkPassthroughKey = "activator.llm-d.ai/benchmark-passthrough" // Optional annotation
+	LogVerbosityKey         = "activator.llm-d.ai/log-verbosity"         // Optional annotation
+	ReplicasFallbackKey     = "activator.llm-d.ai/replicas-fallback"     // Optional annotation
+	ZoneAwareActivationKey  = "activator.llm-d.ai/zone-aware-activation" // Optional annotation
+
+	StrategyKey                = "activator.llm-d.ai/strategy"                     // Optional annotation
+	HPANameKey                 = "activator.llm-d.ai/hpa-name"                     // Required by the hpa-min strategy
+	KEDAScaledObjectNameKey    = "activator.llm-d.ai/keda-scaledobject-name"       // Required by the keda-pause strategy
+	WebhookURLKey              = "activator.llm-d.ai/webhook-url"                  // Required by the webhook strategy
+	WebhookSecretNameKey       = "activator.llm-d.ai/webhook-secret-name"          // Optional, used by the webhook strategy
+	WebhookHealthURLKey        = "activator.llm-d.ai/webhook-health-url"           // Optional, used by the webhook strategy
+	ManagedEndpointProviderKey = "activator.llm-d.ai/managed-endpoint-provider"    // Required by the managed-endpoint strategy
+	ManagedEndpointKey         = "activator.llm-d.ai/managed-endpoint"             // Required by the managed-endpoint strategy
+	ManagedEndpointModelsKey   = "activator.llm-d.ai/managed-endpoint-models"      // Optional, used by the managed-endpoint strategy
+	ManagedEndpointSecretKey   = "activator.llm-d.ai/managed-endpoint-secret-name" // Optional, used by the managed-endpoint strategy
+	VLLMSleepLevelKey          = "activator.llm-d.ai/vllm-sleep-level"             // Optional, used by the vllm-sleep strategy
+
+	InitialScaleKey                   = "activator.llm-d.ai/initial-scale"                      // Optional annotation
+	InitialScaleRequestsPerReplicaKey = "activator.llm-d.ai/initial-scale-requests-per-replica" // Optional annotation
+	MinReplicasKey                    = "activator.llm-d.ai/min-replicas"                       // Optional annotation
+	MaxReplicasKey                    = "activator.llm-d.ai/max-replicas"                       // Required when panic mode is enabled
+	TargetRequestRateKey              = "activator.llm-d.ai/target-request-rate"                // Optional annotation, enables panic mode
+	PanicThresholdKey                 = "activator.llm-d.ai/panic-threshold"                    // Optional annotation
+	ActivationPriorityKey             = "activator.llm-d.ai/activation-priority"                // Optional annotation
+	DeactivationPriorityKey           = "activator.llm-d.ai/deactivation-priority"              // Optional annotation
+	MaxColdStartsPerHourKey           = "activator.llm-d.ai/max-cold-starts-per-hour"           // Optional annotation
+	MonthlyActivationBudgetKey        = "activator.llm-d.ai/monthly-activation-budget"          // Optional annotation
+
+	ActivationPolicyKey   = "activator.llm-d.ai/activation-policy"    // Optional annotation
+	ActivationHooksKey    = "activator.llm-d.ai/activation-hooks"     // Optional annotation
+	AdditionalTargetsKey  = "activator.llm-d.ai/additional-targets"   // Optional annotation
+	PrefillTargetKey      = "activator.llm-d.ai/prefill-target"       // Optional annotation
+	PrefillDecodeRatioKey = "activator.llm-d.ai/prefill-decode-ratio" // Optional annotation
+	RolloverTargetKey     = "activator.llm-d.ai/rollover-target"      // Optional annotation
+
+	ReleaseStrategyKey      = "activator.llm-d.ai/release-strategy"        // Optional annotation
+	ReleaseRateKey          = "activator.llm-d.ai/release-rate"            // Optional annotation
+	ReleaseJitterKey        = "activator.llm-d.ai/release-jitter"          // Optional annotation
+	ReleaseGatesKey         = "activator.llm-d.ai/release-gates"           // Optional annotation
+	ReleaseGateURLKey       = "activator.llm-d.ai/release-gate-url"        // Required by the external release gate
+	ReleaseGatesFailOpenKey = "activator.llm-d.ai/release-gates-fail-open" // Optional annotation
+
+	ReadyConditionKey           = "activator.llm-d.ai/ready-condition"          // Optional annotation
+	ReadyReplicasJSONPathKey    = "activator.llm-d.ai/ready-replicas-jsonpath"  // Optional annotation
+	ReadinessProbePathKey       = "activator.llm-d.ai/readiness-probe-path"     // Optional annotation
+	WarmUpPathKey               = "activator.llm-d.ai/warm-up-path"             // Optional annotation
+	WarmUpBodyKey               = "activator.llm-d.ai/warm-up-body"             // Optional annotation
+	ModelCacheCheckKey          = "activator.llm-d.ai/model-cache-check"        // Optional annotation
+	EndpointPickerMetricsURLKey = "activator.llm-d.ai/epp-metrics-url"          // Optional annotation
+	VerificationWebhookURLKey   = "activator.llm-d.ai/verification-webhook-url" // Optional annotation
+
+	IdlenessKey                  = "activator.llm-d.ai/idleness"         // Optional annotation
+	InFlightMetricKey            = "activator.llm-d.ai/in-flight-metric" // Optional, used by the no-in-flight predicate
+	QueueMetricKey               = "activator.llm-d.ai/queue-metric"     // Optional, used by the queue-empty predicate
+	IdlenessWebhookKey           = "activator.llm-d.ai/idleness-url"     // Required by the external predicate
+	EndpointPickerQueueMetricKey = "activator.llm-d.ai/epp-queue-metric" // Optional, used by the epp-queue-empty predicate
+	ModelAliasesKey              = "activator.llm-d.ai/model-aliases"    // Optional annotation
+
+	// ExternalVersionKey records the versions of the objects other than the pool its activator annotations were
+	// filled in from, e.g. its companion ConfigMap. It is only set in memory, by the activator.
+	ExternalVersionKey = "activator.llm-d.ai/external-version"
+)
+
+const (
+	// DefaultScaleFromZeroGracePeriod is the time we will wait for a scale-from-zero decision to complete
+	DefaultScaleFromZeroGracePeriod = time.Duration(60 * time.Second)
+
+	// DefaultScaleDownDelay is the amount of time that must pass before a scale-down decision is applied
+	DefaultScaleDownDelay = time.Duration(120 * time.Second)
+
+	// DefaultQueuedTimeout is the time we will wait for Kueue to admit the pods of a scale from zero
+	DefaultQueuedTimeout = time.Duration(10 * time.Minute)
+
+	// DefaultNodeProvisioningTimeout is the most the scale grace period is extended by while nodes, typically
+	// GPU nodes taking minutes to boot, are provisioned for the pods of a scale from zero
+	DefaultNodeProvisioningTimeout = time.Duration(10 * time.Minute)
+
+	// DefaultMeanRequestDuration is the mean request duration assumed when estimating the concurrency of a pool
+	DefaultMeanRequestDuration = time.Duration(1 * time.Second)
+
+	// DefaultPanicThreshold is the ratio of desired to current replicas that triggers panic mode
+	DefaultPanicThreshold = 2.0
+
+	// DefaultActivationPriority is the activation priority of pools without an activation priority annotation
+	DefaultActivationPriority = 0
+
+	// DefaultDeactivationPriority is the deactivation priority of pools without a deactivation priority annotation
+	DefaultDeactivationPriority = 0
+
+	// DefaultPrefillDecodeRatio scales pools up from zero to as many prefill as decode replicas
+	DefaultPrefillDecodeRatio = "1:1"
+
+	ReleaseAllAtOnce   = "all-at-once"
+	ReleaseRateLimited = "rate-limited"
+	ReleaseJittered    = "jittered"
+
+	// DefaultReleaseRate is the release rate of pools without release rate annotation
+	DefaultReleaseRate = 10
+
+	// DefaultReleaseJitter is the release jitter of pools without release jitter annotation
+	DefaultReleaseJitter = time.Duration(2 * time.Second)
+
+	// DefaultWarmUpBody is the body of the warm-up requests of pools without a warm-up body annotation
+	DefaultWarmUpBody = `{"prompt":"Hello","max_tokens":1}`
+
+	// DefaultVLLMSleepLevel offloads the model weights to CPU memory while sleeping, level 2 discarding them
+	DefaultVLLMSleepLevel = "1"
+
+	// ModelCacheCheckPods and ModelCacheCheckClaimPrefix are the model cache checks of the pods and of a
+	// PersistentVolumeClaim of the pool
+	ModelCacheCheckPods        = "pods"
+	ModelCacheCheckClaimPrefix = "claim:"
+)
+
+// Target is the workload of the pool scaled by the activator.
+type Target struct {
+	APIVersion string
+	Kind       string
+	Name       string
+}
+
+// AdditionalTarget is a workload scaled together with the target workload of a pool.
+type AdditionalTarget struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	// Replicas is the number of replicas the workload is scaled up to, 1 when the annotation omits it.
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// Ratio is the ratio of prefill to decode replicas of a pool disaggregating prefill and decode.
+type Ratio struct {
+	Prefill int32
+	Decode  int32
+}
+
+// Condition is the status condition the readiness of the target workload is derived from.
+type Condition struct {
+	Type   string
+	Status string
+}
+
+// Config is the activator configuration of an InferencePool.
+type Config struct {
+	Target Target
+	// MissingTarget lists the target annotations the pool does not set. Pools whose target is activated by
+	// an external strategy set none.
+	MissingTarget []string
+
+	ScaleFromZeroGracePeriod time.Duration
+	ScaleDownDelay           time.Duration
+	QueuedTimeout            time.Duration
+	// ScaleToZeroGracePeriod is the least time the pool is kept up once active, e.g. after a scale up from zero,
+	// whatever its scale down delay, so that models slow to load are not scaled back to zero right away. Zero
+	// when not set.
+	ScaleToZeroGracePeriod time.Duration
+	// NodeProvisioningTimeout is the most the scale grace period is extended by while nodes are provisioned
+	// for the pods of the pool.
+	NodeProvisioningTimeout time.Duration
+	// AbandonedLinger is the time an abandoned scale up from zero lingers before it is reverted. Zero when
+	// abandoned scale ups are not reverted.
+	AbandonedLinger     time.Duration
+	MeanRequestDuration time.Duration
+	// PropagationDelay is the time the requests held are still held once the pods are ready. Zero when the
+	// learned propagation delay applies.
+	PropagationDelay time.Duration
+	// ActivationSLO defaults to the scale from zero grace period.
+	ActivationSLO time.Duration
+
+	// Enabled is unset when the pool is out of activator control.
+	Enabled bool
+	// BenchmarkPassthrough overrides the benchmark passthrough mode of the activator when set.
+	BenchmarkPassthrough *bool
+	// LogVerbosity raises the verbosity of the activator logs when set.
+	LogVerbosity        *int8
+	ReplicasFallback    bool
+	ZoneAwareActivation bool
+
+	// Strategy is empty when the pool uses the default activation strategy. The options of the strategies are
+	// empty when not set.
+	Strategy                  string
+	HPAName                   string
+	KEDAScaledObjectName      string
+	WebhookURL                string
+	WebhookSecretName         string
+	WebhookHealthURL          string
+	ManagedEndpointProvider   string
+	ManagedEndpoint           string
+	ManagedEndpointSecretName string
+	// ManagedEndpointModels is empty when the managed endpoint serves every model.
+	ManagedEndpointModels []string
+	VLLMSleepLevel        string
+
+	// InitialScale, InitialScaleRequestsPerReplica, MinReplicas and MaxReplicas are zero when not set.
+	InitialScale                   int32
+	InitialScaleRequestsPerReplica int32
+	MinReplicas                    int32
+	MaxReplicas                    int32
+	// TargetRequestRate enables panic mode when set, and is zero otherwise.
+	TargetRequestRate    float64
+	PanicThreshold       float64
+	ActivationPriority   int
+	DeactivationPriority int
+	// MaxColdStartsPerHour and MonthlyActivationBudget are zero when not set.
+	MaxColdStartsPerHour    int
+	MonthlyActivationBudget int64
+
+	ActivationPolicy  string
+	ActivationHooks   []string
+	AdditionalTargets []AdditionalTarget
+	// PrefillTarget and RolloverTarget are empty when not set.
+	PrefillTarget      string
+	PrefillDecodeRatio Ratio
+	RolloverTarget     string
+
+	ReleaseStrategy string
+	ReleaseRate     int
+	ReleaseJitter   time.Duration
+	ReleaseGates    []string
+	ReleaseGateURL  string
+	// ReleaseGatesFailOpen releases the requests the release gates fail to decide on, which are rejected otherwise.
+	ReleaseGatesFailOpen bool
+
+	// ReadyCondition has an empty type when not set, and ReadyReplicasJSONPath is empty when not set.
+	ReadyCondition           Condition
+	ReadyReplicasJSONPath    string
+	ReadinessProbePath       string
+	WarmUpPath               string
+	WarmUpBody               string
+	ModelCacheCheck          string
+	EndpointPickerMetricsURL string
+	VerificationWebhookURL   string
+
+	// Idleness and the options of the idleness predicates are empty when not set.
+	Idleness                  string
+	InFlightMetric            string
+	QueueMetric               string
+	IdlenessURL               string
+	EndpointPickerQueueMetric string
+	// ModelAliases maps the model aliases of the pool to the model it serves.
+	ModelAliases map[string]string
+
+	// Err reports the optional annotations set to invalid values, their defaults being used instead.
+	Err error
+	// errs holds the error of each annotation set to an invalid value.
+	errs map[string]error
+}
+
+// HasTarget reports whether the pool sets all of the target annotations.
+func (c *Config) HasTarget() bool {
+	return len(c.MissingTarget) == 0
+}
+
+// ErrOf returns the error of the given annotation of the pool, if it is set to an invalid value.
+func (c *Config) ErrOf(key string) error {
+	return c.errs[key]
+}
+
+// Parse parses the activator annotations of the given pool. Missing or invalid optional annotations take
+// their default values.
+func Parse(pool *v1.InferencePool) *Config {
+	config := &Config{}
+	for _, target := range []struct {
+		key   string
+		value *string
+	}{
+		{TargetAPIVersionKey, &config.Target.APIVersion},
+		{TargetKindKey, &config.Target.Kind},
+		{TargetNameKey, &config.Target.Name},
+	} {
+		var found bool
+		if *target.value, found = pool.Annotations[target.key]; !found {
+			config.MissingTarget = append(config.MissingTarget, target.key)
+		}
+	}
+
+	p := &parser{pool: pool, errs: map[string]error{}}
+	for _, option := range []struct {
+		key      string
+		value    *time.Duration
+		defaults time.Duration
+	}{
+		{ScaleFromZeroGracePeriodKey, &config.ScaleFromZeroGracePeriod, DefaultScaleFromZeroGracePeriod},
+		{ScaleDownDelayKey, &config.ScaleDownDelay, DefaultScaleDownDelay},
+		{ScaleToZeroGracePeriodKey, &config.ScaleToZeroGracePeriod, 0},
+		{QueuedTimeoutKey, &config.QueuedTimeout, DefaultQueuedTimeout},
+		{NodeProvisioningTimeoutKey, &config.NodeProvisioningTimeout, DefaultNodeProvisioningTimeout},
+		{AbandonedLingerKey, &config.AbandonedLinger, 0},
+		{MeanRequestDurationKey, &config.MeanRequestDuration, DefaultMeanRequestDuration},
+		{PropagationDelayKey, &config.PropagationDelay, 0},
+		{ReleaseJitterKey, &config.ReleaseJitter, DefaultReleaseJitter},
+	} {
+		*option.value = p.duration(option.key, option.defaults)
+	}
+	config.ActivationSLO = p.duration(ActivationSLOKey, config.ScaleFromZeroGracePeriod)
+
+	p.parseSwitches(config)
+	p.parseStrategy(config)
+	p.parseScaling(config)
+	p.parseTargets(config)
+	p.parseRelease(config)
+	p.parseReadiness(config)
+	p.parseIdleness(config)
+
+	config.errs = p.errs
+	config.Err = p.err()
+	return config
+}
+
+// Duration returns the positive duration of the given optional annotation of the pool, or zero if it is not set.
+func Duration(pool *v1.InferencePool, key string) (time.Duration, error) {
+	value, found := pool.Annotations[key]
+	if !found {
+		return 0, nil
+	}
+	duration, err := ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("annotation %s of inferencePool %s must be a positive duration, e.g. \"90s\" or \"2m\", got %q", key, pool.Name, value)
+	}
+	return duration, nil
+}
+
+// ParseDuration parses the value of a timing annotation, either a Go duration such as "90s" or "2m", or a
+// bare number of seconds such as "90".
+func ParseDuration(value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err == nil {
+		return duration, nil
+	}
+	if seconds, convErr := strconv.ParseInt(value, 10, 32); convErr == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return 0, err
+}
+
+var cache struct {
+	sync.Mutex
+	uid             types.UID
+	resourceVersion string
+	annotations     map[string]string
+	config          *Config
+}
+
+// For returns the configuration of the given pool, parsing its annotations only once per version of the
+// pool. An activator serving a single pool, the configuration of the latest version is the only one kept.
+func For(pool *v1.InferencePool) *Config {
+	// Pools not stored by the API server have no version to invalidate their configuration on
+	if pool.UID == "" || pool.ResourceVersion == "" {
+		return Parse(pool)
+	}
+
+	cache.Lock()
+	defer cache.Unlock()
+	// The annotations of the pool may be changed in memory by the activator without a new version, e.g. when
+	// its target is discovered or its annotations are filled in from other objects
+	if cache.config == nil || cache.uid != pool.UID || cache.resourceVersion != pool.ResourceVersion ||
+		!maps.Equal(cache.annotations, pool.Annotations) {
+		cache.uid, cache.resourceVersion, cache.config = pool.UID, pool.ResourceVersion, Parse(pool)
+		cache.annotations = maps.Clone(pool.Annotations)
+	}
+	return cache.config
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package poolconfig
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestParse(t *testing.T) {
+	target := map[string]string{
+		TargetAPIVersionKey: "apps/v1",
+		TargetKindKey:       "Deployment",
+		TargetNameKey:       "model",
+	}
+	with := func(annotations map[string]string) map[string]string {
+		merged := map[string]string{}
+		for k, v := range target {
+			merged[k] = v
+		}
+		for k, v := range annotations {
+			merged[k] = v
+		}
+		return merged
+	}
+
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		wantTarget      bool
+		wantGracePeriod time.Duration
+		wantDelay       time.Duration
+		wantToZero      time.Duration
+		wantErr         bool
+	}{
+		{name: "defaults", annotations: target, wantTarget: true, wantGracePeriod: DefaultScaleFromZeroGracePeriod, wantDelay: DefaultScaleDownDelay},
+		{name: "no target", annotations: nil, wantTarget: false, wantGracePeriod: DefaultScaleFromZeroGracePeriod, wantDelay: DefaultScaleDownDelay},
+		{
+			name:            "custom durations",
+			annotations:     with(map[string]string{ScaleFromZeroGracePeriodKey: "5m", ScaleDownDelayKey: "30s", ScaleToZeroGracePeriodKey: "15m"}),
+			wantTarget:      true,
+			wantGracePeriod: 5 * time.Minute,
+			wantDelay:       30 * time.Second,
+			wantToZero:      15 * time.Minute,
+		},
+		{
+			name:            "bare number of seconds",
+			annotations:     with(map[string]string{ScaleFromZeroGracePeriodKey: "90", QueuedTimeoutKey: "1h30m"}),
+			wantTarget:      true,
+			wantGracePeriod: 90 * time.Second,
+			wantDelay:       DefaultScaleDownDelay,
+		},
+		{
+			name:            "invalid duration falls back to its default",
+			annotations:     with(map[string]string{ScaleFromZeroGracePeriodKey: "soon", ScaleDownDelayKey: "-1s", ScaleToZeroGracePeriodKey: "0s"}),
+			wantTarget:      true,
+			wantGracePeriod: DefaultScaleFromZeroGracePeriod,
+			wantDelay:       DefaultScaleDownDelay,
+			wantErr:         true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := Parse(&v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Annotations: test.annotations}})
+			if config.HasTarget() != test.wantTarget {
+				t.Errorf("HasTarget() = %t, want %t, missing %v", config.HasTarget(), test.wantTarget, config.MissingTarget)
+			}
+			if config.ScaleFromZeroGracePeriod != test.wantGracePeriod {
+				t.Errorf("ScaleFromZeroGracePeriod = %v, want %v", config.ScaleFromZeroGracePeriod, test.wantGracePeriod)
+			}
+			if config.ScaleDownDelay != test.wantDelay {
+				t.Errorf("ScaleDownDelay = %v, want %v", config.ScaleDownDelay, test.wantDelay)
+			}
+			if config.ScaleToZeroGracePeriod != test.wantToZero {
+				t.Errorf("ScaleToZeroGracePeriod = %v, want %v", config.ScaleToZeroGracePeriod, test.wantToZero)
+			}
+			if (config.Err != nil) != test.wantErr {
+				t.Errorf("Err = %v, wantErr %t", config.Err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestForInvalidatesOnPoolUpdate(t *testing.T) {
+	pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", UID: "uid", ResourceVersion: "1",
+		Annotations: map[string]string{ScaleDownDelayKey: "30s"}}}
+	if got := For(pool).ScaleDownDelay; got != 30*time.Second {
+		t.Fatalf("ScaleDownDelay = %v, want 30s", got)
+	}
+	if For(pool) != For(pool) {
+		t.Errorf("For() parsed the same version of the pool twice")
+	}
+
+	updated := pool.DeepCopy()
+	updated.ResourceVersion = "2"
+	updated.Annotations[ScaleDownDelayKey] = "1m"
+	if got := For(updated).ScaleDownDelay; got != time.Minute {
+		t.Errorf("ScaleDownDelay after update = %v, want 1m", got)
+	}
+
+	// The annotations filled in from other objects change without a new version of the pool
+	external := updated.DeepCopy()
+	external.Annotations[ScaleDownDelayKey] = "2m"
+	external.Annotations[ExternalVersionKey] = "configmap/7"
+	if got := For(external).ScaleDownDelay; got != 2*time.Minute {
+		t.Errorf("ScaleDownDelay after external update = %v, want 2m", got)
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Annotations: map[string]string{
+		TargetAPIVersionKey:         "apps/v1",
+		TargetKindKey:               "Deployment",
+		TargetNameKey:               "decode",
+		ActivatorEnabledKey:         "false",
+		LogVerbosityKey:             "0",
+		ManagedEndpointModelsKey:    " llama , mistral ",
+		MinReplicasKey:              "1",
+		MaxReplicasKey:              "4",
+		PrefillTargetKey:            "prefill",
+		PrefillDecodeRatioKey:       "1:2",
+		AdditionalTargetsKey:        `[{"apiVersion":"apps/v1","kind":"Deployment","name":"router"}]`,
+		ReleaseStrategyKey:          ReleaseRateLimited,
+		ReleaseRateKey:              "5",
+		ReadyConditionKey:           "Available=False",
+		ReadyReplicasJSONPathKey:    ".status.available",
+		ModelAliasesKey:             "fast=llama-8b,smart=llama-70b",
+		ScaleFromZeroGracePeriodKey: "5m",
+	}}}
+	config := Parse(pool)
+	if config.Err != nil {
+		t.Fatalf("Err = %v, want nil", config.Err)
+	}
+	if config.Enabled {
+		t.Errorf("Enabled = true, want false")
+	}
+	if config.LogVerbosity == nil || *config.LogVerbosity != 0 {
+		t.Errorf("LogVerbosity = %v, want 0", config.LogVerbosity)
+	}
+	if want := []string{"llama", "mistral"}; !slices.Equal(config.ManagedEndpointModels, want) {
+		t.Errorf("ManagedEndpointModels = %v, want %v", config.ManagedEndpointModels, want)
+	}
+	if config.MinReplicas != 1 || config.MaxReplicas != 4 {
+		t.Errorf("MinReplicas, MaxReplicas = %d, %d, want 1, 4", config.MinReplicas, config.MaxReplicas)
+	}
+	if config.PrefillTarget != "prefill" || config.PrefillDecodeRatio != (Ratio{Prefill: 1, Decode: 2}) {
+		t.Errorf("PrefillTarget, PrefillDecodeRatio = %q, %v, want prefill, 1:2", config.PrefillTarget, config.PrefillDecodeRatio)
+	}
+	if len(config.AdditionalTargets) != 1 || config.AdditionalTargets[0].Replicas == nil || *config.AdditionalTargets[0].Replicas != 1 {
+		t.Errorf("AdditionalTargets = %v, want router scaled up to 1 replica", config.AdditionalTargets)
+	}
+	if config.ReleaseStrategy != ReleaseRateLimited || config.ReleaseRate != 5 {
+		t.Errorf("ReleaseStrategy, ReleaseRate = %q, %d, want %q, 5", config.ReleaseStrategy, config.ReleaseRate, ReleaseRateLimited)
+	}
+	if config.ReadyCondition != (Condition{Type: "Available", Status: "False"}) {
+		t.Errorf("ReadyCondition = %v, want Available=False", config.ReadyCondition)
+	}
+	if config.ReadyReplicasJSONPath != "{.status.available}" {
+		t.Errorf("ReadyReplicasJSONPath = %q, want {.status.available}", config.ReadyReplicasJSONPath)
+	}
+	if want := map[string]string{"fast": "llama-8b", "smart": "llama-70b"}; !maps.Equal(config.ModelAliases, want) {
+		t.Errorf("ModelAliases = %v, want %v", config.ModelAliases, want)
+	}
+	if config.ActivationSLO != 5*time.Minute {
+		t.Errorf("ActivationSLO = %v, want the scale from zero grace period of 5m", config.ActivationSLO)
+	}
+}
+
+func TestParseErrOf(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		key         string
+	}{
+		{name: "invalid enabled", annotations: map[string]string{ActivatorEnabledKey: "maybe"}, key: ActivatorEnabledKey},
+		{name: "log verbosity out of range", annotations: map[string]string{LogVerbosityKey: "200"}, key: LogVerbosityKey},
+		{name: "unknown vllm sleep level", annotations: map[string]string{VLLMSleepLevelKey: "3"}, key: VLLMSleepLevelKey},
+		{name: "relative webhook url", annotations: map[string]string{WebhookURLKey: "/scale"}, key: WebhookURLKey},
+		{name: "empty webhook secret", annotations: map[string]string{WebhookSecretNameKey: ""}, key: WebhookSecretNameKey},
+		{name: "negative min replicas", annotations: map[string]string{MinReplicasKey: "-1"}, key: MinReplicasKey},
+		{name: "min above max replicas", annotations: map[string]string{MinReplicasKey: "5", MaxReplicasKey: "4"}, key: MinReplicasKey},
+		{name: "request rate without max replicas", annotations: map[string]string{TargetRequestRateKey: "10"}, key: MaxReplicasKey},
+		{name: "prefill target is the target", annotations: map[string]string{TargetNameKey: "model", PrefillTargetKey: "model"}, key: PrefillTargetKey},
+		{name: "invalid prefill decode ratio", annotations: map[string]string{PrefillDecodeRatioKey: "1:0"}, key: PrefillDecodeRatioKey},
+		{name: "additional target listed twice", annotations: map[string]string{AdditionalTargetsKey: `[{"apiVersion":"v1","kind":"Service","name":"a"},{"apiVersion":"v1","kind":"Service","name":"a"}]`}, key: AdditionalTargetsKey},
+		{name: "unknown release strategy", annotations: map[string]string{ReleaseStrategyKey: "burst"}, key: ReleaseStrategyKey},
+		{name: "invalid release gates fail open", annotations: map[string]string{ReleaseGatesFailOpenKey: "sometimes"}, key: ReleaseGatesFailOpenKey},
+		{name: "invalid ready condition", annotations: map[string]string{ReadyConditionKey: "Available=Maybe"}, key: ReadyConditionKey},
+		{name: "invalid jsonpath", annotations: map[string]string{ReadyReplicasJSONPathKey: "{.status["}, key: ReadyReplicasJSONPathKey},
+		{name: "relative readiness probe path", annotations: map[string]string{ReadinessProbePathKey: "health"}, key: ReadinessProbePathKey},
+		{name: "invalid warm up body", annotations: map[string]string{WarmUpBodyKey: "{"}, key: WarmUpBodyKey},
+		{name: "unknown model cache check", annotations: map[string]string{ModelCacheCheckKey: "nodes"}, key: ModelCacheCheckKey},
+		{name: "duplicate model alias", annotations: map[string]string{ModelAliasesKey: "fast=a,fast=b"}, key: ModelAliasesKey},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := Parse(&v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Annotations: test.annotations}})
+			if config.ErrOf(test.key) == nil {
+				t.Errorf("ErrOf(%s) = nil, want an error", test.key)
+			}
+			if config.Err == nil {
+				t.Errorf("Err = nil, want the error of %s", test.key)
+			}
+		})
+	}
+}
+
+func TestForInvalidatesOnAnnotationsChangedInMemory(t *testing.T) {
+	pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", UID: "uid", ResourceVersion: "1",
+		Annotations: map[string]string{}}}
+	if For(pool).HasTarget() {
+		t.Fatalf("HasTarget() = true before the target was discovered")
+	}
+
+	// The target of the pool is discovered and set in memory on the same version of the pool
+	pool.Annotations[TargetAPIVersionKey] = "apps/v1"
+	pool.Annotations[TargetKindKey] = "Deployment"
+	pool.Annotations[TargetNameKey] = "model"
+	if config := For(pool); !config.HasTarget() || config.Target.Name != "model" {
+		t.Errorf("Target = %v, want the discovered Deployment model", config.Target)
+	}
+}
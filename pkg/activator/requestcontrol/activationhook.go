@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/audit"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// ActivationHooksKey selects the comma separated activation hooks run around the scale ups from zero of the
+// pool, in order, e.g. "license-check,notify".
+const ActivationHooksKey = poolconfig.ActivationHooksKey // Optional annotation
+
+// Activation describes the scale up from zero an activation hook runs for.
+type Activation struct {
+	// RequestID is the ID of the request that triggered the scale up.
+	RequestID string
+	// ActivationKey identifies the scale up across the activator replicas, when activation claims are enabled.
+	ActivationKey string
+	// Target is the kind and name of the workload scaled up, e.g. "Deployment/llama".
+	Target string
+	// Replicas is the number of replicas the target workload is scaled up to.
+	Replicas int32
+}
+
+// ActivationResult is the outcome of a scale up from zero.
+type ActivationResult struct {
+	// Succeeded is set when the pool became ready to serve the requests held.
+	Succeeded bool
+	// Reason classifies why the scale up failed.
+	Reason FailureReason
+	// Message describes the outcome.
+	Message string
+	// Duration is how long the scale up took.
+	Duration time.Duration
+}
+
+// PreActivationHook runs before the claiming activator scales the target workload of the pool up from zero,
+// e.g. to check a license. An error fails the scale up, the requests held being answered with it.
+type PreActivationHook interface {
+	PreActivate(ctx context.Context, pool *v1.InferencePool, activation Activation) error
+}
+
+// PostActivationHook runs once a scale up from zero of the pool succeeded or failed, before the requests held
+// are released, e.g. to prime a cache or notify an external system. It is run for every scale up recorded,
+// including those failed before the pre-activation hooks ran.
+type PostActivationHook interface {
+	PostActivate(ctx context.Context, pool *v1.InferencePool, activation Activation, result ActivationResult)
+}
+
+// ActivationHookFactory creates an activation hook with the given clients. The hook implements
+// PreActivationHook, PostActivationHook or both.
+type ActivationHookFactory func(clients StrategyClients) any
+
+var (
+	activationHooksMu sync.RWMutex
+	activationHooks   = map[string]ActivationHookFactory{}
+)
+
+// RegisterActivationHook registers an activation hook selectable with the activation hooks annotation.
+// It is meant to be called before the activator starts, e.g. for out-of-tree hooks.
+func RegisterActivationHook(name string, factory ActivationHookFactory) {
+	activationHooksMu.Lock()
+	defer activationHooksMu.Unlock()
+
+	activationHooks[name] = factory
+}
+
+// RegisteredActivationHooks returns the sorted names of the registered activation hooks.
+func RegisteredActivationHooks() []string {
+	activationHooksMu.RLock()
+	defer activationHooksMu.RUnlock()
+
+	names := make([]string, 0, len(activationHooks))
+	for name := range activationHooks {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// newActivationHooks instantiates all registered activation hooks with the given clients.
+func newActivationHooks(clients StrategyClients) map[string]any {
+	activationHooksMu.RLock()
+	defer activationHooksMu.RUnlock()
+
+	instances := make(map[string]any, len(activationHooks))
+	for name, factory := range activationHooks {
+		instances[name] = factory(clients)
+	}
+	return instances
+}
+
+// activationHooksFor returns the names of the activation hooks selected by the pool.
+func activationHooksFor(pool *v1.InferencePool) []string {
+	return poolconfig.For(pool).ActivationHooks
+}
+
+// validateActivationHooks checks that the activation hooks selected by the pool are registered.
+func validateActivationHooks(pool *v1.InferencePool) error {
+	registered := RegisteredActivationHooks()
+	for _, name := range activationHooksFor(pool) {
+		if !slices.Contains(registered, name) {
+			return fmt.Errorf("invalid annotation '%s' on pool '%s': unknown activation hook %q, registered hooks: %s",
+				ActivationHooksKey, pool.Name, name, strings.Join(registered, ", "))
+		}
+	}
+	return nil
+}
+
+// activationOf returns the scale up from zero described by the given audit record.
+func activationOf(record audit.Record) Activation {
+	return Activation{RequestID: record.RequestID, ActivationKey: record.ActivationKey, Target: record.Target, Replicas: record.Replicas}
+}
+
+// preActivate runs the pre-activation hooks of the pool in order, failing the scale up on the first error.
+func (a *Activator) preActivate(ctx context.Context, pool *v1.InferencePool, activation Activation) error {
+	for _, name := range activationHooksFor(pool) {
+		hook, ok := a.activationHooks[name].(PreActivationHook)
+		if !ok {
+			continue
+		}
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Running pre-activation hook", "hook", name)
+		if err := hook.PreActivate(ctx, pool, activation); err != nil {
+			return &failureError{reason: FailureActivationHook, err: fmt.Errorf("pre-activation hook %s rejected the scale up of inferencePool %s: %w", name, pool.Name, err)}
+		}
+	}
+	return nil
+}
+
+// postActivate runs the post-activation hooks of the pool in order.
+func (a *Activator) postActivate(ctx context.Context, pool *v1.InferencePool, activation Activation, result ActivationResult) {
+	for _, name := range activationHooksFor(pool) {
+		if hook, ok := a.activationHooks[name].(PostActivationHook); ok {
+			log.FromContext(ctx).V(logutil.DEBUG).Info("Running post-activation hook", "hook", name)
+			hook.PostActivate(ctx, pool, activation, result)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+// recordingHook records the hooks run, and rejects the scale ups with the given error.
+type recordingHook struct {
+	name string
+	err  error
+	runs *[]string
+}
+
+func (h *recordingHook) PreActivate(context.Context, *v1.InferencePool, Activation) error {
+	*h.runs = append(*h.runs, "pre:"+h.name)
+	return h.err
+}
+
+func (h *recordingHook) PostActivate(context.Context, *v1.InferencePool, Activation, ActivationResult) {
+	*h.runs = append(*h.runs, "post:"+h.name)
+}
+
+// notifyHook only implements PostActivationHook.
+type notifyHook struct{ runs *[]string }
+
+func (h *notifyHook) PostActivate(context.Context, *v1.InferencePool, Activation, ActivationResult) {
+	*h.runs = append(*h.runs, "post:notify")
+}
+
+func TestActivationHooks(t *testing.T) {
+	tests := []struct {
+		name     string
+		hooks    string
+		reject   bool
+		wantRuns []string
+		wantErr  bool
+	}{
+		{name: "no hooks"},
+		{name: "hooks run in order", hooks: "license, notify", wantRuns: []string{"pre:license", "post:license", "post:notify"}},
+		{name: "pre-activation hook rejects", hooks: "license,notify", reject: true, wantRuns: []string{"pre:license"}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var runs []string
+			license := &recordingHook{name: "license", runs: &runs}
+			if test.reject {
+				license.err = errors.New("license expired")
+			}
+			a := &Activator{activationHooks: map[string]any{"license": license, "notify": &notifyHook{runs: &runs}}}
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Annotations: map[string]string{ActivationHooksKey: test.hooks}}}
+
+			err := a.preActivate(context.Background(), pool, Activation{})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("preActivate() error = %v, wantErr %t", err, test.wantErr)
+			}
+			if err != nil {
+				if reason := failureReasonOf(err); reason != FailureActivationHook {
+					t.Errorf("preActivate() failure reason = %s, want %s", reason, FailureActivationHook)
+				}
+			} else {
+				a.postActivate(context.Background(), pool, Activation{}, ActivationResult{Succeeded: true})
+			}
+			if len(runs) != len(test.wantRuns) {
+				t.Fatalf("hooks run = %v, want %v", runs, test.wantRuns)
+			}
+			for i := range runs {
+				if runs[i] != test.wantRuns[i] {
+					t.Errorf("hooks run = %v, want %v", runs, test.wantRuns)
+					break
+				}
+			}
+		})
+	}
+
+	RegisterActivationHook("test-notify", func(StrategyClients) any { return &notifyHook{} })
+	for hooks, wantErr := range map[string]bool{"test-notify": false, "test-notify,unknown": true} {
+		pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Annotations: map[string]string{ActivationHooksKey: hooks}}}
+		if err := validateActivationHooks(pool); (err != nil) != wantErr {
+			t.Errorf("validateActivationHooks(%q) error = %v, wantErr %t", hooks, err, wantErr)
+		}
+	}
+}
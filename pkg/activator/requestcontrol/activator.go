@@ -3,48 +3,66 @@ package requestcontrol
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
+	"golang.org/x/sync/singleflight"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	activatorv1alpha1 "github.com/llm-d-incubation/llm-d-activator/api/v1alpha1"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/attribution"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/audit"
 	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/features"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
 	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 
 	autoscaling "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/discovery"
 	cached "k8s.io/client-go/discovery/cached"
 	"k8s.io/client-go/scale"
+	"k8s.io/client-go/tools/record"
 )
 
 const (
-	ObjectApiVersionKey         = "activator.llm-d.ai/target-apiversion"
-	ObjectkindKey               = "activator.llm-d.ai/target-kind"
-	ObjectNameKey               = "activator.llm-d.ai/target-name"
-	ScaleFromZeroGracePeriodKey = "activator.llm-d.ai/scale-from-zero-grace-period" // Optional annotation
+	ObjectApiVersionKey         = poolconfig.TargetAPIVersionKey
+	ObjectkindKey               = poolconfig.TargetKindKey
+	ObjectNameKey               = poolconfig.TargetNameKey
+	ScaleFromZeroGracePeriodKey = poolconfig.ScaleFromZeroGracePeriodKey // Optional annotation
+
+	// RequestIDAnnotationKey is set on scale events to the gateway request ID that triggered the scale action
+	RequestIDAnnotationKey = "activator.llm-d.ai/request-id"
 
 	// DefaultScaleFromZeroGracePeriod is the time we will wait for a scale-from-zero decision to complete
-	DefaultScaleFromZeroGracePeriod = time.Duration(60 * time.Second)
+	DefaultScaleFromZeroGracePeriod = poolconfig.DefaultScaleFromZeroGracePeriod
 
 	// DefaultScaleDownDelay is the amount of time that must pass before a scale-down decision is applied
-	DefaultScaleDownDelay = time.Duration(120 * time.Second)
+	DefaultScaleDownDelay = poolconfig.DefaultScaleDownDelay
 
-	// ScaleToZeroRequestRetentionPeriod it is the amount of time we will wait before releasing the request after a scale from zero event
+	// ScaleToZeroRequestRetentionPeriod is the default amount of time we will wait before releasing the request after a scale from zero
+	// event, until an Endpoint Picker sync was measured. Pools may set their own with the propagation delay annotation
 	ScaleToZeroRequestRetentionPeriod = time.Duration(5 * time.Second)
 )
 
 type ScaledObjectData struct {
+	pool             *v1.InferencePool
 	name             string
 	scaleGracePeriod time.Duration
 	numReplicas      int32
@@ -55,10 +73,94 @@ type Activator struct {
 	DynamicClient *dynamic.DynamicClient
 	ScaleClient   scale.ScalesGetter
 	Mapper        meta.RESTMapper
-	datastore     datastore.Datastore
+	// Recorder emits Kubernetes events on the InferencePool for scale actions. Optional.
+	Recorder record.EventRecorder
+	// Recommender is fed the requests handled by the Activator to compute right-sizing recommendations. Optional.
+	Recommender *Recommender
+	// Attribution records the activations and the accelerator time they caused per attribution key. Optional.
+	Attribution *attribution.Ledger
+	// IdleClock persists the time of the last request, for a new leader to rebuild the idle timer of the pool. Optional.
+	IdleClock *IdleClock
+	// CancelAbandoned cancels the scale up from zero in progress once the clients of every request held
+	// for it disconnected. The replicas already requested are left to the Deactivator, which reverts them
+	// after the abandoned activation linger of pools that set one.
+	CancelAbandoned bool
+	// Claims makes a single activator replica scale the pool up from zero and record the activation. Optional.
+	Claims *ActivationClaims
+	// Precheck fails scale ups of target workloads whose pods will never become ready. Optional.
+	Precheck *Precheck
+	// Slots bounds the scale ups from zero in flight at once in the namespace of the pool. Optional.
+	Slots *ActivationSlots
+	// InitialScale is the number of replicas pools without an initial scale annotation are scaled up to from
+	// zero. Defaults to DefaultInitialScale when unset.
+	InitialScale int32
+	// Zone is the zone of the activator, that is of the gateway traffic it serves. Pools setting the
+	// zone-aware activation annotation prefer waking replicas in this zone, and the zone of the replicas
+	// woken by every scale up from zero is reported. Optional.
+	Zone string
+	// Events is published the lifecycle events of the activations. Optional.
+	Events *EventBus
+	// Verifier records the first scale up from zero after each rollout of the target workload, for deployment
+	// pipelines to verify it. Optional.
+	Verifier *ActivationVerifier
+	// Budget fails the scale ups from zero of pools that consumed their monthly activation budget. Optional.
+	Budget *ActivationBudget
+	// Pods reads the pods of the pool from the cache of the manager, to double-check the pool is still ready
+	// right before releasing the requests held for its scale up from zero. Optional.
+	Pods client.Reader
+	// Handoff hands the held requests off to a peer activator replica when this one shuts down during an
+	// activation, and receives those of its peers. Optional.
+	Handoff *RequestHandoff
+	// ExternalConfig fills in the activator annotations of the other pools of a pool group from their companion
+	// ConfigMap and the annotations of their target workload, as the reconciler does for the pool. Optional.
+	ExternalConfig bool
+	datastore      datastore.Datastore
+	strategies     map[string]Strategy
+	burst          *burstDetector
+
+	// releaseGates holds the registered release gates the pools can select
+	releaseGates map[string]ReleaseGate
+
+	// activationHooks holds the registered activation hooks the pools can select
+	activationHooks map[string]any
+
+	// releases paces the release of the held requests of the rate-limited release strategy
+	releases releasePacer
+
+	// priorities caches the priority classes of the held requests
+	priorities priorityClasses
+
+	// rollover tracks the roll over of the pool to its rollover target, if any
+	rollover rollover
+
+	// warmUntil is the Unix nanoseconds until which the pool is known to be warm, since a request found it ready
+	warmUntil atomic.Int64
+
+	// lastRequest is the Unix nanoseconds of the last request of the pool
+	lastRequest atomic.Int64
+
+	// held counts the requests held until the pool is ready
+	held atomic.Int32
+
+	// progress tracks the scale up from zero in progress, for ProgressHandler
+	progress progressTracker
+
+	// timeline records the steps of the scale up from zero in progress, to break the latency of the requests
+	// held for it down by stage
+	timeline activationTimeline
+
+	// propagation learns how long the Endpoint Picker takes to pick up the pods of the pool once ready
+	propagation propagationEstimate
+
+	// queuedForCapacity is set while the scale from zero in progress waits for Kueue admission
+	queuedForCapacity atomic.Bool
+
+	// readiness runs a single readiness check of the pool for the requests arriving concurrently
+	readiness singleflight.Group
 
 	scalingUp           bool
 	guard               chan struct{}
+	cancelScaleUp       context.CancelFunc
 	scalingUpAndGuardMu sync.Mutex
 }
 
@@ -74,150 +176,726 @@ func NewActivatorWithConfig(config *rest.Config, datastore datastore.Datastore)
 	}
 
 	return &Activator{
-		datastore:     datastore,
-		DynamicClient: dynamicClient,
-		Mapper:        mapper,
-		ScaleClient:   scaleClient}, nil
+		datastore:       datastore,
+		DynamicClient:   dynamicClient,
+		Mapper:          mapper,
+		ScaleClient:     scaleClient,
+		strategies:      newStrategies(StrategyClients{ScaleClient: scaleClient, DynamicClient: dynamicClient}),
+		releaseGates:    newReleaseGates(StrategyClients{ScaleClient: scaleClient, DynamicClient: dynamicClient}),
+		activationHooks: newActivationHooks(StrategyClients{ScaleClient: scaleClient, DynamicClient: dynamicClient}),
+		burst:           newBurstDetector(DefaultPanicWindow)}, nil
+}
+
+// ValidatePool checks that the activator annotations of the pool are valid, and that the activation strategy,
+// idleness predicates, release gates and activation hooks it selects are registered and correctly configured.
+func (a *Activator) ValidatePool(pool *v1.InferencePool) error {
+	if err := poolconfig.For(pool).Err; err != nil {
+		return err
+	}
+	if err := validateIdleness(pool); err != nil {
+		return err
+	}
+	if err := validateReleaseGates(pool); err != nil {
+		return err
+	}
+	if err := validateActivationHooks(pool); err != nil {
+		return err
+	}
+	return validatePoolStrategy(a.strategies, pool)
 }
 
-// MayActivate checks if the inferencePool associated with the request is scaled to one or more replicas
-func (a *Activator) MayActivate(ctx context.Context) error {
+// MayActivate checks if the inferencePool associated with the request is scaled to one or more replicas.
+// It reports whether the request was held while the inferencePool was scaling up from zero replicas.
+func (a *Activator) MayActivate(ctx context.Context) (bool, error) {
 	logger := log.FromContext(ctx)
 
 	// Get InferencePool Info
 	pool, err := a.datastore.PoolGet()
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	logger.V(logutil.TRACE).Info("InferencePool found", "name", pool.Name, "namespace", pool.Namespace)
+	now := time.Now()
+	a.burst.observe(now)
+	if a.Recommender != nil {
+		a.Recommender.Observe(now)
+	}
+	a.resumeHandedOff(ctx, pool.Namespace+"/"+pool.Name)
 
 	// First: check if the inferencePool is currently scaling up from zero replicas
+	rechecked := false
 	if scalingUp, guard := a.isScalingUp(); scalingUp {
 		logger.V(logutil.DEBUG).Info("InferencePool is currently scaling up. Waiting for it to be done.")
 
-		a.waitOnGuard(guard, DefaultScaleFromZeroGracePeriod)
-		return nil // After scaling up is done, allow the request to proceed even if scaling failed
+		if err := a.holdOnGuard(ctx, pool, guard); err != nil {
+			return true, err
+		}
+		if a.queuedForCapacity.Load() {
+			return true, a.queuedForCapacityError(ctx)
+		}
+		// After scaling up is done, allow the request to proceed even if scaling failed, unless the pool lost
+		// its ready pods already
+		if a.readyBeforeRelease(ctx, pool) {
+			a.hintEndpointSubset(ctx, pool)
+			return true, a.release(ctx, pool, true)
+		}
+		rechecked = true
 	}
 
-	// Then: block until the inferencePool has enough replicas and is ready
-	if ready := a.InferencePoolReady(ctx, pool); !ready {
-		return errutil.Error{Code: errutil.ServiceUnavailable, Msg: "failed to find active candidate pods in the inferencePool for serving the request"}
+	// Then: block until the inferencePool has enough replicas and is ready, waiting for its activation once
+	// more if it lost its ready pods right before the release of a cold start
+	var ready, scaled bool
+	for {
+		var err error
+		ready, scaled, err = a.holdReady(ctx, pool, func(ctx context.Context) (bool, bool) {
+			return a.sharedPoolReady(ctx, pool, a.InferencePoolReady)
+		})
+		scaled = scaled || rechecked
+		if err != nil {
+			return scaled, err
+		}
+		if !ready {
+			if state := activationStateFromContext(ctx); state != nil && state.queuedForCapacity {
+				return scaled, a.queuedForCapacityError(ctx)
+			}
+			return scaled, activationFailedError(ctx)
+		}
+		if !scaled || rechecked || a.readyBeforeRelease(ctx, pool) {
+			break
+		}
+		rechecked = true
 	}
 
-	// Reset the Deactivator ticker for scale to zero monitoring
-	scaleDownDelay := DefaultScaleDownDelay
-	if value, found := GetOptionalPoolAnnotation(logger, ScaleDownDelayKey, pool); found {
-		scaleDownDelay, _ = time.ParseDuration(value)
+	a.keepWarm(pool, time.Now())
+	a.warmUntil.Store(time.Now().Add(DefaultWarmWindow).UnixNano())
+	if scaled {
+		a.hintEndpointSubset(ctx, pool)
 	}
+	return scaled, a.release(ctx, pool, scaled)
+}
 
-	a.datastore.ResetTicker(scaleDownDelay)
-	return nil
+// sharedPoolReady runs the given readiness check of the pool, which may scale it up from zero, once for
+// all the requests arriving concurrently, so that a burst against a cold pool results in a single scale
+// up. The requests block on the shared result, and share how the activation went.
+func (a *Activator) sharedPoolReady(ctx context.Context, pool *v1.InferencePool, check func(context.Context, *v1.InferencePool) (bool, bool)) (bool, bool) {
+	type result struct {
+		ready, scaled bool
+		state         activationState
+	}
+
+	poolName := pool.Namespace + "/" + pool.Name
+	leader := false
+	value, _, shared := a.readiness.Do(poolName, func() (any, error) {
+		leader = true
+		state := &activationState{}
+		ready, scaled := check(withActivationState(ctx, state), pool)
+		return result{ready: ready, scaled: scaled, state: *state}, nil
+	})
+	r := value.(result)
+	if shared {
+		metrics.RecordSharedReadinessCheck(poolName)
+	}
+	if state := activationStateFromContext(ctx); state != nil {
+		state.queuedForCapacity = state.queuedForCapacity || r.state.queuedForCapacity
+		state.failure, state.reason = r.state.failure, r.state.reason
+		state.shared = !leader
+	}
+	return r.ready, r.scaled
+}
+
+// keepWarm resets the Deactivator ticker for scale to zero monitoring after a request of the pool, or the
+// completion of its response. It is called concurrently.
+func (a *Activator) keepWarm(pool *v1.InferencePool, now time.Time) {
+	a.datastore.ResetTicker(scaleDownDelayFor(pool))
+	storeLatest(&a.lastRequest, now.UnixNano())
+	if a.IdleClock != nil {
+		a.IdleClock.Touch(now)
+	}
+}
+
+// KnownWarm reports whether a request recently found the pool ready and no scale up is in progress, in
+// which case requests may bypass the pool pipeline.
+func (a *Activator) KnownWarm(now time.Time) bool {
+	if scalingUp, _ := a.isScalingUp(); scalingUp {
+		return false
+	}
+	return now.UnixNano() < a.warmUntil.Load()
 }
 
-// InferencePoolReady checks if the inferencePool has enough replicas and is ready
-func (a *Activator) InferencePoolReady(ctx context.Context, pool *v1.InferencePool) bool {
+// InferencePoolReady checks if the inferencePool has enough replicas and is ready.
+// The second return value reports whether the inferencePool had to be scaled up from zero replicas.
+func (a *Activator) InferencePoolReady(ctx context.Context, pool *v1.InferencePool) (bool, bool) {
 	logger := log.FromContext(ctx)
 	namespace := pool.Namespace
 
-	// TODO: store annotation values in datastore to avoid reading them every time
+	// Targets outside of Kubernetes are activated and checked for readiness by their strategy
+	if strategy, err := strategyFor(a.strategies, pool); err == nil {
+		if external, ok := strategy.(ExternalTarget); ok {
+			return a.externalTargetReady(ctx, pool, external)
+		}
+	}
+
 	// verify required inferencePool annotations
 	valid := VerifyPoolObjectAnnotations(logger, pool)
 	if !valid {
-		return false
-	}
-
-	// extract optional inferencePool annotation if it exists, otherwise use a default value
-	scaleGracePeriod := DefaultScaleFromZeroGracePeriod
-	if value, found := GetOptionalPoolAnnotation(logger, ScaleFromZeroGracePeriodKey, pool); found {
-		scaleGracePeriod, _ = time.ParseDuration(value)
+		return false, false
 	}
+	scaleGracePeriod := a.scaleGracePeriodFor(ctx, pool)
 
 	// Get the scale subresource for the target inferencePool object
-	gvr, err := GetResourceForKind(a.Mapper, pool.Annotations[ObjectApiVersionKey], pool.Annotations[ObjectkindKey])
+	gvr, err := targetResourceFor(a.Mapper, pool)
 	if err != nil {
 		msg := "Failed to parse Group, Version, Kind, Resource"
-		logger.Error(err, msg, "apiVersion", pool.Annotations[ObjectApiVersionKey], "kind", pool.Annotations[ObjectkindKey])
-		return false
+		logger.Error(err, msg, "apiVersion", poolconfig.For(pool).Target.APIVersion, "kind", poolconfig.For(pool).Target.Kind)
+		return false, false
 	}
 
 	gr := gvr.GroupResource()
-	scaleObject, err := a.ScaleClient.Scales(namespace).Get(ctx, gr, pool.Annotations[ObjectNameKey], metav1.GetOptions{})
+	scaleObject, err := scaleOf(ctx, a.ScaleClient, a.DynamicClient, pool, gvr)
 	if err != nil {
 		logger.Error(err, "Error getting scale subresource object")
-		return true
+		return true, false
+	}
+
+	// Wake a pool rolling over to a new workload on the new workload, or keep serving the requests on its
+	// target workload while the new workload is woken
+	if rolledOver, ok := a.rollOverIdle(logger, pool, scaleObject.Spec.Replicas, gvr); ok {
+		pool = rolledOver
+		if scaleObject, err = scaleOf(ctx, a.ScaleClient, a.DynamicClient, pool, gvr); err != nil {
+			logger.Error(err, "Error getting scale subresource object of the rollover target")
+			return true, false
+		}
+	}
+
+	// Keep serving the requests on the fallback target of a pool whose target workload failed to activate
+	if scaleObject.Spec.Replicas == 0 && a.datastore.PoolState() != datastore.PoolDeactivating && a.fallbackServing(ctx, logger, pool) {
+		a.datastore.PoolTransition(datastore.PoolActive, datastore.PoolIdle)
+		return true, false
 	}
 
-	// Common case: enough replicas?
-	if scaleObject.Spec.Replicas > 0 {
-		if a.InferencePoolPodsReady(logger, namespace, pool.Annotations[ObjectNameKey], scaleObject.Spec.Replicas, scaleGracePeriod, gr, gvr) {
+	// Common case: enough replicas? Decide on the replicas running rather than the replicas requested, which
+	// may be blocked, e.g. by a quota. A pool being scaled down to zero is scaled up again instead
+	if scaleObject.Spec.Replicas > 0 && scaleObject.Status.Replicas > 0 && a.datastore.PoolState() != datastore.PoolDeactivating {
+		// Leave the replicas of a workload rolling out to its deployment strategy, and release requests on
+		// the replicas available meanwhile
+		if targetRollingOut(ctx, a.DynamicClient, gvr, namespace, poolconfig.For(pool).Target.Name) {
+			logger.V(logutil.DEBUG).Info("Scale Object is rolling out, pausing activation replica changes", "name", scaleObject.Name)
+			return a.rolloutPodsReady(logger, pool, scaleGracePeriod, gvr), false
+		}
+		if a.InferencePoolPodsReady(context.Background(), logger, pool, scaleObject.Spec.Replicas, scaleGracePeriod, gr, gvr) {
 			// Scale object exists and has no zero running replicas then do not scale it
 			logger.V(logutil.DEBUG).Info(fmt.Sprintf("Scale Object %s have at least one replica ready. Skipping scaling from zero", scaleObject.Name))
-			return true
+			a.datastore.PoolTransition(datastore.PoolActive, datastore.PoolIdle)
+			a.mayPanicScale(ctx, pool, gvr, scaleObject)
+			return true, false
 		}
 	}
 
-	// Need to scale inferencePool workload from zero to one replicas
-	numReplicas := int32(1)
-	scaleData := ScaledObjectData{name: pool.Annotations[ObjectNameKey], scaleGracePeriod: DefaultScaleFromZeroGracePeriod, numReplicas: numReplicas, scaleObject: scaleObject}
-	return a.scaleInferencePool(ctx, logger, namespace, scaleData, gr, gvr)
+	// Need to scale inferencePool workload from zero to its initial replicas. Replicas requested while none is
+	// running are requested again, the activation failure then surfacing why their pods are not created or not ready
+	numReplicas := initialScaleFor(pool, a.held.Load(), a.InitialScale)
+	if scaleObject.Spec.Replicas > 0 && a.datastore.PoolState() != datastore.PoolDeactivating {
+		logger.Info("Scale Object requests replicas but they are not running, requesting them again",
+			"name", scaleObject.Name, "replicas", scaleObject.Spec.Replicas, "running", scaleObject.Status.Replicas)
+		numReplicas = scaleObject.Spec.Replicas
+	}
+	scaleData := ScaledObjectData{pool: pool, name: poolconfig.For(pool).Target.Name, scaleGracePeriod: scaleGracePeriod, numReplicas: numReplicas, scaleObject: scaleObject}
+	return a.activating(ctx, pool, func() bool {
+		return a.progress.track(pool.Namespace+"/"+pool.Name, numReplicas, scaleGracePeriod, func() bool {
+			return a.scaleInferencePool(ctx, logger, namespace, scaleData, gr, gvr)
+		})
+	}), true
 }
 
-func (a *Activator) InferencePoolPodsReady(logger logr.Logger, namespace, objname string, numReplicas int32, scaleGracePeriod time.Duration, gr schema.GroupResource, gvr schema.GroupVersionResource) bool {
-	// Don't inherit the parent context to avoid cancellation
-	err := wait.PollUntilContextTimeout(context.Background(), 1*time.Second, scaleGracePeriod, false, func(ctx context.Context) (done bool, err error) {
+// InferencePoolPodsReady waits until the target object of the pool has the given number of ready replicas, or a
+// ready group for LeaderWorkerSets, or meets its ready condition, its model cache is warm, its pods pass the
+// readiness probe of the pool and its additional and prefill targets are ready, if any, the scale grace period,
+// extended while nodes are provisioned for the pods, elapsed or the given context is done. The context must not
+// be the one of a request, which would stop the wait when the request gives up.
+func (a *Activator) InferencePoolPodsReady(ctx context.Context, logger logr.Logger, pool *v1.InferencePool, numReplicas int32, scaleGracePeriod time.Duration, gr schema.GroupResource, gvr schema.GroupVersionResource) bool {
+	ready, readyReplicasPath := readinessCheckFor(logger, pool, numReplicas), readyReplicasPathFor(pool)
+	check := func(target *unstructured.Unstructured) bool {
+		if replicas, ok := readyReplicasOf(target, readyReplicasPath); ok {
+			a.progress.observeReady(replicas)
+		}
+		return ready(target)
+	}
+	start := time.Now()
+	passed, scaleGracePeriod := waitNodeProvisioning(ctx, logger, a.DynamicClient, pool, scaleGracePeriod, func(timeout time.Duration) bool {
+		return watchReadiness(ctx, logger, a.DynamicClient, gvr, pool.Namespace, poolconfig.For(pool).Target.Name, timeout, check, func() {
+			a.datastore.ResetTicker(DefaultScaleDownDelay) // turn off the deactivator during scale from zero events
+		})
+	})
+	if !passed {
+		return false
+	}
+	if !a.additionalTargetsReady(ctx, logger, pool, numReplicas, scaleGracePeriod-time.Since(start)) {
+		return false
+	}
+	if !modelCacheReady(ctx, logger, a.DynamicClient, pool, releaseReplicasFor(pool, numReplicas), scaleGracePeriod-time.Since(start)) {
+		return false
+	}
+	return a.probeReadiness(ctx, logger, pool, releaseReplicasFor(pool, numReplicas), scaleGracePeriod-time.Since(start))
+}
 
-		a.datastore.ResetTicker(DefaultScaleDownDelay) // turn off the deactivator during scale from zero events
+func (a *Activator) scaleInferencePool(ctx context.Context, logger logr.Logger, namespace string, objData ScaledObjectData, gr schema.GroupResource, gvr schema.GroupVersionResource) bool {
+	activation := a.beginScalingUp()
+	defer a.endScalingUp()
+	a.timeline.begin()
+
+	start := time.Now()
+	requestID := requestIDFromContext(ctx)
+	poolName := fmt.Sprintf("%s/%s", namespace, objData.pool.Name)
+	record := audit.Record{
+		Action:    audit.ActionScaleUp,
+		Pool:      poolName,
+		Target:    fmt.Sprintf("%s/%s", poolconfig.For(objData.pool).Target.Kind, objData.name),
+		Replicas:  objData.numReplicas,
+		RequestID: requestID,
+		TraceID:   traceIDFromContext(ctx),
+	}
 
-		unstructuredObj, err := a.DynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, objname, metav1.GetOptions{})
-		if err != nil {
-			logger.Error(err, "Error getting unstructured object")
-			return false, nil // continue polling
-		}
+	strategy, err := strategyFor(a.strategies, objData.pool)
+	if err != nil {
+		logger.Error(err, "Error selecting activation strategy")
+		a.recordScaleUp(ctx, objData.pool, record, audit.OutcomeFailed, err.Error(), start)
+		return false
+	}
 
-		// NOTE: this assumes that the target object has a status.readyReplicas field
-		if readyReplicas, ok := unstructuredObj.Object["status"].(map[string]any)["readyReplicas"].(int64); !ok {
-			logger.V(logutil.DEBUG).Info("Object status.readyReplicas field is not set yet - candidate pods for serving the request are NOT READY ")
-			return false, nil
-		} else {
-			if numReplicas == int32(readyReplicas) {
-				logger.V(logutil.DEBUG).Info("Candidate pods are READY")
-				return true, nil
+	// Fail fast once the pool consumed its monthly activation budget
+	if a.Budget != nil {
+		if err := a.Budget.check(objData.pool, time.Now()); err != nil {
+			logger.Info("InferencePool consumed its monthly activation budget, not scaling it up", "reason", err.Error())
+			record.Reason = string(FailureBudgetExhausted)
+			if state := activationStateFromContext(ctx); state != nil {
+				state.failure, state.reason = err.Error(), FailureBudgetExhausted
 			}
-			logger.V(logutil.DEBUG).Info("Candidate pods are NOT READY")
+			a.recordScaleUp(ctx, objData.pool, record, audit.OutcomeFailed, err.Error(), start)
+			return false
 		}
+	}
 
-		return false, nil
-	})
+	// Leave the activation to the activator that claimed it, only waiting for the pods to be ready
+	var claimed bool
+	if record.ActivationKey, claimed = a.claimActivation(ctx, objData.pool, objData.scaleGracePeriod); !claimed {
+		logger.Info("Activation claimed by another activator, waiting for it", "activation-key", record.ActivationKey)
+		return a.InferencePoolPodsReady(activation, logger, objData.pool, objData.numReplicas, objData.scaleGracePeriod, gr, gvr)
+	}
 
-	return err == nil
-}
+	// Wait for the turn of the pool when many pools of the namespace wake at once
+	releaseSlot, err := a.acquireActivationSlot(activation, objData.pool, objData.scaleGracePeriod)
+	if err != nil {
+		logger.Error(err, "Error acquiring activation slot")
+		a.recordScaleUp(ctx, objData.pool, record, audit.OutcomeFailed, err.Error(), start)
+		return false
+	}
+	defer releaseSlot()
+
+	// Let the pre-activation hooks of the pool veto the scale up
+	if err := a.preActivate(ctx, objData.pool, activationOf(record)); err != nil {
+		logger.Info("Pre-activation hook rejected the scale up", "reason", err.Error())
+		record.Reason = string(FailureActivationHook)
+		if state := activationStateFromContext(ctx); state != nil {
+			state.failure, state.reason = err.Error(), FailureActivationHook
+		}
+		a.recordScaleUp(ctx, objData.pool, record, audit.OutcomeFailed, err.Error(), start)
+		return false
+	}
+	a.Events.publishRecord(ActivationStarted, record, 0)
 
-func (a *Activator) scaleInferencePool(ctx context.Context, logger logr.Logger, namespace string, objData ScaledObjectData, gr schema.GroupResource, gvr schema.GroupVersionResource) bool {
-	a.beginScalingUp()
-	defer a.endScalingUp()
+	// Claim the capacity of the target workload, when it asks for it, before scaling it up
+	target, err := a.DynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, objData.name, metav1.GetOptions{})
+	if err != nil {
+		logger.V(logutil.DEBUG).Info("Error getting target object, skipping capacity reservation", "error", err.Error())
+		target = nil
+	} else {
+		if a.Precheck != nil {
+			if err := a.Precheck.Check(ctx, a.DynamicClient, target); err != nil {
+				logger.Error(err, "Scale Object failed the scale up pre-check")
+				record.Reason = string(failureReasonOf(err))
+				if state := activationStateFromContext(ctx); state != nil {
+					state.failure, state.reason = err.Error(), failureReasonOf(err)
+				}
+				a.recordScaleUp(ctx, objData.pool, record, audit.OutcomeFailed, err.Error(), start)
+				return false
+			}
+		}
 
-	// Modify the desired replicas
-	objData.scaleObject.Spec.Replicas = objData.numReplicas
+		// Steer the activation replicas with the placement of the activation policy of the pool, if any
+		if err := a.placeActivationReplicas(ctx, logger, objData.pool, gvr, target); err != nil {
+			logger.Error(err, "Error applying activation placement, scaling up with the workload placement")
+		}
+		if err := reserveCapacity(ctx, a.DynamicClient, target, objData.numReplicas,
+			objData.scaleGracePeriod+poolconfig.For(objData.pool).NodeProvisioningTimeout); err != nil {
+			logger.Error(err, "Error reserving capacity for Scale Object")
+			a.recordScaleUp(ctx, objData.pool, record, audit.OutcomeFailed, err.Error(), start)
+			return false
+		}
+	}
 
-	// Update the Scale object
-	_, err := a.ScaleClient.Scales(namespace).Update(ctx, gr, objData.scaleObject, metav1.UpdateOptions{})
+	// Bring the additional and prefill targets and then the target workload to the desired replicas, all or none of them
+	a.timeline.mark(scaleStarted, time.Now())
+	clients := StrategyClients{ScaleClient: a.ScaleClient, DynamicClient: a.DynamicClient}
+	additional, err := scaleAdditionalTargets(ctx, logger, clients, a.Mapper, objData.pool, objData.numReplicas)
+	if err != nil {
+		logger.Error(err, "Error scaling up the additional targets")
+		a.recordScaleUp(ctx, objData.pool, record, audit.OutcomeFailed, err.Error(), start)
+		return false
+	}
+	scaleTarget := &ScaleTarget{Pool: objData.pool, Resource: gvr, Scale: objData.scaleObject}
+	record.ResourceVersionBefore = objData.scaleObject.ResourceVersion
+	err = strategy.ScaleUp(ctx, scaleTarget, objData.numReplicas)
 	if err != nil {
 		logger.Error(err, "Error increasing Scale Object number of replicas to one")
+		restoreAdditionalTargets(ctx, logger, clients, additional)
+		a.recordScaleUp(ctx, objData.pool, record, audit.OutcomeFailed, err.Error(), start)
 		return false
 	}
-	logger.Info(fmt.Sprintf("Scale Object %s in namespace %s scaled up to %d replicas with scale grace period %d \n", objData.name, namespace, objData.numReplicas, int(objData.scaleGracePeriod)))
+	a.timeline.mark(scaled, time.Now())
+	recordResourceVersionAfter(ctx, clients, &record, scaleTarget)
+	logger.Info(fmt.Sprintf("Scale Object %s in namespace %s scaled up to %d replicas with scale grace period %s", objData.name, namespace, objData.numReplicas, objData.scaleGracePeriod))
+	go a.activatePoolGroup(logger, objData.pool)
+
+	// Wait for Kueue to admit the pods, the readiness grace period only starts once capacity is granted
+	if target != nil && kueueManaged(target) {
+		logger.Info("Scale Object is queued for capacity, waiting for Kueue admission")
+		if !a.waitForKueueAdmission(activation, logger, objData.pool, objData.numReplicas) {
+			if state := activationStateFromContext(ctx); state != nil {
+				state.queuedForCapacity = true
+			}
+			a.recordScaleUp(ctx, objData.pool, record, audit.OutcomeFailed, "pods were not admitted by Kueue within the queued timeout", start)
+			return false
+		}
+	}
 
 	// Wait for the pods to be ready
-	ready := a.InferencePoolPodsReady(logger, namespace, objData.name, objData.numReplicas, objData.scaleGracePeriod, gr, gvr)
+	ready := a.InferencePoolPodsReady(activation, logger, objData.pool, objData.numReplicas, objData.scaleGracePeriod, gr, gvr)
 	if ready {
-		// Give some time for the Endpoint Picker to pick up the newly created pods
-		time.Sleep(ScaleToZeroRequestRetentionPeriod)
+		a.warmUp(activation, logger, objData.pool)
+		a.timeline.mark(podsReady, time.Now())
+		// Wait for the Endpoint Picker to pick up the newly created pods
+		propagation, synced := waitEndpointPickerSync(activation, logger, &http.Client{Timeout: endpointPickerScrapeTimeout}, objData.pool, releaseReplicasFor(objData.pool, objData.numReplicas),
+			DefaultEndpointPickerSyncTimeout, a.propagationDelayFor(objData.pool))
+		a.timeline.mark(endpointPickerSynced, time.Now())
+		if synced {
+			a.propagation.observe(propagation)
+			metrics.RecordEndpointPickerPropagation(namespace+"/"+objData.pool.Name, propagation)
+		}
+		if target != nil {
+			a.Verifier.observe(logger, objData.pool, targetRevision(target), true, time.Since(start), time.Now())
+		}
+		a.recordScaleUp(ctx, objData.pool, record, audit.OutcomeSucceeded, "candidate pods are ready", start)
+		go a.reportActivationZone(logger, objData.pool)
+		if a.Attribution != nil || a.Budget != nil {
+			var accelerators int64
+			if obj, err := a.DynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, objData.name, metav1.GetOptions{}); err == nil {
+				accelerators = acceleratorsPerReplica(obj) * int64(objData.numReplicas)
+			}
+			if a.Attribution != nil {
+				a.Attribution.Activated(poolName, attributionKeyFromContext(ctx), accelerators, time.Now())
+			}
+			if a.Budget != nil {
+				// Replicas without accelerators are charged as one accelerator each
+				a.Budget.Activated(max(accelerators, int64(objData.numReplicas)), time.Now())
+			}
+		}
 		return true
 	}
+	if activation.Err() != nil {
+		a.recordScaleUp(ctx, objData.pool, record, audit.OutcomeFailed, "every request held for the scale up disconnected", start)
+		a.revertAbandoned(ctx, objData.pool)
+		return false
+	}
+	// Don't inherit the parent context to classify the failure even if the request gave up
+	reason := a.classifyActivationFailure(context.Background(), objData.pool)
+	if target != nil {
+		a.Verifier.observe(logger, objData.pool, targetRevision(target), false, time.Since(start), time.Now())
+	}
+	// Serve the requests held on a fallback target of the pool when the failure calls for it
+	if served, ok := a.activateFallback(activation, logger, objData, strategy, gvr, reason, additional); ok {
+		record.Target, record.Reason = fmt.Sprintf("%s/%s", served.Kind, served.Name), string(reason)
+		record.ResourceVersionBefore, record.ResourceVersionAfter = "", ""
+		a.recordScaleUp(ctx, objData.pool, record, audit.OutcomeSucceeded, fmt.Sprintf("target workload failed to activate, served by its fallback target (reason: %s)", reason), start)
+		return true
+	}
+	if state := activationStateFromContext(ctx); state != nil {
+		state.reason = reason
+	}
+	record.Reason = string(reason)
+	a.recordScaleUp(ctx, objData.pool, record, audit.OutcomeFailed, "candidate pods did not become ready within the scale grace period", start)
 	return false
 }
 
+// placeActivationReplicas overlays the placement of the activation policy of the inferencePool, and the
+// preference for the zone of the activator of zone-aware pools, on the target workload, and refreshes the
+// given target object accordingly.
+func (a *Activator) placeActivationReplicas(ctx context.Context, logger logr.Logger, pool *v1.InferencePool, gvr schema.GroupVersionResource, target *unstructured.Unstructured) error {
+	policy, err := activationPolicyFor(ctx, a.DynamicClient, pool)
+	if err != nil {
+		return err
+	}
+	var placement *activatorv1alpha1.PlacementSpec
+	policyName := ""
+	if policy != nil {
+		placement, policyName = policy.Spec.Placement, policy.Name
+	}
+	if a.Zone != "" && zoneAware(pool) {
+		placement = withZonePreference(placement, a.Zone)
+	}
+	if placement == nil {
+		return nil
+	}
+	if err := applyPlacement(ctx, a.DynamicClient, gvr, target, placement); err != nil {
+		return err
+	}
+	logger.V(logutil.DEBUG).Info("Applied activation placement", "policy", policyName, "zone", a.Zone, "target", target.GetName())
+
+	if obj, err := a.DynamicClient.Resource(gvr).Namespace(target.GetNamespace()).Get(ctx, target.GetName(), metav1.GetOptions{}); err == nil {
+		obj.DeepCopyInto(target)
+	}
+	return nil
+}
+
+// activationFailedError is returned to requests whose activation failed, with the reason of the failure
+// when it is known.
+func activationFailedError(ctx context.Context) error {
+	msg := "failed to find active candidate pods in the inferencePool for serving the request"
+	state := activationStateFromContext(ctx)
+	if state == nil {
+		return errutil.Error{Code: errutil.ServiceUnavailable, Msg: msg}
+	}
+	if state.failure != "" {
+		msg = "failed to activate the inferencePool: " + state.failure
+	}
+	if state.reason != "" {
+		msg = fmt.Sprintf("%s (reason: %s)", msg, state.reason)
+	}
+	return errutil.Error{Code: errutil.ServiceUnavailable, Msg: msg}
+}
+
+// queuedForCapacityError is returned to requests that gave up while the inferencePool was queued for capacity.
+func (a *Activator) queuedForCapacityError(ctx context.Context) error {
+	if state := activationStateFromContext(ctx); state != nil {
+		state.queuedForCapacity = true
+	}
+	return errutil.Error{Code: errutil.ServiceUnavailable, Msg: "inferencePool is queued for capacity, retry later"}
+}
+
+// mayPanicScale scales the warm inferencePool up ahead of the external autoscalers when a request burst
+// puts it in panic mode. Panic mode never scales the inferencePool down.
+func (a *Activator) mayPanicScale(ctx context.Context, pool *v1.InferencePool, gvr schema.GroupVersionResource, scaleObject *autoscaling.Scale) {
+	logger := log.FromContext(ctx)
+
+	if !features.Enabled(features.PanicMode) {
+		return
+	}
+	config := panicConfigFor(pool)
+	if config == nil {
+		return
+	}
+
+	poolName := fmt.Sprintf("%s/%s", pool.Namespace, pool.Name)
+	current := scaleObject.Spec.Replicas
+	desired, panicking := a.burst.evaluate(time.Now(), current, config)
+	metrics.RecordPanicMode(poolName, panicking)
+	if !panicking || desired <= current {
+		return
+	}
+
+	// Another request is already applying the panic scale up
+	if !a.burst.scaling.TryLock() {
+		return
+	}
+	defer a.burst.scaling.Unlock()
+
+	record := audit.Record{
+		Action:    audit.ActionScaleUp,
+		Pool:      poolName,
+		Target:    fmt.Sprintf("%s/%s", poolconfig.For(pool).Target.Kind, poolconfig.For(pool).Target.Name),
+		Replicas:  desired,
+		RequestID: requestIDFromContext(ctx),
+		TraceID:   traceIDFromContext(ctx),
+	}
+
+	strategy, err := strategyFor(a.strategies, pool)
+	target := &ScaleTarget{Pool: pool, Resource: gvr, Scale: scaleObject}
+	record.ResourceVersionBefore = scaleObject.ResourceVersion
+	if err == nil {
+		err = strategy.ScaleUp(ctx, target, desired)
+	}
+	if err != nil {
+		logger.Error(err, "Error scaling up inferencePool in panic mode")
+		record.Outcome, record.Message = audit.OutcomeFailed, err.Error()
+		audit.Log(record)
+		return
+	}
+	recordResourceVersionAfter(ctx, StrategyClients{ScaleClient: a.ScaleClient, DynamicClient: a.DynamicClient}, &record, target)
+
+	message := fmt.Sprintf("Panic mode: request burst requires %d replicas, scaled up from %d replicas", desired, current)
+	logger.Info(message, "pool", poolName)
+	metrics.RecordPanicScaleUp(poolName)
+	record.Outcome, record.Message = audit.OutcomeSucceeded, message
+	audit.Log(record)
+	if a.Recorder != nil {
+		a.Recorder.Event(pool, corev1.EventTypeNormal, "PanicScaleUp", message)
+	}
+}
+
+// externalTargetReady checks if the external target of the inferencePool is ready, activating it if needed.
+// The second return value reports whether the external target had to be activated.
+func (a *Activator) externalTargetReady(ctx context.Context, pool *v1.InferencePool, target ExternalTarget) (bool, bool) {
+	logger := log.FromContext(ctx)
+
+	if modelName := modelNameFromContext(ctx); !target.Matches(pool, modelName) {
+		logger.V(logutil.DEBUG).Info("Requested model is not served by the external target, skipping activation", "model", modelName)
+		return true, false
+	}
+
+	ready, err := target.Ready(ctx, pool)
+	if err != nil {
+		logger.Error(err, "Error checking external target readiness")
+	} else if ready && a.datastore.PoolState() != datastore.PoolDeactivating {
+		a.datastore.PoolTransition(datastore.PoolActive, datastore.PoolIdle)
+		return true, false
+	}
+
+	return a.activating(ctx, pool, func() bool { return a.activateExternalTarget(ctx, pool, target) }), true
+}
+
+// activateExternalTarget activates the external target of the given pool and waits for it to be ready.
+func (a *Activator) activateExternalTarget(ctx context.Context, pool *v1.InferencePool, target ExternalTarget) bool {
+	logger := log.FromContext(ctx)
+
+	scaleGracePeriod := a.scaleGracePeriodFor(ctx, pool)
+
+	activation := a.beginScalingUp()
+	defer a.endScalingUp()
+	a.timeline.begin()
+
+	start := time.Now()
+	record := audit.Record{
+		Action:    audit.ActionScaleUp,
+		Pool:      fmt.Sprintf("%s/%s", pool.Namespace, pool.Name),
+		Target:    fmt.Sprintf("%s/%s", poolconfig.For(pool).Strategy, poolconfig.For(pool).ManagedEndpoint),
+		Replicas:  1,
+		RequestID: requestIDFromContext(ctx),
+		TraceID:   traceIDFromContext(ctx),
+	}
+
+	waitReady := func() error {
+		return wait.PollUntilContextTimeout(activation, 1*time.Second, scaleGracePeriod, false, func(ctx context.Context) (bool, error) {
+			a.datastore.ResetTicker(DefaultScaleDownDelay) // turn off the deactivator during scale from zero events
+
+			ready, err := target.Ready(ctx, pool)
+			if err != nil {
+				logger.V(logutil.DEBUG).Info("Error checking external target readiness", "error", err.Error())
+				return false, nil
+			}
+			return ready, nil
+		})
+	}
+
+	// Leave the activation to the activator that claimed it, only waiting for the external target to be ready
+	var claimed bool
+	if record.ActivationKey, claimed = a.claimActivation(ctx, pool, scaleGracePeriod); !claimed {
+		logger.Info("Activation claimed by another activator, waiting for it", "activation-key", record.ActivationKey)
+		return waitReady() == nil
+	}
+
+	// Wait for the turn of the pool when many pools of the namespace wake at once
+	releaseSlot, err := a.acquireActivationSlot(activation, pool, scaleGracePeriod)
+	if err != nil {
+		logger.Error(err, "Error acquiring activation slot")
+		a.recordScaleUp(ctx, pool, record, audit.OutcomeFailed, err.Error(), start)
+		return false
+	}
+	defer releaseSlot()
+
+	if err := a.preActivate(ctx, pool, activationOf(record)); err != nil {
+		logger.Info("Pre-activation hook rejected the scale up", "reason", err.Error())
+		record.Reason = string(FailureActivationHook)
+		if state := activationStateFromContext(ctx); state != nil {
+			state.failure, state.reason = err.Error(), FailureActivationHook
+		}
+		a.recordScaleUp(ctx, pool, record, audit.OutcomeFailed, err.Error(), start)
+		return false
+	}
+	a.Events.publishRecord(ActivationStarted, record, 0)
+
+	a.timeline.mark(scaleStarted, time.Now())
+	if err := target.ScaleUp(ctx, &ScaleTarget{Pool: pool}, 1); err != nil {
+		logger.Error(err, "Error activating external target")
+		a.recordScaleUp(ctx, pool, record, audit.OutcomeFailed, err.Error(), start)
+		return false
+	}
+	a.timeline.mark(scaled, time.Now())
+
+	err = waitReady()
+	if activation.Err() != nil {
+		a.recordScaleUp(ctx, pool, record, audit.OutcomeFailed, "every request held for the scale up disconnected", start)
+		a.revertAbandoned(ctx, pool)
+		return false
+	}
+	if err != nil {
+		a.recordScaleUp(ctx, pool, record, audit.OutcomeFailed, "external target did not become ready within the scale grace period", start)
+		return false
+	}
+	// External targets are ready once they serve requests, with no Endpoint Picker to sync
+	a.timeline.mark(podsReady, time.Now())
+	a.timeline.mark(endpointPickerSynced, time.Now())
+	a.recordScaleUp(ctx, pool, record, audit.OutcomeSucceeded, "external target is ready", start)
+	if a.Attribution != nil {
+		// The accelerators of external targets are not known to the activator
+		a.Attribution.Activated(record.Pool, attributionKeyFromContext(ctx), 0, time.Now())
+	}
+	return true
+}
+
+// claimActivation claims the scale from zero of the given pool for the given time, if claims are enabled. It
+// returns the key of the activation, and whether the caller claimed it.
+func (a *Activator) claimActivation(ctx context.Context, pool *v1.InferencePool, ttl time.Duration) (string, bool) {
+	if a.Claims == nil {
+		return "", true
+	}
+	return a.Claims.Claim(ctx, pool, ttl, time.Now())
+}
+
+// recordScaleUp reports the outcome of a scale from zero as an event on the InferencePool, an audit record
+// and an activation duration observation, all tagged with the ID of the request that triggered it, and runs
+// the post-activation hooks of the pool.
+func (a *Activator) recordScaleUp(ctx context.Context, pool *v1.InferencePool, record audit.Record, outcome audit.Outcome, message string, start time.Time) {
+	record.Outcome = outcome
+	record.Message = message
+	if outcome == audit.OutcomeFailed && record.Reason == "" {
+		record.Reason = string(FailureUnknown)
+	}
+	audit.Log(record)
+	a.postActivate(ctx, pool, activationOf(record), ActivationResult{
+		Succeeded: outcome == audit.OutcomeSucceeded,
+		Reason:    FailureReason(record.Reason),
+		Message:   message,
+		Duration:  time.Since(start),
+	})
+
+	metrics.RecordActivationDuration(record.Pool, string(outcome), record.Reason, record.RequestID, record.TraceID, time.Since(start))
+	if outcome == audit.OutcomeSucceeded {
+		a.Events.publishRecord(ActivationReady, record, time.Since(start))
+	} else {
+		a.Events.publishRecord(ActivationFailed, record, time.Since(start))
+	}
+
+	if a.Recorder == nil {
+		return
+	}
+	eventType := corev1.EventTypeNormal
+	if outcome != audit.OutcomeSucceeded {
+		eventType = corev1.EventTypeWarning
+	}
+	a.Recorder.AnnotatedEventf(pool, map[string]string{RequestIDAnnotationKey: record.RequestID}, eventType, "ScaleUp"+string(outcome),
+		"Scale from zero of %s to %d replicas triggered by request %q: %s", record.Target, record.Replicas, record.RequestID, message)
+}
+
 func InitScaleClient(config *rest.Config) (scale.ScalesGetter, meta.RESTMapper, error) {
 	clientset, err := discovery.NewDiscoveryClientForConfig(config)
 	if err != nil {
@@ -234,36 +912,26 @@ func InitScaleClient(config *rest.Config) (scale.ScalesGetter, meta.RESTMapper,
 	), restMapper, nil
 }
 
+// VerifyPoolObjectAnnotations reports whether the given pool sets all of the target annotations.
 func VerifyPoolObjectAnnotations(logger logr.Logger, pool *v1.InferencePool) bool {
-	if _, ok := pool.Annotations[ObjectApiVersionKey]; !ok {
-		logger.Info(fmt.Sprintf("Annotation '%s' not found on pool '%s'", ObjectApiVersionKey, pool.Name))
-		return false
-	}
-	if _, ok := pool.Annotations[ObjectkindKey]; !ok {
-		logger.Info(fmt.Sprintf("Annotation '%s' not found on pool '%s'", ObjectkindKey, pool.Name))
-		return false
+	config := poolconfig.For(pool)
+	for _, key := range config.MissingTarget {
+		logger.Info(fmt.Sprintf("Annotation '%s' not found on pool '%s'", key, pool.Name))
 	}
-	if _, ok := pool.Annotations[ObjectNameKey]; !ok {
-		logger.Info(fmt.Sprintf("Annotation '%s' not found on pool '%s'", ObjectNameKey, pool.Name))
-		return false
-	}
-	return true
+	return config.HasTarget()
 }
 
-func GetOptionalPoolAnnotation(logger logr.Logger, annotationKey string, pool *v1.InferencePool) (string, bool) {
-	if value, ok := pool.Annotations[annotationKey]; ok {
-		return value, true
-	}
-	logger.V(logutil.DEBUG).Info(fmt.Sprintf("Annotation '%s' not found on pool '%s'", annotationKey, pool.Name))
-	return "", false
-}
-
-func (a *Activator) beginScalingUp() {
+// beginScalingUp marks a scale up in progress. It returns the context of the scale up waits, which is not
+// derived from any request and is only cancelled when every request held for the scale up disconnected.
+func (a *Activator) beginScalingUp() context.Context {
 	a.scalingUpAndGuardMu.Lock()
 	defer a.scalingUpAndGuardMu.Unlock()
 
 	a.scalingUp = true
 	a.guard = make(chan struct{})
+	var activation context.Context
+	activation, a.cancelScaleUp = context.WithCancel(context.Background())
+	return activation
 }
 
 func (a *Activator) endScalingUp() {
@@ -273,6 +941,8 @@ func (a *Activator) endScalingUp() {
 	a.scalingUp = false
 	close(a.guard)
 	a.guard = nil
+	a.cancelScaleUp()
+	a.cancelScaleUp = nil
 }
 
 func (a *Activator) isScalingUp() (bool, chan struct{}) {
@@ -281,12 +951,3 @@ func (a *Activator) isScalingUp() (bool, chan struct{}) {
 
 	return a.scalingUp, a.guard
 }
-
-// waitOnGuard blocks until the InferencePool is marked as ready or the timeout is reached.
-func (a *Activator) waitOnGuard(guard <-chan struct{}, timeout time.Duration) {
-	select {
-	case <-time.After(timeout):
-		return
-	case <-guard:
-	}
-}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// AdditionalTargetsKey lists the workloads scaled together with the target workload of the pool, e.g. the
+// router in front of its model servers, as a JSON list of objects with an apiVersion, a kind, a name and
+// optionally the replicas they are scaled up to, 1 by default, e.g.
+// [{"apiVersion":"apps/v1","kind":"Deployment","name":"router"}]. They are scaled up before the target
+// workload, the scale up from zero only succeeding once all of them are ready, and scaled down to zero with it.
+const AdditionalTargetsKey = poolconfig.AdditionalTargetsKey // Optional annotation
+
+// AdditionalTarget is a workload scaled together with the target workload of a pool.
+type AdditionalTarget = poolconfig.AdditionalTarget
+
+// additionalTargetsFor returns the additional targets of the given pool, if any.
+func additionalTargetsFor(pool *v1.InferencePool) ([]AdditionalTarget, error) {
+	config := poolconfig.For(pool)
+	return config.AdditionalTargets, config.ErrOf(AdditionalTargetsKey)
+}
+
+// scaledAlongTarget returns the workloads scaled along the target workload of the given pool when it is
+// scaled to the given replicas: its additional targets and its prefill target, if any.
+func scaledAlongTarget(pool *v1.InferencePool, replicas int32) ([]AdditionalTarget, error) {
+	targets, err := additionalTargetsFor(pool)
+	if err != nil {
+		return nil, err
+	}
+	prefill, err := prefillTargetFor(pool, replicas)
+	if err != nil || prefill == nil {
+		return targets, err
+	}
+	return append(targets, *prefill), nil
+}
+
+// scaledTarget is an additional target scaled by the activator, with the replicas it had before.
+type scaledTarget struct {
+	target   *ScaleTarget
+	previous int32
+}
+
+// scaleAdditionalTargets brings the workloads scaled along the target workload of the given pool to at least
+// their replicas for the given replicas of the target workload, or down to zero when these are zero, through
+// their scale subresource. It stops at the first failure, restoring
+// the replicas of the targets it already scaled, so that the workloads of the pool are scaled all or none.
+// It returns the targets it scaled, for the caller to restore them if the rest of the activation fails.
+func scaleAdditionalTargets(ctx context.Context, logger logr.Logger, clients StrategyClients, mapper meta.RESTMapper, pool *v1.InferencePool, targetReplicas int32) ([]scaledTarget, error) {
+	targets, err := scaledAlongTarget(pool, targetReplicas)
+	if err != nil || len(targets) == 0 {
+		return nil, err
+	}
+
+	scaler := &scaleStrategy{clients: clients}
+	var scaled []scaledTarget
+	for _, target := range targets {
+		up, replicas := targetReplicas > 0, int32(0)
+		if up {
+			replicas = *target.Replicas
+		}
+		scaleTarget, err := additionalScaleTarget(ctx, clients, mapper, pool, target)
+		if err != nil {
+			restoreAdditionalTargets(ctx, logger, clients, scaled)
+			return nil, fmt.Errorf("failed to get the scale of additional target %s/%s of inferencePool %s: %w", target.Kind, target.Name, pool.Name, err)
+		}
+		previous := scaleTarget.Scale.Spec.Replicas
+		if (up && previous >= replicas) || (!up && previous == 0) {
+			continue
+		}
+		if err := scaler.setReplicas(ctx, scaleTarget, replicas); err != nil {
+			restoreAdditionalTargets(ctx, logger, clients, scaled)
+			return nil, fmt.Errorf("failed to scale additional target %s/%s of inferencePool %s to %d replicas: %w", target.Kind, target.Name, pool.Name, replicas, err)
+		}
+		logger.V(logutil.DEBUG).Info("Scaled additional target", "kind", target.Kind, "name", target.Name, "replicas", replicas)
+		scaled = append(scaled, scaledTarget{target: scaleTarget, previous: previous})
+	}
+	return scaled, nil
+}
+
+// restoreAdditionalTargets brings the given scaled additional targets back to the replicas they had before.
+func restoreAdditionalTargets(ctx context.Context, logger logr.Logger, clients StrategyClients, scaled []scaledTarget) {
+	scaler := &scaleStrategy{clients: clients}
+	for _, s := range scaled {
+		if err := scaler.setReplicas(ctx, s.target, s.previous); err != nil {
+			logger.Error(err, "Error restoring the replicas of additional target", "name", s.target.Scale.Name, "replicas", s.previous)
+		}
+	}
+}
+
+// additionalScaleTarget returns the resource and the current scale subresource of the given additional target.
+func additionalScaleTarget(ctx context.Context, clients StrategyClients, mapper meta.RESTMapper, pool *v1.InferencePool, target AdditionalTarget) (*ScaleTarget, error) {
+	gvr, err := GetResourceForKind(mapper, target.APIVersion, target.Kind)
+	if err != nil {
+		return nil, err
+	}
+	scaleObject, err := clients.ScaleClient.Scales(pool.Namespace).Get(ctx, gvr.GroupResource(), target.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &ScaleTarget{Pool: pool, Resource: gvr, Scale: scaleObject}, nil
+}
+
+// additionalTargetsReady waits until every workload scaled along the target workload of the given pool has its
+// replicas for the given replicas of the target workload ready, the timeout elapsed or the given context is
+// done. Pools without additional or prefill targets pass at once.
+func (a *Activator) additionalTargetsReady(ctx context.Context, logger logr.Logger, pool *v1.InferencePool, targetReplicas int32, timeout time.Duration) bool {
+	targets, err := scaledAlongTarget(pool, targetReplicas)
+	if err != nil || len(targets) == 0 {
+		return err == nil
+	}
+	path, _ := parseReadyReplicasPath(DefaultReadyReplicasJSONPath)
+
+	var wg sync.WaitGroup
+	ready := make([]bool, len(targets))
+	for i, target := range targets {
+		var gvr schema.GroupVersionResource
+		if gvr, err = GetResourceForKind(a.Mapper, target.APIVersion, target.Kind); err != nil {
+			logger.Error(err, "Failed to parse Group, Version, Kind, Resource of additional target", "apiVersion", target.APIVersion, "kind", target.Kind)
+			return false
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			targetLogger := logger.WithValues("additional-target", target.Kind+"/"+target.Name)
+			ready[i] = watchReadiness(ctx, targetLogger, a.DynamicClient, gvr, pool.Namespace, target.Name, timeout, func(obj *unstructured.Unstructured) bool {
+				readyReplicas, found := readyReplicasOf(obj, path)
+				return found && readyReplicas >= int64(*target.Replicas)
+			}, nil)
+		}()
+	}
+	wg.Wait()
+
+	for i, target := range targets {
+		if !ready[i] {
+			logger.Info("Additional target did not become ready within the scale grace period", "kind", target.Kind, "name", target.Name)
+			return false
+		}
+	}
+	return true
+}
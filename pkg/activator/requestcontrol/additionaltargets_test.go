@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	autoscaling "k8s.io/api/autoscaling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakescale "k8s.io/client-go/scale/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestAdditionalTargetsFor(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        string
+		wantReplicas []int32
+		wantErr      bool
+	}{
+		{name: "default replicas", value: `[{"apiVersion":"apps/v1","kind":"Deployment","name":"router"}]`, wantReplicas: []int32{1}},
+		{name: "several targets", value: `[{"apiVersion":"apps/v1","kind":"Deployment","name":"router","replicas":2},{"apiVersion":"apps/v1","kind":"StatefulSet","name":"cache"}]`, wantReplicas: []int32{2, 1}},
+		{name: "not a list", value: `{"apiVersion":"apps/v1","kind":"Deployment","name":"router"}`, wantErr: true},
+		{name: "missing name", value: `[{"apiVersion":"apps/v1","kind":"Deployment"}]`, wantErr: true},
+		{name: "zero replicas", value: `[{"apiVersion":"apps/v1","kind":"Deployment","name":"router","replicas":0}]`, wantErr: true},
+		{name: "duplicate target", value: `[{"apiVersion":"apps/v1","kind":"Deployment","name":"router"},{"apiVersion":"apps/v1","kind":"Deployment","name":"router"}]`, wantErr: true},
+		{name: "target of the pool", value: `[{"apiVersion":"apps/v1","kind":"Deployment","name":"model"}]`, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Annotations: map[string]string{
+				ObjectkindKey:        "Deployment",
+				ObjectNameKey:        "model",
+				AdditionalTargetsKey: test.value,
+			}}}
+			targets, err := additionalTargetsFor(pool)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("additionalTargetsFor() error = %v, wantErr %t", err, test.wantErr)
+			}
+			if len(targets) != len(test.wantReplicas) {
+				t.Fatalf("additionalTargetsFor() = %v, want %d targets", targets, len(test.wantReplicas))
+			}
+			for i, target := range targets {
+				if *target.Replicas != test.wantReplicas[i] {
+					t.Errorf("replicas of target %s = %d, want %d", target.Name, *target.Replicas, test.wantReplicas[i])
+				}
+			}
+		})
+	}
+}
+
+func TestScaleAdditionalTargetsRollsBack(t *testing.T) {
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+	client := &fakescale.FakeScaleClient{}
+	client.AddReactor("get", "deployments", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, &autoscaling.Scale{ObjectMeta: metav1.ObjectMeta{Name: action.(clienttesting.GetAction).GetName()}}, nil
+	})
+	patched := map[string][]string{}
+	client.AddReactor("patch", "deployments", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		patch := action.(clienttesting.PatchAction)
+		patched[patch.GetName()] = append(patched[patch.GetName()], string(patch.GetPatch()))
+		if patch.GetName() == "cache" {
+			return true, nil, apierrors.NewForbidden(deployments.GroupResource(), "cache", errors.New("denied"))
+		}
+		return true, &autoscaling.Scale{ObjectMeta: metav1.ObjectMeta{Name: patch.GetName()}, Spec: autoscaling.ScaleSpec{Replicas: 1}}, nil
+	})
+
+	pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", Annotations: map[string]string{
+		AdditionalTargetsKey: `[{"apiVersion":"apps/v1","kind":"Deployment","name":"router"},{"apiVersion":"apps/v1","kind":"Deployment","name":"cache"}]`,
+	}}}
+	scaled, err := scaleAdditionalTargets(context.Background(), logr.Discard(), StrategyClients{ScaleClient: client}, mapper, pool, 1)
+	if err == nil {
+		t.Fatalf("scaleAdditionalTargets() = %v, want an error", scaled)
+	}
+	if got := patched["router"]; len(got) != 2 || got[0] != `{"spec":{"replicas":1}}` || got[1] != `{"spec":{"replicas":0}}` {
+		t.Errorf("router patches = %v, want a scale up rolled back to zero", got)
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+// ModelAliasesKey maps model names to the model served by the inferencePool, as a comma separated list
+// of alias=model pairs, e.g. "llama-3-8b-fp8=llama-3-8b,llama-3-8b-int4=llama-3-8b". Requests for an alias
+// activate the shared inferencePool and are forwarded with their model rewritten by the Endpoint Picker,
+// so that similar models, such as quantization variants, can be served by one runtime instead of one pool
+// each.
+const ModelAliasesKey = poolconfig.ModelAliasesKey // Optional annotation
+
+// resolveModelAlias returns the model served by the inferencePool for the requested model, and whether
+// the requested model is an alias of it.
+func resolveModelAlias(pool *v1.InferencePool, modelName string) (string, bool) {
+	model, ok := poolconfig.For(pool).ModelAliases[modelName]
+	if !ok || model == modelName {
+		return modelName, false
+	}
+	return model, true
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestResolveModelAlias(t *testing.T) {
+	tests := []struct {
+		name        string
+		aliases     string
+		model       string
+		want        string
+		wantAliased bool
+	}{
+		{name: "No aliases", model: "llama-3-8b", want: "llama-3-8b"},
+		{name: "Served model", aliases: "llama-3-8b-fp8=llama-3-8b", model: "llama-3-8b", want: "llama-3-8b"},
+		{name: "Alias", aliases: "llama-3-8b-fp8=llama-3-8b, llama-3-8b-int4=llama-3-8b", model: "llama-3-8b-int4", want: "llama-3-8b", wantAliased: true},
+		{name: "Invalid aliases", aliases: "llama-3-8b-fp8", model: "llama-3-8b-fp8", want: "llama-3-8b-fp8"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+			if test.aliases != "" {
+				pool.Annotations = map[string]string{ModelAliasesKey: test.aliases}
+			}
+
+			got, aliased := resolveModelAlias(pool, test.model)
+			if got != test.want || aliased != test.wantAliased {
+				t.Errorf("resolveModelAlias() = (%s, %t), want (%s, %t)", got, aliased, test.want, test.wantAliased)
+			}
+		})
+	}
+}
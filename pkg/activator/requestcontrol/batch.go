@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// PathHeaderKey is the pseudo header carrying the path of the request.
+	PathHeaderKey = ":path"
+
+	// DefaultBatchCompletionBuffer is the amount of time a pool stays exempt from scale down after its last batch request completed
+	DefaultBatchCompletionBuffer = time.Duration(5 * time.Minute)
+)
+
+// BatchConfig defines which requests are long-running batch requests.
+type BatchConfig struct {
+	// Paths are the path prefixes of batch requests.
+	Paths []string
+	// Header is the name of a request header marking batch requests, whatever its value.
+	Header string
+	// CompletionBuffer is the amount of time the pool stays exempt from scale down after the last batch request completed.
+	CompletionBuffer time.Duration
+}
+
+// NewBatchConfig returns a BatchConfig that matches no request.
+func NewBatchConfig() *BatchConfig {
+	return &BatchConfig{CompletionBuffer: DefaultBatchCompletionBuffer}
+}
+
+// Enabled reports whether the configuration matches any request.
+func (c *BatchConfig) Enabled() bool {
+	return len(c.Paths) > 0 || c.Header != ""
+}
+
+// BatchTracker keeps track of the batch requests in progress, so that the Deactivator does not mistake
+// a pool serving long-running jobs for an idle pool because no new request arrived for a while.
+type BatchTracker struct {
+	config *BatchConfig
+
+	mu       sync.Mutex
+	inFlight int
+	lastDone time.Time
+}
+
+func NewBatchTracker(config *BatchConfig) *BatchTracker {
+	return &BatchTracker{config: config}
+}
+
+// Matches reports whether the request with the given lower-cased headers is a batch request.
+func (t *BatchTracker) Matches(headers map[string]string) bool {
+	if t.config.Header != "" {
+		if _, ok := headers[strings.ToLower(t.config.Header)]; ok {
+			return true
+		}
+	}
+	path, _, _ := strings.Cut(headers[PathHeaderKey], "?")
+	for _, prefix := range t.config.Paths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Begin records the start of a batch request.
+func (t *BatchTracker) Begin() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.inFlight++
+}
+
+// End records the completion of a batch request at the given time.
+func (t *BatchTracker) End(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.inFlight--
+	t.lastDone = now
+}
+
+// Busy reports whether batch requests are in progress or completed less than the completion buffer ago.
+func (t *BatchTracker) Busy(now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.inFlight > 0 || (!t.lastDone.IsZero() && now.Before(t.lastDone.Add(t.config.CompletionBuffer)))
+}
+
+// InFlight returns the number of batch requests in progress.
+func (t *BatchTracker) InFlight() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.inFlight
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// MonthlyActivationBudgetKey caps the accelerator minutes the pool may consume after its scale ups from zero
+	// per calendar month (UTC), e.g. "6000" for 100 hours of a single GPU. Replicas without accelerators count
+	// as one accelerator each. Once the budget is consumed, the scale ups from zero of the pool fail fast until
+	// the next month, while a warm pool keeps serving.
+	MonthlyActivationBudgetKey = poolconfig.MonthlyActivationBudgetKey // Optional annotation
+
+	// ActivationBudgetMonthKey and ActivationBudgetConsumedKey annotate the Lease persisting the activation budget
+	// of the pool with the month it tracks, e.g. "2025-06", and the accelerator seconds consumed in that month.
+	ActivationBudgetMonthKey    = "activator.llm-d.ai/budget-month"
+	ActivationBudgetConsumedKey = "activator.llm-d.ai/budget-consumed-seconds"
+
+	// DefaultActivationBudgetInterval is the interval between two writes of the consumption of the pool
+	DefaultActivationBudgetInterval = time.Duration(30 * time.Second)
+
+	// activationBudgetSuffix is appended to the name of the pool to name the Lease persisting its budget
+	activationBudgetSuffix = "-activator-budget"
+
+	// activationBudgetFlushTimeout bounds the last write of the consumption on shutdown
+	activationBudgetFlushTimeout = time.Duration(2 * time.Second)
+
+	budgetMonthLayout = "2006-01"
+)
+
+// ActivationBudgetName returns the name of the Lease persisting the activation budget of the given pool.
+func ActivationBudgetName(poolName string) string {
+	return poolName + activationBudgetSuffix
+}
+
+// ActivationBudget tracks the accelerator time the pool consumes from each of its scale ups from zero to its
+// next scale down, and persists the consumption of the month in a Lease shared by the activator replicas, so
+// that neither a restart nor a failover resets it. Each replica adds the consumption it observed since its
+// last write.
+type ActivationBudget struct {
+	client    dynamic.Interface
+	datastore datastore.Datastore
+
+	mu sync.Mutex
+	// month is the month the consumption is tracked for
+	month string
+	// persisted is the consumption of the month in accelerator seconds as of the last write, and unflushed
+	// the consumption settled by this replica since then
+	persisted float64
+	unflushed float64
+	// active is set from a scale up from zero of the pool to its scale down, using the given accelerators
+	active       bool
+	since        time.Time
+	accelerators int64
+}
+
+func NewActivationBudget(client dynamic.Interface, datastore datastore.Datastore) *ActivationBudget {
+	return &ActivationBudget{client: client, datastore: datastore}
+}
+
+// Activated records a scale up from zero of the pool bringing the given number of accelerators into use.
+func (b *ActivationBudget) Activated(accelerators int64, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.settleLocked(now)
+	b.active, b.since, b.accelerators = true, now, accelerators
+}
+
+// Deactivated records the scale down of the pool, charging the time it was active to the budget.
+func (b *ActivationBudget) Deactivated(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.settleLocked(now)
+	b.active = false
+}
+
+// Consumed returns the accelerator seconds consumed by the pool in the month of the given time, including
+// the activation in progress.
+func (b *ActivationBudget) Consumed(now time.Time) float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.settleLocked(now)
+	return b.persisted + b.unflushed
+}
+
+// check fails with the budget exhausted reason when the given pool consumed its monthly activation budget.
+func (b *ActivationBudget) check(pool *v1.InferencePool, now time.Time) error {
+	budget, ok := monthlyActivationBudgetFor(pool)
+	if !ok {
+		return nil
+	}
+	consumed := b.Consumed(now) / 60
+	metrics.RecordActivationBudget(pool.Namespace+"/"+pool.Name, consumed, max(float64(budget)-consumed, 0))
+	if consumed < float64(budget) {
+		return nil
+	}
+	return &failureError{reason: FailureBudgetExhausted, err: fmt.Errorf("inferencePool %s consumed its monthly activation budget of %d accelerator minutes, scale ups from zero resume on %s",
+		pool.Name, budget, nextMonth(now).Format(time.DateOnly))}
+}
+
+// settleLocked charges the activation in progress, if any, up to the given time, starting the consumption
+// over when a new month began. b.mu must be held.
+func (b *ActivationBudget) settleLocked(now time.Time) {
+	month := now.UTC().Format(budgetMonthLayout)
+	if month != b.month {
+		// The new month is charged from its start
+		b.month, b.persisted, b.unflushed = month, 0, 0
+		if start := monthStart(now); b.active && b.since.Before(start) {
+			b.since = start
+		}
+	}
+	if b.active && now.After(b.since) {
+		b.unflushed += now.Sub(b.since).Seconds() * float64(b.accelerators)
+		b.since = now
+	}
+}
+
+// Run loads the consumption of the pool, then persists it at the given interval until the context is done,
+// and one last time on shutdown.
+func (b *ActivationBudget) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	b.flush(ctx, time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			// Don't inherit the parent context, it is already cancelled
+			flushCtx, cancel := context.WithTimeout(context.Background(), activationBudgetFlushTimeout)
+			b.flush(log.IntoContext(flushCtx, log.FromContext(ctx)), time.Now())
+			cancel()
+			return
+		case <-ticker.C:
+			b.flush(ctx, time.Now())
+		}
+	}
+}
+
+// flush adds the consumption settled since the last write to the consumption persisted by every replica, and
+// reports the budget of the pool, if it sets one.
+func (b *ActivationBudget) flush(ctx context.Context, now time.Time) {
+	pool, err := b.datastore.PoolGet()
+	if err != nil {
+		return
+	}
+	budget, ok := monthlyActivationBudgetFor(pool)
+	if !ok {
+		return
+	}
+	b.mu.Lock()
+	b.settleLocked(now)
+	month, delta := b.month, b.unflushed
+	b.mu.Unlock()
+
+	total, err := b.persist(ctx, pool, month, delta)
+	if err != nil {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Error persisting the activation budget of the inferencePool", "error", err.Error())
+		return
+	}
+	b.mu.Lock()
+	if b.month == month {
+		b.persisted, b.unflushed = total, b.unflushed-delta
+	}
+	b.mu.Unlock()
+
+	consumed := b.Consumed(now) / 60
+	metrics.RecordActivationBudget(pool.Namespace+"/"+pool.Name, consumed, max(float64(budget)-consumed, 0))
+}
+
+// persist adds the given consumption to the consumption of the given month persisted in the Lease of the pool,
+// and returns the new total. A Lease tracking a past month is started over.
+func (b *ActivationBudget) persist(ctx context.Context, pool *v1.InferencePool, month string, delta float64) (float64, error) {
+	leases := b.client.Resource(leaseGVR).Namespace(pool.Namespace)
+	lease, err := leases.Get(ctx, ActivationBudgetName(pool.Name), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lease = &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "coordination.k8s.io/v1",
+			"kind":       "Lease",
+			"metadata":   map[string]any{"name": ActivationBudgetName(pool.Name), "namespace": pool.Namespace},
+			"spec":       map[string]any{},
+		}}
+		lease.SetAnnotations(budgetAnnotations(month, delta))
+		_, err = leases.Create(ctx, lease, metav1.CreateOptions{})
+		return delta, err
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var consumed float64
+	if annotations := lease.GetAnnotations(); annotations[ActivationBudgetMonthKey] == month {
+		consumed, _ = strconv.ParseFloat(annotations[ActivationBudgetConsumedKey], 64)
+	}
+	if delta == 0 {
+		return consumed, nil
+	}
+	annotations := lease.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for k, v := range budgetAnnotations(month, consumed+delta) {
+		annotations[k] = v
+	}
+	lease.SetAnnotations(annotations)
+	// Updating the Lease read fails on conflict with another replica, retrying on the next write
+	if _, err := leases.Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+		return 0, err
+	}
+	return consumed + delta, nil
+}
+
+// budgetAnnotations returns the annotations persisting the given consumption of the given month.
+func budgetAnnotations(month string, consumed float64) map[string]string {
+	return map[string]string{
+		ActivationBudgetMonthKey:    month,
+		ActivationBudgetConsumedKey: strconv.FormatFloat(consumed, 'f', 0, 64),
+	}
+}
+
+// monthStart returns the start of the month (UTC) of the given time.
+func monthStart(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// nextMonth returns the start of the month (UTC) following the given time.
+func nextMonth(now time.Time) time.Time {
+	return monthStart(now).AddDate(0, 1, 0)
+}
+
+// monthlyActivationBudgetFor returns the monthly activation budget of the given pool in accelerator minutes,
+// if it sets a valid one.
+func monthlyActivationBudgetFor(pool *v1.InferencePool) (int64, bool) {
+	budget := poolconfig.For(pool).MonthlyActivationBudget
+	return budget, budget > 0
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestActivationBudget(t *testing.T) {
+	start := time.Date(2025, time.June, 30, 22, 0, 0, 0, time.UTC)
+	pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", Annotations: map[string]string{MonthlyActivationBudgetKey: "120"}}}
+
+	tests := []struct {
+		name         string
+		accelerators int64
+		active       time.Duration
+		at           time.Duration
+		wantConsumed float64
+		wantErr      bool
+	}{
+		{name: "within the budget", accelerators: 1, active: time.Hour, at: time.Hour, wantConsumed: 3600},
+		{name: "activation in progress", accelerators: 2, at: 30 * time.Minute, wantConsumed: 3600},
+		{name: "budget consumed", accelerators: 2, active: time.Hour, at: time.Hour, wantConsumed: 7200, wantErr: true},
+		{name: "next month charged from its start", accelerators: 4, at: 3 * time.Hour, wantConsumed: 14400, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			budget := NewActivationBudget(nil, nil)
+			budget.Activated(test.accelerators, start)
+			if test.active > 0 {
+				budget.Deactivated(start.Add(test.active))
+			}
+			now := start.Add(test.at)
+			if got := budget.Consumed(now); got != test.wantConsumed {
+				t.Errorf("Consumed() = %v, want %v", got, test.wantConsumed)
+			}
+			err := budget.check(pool, now)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("check() error = %v, want error %t", err, test.wantErr)
+			}
+			var failure *failureError
+			if err != nil && (!errors.As(err, &failure) || failure.reason != FailureBudgetExhausted) {
+				t.Errorf("check() error = %v, want a %s failure", err, FailureBudgetExhausted)
+			}
+		})
+	}
+}
+
+func TestActivationBudgetPersist(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.June, 10, 12, 0, 0, 0, time.UTC)
+	pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", Annotations: map[string]string{MonthlyActivationBudgetKey: "600"}}}
+	ds := datastore.NewDatastore(ctx)
+	ds.PoolSet(pool)
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{leaseGVR: "LeaseList"})
+
+	// Two replicas share the consumption of the pool
+	first, second := NewActivationBudget(client, ds), NewActivationBudget(client, ds)
+	first.Activated(1, now.Add(-time.Hour))
+	first.Deactivated(now.Add(-30 * time.Minute))
+	first.flush(ctx, now)
+	second.Activated(2, now.Add(-10*time.Minute))
+	second.flush(ctx, now)
+
+	if got := second.Consumed(now); got != 1800+1200 {
+		t.Errorf("Consumed() = %v after both replicas wrote, want 3000", got)
+	}
+	first.flush(ctx, now)
+	if got := first.Consumed(now); got != 3000 {
+		t.Errorf("Consumed() = %v after reading the other replica, want 3000", got)
+	}
+
+	lease, err := client.Resource(leaseGVR).Namespace("default").Get(ctx, ActivationBudgetName(pool.Name), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if annotations := lease.GetAnnotations(); annotations[ActivationBudgetMonthKey] != "2025-06" || annotations[ActivationBudgetConsumedKey] != "3000" {
+		t.Errorf("lease annotations = %v, want month 2025-06 and 3000 seconds consumed", annotations)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/handlers"
+)
+
+const (
+	// ForwardedClientCertHeaderKey carries the identity of the mTLS client, as set by Envoy
+	ForwardedClientCertHeaderKey = "x-forwarded-client-cert"
+
+	// MethodHeaderKey is the pseudo header carrying the method of the request.
+	MethodHeaderKey = ":method"
+	// AuthorityHeaderKey is the pseudo header carrying the authority of the request.
+	AuthorityHeaderKey = ":authority"
+
+	// DefaultBypassMaxSkew is how far the timestamp of a signed bypass header may be from the current time
+	DefaultBypassMaxSkew = time.Duration(30 * time.Second)
+
+	// DefaultWarmWindow is how long a pool found ready by a request is known to be warm for bypassing requests
+	DefaultWarmWindow = time.Duration(10 * time.Second)
+)
+
+// BypassConfig holds the credentials of the internal clients allowed to bypass the activator.
+type BypassConfig struct {
+	// Secret signs the bypass header of trusted clients
+	Secret []byte
+	// Identities are the trusted client certificate URIs, e.g. SPIFFE IDs, forwarded by Envoy
+	Identities []string
+	// MaxSkew bounds the age of signed bypass headers
+	MaxSkew time.Duration
+}
+
+// Enabled reports whether any internal client is trusted to bypass the activator.
+func (c *BypassConfig) Enabled() bool {
+	return len(c.Secret) > 0 || len(c.Identities) > 0
+}
+
+// Bypass recognizes the requests of trusted internal clients, which skip model parsing and the pool
+// pipeline altogether while their pool is known to be warm.
+//
+// A client is trusted either when it presents a bypass header "<unix seconds>.<hex signature>" whose signature
+// is the HMAC-SHA256, with the shared secret, of the unix seconds and of the method, authority and path of
+// the request, or when Envoy forwards one of the trusted identities of its client certificate. Binding the
+// signature to the request keeps a leaked header from bypassing the activator for other requests, and the
+// short skew bounds how long it can be replayed for the same one. The latter is only safe when the gateway sanitizes the client cert header
+// received from downstream, e.g. with forward_client_cert_details set to SANITIZE_SET.
+type Bypass struct {
+	secret     []byte
+	identities map[string]bool
+	maxSkew    time.Duration
+}
+
+func NewBypass(config *BypassConfig) *Bypass {
+	identities := make(map[string]bool, len(config.Identities))
+	for _, identity := range config.Identities {
+		identities[identity] = true
+	}
+	return &Bypass{secret: config.Secret, identities: identities, maxSkew: config.MaxSkew}
+}
+
+// Authorized reports whether the request with the given headers comes from a trusted internal client.
+func (b *Bypass) Authorized(headers map[string]string, now time.Time) bool {
+	if value := headers[handlers.BypassHeaderKey]; value != "" && len(b.secret) > 0 && b.validSignature(value, headers, now) {
+		return true
+	}
+	if value := headers[ForwardedClientCertHeaderKey]; value != "" && len(b.identities) > 0 {
+		return b.identities[clientCertURI(value)]
+	}
+	return false
+}
+
+func (b *Bypass) validSignature(value string, headers map[string]string, now time.Time) bool {
+	timestamp, signature, ok := strings.Cut(value, ".")
+	if !ok {
+		return false
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	if skew := now.Sub(time.Unix(seconds, 0)); skew > b.maxSkew || skew < -b.maxSkew {
+		return false
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(got, SignBypass(b.secret, seconds, headers[MethodHeaderKey], headers[AuthorityHeaderKey], headers[PathHeaderKey]))
+}
+
+// SignBypass returns the HMAC-SHA256 signature, with the given secret, of the given unix seconds and of the
+// method, authority and path, query included, of the request, joined by new lines.
+func SignBypass(secret []byte, seconds int64, method, authority, path string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strings.Join([]string{strconv.FormatInt(seconds, 10), method, authority, path}, "\n")))
+	return mac.Sum(nil)
+}
+
+// clientCertURI returns the URI of the client certificate added by the nearest proxy to the given
+// x-forwarded-client-cert header, e.g. `By=spiffe://gw;Hash=...;URI=spiffe://cluster.local/ns/a/sa/b`.
+func clientCertURI(value string) string {
+	elements := strings.Split(value, ",")
+	for _, pair := range strings.Split(elements[len(elements)-1], ";") {
+		if key, uri, ok := strings.Cut(pair, "="); ok && strings.EqualFold(strings.TrimSpace(key), "URI") {
+			return strings.Trim(strings.TrimSpace(uri), `"`)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/handlers"
+)
+
+func TestBypassAuthorized(t *testing.T) {
+	now := time.Unix(1735732800, 0)
+	secret := []byte("secret")
+	signed := func(secret []byte, at time.Time) string {
+		return strconv.FormatInt(at.Unix(), 10) + "." + hex.EncodeToString(SignBypass(secret, at.Unix(), "POST", "gateway", "/v1/completions"))
+	}
+	request := func(headers map[string]string) map[string]string {
+		all := map[string]string{MethodHeaderKey: "POST", AuthorityHeaderKey: "gateway", PathHeaderKey: "/v1/completions"}
+		for key, value := range headers {
+			all[key] = value
+		}
+		return all
+	}
+	bypass := NewBypass(&BypassConfig{
+		Secret:     secret,
+		Identities: []string{"spiffe://cluster.local/ns/search/sa/ranker"},
+		MaxSkew:    DefaultBypassMaxSkew,
+	})
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    bool
+	}{
+		{name: "no credentials", headers: map[string]string{}, want: false},
+		{name: "signed header", headers: request(map[string]string{handlers.BypassHeaderKey: signed(secret, now)}), want: true},
+		{name: "signed header within the skew", headers: request(map[string]string{handlers.BypassHeaderKey: signed(secret, now.Add(-20*time.Second))}), want: true},
+		{name: "expired signed header", headers: request(map[string]string{handlers.BypassHeaderKey: signed(secret, now.Add(-time.Minute))}), want: false},
+		{name: "wrong secret", headers: request(map[string]string{handlers.BypassHeaderKey: signed([]byte("other"), now)}), want: false},
+		{name: "malformed signed header", headers: request(map[string]string{handlers.BypassHeaderKey: "not-a-signature"}), want: false},
+		{name: "signed header replayed on another path", headers: request(map[string]string{handlers.BypassHeaderKey: signed(secret, now), PathHeaderKey: "/v1/chat/completions"}), want: false},
+		{name: "signed header replayed with another method", headers: request(map[string]string{handlers.BypassHeaderKey: signed(secret, now), MethodHeaderKey: "GET"}), want: false},
+		{name: "signed header replayed on another authority", headers: request(map[string]string{handlers.BypassHeaderKey: signed(secret, now), AuthorityHeaderKey: "other"}), want: false},
+		{
+			name:    "trusted identity",
+			headers: map[string]string{ForwardedClientCertHeaderKey: `By=spiffe://gw;Hash=abc;Subject="CN=ranker";URI=spiffe://cluster.local/ns/search/sa/ranker`},
+			want:    true,
+		},
+		{
+			name:    "untrusted identity",
+			headers: map[string]string{ForwardedClientCertHeaderKey: `By=spiffe://gw;Hash=abc;URI=spiffe://cluster.local/ns/other/sa/default`},
+			want:    false,
+		},
+		{
+			name:    "trusted identity forwarded by an earlier proxy",
+			headers: map[string]string{ForwardedClientCertHeaderKey: `URI=spiffe://cluster.local/ns/search/sa/ranker,URI=spiffe://cluster.local/ns/other/sa/default`},
+			want:    false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := bypass.Authorized(test.headers, now); got != test.want {
+				t.Errorf("Authorized() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/handlers"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+const (
+	// DefaultResponseCacheSize is the default maximum number of responses kept by the response cache
+	DefaultResponseCacheSize = 1000
+
+	// DefaultResponseCacheTTL is the default amount of time a cached response may be served while the pool is cold
+	DefaultResponseCacheTTL = time.Duration(10 * time.Minute)
+
+	// maxCachedBodySize bounds the size of the responses kept by the response cache
+	maxCachedBodySize = 1 << 20
+)
+
+// ResponseCacheConfig defines which requests are idempotent enough to be answered from the response
+// cache while their pool is cold.
+type ResponseCacheConfig struct {
+	// Paths are the path prefixes of cacheable requests, e.g. "/v1/embeddings".
+	Paths []string
+	// Size is the maximum number of responses kept.
+	Size int
+	// TTL is the amount of time a response may be served from the cache after it was stored.
+	TTL time.Duration
+}
+
+// NewResponseCacheConfig returns a ResponseCacheConfig that matches no request.
+func NewResponseCacheConfig() *ResponseCacheConfig {
+	return &ResponseCacheConfig{Size: DefaultResponseCacheSize, TTL: DefaultResponseCacheTTL}
+}
+
+// Enabled reports whether the configuration matches any request.
+func (c *ResponseCacheConfig) Enabled() bool {
+	return len(c.Paths) > 0 && c.Size > 0 && c.TTL > 0
+}
+
+// ResponseCache keeps the successful responses of cacheable requests, keyed by path and body, to answer
+// repeated requests immediately while their pool is scaling up from zero. Responses are only served
+// from the cache while the pool is cold, a warm pool always serves fresh responses.
+type ResponseCache struct {
+	config *ResponseCacheConfig
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type cacheEntry struct {
+	key      string
+	response *handlers.CachedResponse
+}
+
+func NewResponseCache(config *ResponseCacheConfig) *ResponseCache {
+	return &ResponseCache{
+		config:  config,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Matches reports whether the request with the given lower-cased headers is cacheable.
+func (c *ResponseCache) Matches(headers map[string]string) bool {
+	path, _, _ := strings.Cut(headers[PathHeaderKey], "?")
+	for _, prefix := range c.config.Paths {
+		if prefix != "" && strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Key returns the cache key of the request with the given path and body.
+func (c *ResponseCache) Key(path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Get returns the response cached for the given key, if it did not expire.
+func (c *ResponseCache) Get(key string, now time.Time) (*handlers.CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*cacheEntry)
+	if now.Sub(entry.response.StoredAt) >= c.config.TTL {
+		c.lru.Remove(element)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(element)
+	return entry.response, true
+}
+
+// Put stores the given response for the given key, evicting the least recently used responses beyond
+// the cache size. Oversized responses are not stored.
+func (c *ResponseCache) Put(key string, response *handlers.CachedResponse) {
+	if len(response.Body) > maxCachedBodySize {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*cacheEntry).response = response
+		c.lru.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, response: response})
+	for c.lru.Len() > c.config.Size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// IsCold reports whether the inferencePool has no replica, or is scaling up from zero replicas.
+func (a *Activator) IsCold(ctx context.Context, pool *v1.InferencePool) bool {
+	if scalingUp, _ := a.isScalingUp(); scalingUp {
+		return true
+	}
+
+	logger := log.FromContext(ctx)
+	if !VerifyPoolObjectAnnotations(logger, pool) {
+		return false
+	}
+	gvr, err := targetResourceFor(a.Mapper, pool)
+	if err != nil {
+		return false
+	}
+	scaleObject, err := scaleOf(ctx, a.ScaleClient, a.DynamicClient, pool, gvr)
+	return err == nil && scaleObject.Spec.Replicas == 0
+}
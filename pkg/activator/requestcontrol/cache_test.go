@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/handlers"
+)
+
+func TestResponseCache(t *testing.T) {
+	config := NewResponseCacheConfig()
+	config.Paths = []string{"/v1/embeddings"}
+	config.Size = 2
+	config.TTL = time.Minute
+	cache := NewResponseCache(config)
+
+	if !cache.Matches(map[string]string{PathHeaderKey: "/v1/embeddings?model=e5"}) {
+		t.Errorf("Matches() = false for a cacheable path")
+	}
+	if cache.Matches(map[string]string{PathHeaderKey: "/v1/completions"}) {
+		t.Errorf("Matches() = true for a non cacheable path")
+	}
+
+	now := time.Now()
+	keyA := cache.Key("/v1/embeddings", []byte(`{"input":"a"}`))
+	keyB := cache.Key("/v1/embeddings", []byte(`{"input":"b"}`))
+	keyC := cache.Key("/v1/embeddings", []byte(`{"input":"c"}`))
+	cache.Put(keyA, &handlers.CachedResponse{Body: []byte("a"), StoredAt: now})
+	cache.Put(keyB, &handlers.CachedResponse{Body: []byte("b"), StoredAt: now})
+
+	tests := []struct {
+		name string
+		key  string
+		at   time.Time
+		want bool
+	}{
+		{name: "Cached", key: keyA, at: now, want: true},
+		{name: "Not cached", key: keyC, at: now, want: false},
+		{name: "Expired", key: keyB, at: now.Add(time.Minute), want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, got := cache.Get(test.key, test.at); got != test.want {
+				t.Errorf("Get() = %t, want %t", got, test.want)
+			}
+		})
+	}
+
+	// keyA is now the least recently used response, and is evicted once the cache is over its size
+	cache.Put(keyB, &handlers.CachedResponse{Body: []byte("b"), StoredAt: now})
+	cache.Put(keyC, &handlers.CachedResponse{Body: []byte("c"), StoredAt: now})
+	if _, ok := cache.Get(keyA, now); ok {
+		t.Errorf("Get() found the least recently used response beyond the cache size")
+	}
+}
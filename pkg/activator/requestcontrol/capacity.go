@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// acceleratorResourceNames are the extended resources counted as accelerators when reporting capacity.
+var acceleratorResourceNames = []string{"nvidia.com/gpu", "amd.com/gpu", "google.com/tpu", "habana.ai/gaudi"}
+
+// acceleratorsPerReplica returns the number of accelerators requested by a single replica of the given
+// workload, based on the container limits of its pod template. It returns zero when the workload
+// does not expose a pod template under spec.template.
+func acceleratorsPerReplica(obj *unstructured.Unstructured) int64 {
+	containers, found, err := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+	if err != nil || !found {
+		return 0
+	}
+
+	var total int64
+	for _, c := range containers {
+		container, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		limits, found, err := unstructured.NestedStringMap(container, "resources", "limits")
+		if err != nil || !found {
+			continue
+		}
+		for _, name := range acceleratorResourceNames {
+			if value, ok := limits[name]; ok {
+				if quantity, err := resource.ParseQuantity(value); err == nil {
+					total += quantity.Value()
+				}
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+// MaxColdStartsPerHourKey caps the number of scale from zero cycles of the pool per hour, e.g. "6". Once the
+// cap is reached, the pool is kept warm for the remainder of the hour instead of being scaled to zero.
+const MaxColdStartsPerHourKey = poolconfig.MaxColdStartsPerHourKey // Optional annotation
+
+// coldStartWindow is the sliding window the cold starts of a pool are capped over.
+const coldStartWindow = time.Hour
+
+// coldStartCap tracks the recent scale downs of the pool. Every scale down to zero is followed by a cold
+// start on the next request, so capping the scale downs caps the cold starts, and keeps the count with the
+// Deactivator of the leader whichever activator replica performs the scale ups.
+type coldStartCap struct {
+	mu         sync.Mutex
+	scaleDowns []time.Time
+}
+
+// record records a scale down of the pool at the given time.
+func (c *coldStartCap) record(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prune(now)
+	c.scaleDowns = append(c.scaleDowns, now)
+}
+
+// warmUntil reports whether the pool reached its cap of cold starts over the last hour, and until when it
+// must be kept warm in that case.
+func (c *coldStartCap) warmUntil(pool *v1.InferencePool, now time.Time) (time.Time, bool) {
+	limit, ok := maxColdStartsFor(pool)
+	if !ok {
+		return time.Time{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prune(now)
+	if len(c.scaleDowns) < limit {
+		return time.Time{}, false
+	}
+	return c.scaleDowns[len(c.scaleDowns)-limit].Add(coldStartWindow), true
+}
+
+// snapshot returns the scale downs of the pool over the last hour.
+func (c *coldStartCap) snapshot(now time.Time) []time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prune(now)
+	return slices.Clone(c.scaleDowns)
+}
+
+// restore merges the given scale downs of the pool, recorded by another activator, into the tracked ones.
+func (c *coldStartCap) restore(scaleDowns []time.Time, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, at := range scaleDowns {
+		if !slices.ContainsFunc(c.scaleDowns, at.Equal) {
+			c.scaleDowns = append(c.scaleDowns, at)
+		}
+	}
+	slices.SortFunc(c.scaleDowns, time.Time.Compare)
+	c.prune(now)
+}
+
+// prune drops the scale downs older than the cold start window. c.mu must be held.
+func (c *coldStartCap) prune(now time.Time) {
+	start := 0
+	for start < len(c.scaleDowns) && !c.scaleDowns[start].After(now.Add(-coldStartWindow)) {
+		start++
+	}
+	c.scaleDowns = c.scaleDowns[start:]
+}
+
+// maxColdStartsFor returns the cap of cold starts per hour of the given pool, if it sets a valid one.
+func maxColdStartsFor(pool *v1.InferencePool) (int, bool) {
+	limit := poolconfig.For(pool).MaxColdStartsPerHour
+	return limit, limit > 0
+}
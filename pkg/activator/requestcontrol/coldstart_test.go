@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestColdStartCap(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		limit      string
+		scaleDowns []time.Duration
+		wantCapped bool
+		wantUntil  time.Time
+	}{
+		{name: "no cap", scaleDowns: []time.Duration{-time.Minute, -2 * time.Minute}},
+		{name: "invalid cap", limit: "many", scaleDowns: []time.Duration{-time.Minute}},
+		{name: "below the cap", limit: "3", scaleDowns: []time.Duration{-10 * time.Minute, -5 * time.Minute}},
+		{
+			name:       "cap reached",
+			limit:      "2",
+			scaleDowns: []time.Duration{-50 * time.Minute, -20 * time.Minute, -5 * time.Minute},
+			wantCapped: true,
+			wantUntil:  now.Add(-20 * time.Minute).Add(coldStartWindow),
+		},
+		{name: "cap reached over an hour ago", limit: "2", scaleDowns: []time.Duration{-3 * time.Hour, -2 * time.Hour}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+			if test.limit != "" {
+				pool.Annotations = map[string]string{MaxColdStartsPerHourKey: test.limit}
+			}
+			var c coldStartCap
+			for _, at := range test.scaleDowns {
+				c.record(now.Add(at))
+			}
+
+			until, capped := c.warmUntil(pool, now)
+			if capped != test.wantCapped || !until.Equal(test.wantUntil) {
+				t.Errorf("warmUntil() = (%v, %t), want (%v, %t)", until, capped, test.wantUntil, test.wantCapped)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+// podTemplateLabelPaths are the paths of the pod template labels of the workloads, in order: Deployments,
+// StatefulSets and most workloads, then the workers of LeaderWorkerSets.
+var podTemplateLabelPaths = [][]string{
+	{"spec", "template", "metadata", "labels"},
+	{"spec", "leaderWorkerTemplate", "workerTemplate", "metadata", "labels"},
+}
+
+// CheckTarget checks that the pod template of the target workload of the given pool matches the selector of
+// the pool. Otherwise the activation of the pool succeeds, but the requests released are routed to no pod.
+// Targets without a known pod template, e.g. bespoke inference CRDs, are not checked.
+func (a *Activator) CheckTarget(ctx context.Context, pool *v1.InferencePool) error {
+	config := poolconfig.For(pool)
+	if !config.HasTarget() || len(pool.Spec.Selector.MatchLabels) == 0 {
+		return nil
+	}
+	gvr, err := targetResourceFor(a.Mapper, pool)
+	if err != nil {
+		return err
+	}
+	target, err := a.DynamicClient.Resource(gvr).Namespace(pool.Namespace).Get(ctx, config.Target.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get target %s/%s of inferencePool %s: %w", config.Target.Kind, config.Target.Name, pool.Name, err)
+	}
+	return checkTargetTemplate(target, pool)
+}
+
+// checkTargetTemplate checks that the pod template labels of the given target workload match the selector of
+// the given pool.
+func checkTargetTemplate(target *unstructured.Unstructured, pool *v1.InferencePool) error {
+	for _, path := range podTemplateLabelPaths {
+		templateLabels, found, _ := unstructured.NestedStringMap(target.Object, path...)
+		if !found {
+			continue
+		}
+		selector := make(labels.Set, len(pool.Spec.Selector.MatchLabels))
+		for k, v := range pool.Spec.Selector.MatchLabels {
+			selector[string(k)] = string(v)
+		}
+		if !labels.SelectorFromSet(selector).Matches(labels.Set(templateLabels)) {
+			return fmt.Errorf("pod template labels of target %s/%s do not match the selector %s of inferencePool %s, its pods would receive no request once activated",
+				target.GetKind(), target.GetName(), selector, pool.Name)
+		}
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestCheckTargetTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    map[string]any
+		wantErr bool
+	}{
+		{name: "matching deployment", spec: map[string]any{"template": map[string]any{"metadata": map[string]any{"labels": map[string]any{"app": "vllm", "tier": "gpu"}}}}},
+		{name: "diverging deployment", spec: map[string]any{"template": map[string]any{"metadata": map[string]any{"labels": map[string]any{"app": "vllm-v2"}}}}, wantErr: true},
+		{
+			name: "matching leaderworkerset",
+			spec: map[string]any{"leaderWorkerTemplate": map[string]any{"workerTemplate": map[string]any{"metadata": map[string]any{"labels": map[string]any{"app": "vllm"}}}}},
+		},
+		{name: "no pod template", spec: map[string]any{"replicas": int64(0)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target := &unstructured.Unstructured{Object: map[string]any{"kind": "Deployment", "metadata": map[string]any{"name": "llama"}, "spec": test.spec}}
+			pool := &v1.InferencePool{}
+			pool.Name = "pool"
+			pool.Spec.Selector.MatchLabels = map[v1.LabelKey]v1.LabelValue{"app": "vllm"}
+			if err := checkTargetTemplate(target, pool); (err != nil) != test.wantErr {
+				t.Errorf("checkTargetTemplate() error = %v, wantErr %t", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type requestIDKey struct{}
+
+type modelNameKey struct{}
+
+type attributionKey struct{}
+
+type retryKey struct{}
+
+type gatewayKey struct{}
+
+type objectiveKey struct{}
+
+type activationStateKey struct{}
+
+// withRequestID returns a copy of ctx carrying the gateway request ID of the request being handled.
+func withRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// requestIDFromContext returns the gateway request ID carried by ctx, if any.
+func requestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// withModelName returns a copy of ctx carrying the model requested by the request being handled.
+func withModelName(ctx context.Context, modelName string) context.Context {
+	return context.WithValue(ctx, modelNameKey{}, modelName)
+}
+
+// modelNameFromContext returns the requested model carried by ctx, if any.
+func modelNameFromContext(ctx context.Context) string {
+	modelName, _ := ctx.Value(modelNameKey{}).(string)
+	return modelName
+}
+
+// withAttributionKey returns a copy of ctx carrying the attribution key of the request being handled.
+func withAttributionKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, attributionKey{}, key)
+}
+
+// attributionKeyFromContext returns the attribution key carried by ctx, if any.
+func attributionKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(attributionKey{}).(string)
+	return key
+}
+
+// withRetry returns a copy of ctx marking the request being handled as a retry of a failed request.
+func withRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryKey{}, true)
+}
+
+// isRetryFromContext reports whether ctx marks the request being handled as a retry.
+func isRetryFromContext(ctx context.Context) bool {
+	retry, _ := ctx.Value(retryKey{}).(bool)
+	return retry
+}
+
+// activationState is shared through the context between the Director and the Activator to report
+// how the activation of the request went beyond its outcome.
+type activationState struct {
+	// queuedForCapacity is set when the request gave up while the activation was queued by Kueue.
+	queuedForCapacity bool
+	// failure describes why the activation of the request failed, when known.
+	failure string
+	// reason classifies why the activation of the request failed.
+	reason FailureReason
+	// endpointSubset lists the endpoints the request should be sent to, when only some of the replicas
+	// of the inferencePool are ready after its scale from zero.
+	endpointSubset []string
+	// shared is set when the request waited on the readiness check run by another request, which records
+	// the outcome of the activation in the circuit breaker of the pool once for all of them.
+	shared bool
+}
+
+// withActivationState returns a copy of ctx carrying the activation state of the request being handled.
+func withActivationState(ctx context.Context, state *activationState) context.Context {
+	return context.WithValue(ctx, activationStateKey{}, state)
+}
+
+// activationStateFromContext returns the activation state carried by ctx, if any.
+func activationStateFromContext(ctx context.Context) *activationState {
+	state, _ := ctx.Value(activationStateKey{}).(*activationState)
+	return state
+}
+
+// withTraceContext returns a copy of ctx carrying the W3C trace context propagated in the given request headers.
+func withTraceContext(ctx context.Context, headers map[string]string) context.Context {
+	return propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier(headers))
+}
+
+// traceIDFromContext returns the ID of the distributed trace carried by ctx, if any.
+func traceIDFromContext(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
+
+// withGateway returns a copy of ctx carrying the gateway the request being handled came through.
+func withGateway(ctx context.Context, gateway string) context.Context {
+	return context.WithValue(ctx, gatewayKey{}, gateway)
+}
+
+// gatewayFromContext returns the gateway carried by ctx, if any.
+func gatewayFromContext(ctx context.Context) string {
+	gateway, _ := ctx.Value(gatewayKey{}).(string)
+	return gateway
+}
+
+// withObjective returns a copy of ctx carrying the InferenceObjective of the request being handled.
+func withObjective(ctx context.Context, objective string) context.Context {
+	return context.WithValue(ctx, objectiveKey{}, objective)
+}
+
+// objectiveFromContext returns the InferenceObjective carried by ctx, if any.
+func objectiveFromContext(ctx context.Context) string {
+	objective, _ := ctx.Value(objectiveKey{}).(string)
+	return objective
+}
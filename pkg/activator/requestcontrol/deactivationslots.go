@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// DeactivationPriorityKey orders the scale downs of pools idle at once, e.g. at the end of the business day,
+	// when the scale downs of their namespace are rate limited. Pools of lower priority values are scaled down first.
+	DeactivationPriorityKey = poolconfig.DeactivationPriorityKey // Optional annotation
+
+	// DefaultDeactivationPriority is the deactivation priority of pools without a deactivation priority annotation
+	DefaultDeactivationPriority = poolconfig.DefaultDeactivationPriority
+
+	DefaultDeactivationBatchInterval = time.Duration(5 * time.Second)
+
+	// deactivationSlotPrefix names the Leases holding the scale down slots of a namespace
+	deactivationSlotPrefix = "activator-deactivation-slot-"
+
+	// deactivationSlotPeriod is how long each scale down holds its slot, the period of the rate limit
+	deactivationSlotPeriod = time.Minute
+)
+
+// DeactivationSlots rate limits the scale downs of a namespace, across all the activators of its pools, so
+// that the API server and the cluster autoscaler see a smooth drop when many pools are idle at once. Each
+// scale down holds one of the slots for a minute, never releasing it early. Like the activation slots, the
+// slots fail open.
+type DeactivationSlots struct {
+	slots *ActivationSlots
+}
+
+// NewDeactivationSlots returns the slots allowing the given number of scale downs per minute in a namespace.
+// Pools of deactivation priority p retry every p+1 batch intervals while waiting for a slot.
+func NewDeactivationSlots(client dynamic.Interface, actionsPerMinute int, batchInterval time.Duration) *DeactivationSlots {
+	config := HerdConfig{Slots: actionsPerMinute, BatchInterval: batchInterval, Jitter: DefaultActivationJitter}
+	return &DeactivationSlots{slots: &ActivationSlots{client: client, config: config, prefix: deactivationSlotPrefix, priority: deactivationPriorityFor}}
+}
+
+// Acquire waits for a scale down slot in the namespace of the pool. It fails if no slot was freed before the
+// context is done.
+func (s *DeactivationSlots) Acquire(ctx context.Context, pool *v1.InferencePool) error {
+	_, err := s.slots.Acquire(ctx, pool, deactivationSlotPeriod)
+	return err
+}
+
+// awaitDeactivationSlot waits for a scale down slot of the namespace of the given idle pool, if its scale downs
+// are rate limited. It reports whether the pool is to be scaled down: a slot was taken and the pool is still
+// idle once the slot is taken.
+func (da *Deactivator) awaitDeactivationSlot(ctx context.Context, logger logr.Logger, pool *v1.InferencePool) bool {
+	if da.Slots == nil {
+		return true
+	}
+	slotCtx, cancel := context.WithTimeout(ctx, scaleDownDelayFor(pool))
+	defer cancel()
+	if err := da.Slots.Acquire(slotCtx, pool); err != nil {
+		logger.V(logutil.DEBUG).Info("No scale down slot freed, retrying on the next idleness check", "name", pool.Name, "error", err.Error())
+		return false
+	}
+	if idle, err := poolIdle(ctx, da.idleness, pool); err != nil || !idle {
+		logger.V(logutil.DEBUG).Info("InferencePool is no longer idle once a scale down slot was taken, skipping scale down", "name", pool.Name)
+		return false
+	}
+	return true
+}
+
+// deactivationPriorityFor returns the deactivation priority of the given pool.
+func deactivationPriorityFor(pool *v1.InferencePool) int {
+	return poolconfig.For(pool).DeactivationPriority
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestDeactivationSlots(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{leaseGVR: "LeaseList"})
+	slots := NewDeactivationSlots(client, 2, DefaultDeactivationBatchInterval).slots
+
+	steps := []struct {
+		name         string
+		holder       string
+		at           time.Time
+		wantAcquired bool
+	}{
+		{name: "first pool", holder: "default/a", at: now, wantAcquired: true},
+		{name: "second pool", holder: "default/b", at: now, wantAcquired: true},
+		{name: "third pool within the minute", holder: "default/c", at: now.Add(30 * time.Second), wantAcquired: false},
+		{name: "third pool the next minute", holder: "default/c", at: now.Add(deactivationSlotPeriod + time.Second), wantAcquired: true},
+	}
+
+	for _, step := range steps {
+		slot, acquired, err := slots.tryAcquire(ctx, "default", step.holder, deactivationSlotPeriod, step.at)
+		if err != nil {
+			t.Fatalf("%s: tryAcquire() error = %v", step.name, err)
+		}
+		if acquired != step.wantAcquired {
+			t.Errorf("%s: tryAcquire() acquired = %t, want %t", step.name, acquired, step.wantAcquired)
+		}
+		if acquired && !strings.HasPrefix(slot, deactivationSlotPrefix) {
+			t.Errorf("%s: slot = %s, want a deactivation slot", step.name, slot)
+		}
+	}
+
+	pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Annotations: map[string]string{DeactivationPriorityKey: "2"}}}
+	if priority := slots.priority(pool); priority != 2 {
+		t.Errorf("priority = %d, want the deactivation priority 2", priority)
+	}
+	pool.Annotations[DeactivationPriorityKey] = "-1"
+	if err := poolconfig.Parse(pool).ErrOf(DeactivationPriorityKey); err == nil {
+		t.Error("poolconfig.Parse().ErrOf(DeactivationPriorityKey) accepted a negative priority")
+	}
+}
@@ -5,28 +5,84 @@ import (
 	"fmt"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	activatorv1alpha1 "github.com/llm-d-incubation/llm-d-activator/api/v1alpha1"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/attribution"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/audit"
 	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 
 	"k8s.io/client-go/scale"
 )
 
 const (
-	ScaleDownDelayKey         = "activator.llm-d.ai/scale-down-delay"           // Optional annotation
-	ScaleToZeroGracePeriodKey = "activator.llm-d.ai/scale-to-zero-grace-period" // Optional annotation
+	ScaleDownDelayKey         = poolconfig.ScaleDownDelayKey         // Optional annotation
+	ScaleToZeroGracePeriodKey = poolconfig.ScaleToZeroGracePeriodKey // Optional annotation
+
+	// DeactivationReportFieldManager owns the DeactivationReports of the dry-run scale downs
+	DeactivationReportFieldManager = "llm-d-activator-deactivation-report"
 )
 
+var deactivationReportGVR = activatorv1alpha1.SchemeGroupVersion.WithResource("deactivationreports")
+
 type Deactivator struct {
-	DynamicClient *dynamic.DynamicClient
+	DynamicClient dynamic.Interface
 	ScaleClient   scale.ScalesGetter
 	Mapper        meta.RESTMapper
-	datastore     *datastore.Datastore
+	// Recorder emits Kubernetes events on the InferencePool for scale actions. Optional.
+	Recorder record.EventRecorder
+	// DryRun makes the Deactivator report the scale downs it would perform instead of applying them, once per
+	// idle period of the pool.
+	DryRun bool
+	// DryRunReport records the dry-run scale downs in the DeactivationReport named after the pool as well. Optional.
+	DryRunReport bool
+	// Batches exempts the pool from scale down while batch requests are in progress. Optional.
+	Batches *BatchTracker
+	// Responses exempts the pool from scale down while responses are not complete. Optional.
+	Responses *ResponseTracker
+	// Attribution is notified of scale downs to charge the time pools were active. Optional.
+	Attribution *attribution.Ledger
+	// Budget is notified of scale downs to charge the time pools were active to their activation budget. Optional.
+	Budget *ActivationBudget
+	// Elected is closed once this replica becomes the leader. The Deactivator only scales down from the
+	// leader when set. Optional.
+	Elected <-chan struct{}
+	// Claims is advanced to a new activation epoch after each scale down. Optional.
+	Claims *ActivationClaims
+	// IdleClock rebuilds the idle timer of the pool when this replica becomes the leader. Optional.
+	IdleClock *IdleClock
+	// Events is published the scale downs of the pool. Optional.
+	Events *EventBus
+	// KillSwitch disables the scale downs to zero while it is on. Optional.
+	KillSwitch *KillSwitch
+	// Slots rate limits the scale downs of the namespace of the pool, in deactivation priority order. Optional.
+	Slots *DeactivationSlots
+	// ExternalConfig fills in the activator annotations of the other pools of a pool group from their companion
+	// ConfigMap and the annotations of their target workload, as the reconciler does for the pool. Optional.
+	ExternalConfig bool
+	datastore      *datastore.Datastore
+	strategies     map[string]Strategy
+	idleness       map[string]IdlenessPredicate
+	coldStarts     coldStartCap
+
+	// idleCheckedAt is when the target of the pool was last found or left at zero replicas
+	idleCheckedAt time.Time
+	// dryRunPeriod is the idle period of the pool whose scale down was last reported in dry-run mode, if
+	// dryRunReported
+	dryRunPeriod   uint64
+	dryRunReported bool
 }
 
 func DeactivatorWithConfig(config *rest.Config, datastore *datastore.Datastore) (*Deactivator, error) {
@@ -40,19 +96,32 @@ func DeactivatorWithConfig(config *rest.Config, datastore *datastore.Datastore)
 		return nil, err
 	}
 
+	clients := StrategyClients{ScaleClient: scaleClient, DynamicClient: dynamicClient}
 	return &Deactivator{
 		datastore:     datastore,
 		DynamicClient: dynamicClient,
 		Mapper:        mapper,
-		ScaleClient:   scaleClient}, nil
+		ScaleClient:   scaleClient,
+		strategies:    newStrategies(clients),
+		idleness:      newIdlenessPredicates(clients)}, nil
 }
 
 func (da *Deactivator) MonitorInferencePoolIdleness(ctx context.Context) {
 	logger := log.FromContext(ctx)
 	ds := *(da.datastore)
 
+	if da.Elected != nil {
+		logger.Info("Deactivator waiting for leadership")
+		select {
+		case <-ctx.Done():
+			return
+		case <-da.Elected:
+		}
+	}
+
 	ds.ResetTicker(DefaultScaleDownDelay)
 	defer ds.StopTicker()
+	da.reconcileOnStartup(ctx)
 
 	ticker := ds.GetTicker()
 
@@ -71,6 +140,80 @@ func (da *Deactivator) MonitorInferencePoolIdleness(ctx context.Context) {
 				continue
 			}
 
+			// Scale downs are suspected of causing an outage
+			if da.KillSwitch != nil && da.KillSwitch.On() {
+				logger.V(logutil.DEBUG).Info("Kill switch is on, skipping scale down", "name", pool.Name, "namespace", pool.Namespace)
+				continue
+			}
+
+			// Leave the target of an inferencePool out of activator control to its operators
+			if !activatorEnabled(pool) {
+				logger.V(logutil.TRACE).Info("InferencePool is out of activator control, skipping scale down", "name", pool.Name, "namespace", pool.Namespace)
+				continue
+			}
+
+			// Keep the inferencePools with a floor of replicas warm
+			if floor := minReplicasFor(pool); floor > 0 {
+				logger.V(logutil.TRACE).Info("InferencePool has a min replicas floor, skipping scale down", "name", pool.Name, "namespace", pool.Namespace, "minReplicas", floor)
+				continue
+			}
+
+			// Leave the target of an inferencePool already scaled to zero alone, but for an occasional check
+			if da.knownIdle(time.Now()) {
+				logger.V(logutil.TRACE).Info("InferencePool is already scaled to zero, skipping scale down", "name", pool.Name, "namespace", pool.Namespace)
+				continue
+			}
+
+			// Keep the inferencePool up for its grace period once active, whatever its scale down delay
+			if left := da.gracePeriodLeft(pool, time.Now()); left > 0 {
+				logger.V(logutil.DEBUG).Info("InferencePool is within its scale to zero grace period, skipping scale down", "name", pool.Name, "namespace", pool.Namespace, "left", left)
+				ds.ResetTicker(max(left, minIdleTimer))
+				continue
+			}
+
+			// Long-running batch requests keep the inferencePool busy without new requests arriving
+			if da.Batches != nil && da.Batches.Busy(time.Now()) {
+				logger.V(logutil.DEBUG).Info("InferencePool has batch requests in progress, skipping scale down", "name", pool.Name, "namespace", pool.Namespace, "inFlight", da.Batches.InFlight())
+				continue
+			}
+
+			// Long-running generations keep the inferencePool busy until their response completed
+			if da.Responses != nil && da.Responses.Active() > 0 {
+				logger.V(logutil.DEBUG).Info("InferencePool has requests in progress, skipping scale down", "name", pool.Name, "namespace", pool.Namespace, "inFlight", da.Responses.Active())
+				continue
+			}
+
+			// Keep the inferencePool warm once it went through its cap of cold starts over the last hour
+			if until, capped := da.coldStarts.warmUntil(pool, time.Now()); capped {
+				logger.V(logutil.DEBUG).Info("InferencePool reached its cap of cold starts per hour, keeping it warm", "name", pool.Name, "namespace", pool.Namespace, "until", until)
+				continue
+			}
+
+			// Evaluate the idleness definition of the inferencePool
+			idle, err := poolIdle(ctx, da.idleness, pool)
+			if err != nil {
+				logger.Error(err, "Error evaluating inferencePool idleness", "name", pool.Name, "namespace", pool.Namespace)
+				continue
+			}
+			if !idle {
+				logger.V(logutil.DEBUG).Info("InferencePool is not idle", "name", pool.Name, "namespace", pool.Namespace, "idleness", poolconfig.For(pool).Idleness)
+				continue
+			}
+
+			// Keep the pools of a pool group up together until the whole group is idle
+			if wait := da.poolGroupBusy(ctx, logger, pool, time.Now()); wait > 0 {
+				ds.ResetTicker(max(wait, minIdleTimer))
+				continue
+			}
+
+			// Targets outside of Kubernetes have no scale subresource to check
+			if strategy, err := strategyFor(da.strategies, pool); err == nil {
+				if external, ok := strategy.(ExternalTarget); ok {
+					da.deactivateExternalTarget(ctx, pool, external)
+					continue
+				}
+			}
+
 			// Verify required inferencePool annotations
 			valid := VerifyPoolObjectAnnotations(logger, pool)
 			if !valid {
@@ -78,29 +221,253 @@ func (da *Deactivator) MonitorInferencePoolIdleness(ctx context.Context) {
 				continue
 			}
 
-			gvr, err := GetResourceForKind(da.Mapper, pool.Annotations[ObjectApiVersionKey], pool.Annotations[ObjectkindKey])
+			gvr, err := targetResourceFor(da.Mapper, pool)
 			if err != nil {
-				logger.Error(err, "Failed to parse Group, Version, Kind, Resource", "apiVersion", pool.Annotations[ObjectApiVersionKey], "kind", pool.Annotations[ObjectkindKey])
+				logger.Error(err, "Failed to parse Group, Version, Kind, Resource", "apiVersion", poolconfig.For(pool).Target.APIVersion, "kind", poolconfig.For(pool).Target.Kind)
 				continue
 			}
 
-			gr := gvr.GroupResource()
-
-			scaleObject, err := da.ScaleClient.Scales(pool.Namespace).Get(ctx, gr, pool.Annotations[ObjectNameKey], metav1.GetOptions{})
+			scaleObject, err := scaleOf(ctx, da.ScaleClient, da.DynamicClient, pool, gvr)
 			if err != nil {
 				logger.Error(err, "Error getting scale subresource object")
 				continue
 			}
+			if scaleObject.Spec.Replicas == 0 {
+				// Scale down the fallback target serving the pool instead, if any
+				if da.deactivateFallbackTargets(ctx, pool) {
+					continue
+				}
+				logger.V(logutil.TRACE).Info("Scale Object is already at zero replicas, skipping scale down", "name", scaleObject.Name)
+				da.observedIdle(time.Now())
+				continue
+			}
+
+			// Leave the replicas of a workload rolling out to its deployment strategy
+			if targetRollingOut(ctx, da.DynamicClient, gvr, pool.Namespace, poolconfig.For(pool).Target.Name) {
+				logger.V(logutil.DEBUG).Info("Scale Object is rolling out, pausing scale down", "name", scaleObject.Name)
+				continue
+			}
+
+			if da.DryRun {
+				da.reportDryRun(ctx, pool, gvr, scaleObject.Spec.Replicas)
+				continue
+			}
+
+			// Take a scale down slot of the namespace when its scale downs are rate limited
+			if !da.awaitDeactivationSlot(ctx, logger, pool) {
+				continue
+			}
+
+			// Never scale down while the inferencePool is being scaled up from zero
+			if !da.deactivating() {
+				logger.V(logutil.DEBUG).Info("InferencePool is scaling up from zero, skipping scale down", "name", pool.Name, "namespace", pool.Namespace)
+				continue
+			}
+
+			// Requests received since the idleness checks, e.g. while waiting for a scale down slot, keep the
+			// inferencePool up. No request is released to the inferencePool once it is being scaled down.
+			if da.Responses != nil && da.Responses.Active() > 0 {
+				logger.V(logutil.DEBUG).Info("InferencePool received requests, cancelling scale down", "name", pool.Name, "namespace", pool.Namespace, "inFlight", da.Responses.Active())
+				da.deactivated(false)
+				continue
+			}
 
 			// Scale inferencePool to zero replicas
-			scaleObject.Spec.Replicas = 0
-			_, err = da.ScaleClient.Scales(pool.Namespace).Update(ctx, gr, scaleObject, metav1.UpdateOptions{})
+			record := audit.Record{
+				Action: audit.ActionScaleDown,
+				Pool:   fmt.Sprintf("%s/%s", pool.Namespace, pool.Name),
+				Target: fmt.Sprintf("%s/%s", poolconfig.For(pool).Target.Kind, poolconfig.For(pool).Target.Name),
+			}
+			replicas := scaleObject.Spec.Replicas
+			clients := StrategyClients{ScaleClient: da.ScaleClient, DynamicClient: da.DynamicClient}
+			target := &ScaleTarget{Pool: pool, Resource: gvr, Scale: scaleObject}
+			record.ResourceVersionBefore = scaleObject.ResourceVersion
+			strategy, err := strategyFor(da.strategies, pool)
+			if err == nil {
+				err = strategy.ScaleDown(ctx, target)
+			}
+			if err == nil {
+				// Scale the additional and prefill targets down along the target workload, or bring it back if they fail to
+				_, err = scaleAdditionalTargets(ctx, logger, clients, da.Mapper, pool, 0)
+				if err != nil {
+					if restoreErr := strategy.ScaleUp(ctx, target, replicas); restoreErr != nil {
+						logger.Error(restoreErr, "Error restoring the replicas of the Scale Object")
+					}
+				}
+			}
+			recordResourceVersionAfter(ctx, clients, &record, target)
+			da.deactivated(err == nil)
 			if err != nil {
 				logger.Error(err, "InferencePool was not successfully scale down to zero replica")
+				record.Outcome, record.Message = audit.OutcomeFailed, err.Error()
+				audit.Log(record)
 				continue
 			}
+			record.Outcome, record.Message = audit.OutcomeSucceeded, "inferencePool idle for the scale down delay"
+			audit.Log(record)
+			da.Events.publishRecord(ScaleDownExecuted, record, 0)
+			da.coldStarts.record(time.Now())
+			if da.Attribution != nil {
+				da.Attribution.Deactivated(record.Pool, time.Now())
+			}
+			if da.Budget != nil {
+				da.Budget.Deactivated(time.Now())
+			}
+			da.advanceEpoch(ctx, pool)
+
+			// Release the capacity reserved for the target workload and its activation placement, if any
+			if target, err := da.DynamicClient.Resource(gvr).Namespace(pool.Namespace).Get(ctx, poolconfig.For(pool).Target.Name, metav1.GetOptions{}); err == nil {
+				if err := releaseCapacity(ctx, da.DynamicClient, target); err != nil {
+					logger.Error(err, "Error releasing capacity reservation")
+				}
+				// Hand the placement of the workload back to regular autoscaling
+				if err := removePlacement(ctx, da.DynamicClient, gvr, target); err != nil {
+					logger.Error(err, "Error removing activation placement")
+				}
+			}
 
 			logger.V(logutil.DEBUG).Info(fmt.Sprintf("InferencePool '%s' was successfully scale down to zero replica", pool.Name))
 		}
 	}
 }
+
+// deactivateExternalTarget takes the external target of the given idle inferencePool out of service.
+func (da *Deactivator) deactivateExternalTarget(ctx context.Context, pool *v1.InferencePool, target ExternalTarget) {
+	logger := log.FromContext(ctx)
+	record := audit.Record{
+		Action: audit.ActionScaleDown,
+		Pool:   fmt.Sprintf("%s/%s", pool.Namespace, pool.Name),
+		Target: fmt.Sprintf("%s/%s", poolconfig.For(pool).Strategy, poolconfig.For(pool).ManagedEndpoint),
+	}
+
+	if ready, err := target.Ready(ctx, pool); err == nil && !ready {
+		logger.V(logutil.DEBUG).Info("External target is already out of service", "pool", record.Pool)
+		return
+	}
+
+	if da.DryRun {
+		if da.dryRunReportedFor(da.idlePeriod()) {
+			logger.V(logutil.TRACE).Info("Dry-run: scale down of the idle period already reported", "pool", record.Pool)
+			return
+		}
+		record.Outcome, record.Message = audit.OutcomeDryRun, "Dry-run: external target would have been taken out of service"
+		logger.Info(record.Message, "pool", record.Pool)
+		audit.Log(record)
+		return
+	}
+
+	if !da.deactivating() {
+		logger.V(logutil.DEBUG).Info("External target is being activated, skipping scale down", "pool", record.Pool)
+		return
+	}
+	err := target.ScaleDown(ctx, &ScaleTarget{Pool: pool})
+	da.deactivated(err == nil)
+	if err != nil {
+		logger.Error(err, "External target was not successfully taken out of service")
+		record.Outcome, record.Message = audit.OutcomeFailed, err.Error()
+		audit.Log(record)
+		return
+	}
+	record.Outcome, record.Message = audit.OutcomeSucceeded, "inferencePool idle for the scale down delay"
+	audit.Log(record)
+	da.Events.publishRecord(ScaleDownExecuted, record, 0)
+	da.coldStarts.record(time.Now())
+	if da.Attribution != nil {
+		da.Attribution.Deactivated(record.Pool, time.Now())
+	}
+	if da.Budget != nil {
+		da.Budget.Deactivated(time.Now())
+	}
+	da.advanceEpoch(ctx, pool)
+}
+
+// advanceEpoch starts a new activation epoch of the given pool after it was scaled down, if claims are enabled.
+func (da *Deactivator) advanceEpoch(ctx context.Context, pool *v1.InferencePool) {
+	if da.Claims == nil {
+		return
+	}
+	if err := da.Claims.Advance(ctx, pool); err != nil {
+		log.FromContext(ctx).Error(err, "Error advancing the activation epoch of the inferencePool")
+	}
+}
+
+// reportDryRun reports the scale down that would have been applied to the given idle inferencePool,
+// together with the capacity it would have freed, without modifying the target workload. The pool staying
+// idle over the next ticks of the deactivator, its scale down is reported once per idle period.
+func (da *Deactivator) reportDryRun(ctx context.Context, pool *v1.InferencePool, gvr schema.GroupVersionResource, replicas int32) {
+	logger := log.FromContext(ctx)
+	poolName := fmt.Sprintf("%s/%s", pool.Namespace, pool.Name)
+	target := fmt.Sprintf("%s/%s", poolconfig.For(pool).Target.Kind, poolconfig.For(pool).Target.Name)
+
+	if da.dryRunReportedFor(da.idlePeriod()) {
+		logger.V(logutil.TRACE).Info("Dry-run: scale down of the idle period already reported", "pool", poolName)
+		return
+	}
+
+	var accelerators int64
+	if obj, err := da.DynamicClient.Resource(gvr).Namespace(pool.Namespace).Get(ctx, poolconfig.For(pool).Target.Name, metav1.GetOptions{}); err != nil {
+		logger.V(logutil.DEBUG).Error(err, "Dry-run: failed to get target object, reporting replicas only", "pool", poolName)
+	} else {
+		accelerators = acceleratorsPerReplica(obj) * int64(replicas)
+	}
+
+	message := fmt.Sprintf("Dry-run: %s would have been scaled down from %d replicas to zero, freeing %d accelerators", target, replicas, accelerators)
+	logger.Info(message, "pool", poolName)
+	metrics.RecordDeactivationDryRun(poolName, replicas, accelerators)
+	audit.Log(audit.Record{
+		Action:   audit.ActionScaleDown,
+		Outcome:  audit.OutcomeDryRun,
+		Pool:     poolName,
+		Target:   target,
+		Replicas: replicas,
+		Message:  message,
+	})
+	if da.Recorder != nil {
+		da.Recorder.Event(pool, corev1.EventTypeNormal, "ScaleDownDryRun", message)
+	}
+	if da.DryRunReport {
+		if err := da.writeDeactivationReport(ctx, pool, target, replicas, accelerators, time.Now()); err != nil {
+			logger.Error(err, "Dry-run: failed to write the deactivation report", "pool", poolName)
+		}
+	}
+}
+
+// idlePeriod identifies the current idle period of the pool, zero when responses are not tracked.
+func (da *Deactivator) idlePeriod() uint64 {
+	if da.Responses == nil {
+		return 0
+	}
+	return da.Responses.IdlePeriod()
+}
+
+// dryRunReportedFor reports whether the scale down of the given idle period was already reported in dry-run
+// mode, recording it as reported otherwise.
+func (da *Deactivator) dryRunReportedFor(period uint64) bool {
+	if da.dryRunReported && da.dryRunPeriod == period {
+		return true
+	}
+	da.dryRunReported, da.dryRunPeriod = true, period
+	return false
+}
+
+// writeDeactivationReport records the given dry-run scale down in the DeactivationReport named after the pool.
+func (da *Deactivator) writeDeactivationReport(ctx context.Context, pool *v1.InferencePool, target string, replicas int32, accelerators int64, now time.Time) error {
+	report := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": activatorv1alpha1.SchemeGroupVersion.String(),
+		"kind":       "DeactivationReport",
+		"metadata":   map[string]any{"name": pool.Name, "namespace": pool.Namespace},
+	}}
+	reports := da.DynamicClient.Resource(deactivationReportGVR).Namespace(pool.Namespace)
+	options := metav1.ApplyOptions{FieldManager: DeactivationReportFieldManager, Force: true}
+	if _, err := reports.Apply(ctx, pool.Name, report, options); err != nil {
+		return err
+	}
+	report.Object["status"] = map[string]any{
+		"target":         target,
+		"replicas":       int64(replicas),
+		"accelerators":   accelerators,
+		"lastReportTime": now.UTC().Format(time.RFC3339),
+	}
+	_, err := reports.ApplyStatus(ctx, pool.Name, report, options)
+	return err
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	autoscaling "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	fakescale "k8s.io/client-go/scale/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestDeactivationDryRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	metrics.Register()
+
+	pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "dry-run", Namespace: "default", Annotations: map[string]string{
+		ObjectApiVersionKey: "apps/v1",
+		ObjectkindKey:       "Deployment",
+		ObjectNameKey:       "llama",
+		ScaleDownDelayKey:   "10ms",
+	}}}
+	deployment := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "llama", "namespace": "default"},
+		"spec": map[string]any{
+			"replicas": int64(2),
+			"template": map[string]any{"spec": map[string]any{"containers": []any{
+				map[string]any{"name": "vllm", "resources": map[string]any{"limits": map[string]any{"nvidia.com/gpu": "4"}}},
+			}}},
+		},
+	}}
+
+	// Every tick of the deactivator reads the scale subresource of the target
+	ticks := make(chan struct{})
+	scaleClient := &fakescale.FakeScaleClient{}
+	scaleClient.AddReactor("get", "deployments", func(clienttesting.Action) (bool, runtime.Object, error) {
+		select {
+		case ticks <- struct{}{}:
+		case <-ctx.Done():
+		}
+		return true, &autoscaling.Scale{ObjectMeta: metav1.ObjectMeta{Name: "llama"}, Spec: autoscaling.ScaleSpec{Replicas: 2}}, nil
+	})
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), deployment)
+	var reports []map[string]any
+	dynamicClient.PrependReactor("patch", "deactivationreports", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() == "status" {
+			report := &unstructured.Unstructured{}
+			if err := report.UnmarshalJSON(action.(clienttesting.PatchAction).GetPatch()); err != nil {
+				t.Errorf("invalid deactivation report: %v", err)
+			}
+			status, _, _ := unstructured.NestedMap(report.Object, "status")
+			reports = append(reports, status)
+		}
+		return true, &unstructured.Unstructured{}, nil
+	})
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	ds := datastore.NewDatastore(ctx)
+	ds.PoolSet(pool)
+	clients := StrategyClients{ScaleClient: scaleClient, DynamicClient: dynamicClient}
+	recorder := record.NewFakeRecorder(10)
+	da := &Deactivator{
+		DynamicClient: dynamicClient,
+		ScaleClient:   scaleClient,
+		Mapper:        mapper,
+		Recorder:      recorder,
+		DryRun:        true,
+		DryRunReport:  true,
+		Responses:     NewResponseTracker(),
+		datastore:     &ds,
+		strategies:    newStrategies(clients),
+		idleness:      newIdlenessPredicates(clients),
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		da.MonitorInferencePoolIdleness(ctx)
+	}()
+
+	awaitEvent := func() string {
+		t.Helper()
+		for {
+			select {
+			case event := <-recorder.Events:
+				if strings.Contains(event, "ScaleDownDryRun") {
+					return event
+				}
+			case <-ticks:
+			case <-time.After(10 * time.Second):
+				t.Fatal("no dry-run scale down reported")
+			}
+		}
+	}
+	awaitTicks := func(n int) {
+		t.Helper()
+		for range n {
+			<-ticks
+		}
+	}
+
+	if event := awaitEvent(); !strings.Contains(event, "freeing 8 accelerators") {
+		t.Errorf("event = %q, want a dry-run scale down freeing 8 accelerators", event)
+	}
+	// The pool staying idle over the next ticks is not reported again
+	awaitTicks(3)
+	for len(recorder.Events) > 0 {
+		if event := <-recorder.Events; strings.Contains(event, "ScaleDownDryRun") {
+			t.Errorf("dry-run scale down reported again within the same idle period: %q", event)
+		}
+	}
+	// A completed response starts a new idle period
+	da.Responses.Begin()
+	da.Responses.End()
+	awaitEvent()
+	cancel()
+	<-done
+
+	for _, action := range append(scaleClient.Actions(), dynamicClient.Actions()...) {
+		if verb := action.GetVerb(); (verb == "patch" || verb == "update") && action.GetResource().Resource != "deactivationreports" {
+			t.Errorf("dry run issued a %s of %s, want the target left unchanged", verb, action.GetResource().Resource)
+		}
+	}
+	if len(reports) != 2 {
+		t.Fatalf("deactivation reports written = %d, want 2", len(reports))
+	}
+	if reports[0]["target"] != "Deployment/llama" || reports[0]["replicas"] != int64(2) || reports[0]["accelerators"] != int64(8) {
+		t.Errorf("deactivation report = %v, want 2 replicas of Deployment/llama freeing 8 accelerators", reports[0])
+	}
+	if got := dryRunTotal(t, "default/dry-run"); got != 2 {
+		t.Errorf("dry-run scale downs recorded = %v, want 2", got)
+	}
+}
+
+// dryRunTotal returns the number of dry-run scale downs recorded for the given pool.
+func dryRunTotal(t *testing.T, pool string) float64 {
+	t.Helper()
+	families, err := ctrlmetrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather the metrics: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "activator_deactivation_dry_run_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "pool" && label.GetValue() == pool {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
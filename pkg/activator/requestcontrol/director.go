@@ -0,0 +1,394 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/attribution"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/handlers"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metadata"
+	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+	requtil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/request"
+)
+
+// NewDirectorWithConfig creates a new Director instance with all dependencies.
+func NewDirectorWithConfig(datastore datastore.Datastore, activator *Activator, config *PipelineConfig) *Director {
+	return &Director{
+		datastore: datastore,
+		activator: activator,
+		config:    config,
+	}
+}
+
+// Director orchestrates the request handling flow, running the requests through the pipeline of the pool.
+//
+// An activator serves the single InferencePool of its datastore, and the activation state of its Activator, e.g.
+// the scale up in progress, is that of this one pool. The pipeline bounds the requests held for the pool and
+// contains their panics, but pools are only isolated from each other by running one activator per pool, as
+// deployed by the activator chart.
+type Director struct {
+	datastore datastore.Datastore
+	activator *Activator
+	config    *PipelineConfig
+	// Batches tracks the long-running batch requests of the pool. Optional.
+	Batches *BatchTracker
+	// Responses tracks the requests released to the pool until their response completed. Optional.
+	Responses *ResponseTracker
+	// AttributionHeader is the lower-cased name of the request header carrying the API key or team
+	// that activations are attributed to, by a digest of its value. Optional.
+	AttributionHeader string
+	// Retries recognizes retries of failed requests to prioritize them in the pool pipeline. Optional.
+	Retries *RetryTracker
+	// Cache answers repeated cacheable requests while the pool is cold. Optional.
+	Cache *ResponseCache
+	// Overload sheds new requests while the activator itself is overloaded. Optional.
+	Overload *OverloadMonitor
+	// Gateways enforces the rate limits of the gateways sharing the activator. Optional.
+	Gateways *GatewayLimiter
+	// Bypass lets the requests of trusted internal clients skip the pool pipeline while the pool is warm. Optional.
+	Bypass *Bypass
+	// Exclusions recognizes monitoring requests, which neither activate the pool nor keep it warm. Optional.
+	Exclusions *Exclusions
+	// BenchmarkPassthrough lets every request through without activation, recording the decision the
+	// activator would have made, unless the pool overrides it with its benchmark passthrough annotation.
+	BenchmarkPassthrough bool
+	// DebugBypass lets the requests carrying the debug bypass header through without activation check, to debug
+	// the data path.
+	DebugBypass bool
+	// PoolStateMetadata emits the activation state of the pool and the number of requests held for its
+	// activation, as found by each request, as dynamic metadata for Envoy filters to react to.
+	PoolStateMetadata bool
+
+	// deferred holds the context of the cacheable requests whose activation waits for their body
+	deferred sync.Map
+	// backgroundActivation is set while a deferred request activates the pool in the background
+	backgroundActivation atomic.Bool
+
+	// pipeline is the pipeline of the pool, created on first use and guarded by pipelineMu
+	pipelineMu sync.Mutex
+	pipeline   *pipeline
+}
+
+// HandleRequest resolves the InferencePool of the request and runs the request through
+// the pipeline of that pool.
+func (d *Director) HandleRequest(ctx context.Context, reqCtx *handlers.RequestContext) (*handlers.RequestContext, error) {
+	logger := log.FromContext(ctx)
+
+	pool, err := d.datastore.PoolGet()
+	if err != nil {
+		return reqCtx, err
+	}
+	if d.PoolStateMetadata {
+		reqCtx.PoolState, reqCtx.QueueDepth = d.activator.poolStateSignal()
+	}
+
+	if reqCtx.Gateway != "" {
+		logger = logger.WithValues("gateway", reqCtx.Gateway)
+		ctx = log.IntoContext(withGateway(ctx, reqCtx.Gateway), logger)
+	}
+	// Pools out of activator control and debugged requests skip the activation check entirely
+	if reason, ok := unmanagedReason(pool, reqCtx.Request.Headers, d.DebugBypass); ok {
+		logger.V(logutil.TRACE).Info("Request let through without activation check", "reason", reason)
+		metrics.RecordUnmanagedRequest(pool.Namespace+"/"+pool.Name, reason)
+		d.admit(reqCtx)
+		return reqCtx, nil
+	}
+	// Benchmark mode measures the gateway without the activation logic, which is only simulated
+	if now := time.Now(); benchmarkPassthrough(pool, d.BenchmarkPassthrough) {
+		poolName := pool.Namespace + "/" + pool.Name
+		decision := d.activator.simulatedDecision(now)
+		logger.V(logutil.TRACE).Info("Request passed through in benchmark mode", "decision", decision)
+		metrics.RecordRequestCounter(poolName, reqCtx.Gateway)
+		metrics.RecordBenchmarkPassthrough(poolName, decision)
+		d.activator.keepWarm(pool, now)
+		d.admit(reqCtx)
+		return reqCtx, nil
+	}
+	if d.Gateways != nil && !d.Gateways.Allow(reqCtx.Gateway, time.Now()) {
+		logger.V(logutil.DEBUG).Info("Gateway rate limit exceeded, rejecting request")
+		metrics.RecordGatewayRateLimited(pool.Namespace+"/"+pool.Name, reqCtx.Gateway)
+		reqCtx.RateLimited = true
+		return reqCtx, errutil.Error{Code: errutil.InferencePoolResourceExhausted, Msg: fmt.Sprintf("rate limit of gateway %s exceeded, retry later", reqCtx.Gateway)}
+	}
+
+	// Monitoring requests are let through while the pool is warm, without refreshing its idleness
+	if d.Exclusions != nil && d.Exclusions.Matches(reqCtx.Request.Headers) {
+		poolName := pool.Namespace + "/" + pool.Name
+		if d.activator.KnownWarm(time.Now()) || !d.activator.IsCold(ctx, pool) {
+			logger.V(logutil.TRACE).Info("Excluded request passed without keeping the pool warm")
+			metrics.RecordExcludedRequest(poolName, "passed")
+			return reqCtx, nil
+		}
+		logger.V(logutil.DEBUG).Info("Excluded request rejected while the pool is cold")
+		metrics.RecordExcludedRequest(poolName, "rejected")
+		return reqCtx, errutil.Error{Code: errutil.ServiceUnavailable, Msg: fmt.Sprintf("inferencePool %s is scaled to zero, excluded requests do not activate it", pool.Name)}
+	}
+
+	// Trusted internal clients skip model parsing and queueing, and are not rewritten for model aliases
+	if now := time.Now(); d.Bypass != nil && d.activator.KnownWarm(now) && d.Bypass.Authorized(reqCtx.Request.Headers, now) {
+		logger.V(logutil.TRACE).Info("Trusted request bypassing the activator")
+		metrics.RecordBypassedRequest(pool.Namespace + "/" + pool.Name)
+		d.activator.keepWarm(pool, now)
+		d.admit(reqCtx)
+		return reqCtx, nil
+	}
+
+	requestID := reqCtx.Request.Headers[requtil.RequestIdHeaderKey]
+	if requestID != "" {
+		logger = logger.WithValues(requtil.RequestIdHeaderKey, requestID)
+		ctx = log.IntoContext(withRequestID(ctx, requestID), logger)
+	}
+	ctx = withTraceContext(ctx, reqCtx.Request.Headers)
+	if traceID := traceIDFromContext(ctx); traceID != "" {
+		logger = logger.WithValues("trace-id", traceID)
+		ctx = log.IntoContext(ctx, logger)
+	}
+	if modelName := reqCtx.Request.Headers[handlers.ModelNameHeaderKey]; modelName != "" {
+		if model, aliased := resolveModelAlias(pool, modelName); aliased {
+			logger.V(logutil.DEBUG).Info("Model alias requested, rewriting model", "alias", modelName, "model", model)
+			reqCtx.ModelRewrite = model
+			modelName = model
+		}
+		ctx = withModelName(ctx, modelName)
+	}
+	if objective := reqCtx.Request.Headers[metadata.ObjectiveKey]; objective != "" {
+		ctx = withObjective(ctx, objective)
+	}
+
+	if d.AttributionHeader != "" {
+		ctx = withAttributionKey(ctx, attribution.KeyOf(reqCtx.Request.Headers[d.AttributionHeader]))
+	}
+	if d.Batches != nil && d.Batches.Matches(reqCtx.Request.Headers) {
+		logger.V(logutil.DEBUG).Info("Batch request received")
+		reqCtx.Batch = true
+		d.Batches.Begin()
+	}
+
+	if d.Retries != nil && d.Retries.IsRetry(reqCtx.Request.Headers, time.Now()) {
+		logger.V(logutil.DEBUG).Info("Retry of a failed request received")
+		ctx = withRetry(ctx)
+	}
+
+	// Clients of routes reporting the activation progress may prefer the progress of the scale from zero to
+	// being held for it, asking again until the pool is ready
+	if reqCtx.ActivationProgress && handlers.PrefersRespondAsync(reqCtx.Request.Headers) && !d.activator.KnownWarm(time.Now()) && d.activator.IsCold(ctx, pool) {
+		logger.V(logutil.DEBUG).Info("Request preferring an asynchronous response received while the pool is cold, reporting its warm up")
+		d.activateInBackground(ctx)
+		reqCtx.WarmingUp = d.activator.warmingUp(pool, time.Now())
+		return reqCtx, errutil.Error{Code: errutil.ServiceUnavailable, Msg: fmt.Sprintf("inferencePool %s is warming up, retry later", pool.Name)}
+	}
+
+	if d.Cache != nil && d.Cache.Matches(reqCtx.Request.Headers) {
+		reqCtx.Cacheable = true
+		if d.activator.IsCold(ctx, pool) {
+			logger.V(logutil.DEBUG).Info("Cacheable request received while the pool is cold, deferring it until its body is received")
+			reqCtx.ServeStale = true
+			d.deferred.Store(reqCtx, ctx)
+			d.activateInBackground(ctx)
+			return reqCtx, nil
+		}
+	}
+
+	return reqCtx, d.activate(ctx, reqCtx, pool)
+}
+
+// activate runs the request through the pipeline of its pool, holding it until the pool is active.
+func (d *Director) activate(ctx context.Context, reqCtx *handlers.RequestContext, pool *v1.InferencePool) error {
+	logger := log.FromContext(ctx)
+	entered := time.Now()
+	p := d.getOrCreatePipeline(pool)
+
+	if d.Overload != nil && d.Overload.Overloaded() {
+		logger.V(logutil.DEBUG).Info("Activator overloaded, shedding request", "pool", p.name)
+		metrics.RecordOverloadRejected(p.name)
+		reqCtx.Overloaded = true
+		return errutil.Error{Code: errutil.InferencePoolResourceExhausted, Msg: "activator overloaded, retry later"}
+	}
+
+	// Keep the pool from being scaled down from the arrival of the request until its response completed
+	if d.Responses != nil {
+		d.Responses.Arrive()
+		defer d.Responses.Depart()
+	}
+
+	// Stop holding the request once its gateway timeout expired, the scale up carrying on without it
+	if timeout, ok := handlers.RequestTimeout(reqCtx.Request.Headers); ok && !reqCtx.RequestReceivedTimestamp.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, reqCtx.RequestReceivedTimestamp.Add(timeout))
+		defer cancel()
+	}
+
+	logger.V(logutil.TRACE).Info("Dispatching request to pool pipeline", "pool", p.name)
+
+	state := &activationState{}
+	err := p.handle(withActivationState(ctx, state), func(ctx context.Context) error {
+		start := time.Now()
+		coldStart, err := d.activator.MayActivate(ctx)
+		end := time.Now()
+		reqCtx.ColdStart = coldStart
+		reqCtx.ActivationWait = end.Sub(start)
+		if coldStart && err == nil {
+			reqCtx.ActivationStages = d.activator.timeline.stages(reqCtx.RequestReceivedTimestamp, entered, start, end)
+		}
+		return err
+	})
+	for _, stage := range reqCtx.ActivationStages {
+		metrics.RecordColdStartStage(p.name, stage.Stage, stage.Duration)
+	}
+	if len(reqCtx.ActivationStages) > 0 {
+		logger.V(logutil.DEBUG).Info("Cold start latency breakdown", "stages", reqCtx.ActivationStages)
+	}
+	reqCtx.QueuedForCapacity = state.queuedForCapacity
+	reqCtx.FailureReason = string(state.reason)
+	reqCtx.EndpointSubset = state.endpointSubset
+	// Requests rejected by the activator, or abandoned by the client while held, are likely to be retried
+	if d.Retries != nil && (err != nil || ctx.Err() != nil) {
+		d.Retries.RecordFailure(reqCtx.Request.Headers, time.Now())
+	}
+	if err == errPoolDeleted {
+		d.deletePipeline(p)
+	}
+	if err == nil {
+		d.admit(reqCtx)
+	}
+	return err
+}
+
+// admit records the release of the request to the pool, until its completion.
+func (d *Director) admit(reqCtx *handlers.RequestContext) {
+	reqCtx.Admitted = true
+	if d.Responses != nil {
+		d.Responses.Begin()
+	}
+}
+
+// activateInBackground scales the cold pool up on behalf of the deferred cacheable requests, which may
+// be answered from the response cache without waiting for the activation.
+func (d *Director) activateInBackground(ctx context.Context) {
+	if !d.backgroundActivation.CompareAndSwap(false, true) {
+		return
+	}
+	pool, err := d.datastore.PoolGet()
+	if err != nil {
+		d.backgroundActivation.Store(false)
+		return
+	}
+	p := d.getOrCreatePipeline(pool)
+	go func() {
+		defer d.backgroundActivation.Store(false)
+		// The pipeline contains the panics of the activation and counts its failures
+		err := p.handle(context.WithoutCancel(ctx), func(ctx context.Context) error {
+			_, err := d.activator.MayActivate(ctx)
+			return err
+		})
+		if err != nil {
+			log.FromContext(ctx).V(logutil.DEBUG).Info("Background activation failed", "error", err.Error())
+		}
+	}()
+}
+
+// HandleRequestBody answers deferred cacheable requests from the response cache, and activates the pool
+// for the ones that are not cached.
+func (d *Director) HandleRequestBody(ctx context.Context, reqCtx *handlers.RequestContext, body []byte) (*handlers.CachedResponse, error) {
+	if d.Cache == nil {
+		return nil, nil
+	}
+	reqCtx.CacheKey = d.Cache.Key(reqCtx.Request.Headers[PathHeaderKey], body)
+	if !reqCtx.ServeStale {
+		return nil, nil
+	}
+	reqCtx.ServeStale = false
+
+	if deferred, ok := d.deferred.LoadAndDelete(reqCtx); ok {
+		ctx = deferred.(context.Context)
+	}
+	pool, err := d.datastore.PoolGet()
+	if err != nil {
+		return nil, err
+	}
+	if cached, ok := d.Cache.Get(reqCtx.CacheKey, time.Now()); ok {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Answering request from the response cache while the pool is cold")
+		metrics.RecordResponseCacheHit(pool.Namespace + "/" + pool.Name)
+		return cached, nil
+	}
+	return nil, d.activate(ctx, reqCtx, pool)
+}
+
+// HandleResponseBody stores the successful responses of cacheable requests in the response cache.
+func (d *Director) HandleResponseBody(ctx context.Context, reqCtx *handlers.RequestContext, body []byte) {
+	if d.Cache == nil || reqCtx.CacheKey == "" || reqCtx.Response.Headers[":status"] != "200" {
+		return
+	}
+	d.Cache.Put(reqCtx.CacheKey, &handlers.CachedResponse{
+		ContentType: reqCtx.Response.Headers["content-type"],
+		Body:        body,
+		StoredAt:    time.Now(),
+	})
+}
+
+// HandleRequestCompletion records the completion of the requests released to the pool, which restarts the idle
+// period of the pool so that long-running generations keep it warm until they end, and forgets deferred
+// requests that never sent their body.
+func (d *Director) HandleRequestCompletion(ctx context.Context, reqCtx *handlers.RequestContext) {
+	d.deferred.Delete(reqCtx)
+	if reqCtx.Admitted {
+		if d.Responses != nil {
+			d.Responses.End()
+		}
+		if pool, err := d.datastore.PoolGet(); err == nil {
+			d.activator.keepWarm(pool, time.Now())
+		}
+	}
+	if !reqCtx.Batch {
+		return
+	}
+	d.Batches.End(time.Now())
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Batch request completed", "duration", time.Since(reqCtx.RequestReceivedTimestamp))
+}
+
+// getOrCreatePipeline returns the pipeline of the given pool, creating it on first use.
+func (d *Director) getOrCreatePipeline(pool *v1.InferencePool) *pipeline {
+	d.pipelineMu.Lock()
+	defer d.pipelineMu.Unlock()
+
+	if d.pipeline == nil {
+		d.pipeline = newPipeline(pool.Namespace+"/"+pool.Name, d.config)
+	}
+	return d.pipeline
+}
+
+// deletePipeline forgets the given pipeline of the deleted pool, so that a pool created again with the same
+// name starts with an empty queue and a closed circuit breaker.
+func (d *Director) deletePipeline(p *pipeline) {
+	d.pipelineMu.Lock()
+	defer d.pipelineMu.Unlock()
+
+	if d.pipeline == p {
+		d.pipeline = nil
+	}
+}
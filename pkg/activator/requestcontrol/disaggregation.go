@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"errors"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+const (
+	// PrefillTargetKey is the name of the prefill workload of pools disaggregating prefill and decode, the
+	// target workload of the pool being the decode workload. The prefill workload has the apiVersion and kind
+	// of the target workload. It is scaled up from zero along the target workload, in the prefill to decode
+	// ratio of the pool, and down to zero with it, the requests held being released once both are ready.
+	PrefillTargetKey = poolconfig.PrefillTargetKey // Optional annotation
+
+	// PrefillDecodeRatioKey is the ratio of prefill to decode replicas of the pools setting a prefill target,
+	// e.g. "1:2" for a prefill replica per two decode replicas. The prefill replicas are rounded up. Defaults
+	// to DefaultPrefillDecodeRatio.
+	PrefillDecodeRatioKey = poolconfig.PrefillDecodeRatioKey // Optional annotation
+
+	// DefaultPrefillDecodeRatio scales pools up from zero to as many prefill as decode replicas
+	DefaultPrefillDecodeRatio = poolconfig.DefaultPrefillDecodeRatio
+)
+
+// prefillTargetFor returns the prefill workload of the given pool scaled along the given decode replicas,
+// if the pool sets one.
+func prefillTargetFor(pool *v1.InferencePool, decodeReplicas int32) (*AdditionalTarget, error) {
+	config := poolconfig.For(pool)
+	if err := errors.Join(config.ErrOf(PrefillTargetKey), config.ErrOf(PrefillDecodeRatioKey)); err != nil {
+		return nil, err
+	}
+	if config.PrefillTarget == "" {
+		return nil, nil
+	}
+	ratio := config.PrefillDecodeRatio
+	replicas := max((decodeReplicas*ratio.Prefill+ratio.Decode-1)/ratio.Decode, 1)
+	return &AdditionalTarget{
+		APIVersion: config.Target.APIVersion,
+		Kind:       config.Target.Kind,
+		Name:       config.PrefillTarget,
+		Replicas:   &replicas,
+	}, nil
+}
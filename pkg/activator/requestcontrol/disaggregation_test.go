@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestPrefillTargetFor(t *testing.T) {
+	tests := []struct {
+		name           string
+		prefill        string
+		ratio          string
+		decodeReplicas int32
+		wantReplicas   int32
+		wantErr        bool
+	}{
+		{name: "default ratio", prefill: "prefill", decodeReplicas: 2, wantReplicas: 2},
+		{name: "fewer prefill replicas rounded up", prefill: "prefill", ratio: "1:2", decodeReplicas: 3, wantReplicas: 2},
+		{name: "at least one prefill replica", prefill: "prefill", ratio: "1:4", decodeReplicas: 1, wantReplicas: 1},
+		{name: "more prefill replicas", prefill: "prefill", ratio: "3:1", decodeReplicas: 2, wantReplicas: 6},
+		{name: "no prefill target"},
+		{name: "prefill target is the target", prefill: "decode", wantErr: true},
+		{name: "invalid ratio", prefill: "prefill", ratio: "2", wantErr: true},
+		{name: "zero ratio", prefill: "prefill", ratio: "0:1", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			annotations := map[string]string{ObjectApiVersionKey: "apps/v1", ObjectkindKey: "Deployment", ObjectNameKey: "decode"}
+			if test.prefill != "" {
+				annotations[PrefillTargetKey] = test.prefill
+			}
+			if test.ratio != "" {
+				annotations[PrefillDecodeRatioKey] = test.ratio
+			}
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Annotations: annotations}}
+
+			target, err := prefillTargetFor(pool, test.decodeReplicas)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("prefillTargetFor() error = %v, wantErr %t", err, test.wantErr)
+			}
+			if test.prefill == "" || test.wantErr {
+				if target != nil {
+					t.Errorf("prefillTargetFor() = %v, want none", target)
+				}
+				return
+			}
+			if target.Kind != "Deployment" || target.Name != test.prefill || *target.Replicas != test.wantReplicas {
+				t.Errorf("prefillTargetFor() = %s/%s with %d replicas, want Deployment/%s with %d replicas",
+					target.Kind, target.Name, *target.Replicas, test.prefill, test.wantReplicas)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// discoverableKinds are the workloads the target of a pool is discovered among, in order.
+var discoverableKinds = []struct {
+	kind string
+	gvr  schema.GroupVersionResource
+}{
+	{kind: "Deployment", gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}},
+	{kind: "StatefulSet", gvr: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}},
+}
+
+// DiscoverTarget sets the target annotations of the given pool when it sets none, to the Deployment or
+// StatefulSet of its namespace whose pod template matches the selector of the pool. The workload is looked up
+// by its pod template rather than by its pods, which do not exist while the pool is scaled to zero. Pools
+// setting the target annotations are left alone, the annotations overriding the discovery.
+func (a *Activator) DiscoverTarget(ctx context.Context, pool *v1.InferencePool) error {
+	config := poolconfig.Parse(pool)
+	if len(config.MissingTarget) != 3 || len(pool.Spec.Selector.MatchLabels) == 0 {
+		return nil
+	}
+
+	target, err := discoverTarget(ctx, a.DynamicClient, pool)
+	if err != nil {
+		return err
+	}
+	if pool.Annotations == nil {
+		pool.Annotations = map[string]string{}
+	}
+	pool.Annotations[ObjectApiVersionKey] = target.APIVersion
+	pool.Annotations[ObjectkindKey] = target.Kind
+	pool.Annotations[ObjectNameKey] = target.Name
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Discovered the target of the inferencePool", "kind", target.Kind, "name", target.Name)
+	return nil
+}
+
+// discoverTarget returns the only workload of the namespace of the given pool whose pod template matches the
+// selector of the pool.
+func discoverTarget(ctx context.Context, client dynamic.Interface, pool *v1.InferencePool) (poolconfig.Target, error) {
+	selector := make(labels.Set, len(pool.Spec.Selector.MatchLabels))
+	for k, v := range pool.Spec.Selector.MatchLabels {
+		selector[string(k)] = string(v)
+	}
+
+	var targets []poolconfig.Target
+	for _, candidate := range discoverableKinds {
+		list, err := client.Resource(candidate.gvr).Namespace(pool.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return poolconfig.Target{}, fmt.Errorf("failed to list the %ss of namespace %s to discover the target of inferencePool %s: %w", strings.ToLower(candidate.kind), pool.Namespace, pool.Name, err)
+		}
+		for i := range list.Items {
+			templateLabels, _, _ := unstructured.NestedStringMap(list.Items[i].Object, "spec", "template", "metadata", "labels")
+			if labels.SelectorFromSet(selector).Matches(labels.Set(templateLabels)) {
+				targets = append(targets, poolconfig.Target{
+					APIVersion: candidate.gvr.GroupVersion().String(),
+					Kind:       candidate.kind,
+					Name:       list.Items[i].GetName(),
+				})
+			}
+		}
+	}
+
+	switch len(targets) {
+	case 0:
+		return poolconfig.Target{}, fmt.Errorf("no Deployment or StatefulSet matches the selector of inferencePool %s, set its target annotations", pool.Name)
+	case 1:
+		return targets[0], nil
+	default:
+		names := make([]string, 0, len(targets))
+		for _, target := range targets {
+			names = append(names, target.Kind+"/"+target.Name)
+		}
+		return poolconfig.Target{}, fmt.Errorf("several workloads match the selector of inferencePool %s (%s), set its target annotations", pool.Name, strings.Join(names, ", "))
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestDiscoverTarget(t *testing.T) {
+	workload := func(kind, name string, templateLabels map[string]any) runtime.Object {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       kind,
+			"metadata":   map[string]any{"name": name, "namespace": "default"},
+			"spec": map[string]any{"template": map[string]any{
+				"metadata": map[string]any{"labels": templateLabels},
+			}},
+		}}
+	}
+	model := map[string]any{"app": "vllm", "model": "llama"}
+
+	tests := []struct {
+		name      string
+		workloads []runtime.Object
+		want      poolconfig.Target
+		wantErr   bool
+	}{
+		{
+			name:      "deployment",
+			workloads: []runtime.Object{workload("Deployment", "llama", model), workload("Deployment", "other", map[string]any{"app": "other"})},
+			want:      poolconfig.Target{APIVersion: "apps/v1", Kind: "Deployment", Name: "llama"},
+		},
+		{
+			name:      "statefulset",
+			workloads: []runtime.Object{workload("StatefulSet", "llama", model)},
+			want:      poolconfig.Target{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "llama"},
+		},
+		{name: "no match", workloads: []runtime.Object{workload("Deployment", "other", map[string]any{"app": "vllm"})}, wantErr: true},
+		{name: "several matches", workloads: []runtime.Object{workload("Deployment", "llama", model), workload("Deployment", "llama-canary", model)}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			listKinds := map[schema.GroupVersionResource]string{}
+			for _, candidate := range discoverableKinds {
+				listKinds[candidate.gvr] = candidate.kind + "List"
+			}
+			client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, test.workloads...)
+			pool := &v1.InferencePool{}
+			pool.Name, pool.Namespace = "pool", "default"
+			pool.Spec.Selector.MatchLabels = map[v1.LabelKey]v1.LabelValue{"app": "vllm", "model": "llama"}
+
+			got, err := discoverTarget(context.Background(), client, pool)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("discoverTarget() error = %v, wantErr %t", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("discoverTarget() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
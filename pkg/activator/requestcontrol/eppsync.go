@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// EndpointPickerMetricsURLKey is the metrics endpoint of the Endpoint Picker of the pool, e.g.
+	// "http://my-pool-epp:9090/metrics". When set, requests held for a scale up from zero are released once the
+	// Endpoint Picker reports the new ready pods, instead of after the propagation delay of the pool.
+	EndpointPickerMetricsURLKey = poolconfig.EndpointPickerMetricsURLKey // Optional annotation
+
+	// PropagationDelayKey is the time requests held for a scale up from zero of the pool are still held once
+	// its pods are ready, for the Endpoint Picker to pick them up, e.g. "3s". It only applies to pools without
+	// an Endpoint Picker metrics annotation, and defaults to the propagation delay learned from the Endpoint
+	// Picker syncs measured so far, or to ScaleToZeroRequestRetentionPeriod before any.
+	PropagationDelayKey = poolconfig.PropagationDelayKey // Optional annotation
+
+	// EndpointPickerReadyPodsMetric is the gauge of the ready pods of the pool known to the Endpoint Picker
+	EndpointPickerReadyPodsMetric = "inference_pool_ready_pods"
+
+	// DefaultEndpointPickerSyncTimeout bounds the wait for the Endpoint Picker to know the new ready pods,
+	// the requests held being released anyway afterwards
+	DefaultEndpointPickerSyncTimeout = time.Duration(30 * time.Second)
+
+	endpointPickerSyncInterval  = 250 * time.Millisecond
+	endpointPickerScrapeTimeout = 2 * time.Second
+
+	// propagationSmoothing is the weight of the last measured Endpoint Picker sync in the learned propagation
+	// delay, and propagationHeadroom the margin the learned delay is waited for with
+	propagationSmoothing = 0.3
+	propagationHeadroom  = 1.5
+)
+
+// propagationEstimate learns the propagation delay of the pool from the Endpoint Picker syncs measured after
+// its scale ups from zero.
+type propagationEstimate struct {
+	// average is the moving average of the measured syncs in nanoseconds, zero before the first one
+	average atomic.Int64
+}
+
+// observe records an Endpoint Picker sync measured after a scale up from zero.
+func (p *propagationEstimate) observe(propagation time.Duration) {
+	for {
+		average := p.average.Load()
+		next := int64(propagation)
+		if average != 0 {
+			next = average + int64(propagationSmoothing*float64(int64(propagation)-average))
+		}
+		if p.average.CompareAndSwap(average, max(next, 1)) {
+			return
+		}
+	}
+}
+
+// delay returns the learned propagation delay, or ScaleToZeroRequestRetentionPeriod before any sync was
+// measured.
+func (p *propagationEstimate) delay() time.Duration {
+	average := p.average.Load()
+	if average == 0 {
+		return ScaleToZeroRequestRetentionPeriod
+	}
+	delay := time.Duration(float64(average) * propagationHeadroom)
+	return min(max(delay, endpointPickerSyncInterval), DefaultEndpointPickerSyncTimeout)
+}
+
+// propagationDelayFor returns the propagation delay of the given pool, the learned one unless it sets one.
+func (a *Activator) propagationDelayFor(pool *v1.InferencePool) time.Duration {
+	if delay := poolconfig.For(pool).PropagationDelay; delay > 0 {
+		return delay
+	}
+	return a.propagation.delay()
+}
+
+// waitEndpointPickerSync waits, after a scale up from zero of the pool to the given replicas, until the
+// Endpoint Picker can route to them, so that the requests released do not fail with "no healthy upstream".
+// Pools without an Endpoint Picker metrics annotation wait for the given propagation delay instead. It fails
+// open: the requests are released once the sync timeout elapsed even if the Endpoint Picker never reported
+// the new pods. It returns how long the Endpoint Picker took to report them, if it was measured.
+func waitEndpointPickerSync(ctx context.Context, logger logr.Logger, httpClient *http.Client, pool *v1.InferencePool, numReplicas int32, timeout, propagationDelay time.Duration) (time.Duration, bool) {
+	metricsURL := poolconfig.For(pool).EndpointPickerMetricsURL
+	if metricsURL == "" {
+		select {
+		case <-ctx.Done():
+		case <-time.After(propagationDelay):
+		}
+		return 0, false
+	}
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(endpointPickerSyncInterval)
+	defer ticker.Stop()
+	for {
+		readyPods, err := scrapeGauge(ctx, httpClient, metricsURL, EndpointPickerReadyPodsMetric)
+		if err != nil {
+			logger.V(logutil.DEBUG).Info("Error getting the ready pods of the Endpoint Picker", "error", err.Error())
+		} else if readyPods >= float64(numReplicas) {
+			propagation := time.Since(start)
+			logger.V(logutil.DEBUG).Info("Endpoint Picker synchronized with the ready pods", "readyPods", readyPods, "propagation", propagation)
+			return propagation, true
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("Endpoint Picker did not report the ready pods within the sync timeout, releasing requests",
+				"timeout", timeout, "replicas", numReplicas)
+			return 0, false
+		case <-ticker.C:
+		}
+	}
+}
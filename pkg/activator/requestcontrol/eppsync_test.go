@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestWaitEndpointPickerSync(t *testing.T) {
+	tests := []struct {
+		name       string
+		syncAfter  int32
+		timeout    time.Duration
+		wantSynced bool
+	}{
+		{name: "already synchronized", syncAfter: 0, timeout: time.Second, wantSynced: true},
+		{name: "synchronized after a few scrapes", syncAfter: 3, timeout: 5 * time.Second, wantSynced: true},
+		{name: "never synchronized", syncAfter: 1000, timeout: 300 * time.Millisecond, wantSynced: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var scrapes atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				readyPods := 0
+				if scrapes.Add(1) > test.syncAfter {
+					readyPods = 2
+				}
+				fmt.Fprintf(w, "# TYPE %s gauge\n%s{name=\"pool\"} %d\n", EndpointPickerReadyPodsMetric, EndpointPickerReadyPodsMetric, readyPods)
+			}))
+			defer server.Close()
+
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default",
+				Annotations: map[string]string{EndpointPickerMetricsURLKey: server.URL + "/metrics"}}}
+			start := time.Now()
+			_, _ = waitEndpointPickerSync(context.Background(), logr.Discard(), server.Client(), pool, 2, test.timeout, 0)
+
+			if synced := time.Since(start) < test.timeout; synced != test.wantSynced {
+				t.Errorf("waitEndpointPickerSync() synchronized = %t, want %t", synced, test.wantSynced)
+			}
+		})
+	}
+}
+
+func TestPropagationDelay(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		propagations []time.Duration
+		want         time.Duration
+	}{
+		{name: "nothing measured", want: ScaleToZeroRequestRetentionPeriod},
+		{name: "one sync measured", propagations: []time.Duration{2 * time.Second}, want: 3 * time.Second},
+		{name: "syncs averaged", propagations: []time.Duration{2 * time.Second, 12 * time.Second}, want: 7500 * time.Millisecond},
+		{name: "fast syncs", propagations: []time.Duration{time.Millisecond}, want: endpointPickerSyncInterval},
+		{name: "slow syncs", propagations: []time.Duration{time.Minute}, want: DefaultEndpointPickerSyncTimeout},
+		{
+			name:         "pool propagation delay",
+			annotations:  map[string]string{PropagationDelayKey: "1s"},
+			propagations: []time.Duration{10 * time.Second},
+			want:         time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Annotations: test.annotations}}
+			a := &Activator{}
+			for _, propagation := range test.propagations {
+				a.propagation.observe(propagation)
+			}
+			if got := a.propagationDelayFor(pool); got != test.want {
+				t.Errorf("propagationDelayFor() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"strings"
+)
+
+// UserAgentHeaderKey is the header carrying the user agent of the client.
+const UserAgentHeaderKey = "user-agent"
+
+// ExclusionConfig defines the classes of requests, e.g. health checks and synthetic monitors, that neither
+// activate the pool nor keep it warm. They are let through while the pool is warm, and rejected while it is
+// cold.
+type ExclusionConfig struct {
+	// Paths are the path prefixes of excluded requests.
+	Paths []string
+	// Header is the name of a request header marking excluded requests, whatever its value.
+	Header string
+	// UserAgents are substrings of the user agents of excluded requests, matched case-insensitively,
+	// e.g. "kube-probe" or "Pingdom".
+	UserAgents []string
+}
+
+// NewExclusionConfig returns an ExclusionConfig that matches no request.
+func NewExclusionConfig() *ExclusionConfig {
+	return &ExclusionConfig{}
+}
+
+// Enabled reports whether the configuration matches any request.
+func (c *ExclusionConfig) Enabled() bool {
+	return len(c.Paths) > 0 || c.Header != "" || len(c.UserAgents) > 0
+}
+
+// Exclusions recognizes the requests that neither activate the pool nor keep it warm, so that monitoring
+// traffic does not keep idle pools alive or wake cold ones.
+type Exclusions struct {
+	config *ExclusionConfig
+}
+
+func NewExclusions(config *ExclusionConfig) *Exclusions {
+	return &Exclusions{config: config}
+}
+
+// Matches reports whether the request with the given lower-cased headers is excluded.
+func (e *Exclusions) Matches(headers map[string]string) bool {
+	if e.config.Header != "" {
+		if _, ok := headers[strings.ToLower(e.config.Header)]; ok {
+			return true
+		}
+	}
+	path, _, _ := strings.Cut(headers[PathHeaderKey], "?")
+	for _, prefix := range e.config.Paths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	if userAgent := strings.ToLower(headers[UserAgentHeaderKey]); userAgent != "" {
+		for _, agent := range e.config.UserAgents {
+			if agent != "" && strings.Contains(userAgent, strings.ToLower(agent)) {
+				return true
+			}
+		}
+	}
+	return false
+}
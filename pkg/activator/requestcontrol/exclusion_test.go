@@ -0,0 +1,49 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+)
+
+func TestExclusionsMatches(t *testing.T) {
+	exclusions := NewExclusions(&ExclusionConfig{
+		Paths:      []string{"/health"},
+		Header:     "X-Synthetic-Monitor",
+		UserAgents: []string{"kube-probe", "Pingdom"},
+	})
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    bool
+	}{
+		{name: "completion", headers: map[string]string{PathHeaderKey: "/v1/completions", UserAgentHeaderKey: "python-requests/2.32"}, want: false},
+		{name: "health check path", headers: map[string]string{PathHeaderKey: "/health?verbose=1"}, want: true},
+		{name: "monitor header", headers: map[string]string{PathHeaderKey: "/v1/completions", "x-synthetic-monitor": ""}, want: true},
+		{name: "probe user agent", headers: map[string]string{PathHeaderKey: "/v1/models", UserAgentHeaderKey: "kube-probe/1.31"}, want: true},
+		{name: "user agent matched case-insensitively", headers: map[string]string{PathHeaderKey: "/v1/models", UserAgentHeaderKey: "pingdom.com_bot_version_1.4"}, want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := exclusions.Matches(test.headers); got != test.want {
+				t.Errorf("Matches() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
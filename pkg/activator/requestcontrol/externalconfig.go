@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// ExternalConfigResync is the interval pools reading their activator configuration from other objects are
+	// reconciled again at, to pick up the changes of these objects.
+	ExternalConfigResync = 30 * time.Second
+
+	// annotationPrefix prefixes the activator annotations.
+	annotationPrefix = "activator.llm-d.ai/"
+
+	// configMapSuffix names the companion ConfigMap of a pool after it, e.g. "llama-activator".
+	configMapSuffix = "-activator"
+)
+
+var configMapGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+// ApplyConfigMap fills in the activator annotations the given pool does not set from its companion ConfigMap,
+// "<pool name>-activator" in its namespace, for teams that cannot modify the pool, e.g. owned by another
+// chart. The ConfigMap holds the annotations as data, keyed with or without their "activator.llm-d.ai/"
+// prefix. The annotations are only changed in memory.
+func (a *Activator) ApplyConfigMap(ctx context.Context, pool *v1.InferencePool) error {
+	annotations, version, err := configMapAnnotations(ctx, a.DynamicClient, pool)
+	if err != nil || annotations == nil {
+		return err
+	}
+	applied := fillAnnotations(pool, annotations, version)
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Applied the configMap of the inferencePool", "annotations", applied)
+	return nil
+}
+
+// ApplyWorkloadAnnotations fills in the activator annotations the given pool does not set from the annotations
+// of its target workload, for teams that cannot modify the pool. The annotations are only changed in memory.
+func (a *Activator) ApplyWorkloadAnnotations(ctx context.Context, pool *v1.InferencePool) error {
+	annotations, version, err := workloadAnnotations(ctx, a.DynamicClient, a.Mapper, pool)
+	if err != nil || annotations == nil {
+		return err
+	}
+	applied := fillAnnotations(pool, annotations, version)
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Applied the annotations of the target of the inferencePool", "annotations", applied)
+	return nil
+}
+
+// applyExternalConfig fills in the activator annotations the given pool does not set from its companion
+// ConfigMap and then from the annotations of its target workload, as ApplyConfigMap and
+// ApplyWorkloadAnnotations do for the pool of the activator. The annotations are only changed in memory.
+func applyExternalConfig(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, pool *v1.InferencePool) error {
+	annotations, version, err := configMapAnnotations(ctx, client, pool)
+	if err != nil {
+		return err
+	}
+	if annotations != nil {
+		fillAnnotations(pool, annotations, version)
+	}
+	if annotations, version, err = workloadAnnotations(ctx, client, mapper, pool); err != nil {
+		return err
+	}
+	if annotations != nil {
+		fillAnnotations(pool, annotations, version)
+	}
+	return nil
+}
+
+// configMapAnnotations returns the activator annotations held by the companion ConfigMap of the given pool, and
+// the version of the ConfigMap, or nil when the pool has none.
+func configMapAnnotations(ctx context.Context, client dynamic.Interface, pool *v1.InferencePool) (map[string]string, string, error) {
+	name := pool.Name + configMapSuffix
+	configMap, err := client.Resource(configMapGVR).Namespace(pool.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get the configMap %s of inferencePool %s: %w", name, pool.Name, err)
+	}
+
+	data, _, _ := unstructured.NestedStringMap(configMap.Object, "data")
+	annotations := make(map[string]string, len(data))
+	for key, value := range data {
+		if !strings.Contains(key, "/") {
+			key = annotationPrefix + key
+		}
+		annotations[key] = value
+	}
+	return annotations, "configmap/" + configMap.GetResourceVersion(), nil
+}
+
+// workloadAnnotations returns the activator annotations of the target workload of the given pool, and the
+// version of the workload, or nil when the pool has no target or its target does not exist, which the check of
+// the pool against the cluster reports. The target annotations are ignored, the workload being the target.
+func workloadAnnotations(ctx context.Context, client dynamic.Interface, mapper meta.RESTMapper, pool *v1.InferencePool) (map[string]string, string, error) {
+	config := poolconfig.Parse(pool)
+	if !config.HasTarget() {
+		return nil, "", nil
+	}
+	gvr, err := targetResourceFor(mapper, pool)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to map the target of inferencePool %s: %w", pool.Name, err)
+	}
+	name := config.Target.Name
+	workload, err := client.Resource(gvr).Namespace(pool.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get the target %s of inferencePool %s: %w", name, pool.Name, err)
+	}
+
+	annotations := map[string]string{}
+	for key, value := range workload.GetAnnotations() {
+		switch key {
+		case ObjectApiVersionKey, ObjectkindKey, ObjectNameKey:
+			continue
+		}
+		if strings.HasPrefix(key, annotationPrefix) {
+			annotations[key] = value
+		}
+	}
+	return annotations, gvr.Resource + "/" + workload.GetResourceVersion(), nil
+}
+
+// fillAnnotations sets the given annotations the pool does not set, and records the version of the object they
+// were read from so that the configuration of the pool is parsed again once the object changes. It returns the
+// number of annotations set.
+func fillAnnotations(pool *v1.InferencePool, annotations map[string]string, version string) int {
+	if pool.Annotations == nil {
+		pool.Annotations = map[string]string{}
+	}
+	applied := 0
+	for key, value := range annotations {
+		if _, ok := pool.Annotations[key]; !ok && key != poolconfig.ExternalVersionKey {
+			pool.Annotations[key] = value
+			applied++
+		}
+	}
+	if versions := pool.Annotations[poolconfig.ExternalVersionKey]; versions != "" {
+		version = versions + "," + version
+	}
+	pool.Annotations[poolconfig.ExternalVersionKey] = version
+	return applied
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestExternalConfig(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+	configMap := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "pool-activator", "namespace": "default", "resourceVersion": "3"},
+		"data": map[string]any{
+			"target-apiversion":          "apps/v1",
+			"target-kind":                "Deployment",
+			"target-name":                "llama",
+			poolconfig.ScaleDownDelayKey: "5m",
+		},
+	}}
+	deployment := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{"name": "llama", "namespace": "default", "resourceVersion": "8", "annotations": map[string]any{
+			poolconfig.ScaleDownDelayKey:        "10m",
+			poolconfig.QueuedTimeoutKey:         "1m",
+			poolconfig.TargetNameKey:            "other",
+			"deployment.kubernetes.io/revision": "2",
+		}},
+	}}
+
+	tests := []struct {
+		name        string
+		objects     []runtime.Object
+		annotations map[string]string
+		want        map[string]string
+	}{
+		{name: "no external config", want: map[string]string{}},
+		{
+			name:    "configMap takes precedence over the workload",
+			objects: []runtime.Object{configMap, deployment},
+			want: map[string]string{
+				poolconfig.TargetAPIVersionKey: "apps/v1",
+				poolconfig.TargetKindKey:       "Deployment",
+				poolconfig.TargetNameKey:       "llama",
+				poolconfig.ScaleDownDelayKey:   "5m",
+				poolconfig.QueuedTimeoutKey:    "1m",
+				poolconfig.ExternalVersionKey:  "configmap/3,deployments/8",
+			},
+		},
+		{
+			name:        "pool takes precedence over the configMap",
+			objects:     []runtime.Object{configMap, deployment},
+			annotations: map[string]string{poolconfig.ScaleDownDelayKey: "1m", poolconfig.QueuedTimeoutKey: "2m"},
+			want: map[string]string{
+				poolconfig.TargetAPIVersionKey: "apps/v1",
+				poolconfig.TargetKindKey:       "Deployment",
+				poolconfig.TargetNameKey:       "llama",
+				poolconfig.ScaleDownDelayKey:   "1m",
+				poolconfig.QueuedTimeoutKey:    "2m",
+				poolconfig.ExternalVersionKey:  "configmap/3,deployments/8",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), test.objects...)
+			pool := &v1.InferencePool{}
+			pool.Name, pool.Namespace, pool.Annotations = "pool", "default", test.annotations
+			ctx := context.Background()
+
+			for _, source := range []func() (map[string]string, string, error){
+				func() (map[string]string, string, error) { return configMapAnnotations(ctx, client, pool) },
+				func() (map[string]string, string, error) { return workloadAnnotations(ctx, client, mapper, pool) },
+			} {
+				annotations, version, err := source()
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if annotations != nil {
+					fillAnnotations(pool, annotations, version)
+				}
+			}
+
+			if len(pool.Annotations) != len(test.want) {
+				t.Fatalf("annotations = %v, want %v", pool.Annotations, test.want)
+			}
+			for key, want := range test.want {
+				if got := pool.Annotations[key]; got != want {
+					t.Errorf("annotation %s = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+// FailureReason classifies why an activation failed.
+type FailureReason string
+
+const (
+	// FailureImagePull is reported when the images of the pods cannot be pulled.
+	FailureImagePull FailureReason = "ImagePull"
+	// FailureScheduling is reported when the pods cannot be scheduled.
+	FailureScheduling FailureReason = "Scheduling"
+	// FailureOOM is reported when containers of the pods were killed for running out of memory.
+	FailureOOM FailureReason = "OOM"
+	// FailureCrashLoop is reported when containers of the pods keep crashing.
+	FailureCrashLoop FailureReason = "CrashLoop"
+	// FailureModelLoadTimeout is reported when the pods are running but not ready yet, typically because
+	// the model server is still loading the model.
+	FailureModelLoadTimeout FailureReason = "ModelLoadTimeout"
+	// FailurePodsNotCreated is reported when the workload requests replicas but none of its pods was created,
+	// typically because a ResourceQuota or an admission policy rejects them.
+	FailurePodsNotCreated FailureReason = "PodsNotCreated"
+	// FailureBudgetExhausted is reported when the pool consumed its monthly activation budget.
+	FailureBudgetExhausted FailureReason = "BudgetExhausted"
+	// FailureActivationHook is reported when a pre-activation hook of the pool rejected its scale up.
+	FailureActivationHook FailureReason = "ActivationHook"
+	// FailureUnknown is reported when the activation failed for any other reason.
+	FailureUnknown FailureReason = "Unknown"
+)
+
+// failureSeverity orders the failure reasons from the most to the least specific, so that the most
+// telling reason is reported when the pods of a workload fail in different ways.
+var failureSeverity = map[FailureReason]int{
+	FailureImagePull:        5,
+	FailureOOM:              4,
+	FailureCrashLoop:        3,
+	FailureScheduling:       2,
+	FailureModelLoadTimeout: 1,
+	FailurePodsNotCreated:   1,
+	FailureUnknown:          0,
+}
+
+var imagePullWaitingReasons = map[string]bool{
+	"ErrImagePull":      true,
+	"ImagePullBackOff":  true,
+	"InvalidImageName":  true,
+	"ErrImageNeverPull": true,
+}
+
+// failureError is an activation error whose reason is known upfront.
+type failureError struct {
+	reason FailureReason
+	err    error
+}
+
+func (e *failureError) Error() string { return e.err.Error() }
+
+func (e *failureError) Unwrap() error { return e.err }
+
+// failureReasonOf returns the reason carried by the given error, if any.
+func failureReasonOf(err error) FailureReason {
+	var failure *failureError
+	if errors.As(err, &failure) {
+		return failure.reason
+	}
+	return FailureUnknown
+}
+
+// classifyPods returns the reason why the given pods of a workload did not become ready.
+func classifyPods(pods []unstructured.Unstructured) FailureReason {
+	reason := FailureUnknown
+	for i := range pods {
+		if podReason := classifyPod(&pods[i]); failureSeverity[podReason] > failureSeverity[reason] {
+			reason = podReason
+		}
+	}
+	return reason
+}
+
+func classifyPod(pod *unstructured.Unstructured) FailureReason {
+	reason := FailureUnknown
+	raise := func(r FailureReason) {
+		if failureSeverity[r] > failureSeverity[reason] {
+			reason = r
+		}
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(pod.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, _ := c.(map[string]any)
+		if condition["type"] == "PodScheduled" && condition["status"] == string(metav1.ConditionFalse) {
+			raise(FailureScheduling)
+		}
+	}
+	if gates, _, _ := unstructured.NestedSlice(pod.Object, "spec", "schedulingGates"); len(gates) > 0 {
+		raise(FailureScheduling)
+	}
+
+	for _, field := range []string{"initContainerStatuses", "containerStatuses"} {
+		statuses, _, _ := unstructured.NestedSlice(pod.Object, "status", field)
+		for _, s := range statuses {
+			status, _ := s.(map[string]any)
+			waitingReason, _, _ := unstructured.NestedString(status, "state", "waiting", "reason")
+			terminatedReason, _, _ := unstructured.NestedString(status, "state", "terminated", "reason")
+			lastTerminatedReason, _, _ := unstructured.NestedString(status, "lastState", "terminated", "reason")
+			_, running, _ := unstructured.NestedMap(status, "state", "running")
+			ready, _, _ := unstructured.NestedBool(status, "ready")
+
+			switch {
+			case imagePullWaitingReasons[waitingReason]:
+				raise(FailureImagePull)
+			case terminatedReason == "OOMKilled" || lastTerminatedReason == "OOMKilled":
+				raise(FailureOOM)
+			case waitingReason == "CrashLoopBackOff":
+				raise(FailureCrashLoop)
+			case running && !ready && field == "containerStatuses":
+				raise(FailureModelLoadTimeout)
+			}
+		}
+	}
+	return reason
+}
+
+// classifyActivationFailure lists the pods of the inferencePool to find out why they did not become ready.
+func (a *Activator) classifyActivationFailure(ctx context.Context, pool *v1.InferencePool) FailureReason {
+	pods, err := a.DynamicClient.Resource(podGVR).Namespace(pool.Namespace).List(ctx, metav1.ListOptions{LabelSelector: poolPodSelector(pool)})
+	if err != nil {
+		return FailureUnknown
+	}
+	if len(pods.Items) == 0 {
+		return FailurePodsNotCreated
+	}
+	return classifyPods(pods.Items)
+}
+
+// poolPodSelector returns the label selector of the pods of the inferencePool.
+func poolPodSelector(pool *v1.InferencePool) string {
+	selector := make(labels.Set, len(pool.Spec.Selector.MatchLabels))
+	for k, v := range pool.Spec.Selector.MatchLabels {
+		selector[string(k)] = string(v)
+	}
+	return selector.String()
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func podWithStatus(status map[string]any) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]any{"status": status}}
+}
+
+func containerStatus(state, lastState map[string]any, ready bool) map[string]any {
+	status := map[string]any{"name": "server", "ready": ready, "state": state}
+	if lastState != nil {
+		status["lastState"] = lastState
+	}
+	return status
+}
+
+func TestClassifyPods(t *testing.T) {
+	imagePull := podWithStatus(map[string]any{"containerStatuses": []any{
+		containerStatus(map[string]any{"waiting": map[string]any{"reason": "ImagePullBackOff"}}, nil, false),
+	}})
+	oomKilled := podWithStatus(map[string]any{"containerStatuses": []any{
+		containerStatus(map[string]any{"waiting": map[string]any{"reason": "CrashLoopBackOff"}},
+			map[string]any{"terminated": map[string]any{"reason": "OOMKilled"}}, false),
+	}})
+	crashLoop := podWithStatus(map[string]any{"containerStatuses": []any{
+		containerStatus(map[string]any{"waiting": map[string]any{"reason": "CrashLoopBackOff"}},
+			map[string]any{"terminated": map[string]any{"reason": "Error"}}, false),
+	}})
+	unschedulable := podWithStatus(map[string]any{"conditions": []any{
+		map[string]any{"type": "PodScheduled", "status": "False", "reason": "Unschedulable"},
+	}})
+	loading := podWithStatus(map[string]any{"containerStatuses": []any{
+		containerStatus(map[string]any{"running": map[string]any{"startedAt": "2025-01-01T00:00:00Z"}}, nil, false),
+	}})
+
+	tests := []struct {
+		name string
+		pods []unstructured.Unstructured
+		want FailureReason
+	}{
+		{name: "No pods", want: FailureUnknown},
+		{name: "Image pull", pods: []unstructured.Unstructured{imagePull}, want: FailureImagePull},
+		{name: "OOM killed", pods: []unstructured.Unstructured{oomKilled}, want: FailureOOM},
+		{name: "Crash loop", pods: []unstructured.Unstructured{crashLoop}, want: FailureCrashLoop},
+		{name: "Unschedulable", pods: []unstructured.Unstructured{unschedulable}, want: FailureScheduling},
+		{name: "Model loading", pods: []unstructured.Unstructured{loading}, want: FailureModelLoadTimeout},
+		{name: "Most specific reason wins", pods: []unstructured.Unstructured{loading, unschedulable, crashLoop}, want: FailureCrashLoop},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := classifyPods(test.pods); got != test.want {
+				t.Errorf("classifyPods() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	activatorv1alpha1 "github.com/llm-d-incubation/llm-d-activator/api/v1alpha1"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/audit"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// defaultFallbackReasons are the activation failures the fallback targets of a pool are tried for when its
+// activation policy lists none: the failures retrying the target workload would not fix.
+var defaultFallbackReasons = []FailureReason{FailureImagePull, FailureScheduling, FailureOOM, FailureCrashLoop, FailurePodsNotCreated}
+
+// fallbackFor reports whether the fallback targets of the given spec are tried for an activation failing for
+// the given reason.
+func fallbackFor(fallback *activatorv1alpha1.FallbackSpec, reason FailureReason) bool {
+	if len(fallback.Reasons) == 0 {
+		return slices.Contains(defaultFallbackReasons, reason)
+	}
+	return slices.Contains(fallback.Reasons, string(reason))
+}
+
+// fallbackPool returns a copy of the given pool whose target workload is the given fallback target. The copy
+// drops the targets scaled along the target workload, and has no resource version so that its configuration
+// is parsed rather than read from the configuration cached for the pool.
+func fallbackPool(pool *v1.InferencePool, target activatorv1alpha1.FallbackTarget) *v1.InferencePool {
+	pool = pool.DeepCopy()
+	pool.ResourceVersion = ""
+	pool.Annotations[ObjectApiVersionKey] = target.APIVersion
+	pool.Annotations[ObjectkindKey] = target.Kind
+	pool.Annotations[ObjectNameKey] = target.Name
+	for _, key := range []string{AdditionalTargetsKey, PrefillTargetKey, RolloverTargetKey} {
+		delete(pool.Annotations, key)
+	}
+	return pool
+}
+
+// fallbackOf returns the fallback targets of the activation policy of the given pool, if any.
+func (a *Activator) fallbackOf(ctx context.Context, logger logr.Logger, pool *v1.InferencePool) *activatorv1alpha1.FallbackSpec {
+	policy, err := activationPolicyFor(ctx, a.DynamicClient, pool)
+	if err != nil {
+		logger.V(logutil.DEBUG).Info("Error getting the activation policy, ignoring its fallback targets", "error", err.Error())
+		return nil
+	}
+	if policy == nil {
+		return nil
+	}
+	return policy.Spec.Fallback
+}
+
+// activateFallback brings the fallback targets of the given pool up in turn, once its target workload failed
+// to activate for the given reason, until one is ready to serve the requests held. The target workload and the
+// given targets scaled along it are scaled back down first to give their resources up. It returns the fallback
+// target serving the pool, if any.
+func (a *Activator) activateFallback(ctx context.Context, logger logr.Logger, objData ScaledObjectData, strategy Strategy, gvr schema.GroupVersionResource,
+	reason FailureReason, additional []scaledTarget) (*activatorv1alpha1.FallbackTarget, bool) {
+	fallback := a.fallbackOf(ctx, logger, objData.pool)
+	if fallback == nil || !fallbackFor(fallback, reason) {
+		return nil, false
+	}
+
+	clients := StrategyClients{ScaleClient: a.ScaleClient, DynamicClient: a.DynamicClient}
+	if err := strategy.ScaleDown(context.Background(), &ScaleTarget{Pool: objData.pool, Resource: gvr, Scale: objData.scaleObject}); err != nil {
+		logger.Error(err, "Error scaling down the target workload before trying its fallback targets")
+	}
+	restoreAdditionalTargets(context.Background(), logger, clients, additional)
+
+	scaler := &scaleStrategy{clients: clients}
+	for i := range fallback.Targets {
+		target := &fallback.Targets[i]
+		pool := fallbackPool(objData.pool, *target)
+		targetGVR, err := targetResourceFor(a.Mapper, pool)
+		if err != nil {
+			logger.Error(err, "Failed to parse Group, Version, Kind, Resource of fallback target", "apiVersion", target.APIVersion, "kind", target.Kind)
+			continue
+		}
+		scaleObject, err := scaleOf(ctx, a.ScaleClient, a.DynamicClient, pool, targetGVR)
+		if err != nil {
+			logger.Error(err, "Error getting scale subresource object of fallback target", "name", target.Name)
+			continue
+		}
+		replicas := max(scaleObject.Spec.Replicas, objData.numReplicas)
+		scaleTarget := &ScaleTarget{Pool: pool, Resource: targetGVR, Scale: scaleObject}
+		if err := scaler.setReplicas(ctx, scaleTarget, replicas); err != nil {
+			logger.Error(err, "Error scaling up fallback target", "name", target.Name)
+			continue
+		}
+		logger.Info("Target workload failed to activate, trying fallback target", "reason", reason, "kind", target.Kind, "name", target.Name, "replicas", replicas)
+
+		if a.InferencePoolPodsReady(ctx, logger, pool, replicas, objData.scaleGracePeriod, targetGVR.GroupResource(), targetGVR) {
+			waitEndpointPickerSync(ctx, logger, &http.Client{Timeout: endpointPickerScrapeTimeout}, pool, releaseReplicasFor(pool, replicas),
+				DefaultEndpointPickerSyncTimeout, a.propagationDelayFor(pool))
+			return target, true
+		}
+		if err := scaler.setReplicas(context.Background(), scaleTarget, 0); err != nil {
+			logger.Error(err, "Error scaling down fallback target", "name", target.Name)
+		}
+		if ctx.Err() != nil {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// fallbackServing reports whether a fallback target of the given pool, whose target workload is at zero
+// replicas, runs replicas and so keeps serving the pool until the pool is scaled down.
+func (a *Activator) fallbackServing(ctx context.Context, logger logr.Logger, pool *v1.InferencePool) bool {
+	fallback := a.fallbackOf(ctx, logger, pool)
+	if fallback == nil {
+		return false
+	}
+	for _, target := range fallback.Targets {
+		pool := fallbackPool(pool, target)
+		gvr, err := targetResourceFor(a.Mapper, pool)
+		if err != nil {
+			continue
+		}
+		if scaleObject, err := scaleOf(ctx, a.ScaleClient, a.DynamicClient, pool, gvr); err == nil && scaleObject.Spec.Replicas > 0 && scaleObject.Status.Replicas > 0 {
+			logger.V(logutil.DEBUG).Info("Fallback target is serving the pool", "kind", target.Kind, "name", target.Name)
+			return true
+		}
+	}
+	return false
+}
+
+// deactivateFallbackTargets scales down the fallback targets of the given idle pool running replicas, if any.
+// It reports whether any was running.
+func (da *Deactivator) deactivateFallbackTargets(ctx context.Context, pool *v1.InferencePool) bool {
+	logger := log.FromContext(ctx)
+	policy, err := activationPolicyFor(ctx, da.DynamicClient, pool)
+	if err != nil || policy == nil || policy.Spec.Fallback == nil {
+		return false
+	}
+
+	scaler := &scaleStrategy{clients: StrategyClients{ScaleClient: da.ScaleClient, DynamicClient: da.DynamicClient}}
+	running := false
+	for _, target := range policy.Spec.Fallback.Targets {
+		pool := fallbackPool(pool, target)
+		gvr, err := targetResourceFor(da.Mapper, pool)
+		if err != nil {
+			continue
+		}
+		scaleObject, err := scaleOf(ctx, da.ScaleClient, da.DynamicClient, pool, gvr)
+		if err != nil || scaleObject.Spec.Replicas == 0 {
+			continue
+		}
+		running = true
+
+		if da.DryRun {
+			da.reportDryRun(ctx, pool, gvr, scaleObject.Spec.Replicas)
+			continue
+		}
+		if !da.deactivating() {
+			logger.V(logutil.DEBUG).Info("InferencePool is scaling up from zero, skipping scale down of fallback target", "name", target.Name)
+			continue
+		}
+		record := audit.Record{
+			Action:                audit.ActionScaleDown,
+			Pool:                  fmt.Sprintf("%s/%s", pool.Namespace, pool.Name),
+			Target:                fmt.Sprintf("%s/%s", target.Kind, target.Name),
+			ResourceVersionBefore: scaleObject.ResourceVersion,
+		}
+		scaleTarget := &ScaleTarget{Pool: pool, Resource: gvr, Scale: scaleObject}
+		err = scaler.setReplicas(ctx, scaleTarget, 0)
+		recordResourceVersionAfter(ctx, scaler.clients, &record, scaleTarget)
+		da.deactivated(err == nil)
+		if err != nil {
+			logger.Error(err, "Fallback target was not successfully scaled down to zero replica", "name", target.Name)
+			record.Outcome, record.Message = audit.OutcomeFailed, err.Error()
+			audit.Log(record)
+			continue
+		}
+		record.Outcome, record.Message = audit.OutcomeSucceeded, "inferencePool served by its fallback target idle for the scale down delay"
+		audit.Log(record)
+		da.Events.publishRecord(ScaleDownExecuted, record, 0)
+	}
+	return running
+}
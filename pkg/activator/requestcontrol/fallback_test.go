@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	activatorv1alpha1 "github.com/llm-d-incubation/llm-d-activator/api/v1alpha1"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestFallbackFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		reasons []string
+		reason  FailureReason
+		want    bool
+	}{
+		{name: "unrecoverable by default", reason: FailureScheduling, want: true},
+		{name: "model load timeout retried by default", reason: FailureModelLoadTimeout, want: false},
+		{name: "listed reason", reasons: []string{"ModelLoadTimeout"}, reason: FailureModelLoadTimeout, want: true},
+		{name: "reason not listed", reasons: []string{"ImagePull"}, reason: FailureScheduling, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fallback := &activatorv1alpha1.FallbackSpec{Reasons: test.reasons}
+			if got := fallbackFor(fallback, test.reason); got != test.want {
+				t.Errorf("fallbackFor() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFallbackPool(t *testing.T) {
+	pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", UID: "uid", ResourceVersion: "1", Annotations: map[string]string{
+		ObjectApiVersionKey:  "apps/v1",
+		ObjectkindKey:        "Deployment",
+		ObjectNameKey:        "gpu",
+		AdditionalTargetsKey: `[{"apiVersion":"apps/v1","kind":"Deployment","name":"router"}]`,
+	}}}
+	if target := poolconfig.For(pool).Target; target.Name != "gpu" {
+		t.Fatalf("target = %s, want gpu", target.Name)
+	}
+
+	fallback := fallbackPool(pool, activatorv1alpha1.FallbackTarget{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "gpu"})
+	if target := poolconfig.For(fallback).Target; target.Kind != "StatefulSet" || target.Name != "gpu" {
+		t.Errorf("fallback target = %s/%s, want StatefulSet/gpu", target.Kind, target.Name)
+	}
+	if _, ok := fallback.Annotations[AdditionalTargetsKey]; ok {
+		t.Error("fallback pool scales the additional targets of the target workload")
+	}
+	if pool.Annotations[ObjectkindKey] != "Deployment" {
+		t.Error("fallbackPool() changed the given pool")
+	}
+}
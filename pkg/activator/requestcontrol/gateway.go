@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ParseGatewayRateLimits parses per-gateway rate limits in requests per second, e.g. "gw-a=100,gw-b=2.5".
+func ParseGatewayRateLimits(value string) (map[string]float64, error) {
+	limits := map[string]float64{}
+	if strings.TrimSpace(value) == "" {
+		return limits, nil
+	}
+	for _, entry := range strings.Split(value, ",") {
+		gateway, limit, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || gateway == "" {
+			return nil, fmt.Errorf("invalid gateway rate limit %q, expected <gateway>=<requests per second>", entry)
+		}
+		rps, err := strconv.ParseFloat(limit, 64)
+		if err != nil || rps <= 0 {
+			return nil, fmt.Errorf("invalid gateway rate limit %q, the rate must be a positive number", entry)
+		}
+		limits[gateway] = rps
+	}
+	return limits, nil
+}
+
+// GatewayLimiter enforces the rate limits of the gateways sharing the activator. Requests from gateways
+// without rate limit are never limited.
+type GatewayLimiter struct {
+	limiters map[string]*rate.Limiter
+}
+
+// NewGatewayLimiter creates a GatewayLimiter with the given rate limits in requests per second. Each
+// gateway may burst up to one second worth of requests.
+func NewGatewayLimiter(limits map[string]float64) *GatewayLimiter {
+	limiters := make(map[string]*rate.Limiter, len(limits))
+	for gateway, rps := range limits {
+		limiters[gateway] = rate.NewLimiter(rate.Limit(rps), max(int(math.Ceil(rps)), 1))
+	}
+	return &GatewayLimiter{limiters: limiters}
+}
+
+// Allow reports whether a request from the given gateway is within its rate limit.
+func (l *GatewayLimiter) Allow(gateway string, now time.Time) bool {
+	limiter, ok := l.limiters[gateway]
+	return !ok || limiter.AllowN(now, 1)
+}
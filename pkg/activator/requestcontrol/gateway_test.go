@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseGatewayRateLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]float64
+		wantErr bool
+	}{
+		{name: "empty", value: "", want: map[string]float64{}},
+		{name: "several gateways", value: "gw-a=100, gw-b=2.5", want: map[string]float64{"gw-a": 100, "gw-b": 2.5}},
+		{name: "missing rate", value: "gw-a", wantErr: true},
+		{name: "missing gateway", value: "=10", wantErr: true},
+		{name: "zero rate", value: "gw-a=0", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseGatewayRateLimits(test.value)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseGatewayRateLimits() error = %v, wantErr %t", err, test.wantErr)
+			}
+			if diff := cmp.Diff(test.want, got); !test.wantErr && diff != "" {
+				t.Errorf("ParseGatewayRateLimits() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGatewayLimiterAllow(t *testing.T) {
+	now := time.Now()
+	limiter := NewGatewayLimiter(map[string]float64{"gw-a": 2})
+
+	for i := 0; i < 2; i++ {
+		if !limiter.Allow("gw-a", now) {
+			t.Fatalf("request %d within the burst of gw-a was limited", i)
+		}
+	}
+	if limiter.Allow("gw-a", now) {
+		t.Errorf("request beyond the burst of gw-a was allowed")
+	}
+	if !limiter.Allow("gw-a", now.Add(time.Second)) {
+		t.Errorf("request of gw-a was limited after its tokens were refilled")
+	}
+	if !limiter.Allow("gw-b", now) {
+		t.Errorf("request of gw-b without rate limit was limited")
+	}
+}
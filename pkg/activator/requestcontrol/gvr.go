@@ -19,6 +19,9 @@ package requestcontrol
 import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 )
 
 // GetResourceForKind returns GroupVersionResource for specified apiVersion (groupVersion) and Kind
@@ -37,3 +40,9 @@ func GetResourceForKind(mapper meta.RESTMapper, apiVersion string, kind string)
 
 	return mapping.Resource, nil
 }
+
+// targetResourceFor returns the GroupVersionResource of the target workload of the given pool
+func targetResourceFor(mapper meta.RESTMapper, pool *v1.InferencePool) (schema.GroupVersionResource, error) {
+	target := poolconfig.For(pool).Target
+	return GetResourceForKind(mapper, target.APIVersion, target.Kind)
+}
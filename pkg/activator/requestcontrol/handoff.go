@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// StateSnapshot is the in-memory state of an activator, exported by the activator being replaced and imported
+// by the new activator version of a blue-green upgrade, so that the idle timer and the activation history of
+// the pool survive the switchover.
+type StateSnapshot struct {
+	// Pool is the namespace/name of the InferencePool the state belongs to.
+	Pool string `json:"pool"`
+	// State is the activation state of the pool. Only the Idle and Active states are imported, the others
+	// belonging to a scale operation of the exporting activator.
+	State datastore.PoolState `json:"state"`
+	// LastRequest is the time of the last request of the pool, if any.
+	LastRequest *time.Time `json:"lastRequest,omitempty"`
+	// WarmUntil is the time until which the pool is known to be warm, if any.
+	WarmUntil *time.Time `json:"warmUntil,omitempty"`
+	// ScaleDowns are the scale downs of the pool over the last hour, counted against its cap of cold starts.
+	ScaleDowns []time.Time `json:"scaleDowns,omitempty"`
+	// Traffic is the number of requests received per Unix second over the right-sizing recommendation window.
+	Traffic map[int64]int64 `json:"traffic,omitempty"`
+	// Queue summarizes the requests held by the exporting activator. It is not imported.
+	Queue QueueSummary `json:"queue"`
+}
+
+// QueueSummary summarizes the requests held by an activator.
+type QueueSummary struct {
+	// Held is the number of requests held until the pool is ready.
+	Held int32 `json:"held"`
+	// ScalingUp reports whether a scale up from zero of the pool is in progress.
+	ScalingUp bool `json:"scalingUp"`
+}
+
+// StateTransfer exports and imports the in-memory state of the activator and the deactivator of the pool.
+type StateTransfer struct {
+	datastore   datastore.Datastore
+	activator   *Activator
+	deactivator *Deactivator
+}
+
+func NewStateTransfer(datastore datastore.Datastore, activator *Activator, deactivator *Deactivator) *StateTransfer {
+	return &StateTransfer{datastore: datastore, activator: activator, deactivator: deactivator}
+}
+
+// Export returns the state of the pool at the given time.
+func (t *StateTransfer) Export(now time.Time) (StateSnapshot, error) {
+	pool, err := t.datastore.PoolGet()
+	if err != nil {
+		return StateSnapshot{}, err
+	}
+
+	scalingUp, _ := t.activator.isScalingUp()
+	snapshot := StateSnapshot{
+		Pool:  pool.Namespace + "/" + pool.Name,
+		State: t.datastore.PoolState(),
+		Queue: QueueSummary{Held: t.activator.held.Load(), ScalingUp: scalingUp},
+	}
+	if lastRequest := t.activator.lastRequest.Load(); lastRequest != 0 {
+		at := time.Unix(0, lastRequest)
+		snapshot.LastRequest = &at
+	}
+	if warmUntil := t.activator.warmUntil.Load(); warmUntil > now.UnixNano() {
+		at := time.Unix(0, warmUntil)
+		snapshot.WarmUntil = &at
+	}
+	if t.deactivator != nil {
+		snapshot.ScaleDowns = t.deactivator.coldStarts.snapshot(now)
+	}
+	if t.activator.Recommender != nil {
+		snapshot.Traffic = t.activator.Recommender.snapshot(now)
+	}
+	return snapshot, nil
+}
+
+// Import merges the given state of the pool, exported by another activator, into the state of this one. The
+// most recent of the two last requests rebuilds the idle timer of the pool.
+func (t *StateTransfer) Import(ctx context.Context, snapshot StateSnapshot, now time.Time) error {
+	logger := log.FromContext(ctx)
+	pool, err := t.datastore.PoolGet()
+	if err != nil {
+		return err
+	}
+	if poolName := pool.Namespace + "/" + pool.Name; snapshot.Pool != poolName {
+		return fmt.Errorf("state of inferencePool %q cannot be imported into the activator of inferencePool %q", snapshot.Pool, poolName)
+	}
+
+	switch snapshot.State {
+	case datastore.PoolIdle:
+		t.datastore.PoolTransition(datastore.PoolIdle, datastore.PoolActive)
+	case datastore.PoolActive:
+		t.datastore.PoolTransition(datastore.PoolActive, datastore.PoolIdle)
+	}
+
+	if snapshot.LastRequest != nil && snapshot.LastRequest.UnixNano() > t.activator.lastRequest.Load() {
+		lastRequest := *snapshot.LastRequest
+		t.activator.lastRequest.Store(lastRequest.UnixNano())
+		if t.activator.IdleClock != nil {
+			t.activator.IdleClock.Touch(lastRequest)
+		}
+		timer := remainingIdleTimer(lastRequest, now, scaleDownDelayFor(pool))
+		t.datastore.ResetTicker(timer)
+		logger.V(logutil.DEBUG).Info("Rebuilt idle timer of the inferencePool from the imported state", "lastRequest", lastRequest, "timer", timer)
+	}
+	if snapshot.WarmUntil != nil && snapshot.WarmUntil.UnixNano() > t.activator.warmUntil.Load() {
+		t.activator.warmUntil.Store(snapshot.WarmUntil.UnixNano())
+	}
+	if t.deactivator != nil {
+		t.deactivator.coldStarts.restore(snapshot.ScaleDowns, now)
+	}
+	if t.activator.Recommender != nil {
+		t.activator.Recommender.restore(snapshot.Traffic, now)
+	}
+
+	logger.Info(fmt.Sprintf("Imported the state of inferencePool %s", pool.Name), "state", snapshot.State, "scaleDowns", len(snapshot.ScaleDowns))
+	return nil
+}
+
+// Handler serves the state of the pool as JSON on GET, and imports the state of another activator on PUT.
+func (t *StateTransfer) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			snapshot, err := t.Export(time.Now())
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(snapshot)
+		case http.MethodPut:
+			var snapshot StateSnapshot
+			if err := json.NewDecoder(r.Body).Decode(&snapshot); err != nil {
+				http.Error(w, fmt.Sprintf("invalid state: %v", err), http.StatusBadRequest)
+				return
+			}
+			if err := t.Import(r.Context(), snapshot, time.Now()); err != nil {
+				http.Error(w, err.Error(), http.StatusConflict)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
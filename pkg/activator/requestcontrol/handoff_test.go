@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func newStateTransfer(pool *v1.InferencePool) (*StateTransfer, datastore.Datastore) {
+	ds := datastore.NewDatastore(context.Background())
+	ds.PoolSet(pool)
+	activator := &Activator{datastore: ds, Recommender: NewRecommender(ds, time.Hour)}
+	deactivator := &Deactivator{datastore: &ds}
+	return NewStateTransfer(ds, activator, deactivator), ds
+}
+
+func TestStateTransfer(t *testing.T) {
+	now := time.Now()
+	pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"}}
+
+	old, oldDatastore := newStateTransfer(pool)
+	oldDatastore.PoolTransition(datastore.PoolIdle, datastore.PoolActive)
+	old.activator.keepWarm(pool, now.Add(-2*time.Minute))
+	old.activator.Recommender.Observe(now.Add(-time.Minute))
+	old.deactivator.coldStarts.record(now.Add(-10 * time.Minute))
+
+	snapshot, err := old.Export(now)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	// The snapshot goes through the admin endpoint as JSON
+	body, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var imported StateSnapshot
+	if err := json.Unmarshal(body, &imported); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	upgraded, upgradedDatastore := newStateTransfer(pool)
+	upgraded.deactivator.coldStarts.record(now.Add(-5 * time.Minute))
+	if err := upgraded.Import(context.Background(), imported, now); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if state := upgradedDatastore.PoolState(); state != datastore.PoolIdle {
+		t.Errorf("pool state = %s, want %s", state, datastore.PoolIdle)
+	}
+	if lastRequest := upgraded.activator.lastRequest.Load(); lastRequest != now.Add(-2*time.Minute).UnixNano() {
+		t.Errorf("last request = %v, want %v", time.Unix(0, lastRequest), now.Add(-2*time.Minute))
+	}
+	if scaleDowns := upgraded.deactivator.coldStarts.snapshot(now); len(scaleDowns) != 2 {
+		t.Errorf("scale downs = %v, want the imported and the recorded one", scaleDowns)
+	}
+	if traffic := upgraded.activator.Recommender.snapshot(now); traffic[now.Add(-time.Minute).Unix()] != 1 {
+		t.Errorf("traffic = %v, want the imported request", traffic)
+	}
+
+	// Importing the state again must not count the same scale downs twice
+	if err := upgraded.Import(context.Background(), imported, now); err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if scaleDowns := upgraded.deactivator.coldStarts.snapshot(now); len(scaleDowns) != 2 {
+		t.Errorf("scale downs after a second import = %v, want 2", scaleDowns)
+	}
+
+	other, _ := newStateTransfer(&v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"}})
+	if err := other.Import(context.Background(), imported, now); err == nil {
+		t.Error("Import() of the state of another pool succeeded, want an error")
+	}
+}
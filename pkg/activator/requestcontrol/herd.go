@@ -0,0 +1,247 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+const (
+	// ActivationPriorityKey orders the scale ups from zero of pools waking at once, e.g. after a gateway restart
+	// or a regional failover. Pools of lower priority values get the free activation slots first.
+	ActivationPriorityKey = poolconfig.ActivationPriorityKey // Optional annotation
+
+	// DefaultActivationPriority is the activation priority of pools without an activation priority annotation
+	DefaultActivationPriority = poolconfig.DefaultActivationPriority
+
+	DefaultActivationBatchInterval = time.Duration(5 * time.Second)
+	DefaultActivationJitter        = time.Duration(2 * time.Second)
+
+	// activationSlotPrefix names the Leases holding the activation slots of a namespace
+	activationSlotPrefix = "activator-activation-slot-"
+)
+
+// HerdConfig bounds the scale ups from zero in flight at once in a namespace, across all the activators
+// of its pools, so that many pools waking simultaneously do not overload the API server and the scheduler.
+type HerdConfig struct {
+	// Slots is the number of scale ups from zero allowed in flight at once in the namespace.
+	Slots int
+	// BatchInterval separates the attempts of the pools waiting for a slot, pools of priority p retrying
+	// every p+1 intervals, so that lower priority values get the slots freed first.
+	BatchInterval time.Duration
+	// Jitter is the maximum random delay added to every attempt, spreading the pools of a batch.
+	Jitter time.Duration
+}
+
+// Enabled reports whether the scale ups from zero are bounded.
+func (c HerdConfig) Enabled() bool {
+	return c.Slots > 0
+}
+
+// herdDelay returns the delay before the given attempt of a pool of the given priority to get a slot.
+func herdDelay(config HerdConfig, priority, attempt int, jitter func(time.Duration) time.Duration) time.Duration {
+	delay := jitter(config.Jitter)
+	if attempt > 0 {
+		delay += time.Duration(priority+1) * config.BatchInterval
+	}
+	return delay
+}
+
+func randomJitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return rand.N(max)
+}
+
+// ActivationSlots is a semaphore of scale ups from zero shared by the activators of a namespace, whose slots
+// are Leases held by the pools scaling up. Slots fail open: a pool scales up when the Leases cannot be read
+// or written.
+type ActivationSlots struct {
+	client dynamic.Interface
+	config HerdConfig
+	// prefix names the Leases holding the slots
+	prefix string
+	// priority returns the priority of the pools waiting for a slot
+	priority func(pool *v1.InferencePool) int
+}
+
+func NewActivationSlots(client dynamic.Interface, config HerdConfig) *ActivationSlots {
+	return &ActivationSlots{client: client, config: config, prefix: activationSlotPrefix, priority: activationPriorityFor}
+}
+
+// Acquire waits for a free activation slot in the namespace of the pool, holding it for at most the given
+// time, and returns the function releasing it. It fails if no slot was freed before the context is done.
+func (s *ActivationSlots) Acquire(ctx context.Context, pool *v1.InferencePool, ttl time.Duration) (func(), error) {
+	priority := s.priority(pool)
+	holder := pool.Namespace + "/" + pool.Name
+	for attempt := 0; ; attempt++ {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("no slot freed in namespace %s after %d attempts: %w", pool.Namespace, attempt, ctx.Err())
+		case <-time.After(herdDelay(s.config, priority, attempt, randomJitter)):
+		}
+
+		slot, acquired, err := s.tryAcquire(ctx, pool.Namespace, holder, ttl, time.Now())
+		if err != nil {
+			return func() {}, nil
+		}
+		if acquired {
+			return func() { s.release(context.Background(), pool.Namespace, slot, holder) }, nil
+		}
+	}
+}
+
+// tryAcquire takes the first free or expired slot of the namespace. It returns the name of the slot taken,
+// or an error if the slots cannot be read.
+func (s *ActivationSlots) tryAcquire(ctx context.Context, namespace, holder string, ttl time.Duration, now time.Time) (string, bool, error) {
+	leases := s.client.Resource(leaseGVR).Namespace(namespace)
+	for i := 0; i < s.config.Slots; i++ {
+		name := s.prefix + strconv.Itoa(i)
+		lease, err := leases.Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			lease = &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "coordination.k8s.io/v1",
+				"kind":       "Lease",
+				"metadata":   map[string]any{"name": name, "namespace": namespace},
+				"spec":       activationSlot(holder, ttl, now),
+			}}
+			if _, err := leases.Create(ctx, lease, metav1.CreateOptions{}); err == nil {
+				return name, true, nil
+			}
+			continue
+		}
+		if err != nil {
+			return "", false, err
+		}
+
+		if heldBy, expiry := activationHolder(lease); heldBy != "" && heldBy != holder && now.Before(expiry) {
+			continue
+		}
+		if err := unstructured.SetNestedMap(lease.Object, activationSlot(holder, ttl, now), "spec"); err != nil {
+			continue
+		}
+		// The resource version of the Lease makes concurrent acquisitions of the same slot conflict
+		if _, err := leases.Update(ctx, lease, metav1.UpdateOptions{}); err == nil {
+			return name, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// release frees the given slot, unless it expired and was taken by another pool meanwhile.
+func (s *ActivationSlots) release(ctx context.Context, namespace, slot, holder string) {
+	leases := s.client.Resource(leaseGVR).Namespace(namespace)
+	lease, err := leases.Get(ctx, slot, metav1.GetOptions{})
+	if err != nil {
+		return
+	}
+	if heldBy, _ := activationHolder(lease); heldBy != holder {
+		return
+	}
+	unstructured.RemoveNestedField(lease.Object, "spec", "holderIdentity")
+	_, _ = leases.Update(ctx, lease, metav1.UpdateOptions{})
+}
+
+// activationSlot returns the Lease spec of a slot held by the given pool.
+func activationSlot(holder string, ttl time.Duration, now time.Time) map[string]any {
+	return map[string]any{
+		"holderIdentity":       holder,
+		"acquireTime":          metav1.NewMicroTime(now).UTC().Format(metav1.RFC3339Micro),
+		"leaseDurationSeconds": int64(math.Ceil(ttl.Seconds())),
+	}
+}
+
+// activationPriorityFor returns the activation priority of the given pool.
+func activationPriorityFor(pool *v1.InferencePool) int {
+	return poolconfig.For(pool).ActivationPriority
+}
+
+// acquireActivationSlot waits for an activation slot before the scale up from zero of the given pool, if
+// activation slots are enabled. It returns the function releasing the slot.
+func (a *Activator) acquireActivationSlot(ctx context.Context, pool *v1.InferencePool, ttl time.Duration) (func(), error) {
+	if a.Slots == nil {
+		return func() {}, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, ttl)
+	defer cancel()
+	return a.Slots.Acquire(ctx, pool, ttl)
+}
+
+// SimulateHerd simulates pools of the given priorities waking at once, each scale up from zero holding its
+// slot for the given duration, and returns when each pool starts scaling up. It lets operators size the
+// activation slots of a namespace against a thundering herd without waking real pools.
+func SimulateHerd(config HerdConfig, priorities []int, activation time.Duration, seed uint64) []time.Duration {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	jitter := func(max time.Duration) time.Duration {
+		if max <= 0 {
+			return 0
+		}
+		return time.Duration(rng.Int64N(int64(max)))
+	}
+
+	starts := make([]time.Duration, len(priorities))
+	next := make([]time.Duration, len(priorities))
+	attempts := make([]int, len(priorities))
+	pending := make(map[int]bool, len(priorities))
+	for i, priority := range priorities {
+		next[i] = herdDelay(config, priority, 0, jitter)
+		pending[i] = true
+	}
+
+	var running []time.Duration
+	for len(pending) > 0 {
+		pool := -1
+		for i := range pending {
+			if pool < 0 || next[i] < next[pool] || (next[i] == next[pool] && i < pool) {
+				pool = i
+			}
+		}
+		now := next[pool]
+
+		inFlight := running[:0]
+		for _, end := range running {
+			if end > now {
+				inFlight = append(inFlight, end)
+			}
+		}
+		running = inFlight
+
+		if !config.Enabled() || len(running) < config.Slots {
+			starts[pool] = now
+			running = append(running, now+activation)
+			delete(pending, pool)
+			continue
+		}
+		attempts[pool]++
+		next[pool] = now + herdDelay(config, priorities[pool], attempts[pool], jitter)
+	}
+	return starts
+}
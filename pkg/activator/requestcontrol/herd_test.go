@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestActivationSlots(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	ttl := time.Minute
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{leaseGVR: "LeaseList"})
+	slots := NewActivationSlots(client, HerdConfig{Slots: 2})
+
+	steps := []struct {
+		name         string
+		holder       string
+		at           time.Time
+		release      string
+		wantAcquired bool
+	}{
+		{name: "first pool", holder: "default/a", at: now, wantAcquired: true},
+		{name: "second pool", holder: "default/b", at: now, wantAcquired: true},
+		{name: "third pool while the slots are held", holder: "default/c", at: now, wantAcquired: false},
+		{name: "third pool once a slot is released", holder: "default/c", at: now, release: "default/a", wantAcquired: true},
+		{name: "fourth pool once the slots expired", holder: "default/d", at: now.Add(2 * ttl), wantAcquired: true},
+	}
+
+	held := map[string]string{}
+	for _, step := range steps {
+		if step.release != "" {
+			slots.release(ctx, "default", held[step.release], step.release)
+		}
+		slot, acquired, err := slots.tryAcquire(ctx, "default", step.holder, ttl, step.at)
+		if err != nil {
+			t.Fatalf("%s: tryAcquire() error = %v", step.name, err)
+		}
+		if acquired != step.wantAcquired {
+			t.Errorf("%s: tryAcquire() acquired = %t, want %t", step.name, acquired, step.wantAcquired)
+		}
+		held[step.holder] = slot
+	}
+}
+
+func TestSimulateHerd(t *testing.T) {
+	config := HerdConfig{Slots: 5, BatchInterval: 5 * time.Second, Jitter: 2 * time.Second}
+	activation := 30 * time.Second
+	priorities := make([]int, 100)
+	for i := range priorities {
+		priorities[i] = i % 2
+	}
+
+	starts := SimulateHerd(config, priorities, activation, 1)
+
+	// Never more scale ups in flight than slots
+	for _, start := range starts {
+		inFlight := 0
+		for _, other := range starts {
+			if other <= start && start < other+activation {
+				inFlight++
+			}
+		}
+		if inFlight > config.Slots {
+			t.Fatalf("%d scale ups in flight at %v, want at most %d", inFlight, start, config.Slots)
+		}
+	}
+
+	// Lower priority values scale up first
+	var first, second []time.Duration
+	for i, start := range starts {
+		if priorities[i] == 0 {
+			first = append(first, start)
+		} else {
+			second = append(second, start)
+		}
+	}
+	if slices.Max(first) >= slices.Max(second) {
+		t.Errorf("last scale up of priority 0 at %v, want before the last one of priority 1 at %v", slices.Max(first), slices.Max(second))
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+// activationRecordSuffix is appended to the name of the pool to name the Lease recording its activations
+const activationRecordSuffix = "-activator-activation"
+
+// ActivationRecordName returns the name of the Lease recording the activations of the given pool.
+func ActivationRecordName(poolName string) string {
+	return poolName + activationRecordSuffix
+}
+
+// activationKey identifies an activation of the pool across activator replicas and restarts. The epoch of
+// the pool counts its scale downs, so that every scale from zero gets its own key.
+func activationKey(pool *v1.InferencePool, epoch int64) string {
+	return fmt.Sprintf("%s/%d/%d", pool.UID, pool.Generation, epoch)
+}
+
+// ActivationClaims records the activations of the pool in a Lease, so that a single activator replica
+// scales the pool up from zero, and records the activation in metrics and audits, however many replicas
+// or restarts go through the same scale from zero. The holder of the Lease is the key of the last claimed
+// activation, and its lease transitions are the epoch of the pool.
+type ActivationClaims struct {
+	client dynamic.Interface
+}
+
+func NewActivationClaims(client dynamic.Interface) *ActivationClaims {
+	return &ActivationClaims{client: client}
+}
+
+// Claim claims the activation of the current epoch of the pool for the given time. It returns the key of the
+// activation, and whether it was claimed by the caller rather than by another activator whose claim has not
+// expired yet. Claims fail open, the activation is claimed when the Lease cannot be read or written.
+func (c *ActivationClaims) Claim(ctx context.Context, pool *v1.InferencePool, ttl time.Duration, now time.Time) (string, bool) {
+	leases := c.client.Resource(leaseGVR).Namespace(pool.Namespace)
+	lease, err := leases.Get(ctx, ActivationRecordName(pool.Name), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		key := activationKey(pool, 0)
+		lease = &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "coordination.k8s.io/v1",
+			"kind":       "Lease",
+			"metadata":   map[string]any{"name": ActivationRecordName(pool.Name), "namespace": pool.Namespace},
+			"spec":       activationClaim(key, 0, ttl, now),
+		}}
+		_, err := leases.Create(ctx, lease, metav1.CreateOptions{})
+		return key, !apierrors.IsAlreadyExists(err)
+	}
+	if err != nil {
+		return activationKey(pool, 0), true
+	}
+
+	epoch, _, _ := unstructured.NestedInt64(lease.Object, "spec", "leaseTransitions")
+	key := activationKey(pool, epoch)
+	if claimedBy, expiry := activationHolder(lease); claimedBy == key && now.Before(expiry) {
+		return key, false
+	}
+	if err := unstructured.SetNestedMap(lease.Object, activationClaim(key, epoch, ttl, now), "spec"); err != nil {
+		return key, true
+	}
+	// The resource version of the Lease makes concurrent claims of the same activation conflict
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	return key, !apierrors.IsConflict(err)
+}
+
+// Advance starts a new epoch of the pool after it was scaled down to zero, so that its next scale from zero
+// is a new activation.
+func (c *ActivationClaims) Advance(ctx context.Context, pool *v1.InferencePool) error {
+	leases := c.client.Resource(leaseGVR).Namespace(pool.Namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		lease, err := leases.Get(ctx, ActivationRecordName(pool.Name), metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		epoch, _, _ := unstructured.NestedInt64(lease.Object, "spec", "leaseTransitions")
+		if err := unstructured.SetNestedField(lease.Object, epoch+1, "spec", "leaseTransitions"); err != nil {
+			return err
+		}
+		unstructured.RemoveNestedField(lease.Object, "spec", "holderIdentity")
+		_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// activationClaim returns the Lease spec claiming the activation of the given key.
+func activationClaim(key string, epoch int64, ttl time.Duration, now time.Time) map[string]any {
+	return map[string]any{
+		"holderIdentity":       key,
+		"leaseTransitions":     epoch,
+		"acquireTime":          metav1.NewMicroTime(now).UTC().Format(metav1.RFC3339Micro),
+		"leaseDurationSeconds": int64(math.Ceil(ttl.Seconds())),
+	}
+}
+
+// activationHolder returns the key of the activation claimed in the given Lease, and when the claim expires.
+func activationHolder(lease *unstructured.Unstructured) (string, time.Time) {
+	holder, _, _ := unstructured.NestedString(lease.Object, "spec", "holderIdentity")
+	value, _, _ := unstructured.NestedString(lease.Object, "spec", "acquireTime")
+	duration, _, _ := unstructured.NestedInt64(lease.Object, "spec", "leaseDurationSeconds")
+	acquired, err := time.Parse(metav1.RFC3339Micro, value)
+	if err != nil {
+		return holder, time.Time{}
+	}
+	return holder, acquired.Add(time.Duration(duration) * time.Second)
+}
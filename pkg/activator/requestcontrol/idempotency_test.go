@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestActivationClaims(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	ttl := time.Minute
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{leaseGVR: "LeaseList"})
+	claims := NewActivationClaims(client)
+	pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", UID: "uid", Generation: 2}}
+
+	steps := []struct {
+		name        string
+		at          time.Time
+		advance     bool
+		wantKey     string
+		wantClaimed bool
+	}{
+		{name: "first activation", at: now, wantKey: "uid/2/0", wantClaimed: true},
+		{name: "same activation from another replica", at: now.Add(time.Second), wantKey: "uid/2/0", wantClaimed: false},
+		{name: "same activation after the claim expired", at: now.Add(2 * ttl), wantKey: "uid/2/0", wantClaimed: true},
+		{name: "activation after a scale down", at: now.Add(2*ttl + time.Second), advance: true, wantKey: "uid/2/1", wantClaimed: true},
+	}
+
+	for _, step := range steps {
+		if step.advance {
+			if err := claims.Advance(ctx, pool); err != nil {
+				t.Fatalf("%s: Advance() error = %v", step.name, err)
+			}
+		}
+		key, claimed := claims.Claim(ctx, pool, ttl, step.at)
+		if key != step.wantKey || claimed != step.wantClaimed {
+			t.Errorf("%s: Claim() = (%q, %t), want (%q, %t)", step.name, key, claimed, step.wantKey, step.wantClaimed)
+		}
+	}
+}
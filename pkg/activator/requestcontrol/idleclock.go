@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// DefaultIdleClockInterval is the minimum interval between two writes of the time of the last request of
+	// the pool, bounding the API writes to one per interval however many requests the pool serves
+	DefaultIdleClockInterval = time.Duration(5 * time.Second)
+
+	// IdleClockFieldManager owns the Lease persisting the idle clock of the pool
+	IdleClockFieldManager = "llm-d-activator-idle-clock"
+
+	// idleClockSuffix is appended to the name of the pool to name the Lease persisting its idle clock
+	idleClockSuffix = "-activator-idle"
+
+	// idleClockFlushTimeout bounds the last write of the idle clock on shutdown
+	idleClockFlushTimeout = time.Duration(2 * time.Second)
+
+	// minIdleTimer is the shortest idle timer rebuilt by a new leader, to let it sync before scaling down
+	minIdleTimer = time.Duration(1 * time.Second)
+)
+
+var leaseGVR = schema.GroupVersionResource{Group: "coordination.k8s.io", Version: "v1", Resource: "leases"}
+
+// IdleClockName returns the name of the Lease persisting the idle clock of the given pool.
+func IdleClockName(poolName string) string {
+	return poolName + idleClockSuffix
+}
+
+// IdleClock persists the time of the last request of the pool in a Lease, so that a restarted activator
+// or a new leader can rebuild the idle timer of the pool, instead of restarting the idle period from
+// scratch. Requests only record their time in memory, which is written at most once per interval and
+// only when it moved forward.
+type IdleClock struct {
+	client    dynamic.Interface
+	datastore datastore.Datastore
+
+	// lastRequest and persisted are Unix nanoseconds
+	lastRequest atomic.Int64
+	persisted   atomic.Int64
+}
+
+func NewIdleClock(client dynamic.Interface, datastore datastore.Datastore) *IdleClock {
+	return &IdleClock{client: client, datastore: datastore}
+}
+
+// Touch records a request of the pool at the given time.
+func (c *IdleClock) Touch(now time.Time) {
+	storeLatest(&c.lastRequest, now.UnixNano())
+}
+
+// Run persists the time of the last request of the pool at the given interval until the context is done,
+// and one last time on shutdown.
+func (c *IdleClock) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Don't inherit the parent context, it is already cancelled
+			flushCtx, cancel := context.WithTimeout(context.Background(), idleClockFlushTimeout)
+			c.flush(log.IntoContext(flushCtx, log.FromContext(ctx)))
+			cancel()
+			return
+		case <-ticker.C:
+			c.flush(ctx)
+		}
+	}
+}
+
+// flush writes the time of the last request of the pool if it moved forward since the last write.
+func (c *IdleClock) flush(ctx context.Context) {
+	lastRequest := c.lastRequest.Load()
+	if lastRequest == 0 || lastRequest == c.persisted.Load() {
+		return
+	}
+	pool, err := c.datastore.PoolGet()
+	if err != nil {
+		return
+	}
+	if err := c.persist(ctx, pool, time.Unix(0, lastRequest)); err != nil {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Error persisting the idle clock of the inferencePool", "error", err.Error())
+		return
+	}
+	c.persisted.Store(lastRequest)
+	metrics.RecordIdleClockWrite(pool.Namespace + "/" + pool.Name)
+}
+
+func (c *IdleClock) persist(ctx context.Context, pool *v1.InferencePool, lastRequest time.Time) error {
+	lease := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "coordination.k8s.io/v1",
+		"kind":       "Lease",
+		"metadata":   map[string]any{"name": IdleClockName(pool.Name), "namespace": pool.Namespace},
+		"spec": map[string]any{
+			"renewTime": metav1.NewMicroTime(lastRequest).UTC().Format(metav1.RFC3339Micro),
+		},
+	}}
+	_, err := c.client.Resource(leaseGVR).Namespace(pool.Namespace).Apply(ctx, lease.GetName(), lease, metav1.ApplyOptions{FieldManager: IdleClockFieldManager, Force: true})
+	return err
+}
+
+// LastRequest returns the persisted time of the last request of the given pool, if any.
+func (c *IdleClock) LastRequest(ctx context.Context, pool *v1.InferencePool) (time.Time, bool) {
+	lease, err := c.client.Resource(leaseGVR).Namespace(pool.Namespace).Get(ctx, IdleClockName(pool.Name), metav1.GetOptions{})
+	if err != nil {
+		return time.Time{}, false
+	}
+	value, found, _ := unstructured.NestedString(lease.Object, "spec", "renewTime")
+	if !found {
+		return time.Time{}, false
+	}
+	lastRequest, err := time.Parse(metav1.RFC3339Micro, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return lastRequest, true
+}
+
+// remainingIdleTimer returns the part of the scale down delay still to wait for after the last request. Clock
+// skew between replicas never extends the timer beyond the scale down delay.
+func remainingIdleTimer(lastRequest, now time.Time, scaleDownDelay time.Duration) time.Duration {
+	return min(max(scaleDownDelay-now.Sub(lastRequest), minIdleTimer), scaleDownDelay)
+}
+
+// scaleDownDelayFor returns the scale down delay of the given inferencePool.
+func scaleDownDelayFor(pool *v1.InferencePool) time.Duration {
+	return poolconfig.For(pool).ScaleDownDelay
+}
+
+// rebuildIdleTimer sets the idle timer of the pool from its persisted idle clock. It reports whether the
+// pool has been idle for its whole scale down delay already.
+func (da *Deactivator) rebuildIdleTimer(ctx context.Context, pool *v1.InferencePool) bool {
+	logger := log.FromContext(ctx)
+
+	lastRequest, ok := da.IdleClock.LastRequest(ctx, pool)
+	if !ok {
+		logger.V(logutil.DEBUG).Info("No persisted idle clock for the inferencePool, starting a new idle period")
+		return false
+	}
+	scaleDownDelay := scaleDownDelayFor(pool)
+	timer := remainingIdleTimer(lastRequest, time.Now(), scaleDownDelay)
+	logger.Info(fmt.Sprintf("Rebuilt idle timer of inferencePool %s from its last request at %s", pool.Name, lastRequest.Format(time.RFC3339)), "timer", timer)
+	(*da.datastore).ResetTicker(timer)
+	return time.Since(lastRequest) >= scaleDownDelay
+}
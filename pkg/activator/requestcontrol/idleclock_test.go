@@ -0,0 +1,44 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRemainingIdleTimer(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		lastRequest time.Time
+		want        time.Duration
+	}{
+		{name: "recent request", lastRequest: now.Add(-2 * time.Minute), want: 8 * time.Minute},
+		{name: "idle period elapsed", lastRequest: now.Add(-time.Hour), want: minIdleTimer},
+		{name: "request in the future", lastRequest: now.Add(time.Minute), want: 10 * time.Minute},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := remainingIdleTimer(test.lastRequest, now, 10*time.Minute); got != test.want {
+				t.Errorf("remainingIdleTimer() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,371 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+const (
+	// IdlenessKey selects the predicates defining when the pool is idle. Predicates are combined with
+	// "&&" and "||", "&&" taking precedence, e.g. "last-request && no-in-flight || external".
+	IdlenessKey = poolconfig.IdlenessKey // Optional annotation
+
+	// Idleness predicate specific annotations
+	InFlightMetricKey            = poolconfig.InFlightMetricKey            // Optional, used by the no-in-flight predicate
+	QueueMetricKey               = poolconfig.QueueMetricKey               // Optional, used by the queue-empty predicate
+	IdlenessWebhookKey           = poolconfig.IdlenessWebhookKey           // Required by the external predicate
+	EndpointPickerQueueMetricKey = poolconfig.EndpointPickerQueueMetricKey // Optional, used by the epp-queue-empty predicate
+
+	DefaultInFlightMetric = "vllm:num_requests_running"
+	DefaultQueueMetric    = "vllm:num_requests_waiting"
+
+	// DefaultEndpointPickerQueueMetric is the gauge of the requests queued by the flow control of the Endpoint
+	// Picker, and endpointPickerPoolLabel the label of its samples naming their pool
+	DefaultEndpointPickerQueueMetric = "inference_extension_flow_control_queue_size"
+	endpointPickerPoolLabel          = "inference_pool"
+
+	LastRequestPredicateName         = "last-request"
+	NoInFlightPredicateName          = "no-in-flight"
+	QueueEmptyPredicateName          = "queue-empty"
+	ExternalPredicateName            = "external"
+	EndpointPickerQueuePredicateName = "epp-queue-empty"
+
+	// DefaultIdleness is the idleness definition of pools without an idleness annotation
+	DefaultIdleness = LastRequestPredicateName
+
+	idlenessTimeout = 5 * time.Second
+)
+
+var podGVR = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+// IdlenessPredicate decides whether an InferencePool is idle. Predicates are evaluated by the Deactivator
+// once the pool received no request for its scale down delay.
+type IdlenessPredicate interface {
+	Idle(ctx context.Context, pool *v1.InferencePool) (bool, error)
+}
+
+// IdlenessPredicateFactory creates an IdlenessPredicate with the given clients.
+type IdlenessPredicateFactory func(clients StrategyClients) IdlenessPredicate
+
+var (
+	predicatesMu sync.RWMutex
+	predicates   = map[string]IdlenessPredicateFactory{
+		LastRequestPredicateName: func(StrategyClients) IdlenessPredicate { return lastRequestPredicate{} },
+		NoInFlightPredicateName: func(c StrategyClients) IdlenessPredicate {
+			return &podMetricPredicate{clients: c, metric: func(config *poolconfig.Config) string { return config.InFlightMetric }, defaultMetric: DefaultInFlightMetric}
+		},
+		QueueEmptyPredicateName: func(c StrategyClients) IdlenessPredicate {
+			return &podMetricPredicate{clients: c, metric: func(config *poolconfig.Config) string { return config.QueueMetric }, defaultMetric: DefaultQueueMetric}
+		},
+		ExternalPredicateName: func(StrategyClients) IdlenessPredicate {
+			return &externalPredicate{httpClient: &http.Client{Timeout: idlenessTimeout}}
+		},
+		EndpointPickerQueuePredicateName: func(StrategyClients) IdlenessPredicate {
+			return &endpointPickerQueuePredicate{httpClient: &http.Client{Timeout: idlenessTimeout}}
+		},
+	}
+)
+
+// RegisterIdlenessPredicate registers an idleness predicate selectable with the idleness annotation.
+// It is meant to be called before the activator starts, e.g. for out-of-tree predicates.
+func RegisterIdlenessPredicate(name string, factory IdlenessPredicateFactory) {
+	predicatesMu.Lock()
+	defer predicatesMu.Unlock()
+
+	predicates[name] = factory
+}
+
+// RegisteredIdlenessPredicates returns the sorted names of the registered idleness predicates.
+func RegisteredIdlenessPredicates() []string {
+	predicatesMu.RLock()
+	defer predicatesMu.RUnlock()
+
+	names := make([]string, 0, len(predicates))
+	for name := range predicates {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// newIdlenessPredicates instantiates all registered idleness predicates with the given clients.
+func newIdlenessPredicates(clients StrategyClients) map[string]IdlenessPredicate {
+	predicatesMu.RLock()
+	defer predicatesMu.RUnlock()
+
+	instances := make(map[string]IdlenessPredicate, len(predicates))
+	for name, factory := range predicates {
+		instances[name] = factory(clients)
+	}
+	return instances
+}
+
+// parseIdleness parses the idleness definition of the pool into a disjunction of conjunctions of predicate names.
+func parseIdleness(pool *v1.InferencePool) ([][]string, error) {
+	definition := DefaultIdleness
+	if value := poolconfig.For(pool).Idleness; value != "" {
+		definition = value
+	}
+
+	registered := RegisteredIdlenessPredicates()
+	var disjunction [][]string
+	for _, term := range strings.Split(definition, "||") {
+		var conjunction []string
+		for _, name := range strings.Split(term, "&&") {
+			name = strings.TrimSpace(name)
+			if !slices.Contains(registered, name) {
+				return nil, fmt.Errorf("invalid annotation '%s' on pool '%s': unknown idleness predicate %q, registered predicates: %s",
+					IdlenessKey, pool.Name, name, strings.Join(registered, ", "))
+			}
+			conjunction = append(conjunction, name)
+		}
+		disjunction = append(disjunction, conjunction)
+	}
+	return disjunction, nil
+}
+
+// validateIdleness checks the idleness definition of the pool and the options of the predicates it uses.
+func validateIdleness(pool *v1.InferencePool) error {
+	disjunction, err := parseIdleness(pool)
+	if err != nil {
+		return err
+	}
+	config := poolconfig.For(pool)
+	for _, conjunction := range disjunction {
+		if slices.Contains(conjunction, ExternalPredicateName) {
+			if err := requireIdlenessAnnotation(pool, IdlenessWebhookKey, config.IdlenessURL); err != nil {
+				return err
+			}
+		}
+		if slices.Contains(conjunction, EndpointPickerQueuePredicateName) {
+			if err := requireIdlenessAnnotation(pool, EndpointPickerMetricsURLKey, config.EndpointPickerMetricsURL); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// poolIdle evaluates the idleness definition of the pool with the given predicate instances.
+func poolIdle(ctx context.Context, instances map[string]IdlenessPredicate, pool *v1.InferencePool) (bool, error) {
+	disjunction, err := parseIdleness(pool)
+	if err != nil {
+		return false, err
+	}
+
+	var errs []error
+	for _, conjunction := range disjunction {
+		idle := true
+		for _, name := range conjunction {
+			predicateIdle, err := instances[name].Idle(ctx, pool)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("idleness predicate %q: %w", name, err))
+			}
+			if err != nil || !predicateIdle {
+				idle = false
+				break
+			}
+		}
+		if idle {
+			return true, nil
+		}
+	}
+	if len(errs) > 0 {
+		return false, errs[0]
+	}
+	return false, nil
+}
+
+func requireIdlenessAnnotation(pool *v1.InferencePool, key, value string) error {
+	if value == "" {
+		return fmt.Errorf("annotation '%s' is required by idleness definition %q on pool '%s'", key, poolconfig.For(pool).Idleness, pool.Name)
+	}
+	return nil
+}
+
+// lastRequestPredicate considers the pool idle once it received no request for its scale down delay,
+// which is when the Deactivator evaluates idleness. This is the default predicate.
+type lastRequestPredicate struct{}
+
+func (lastRequestPredicate) Idle(context.Context, *v1.InferencePool) (bool, error) {
+	return true, nil
+}
+
+// podMetricPredicate considers the pool idle when a gauge exposed by the model servers of the pool is
+// zero on every pod of the pool, e.g. the number of running or waiting requests of vLLM.
+type podMetricPredicate struct {
+	clients StrategyClients
+	// metric returns the gauge set by the pool, if any
+	metric        func(config *poolconfig.Config) string
+	defaultMetric string
+}
+
+func (p *podMetricPredicate) Idle(ctx context.Context, pool *v1.InferencePool) (bool, error) {
+	metricName := p.defaultMetric
+	if value := p.metric(poolconfig.For(pool)); value != "" {
+		metricName = value
+	}
+	if len(pool.Spec.TargetPorts) == 0 {
+		return false, fmt.Errorf("pool '%s' has no target port to scrape", pool.Name)
+	}
+
+	selector := make(labels.Set, len(pool.Spec.Selector.MatchLabels))
+	for k, v := range pool.Spec.Selector.MatchLabels {
+		selector[string(k)] = string(v)
+	}
+	pods, err := p.clients.DynamicClient.Resource(podGVR).Namespace(pool.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return false, err
+	}
+
+	httpClient := &http.Client{Timeout: idlenessTimeout}
+	for _, pod := range pods.Items {
+		podIP, _, _ := unstructured.NestedString(pod.Object, "status", "podIP")
+		if podIP == "" {
+			continue
+		}
+		value, err := scrapeGauge(ctx, httpClient, fmt.Sprintf("http://%s:%d/metrics", podIP, pool.Spec.TargetPorts[0].Number), metricName)
+		if err != nil {
+			return false, fmt.Errorf("failed to scrape pod '%s': %w", pod.GetName(), err)
+		}
+		if value > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// scrapeGauge returns the sum of the samples of the given gauge exposed at the given URL.
+func scrapeGauge(ctx context.Context, httpClient *http.Client, url, metricName string) (float64, error) {
+	total, found, err := scrapeGaugeSamples(ctx, httpClient, url, metricName, "", "")
+	if err == nil && !found {
+		return 0, fmt.Errorf("metric %q not found", metricName)
+	}
+	return total, err
+}
+
+// scrapeGaugeSamples returns the sum of the samples of the given gauge exposed at the given URL, and whether the
+// gauge is exposed. When a label is given, the samples setting it to another value than the given one are left out.
+func scrapeGaugeSamples(ctx context.Context, httpClient *http.Client, url, metricName, label, value string) (float64, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, false, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, false, fmt.Errorf("metrics endpoint returned status %d", resp.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return 0, false, err
+	}
+	family, ok := families[metricName]
+	if !ok {
+		return 0, false, nil
+	}
+	var total float64
+samples:
+	for _, m := range family.GetMetric() {
+		for _, pair := range m.GetLabel() {
+			if label != "" && pair.GetName() == label && pair.GetValue() != value {
+				continue samples
+			}
+		}
+		total += m.GetGauge().GetValue()
+	}
+	return total, true, nil
+}
+
+// endpointPickerQueuePredicate considers the pool idle when the flow control of its Endpoint Picker queues
+// none of its requests, so that requests admitted by the gateway but not yet dispatched to a model server
+// do not get lost to a scale down, e.g. with "last-request && epp-queue-empty". The Endpoint Picker is
+// scraped at the Endpoint Picker metrics annotation of the pool. An Endpoint Picker without flow control,
+// which does not expose the queue gauge, queues no request.
+type endpointPickerQueuePredicate struct {
+	httpClient *http.Client
+}
+
+func (p *endpointPickerQueuePredicate) Idle(ctx context.Context, pool *v1.InferencePool) (bool, error) {
+	config := poolconfig.For(pool)
+	metricName := DefaultEndpointPickerQueueMetric
+	if config.EndpointPickerQueueMetric != "" {
+		metricName = config.EndpointPickerQueueMetric
+	}
+	queued, _, err := scrapeGaugeSamples(ctx, p.httpClient, config.EndpointPickerMetricsURL, metricName, endpointPickerPoolLabel, pool.Name)
+	if err != nil {
+		return false, fmt.Errorf("failed to scrape the Endpoint Picker: %w", err)
+	}
+	return queued == 0, nil
+}
+
+// externalPredicate delegates the idleness decision to an operator provided HTTP(S) endpoint. The endpoint
+// is called with the namespace and name of the pool as query parameters and answers a JSON object
+// whose "idle" field holds the decision.
+type externalPredicate struct {
+	httpClient *http.Client
+}
+
+func (p *externalPredicate) Idle(ctx context.Context, pool *v1.InferencePool) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, poolconfig.For(pool).IdlenessURL, nil)
+	if err != nil {
+		return false, err
+	}
+	query := req.URL.Query()
+	query.Set("namespace", pool.Namespace)
+	query.Set("pool", pool.Name)
+	req.URL.RawQuery = query.Encode()
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("idleness endpoint returned status %d", resp.StatusCode)
+	}
+
+	var decision struct {
+		Idle bool `json:"idle"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decision); err != nil {
+		return false, fmt.Errorf("failed to decode idleness decision: %w", err)
+	}
+	return decision.Idle, nil
+}
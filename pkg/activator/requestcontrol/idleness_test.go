@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+type staticPredicate bool
+
+func (p staticPredicate) Idle(context.Context, *v1.InferencePool) (bool, error) {
+	return bool(p), nil
+}
+
+func TestPoolIdle(t *testing.T) {
+	instances := map[string]IdlenessPredicate{
+		LastRequestPredicateName: staticPredicate(true),
+		NoInFlightPredicateName:  staticPredicate(false),
+		QueueEmptyPredicateName:  staticPredicate(true),
+		ExternalPredicateName:    staticPredicate(false),
+	}
+
+	tests := []struct {
+		name     string
+		idleness string
+		want     bool
+		wantErr  bool
+	}{
+		{name: "Default definition", want: true},
+		{name: "Conjunction", idleness: "last-request && no-in-flight", want: false},
+		{name: "Disjunction", idleness: "no-in-flight || queue-empty", want: true},
+		{name: "Conjunction takes precedence", idleness: "external || last-request && queue-empty", want: true},
+		{name: "Unknown predicate", idleness: "last-request && unknown", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+			if test.idleness != "" {
+				pool.Annotations = map[string]string{IdlenessKey: test.idleness}
+			}
+
+			got, err := poolIdle(context.Background(), instances, pool)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("poolIdle() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestEndpointPickerQueuePredicate(t *testing.T) {
+	tests := []struct {
+		name     string
+		metrics  string
+		wantIdle bool
+	}{
+		{name: "queues of the pool empty", metrics: `inference_extension_flow_control_queue_size{inference_pool="pool",priority="0"} 0` + "\n" +
+			`inference_extension_flow_control_queue_size{inference_pool="other",priority="0"} 4`, wantIdle: true},
+		{name: "request queued for the pool", metrics: `inference_extension_flow_control_queue_size{inference_pool="pool",priority="1"} 2`, wantIdle: false},
+		{name: "flow control disabled", metrics: `inference_pool_ready_pods{name="pool"} 1`, wantIdle: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				fmt.Fprintf(w, "# TYPE inference_extension_flow_control_queue_size gauge\n# TYPE inference_pool_ready_pods gauge\n%s\n", test.metrics)
+			}))
+			defer server.Close()
+
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Annotations: map[string]string{
+				IdlenessKey:                 "last-request && epp-queue-empty",
+				EndpointPickerMetricsURLKey: server.URL,
+			}}}
+			if err := validateIdleness(pool); err != nil {
+				t.Fatalf("validateIdleness() error = %v", err)
+			}
+			predicate := &endpointPickerQueuePredicate{httpClient: server.Client()}
+			idle, err := predicate.Idle(context.Background(), pool)
+			if err != nil {
+				t.Fatalf("Idle() error = %v", err)
+			}
+			if idle != test.wantIdle {
+				t.Errorf("Idle() = %t, want %t", idle, test.wantIdle)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+const (
+	// InitialScaleKey is the number of replicas the pool is scaled up to from zero, e.g. "2" for heavy models
+	// whose first requests would overwhelm a single replica.
+	InitialScaleKey = poolconfig.InitialScaleKey // Optional annotation
+
+	// InitialScaleRequestsPerReplicaKey scales the initial replicas of the pool with the requests held when it
+	// is scaled up from zero, one replica per the given number of requests, up to its max replicas if set.
+	InitialScaleRequestsPerReplicaKey = poolconfig.InitialScaleRequestsPerReplicaKey // Optional annotation
+
+	// MinReplicasKey is the floor of the replicas of the pool, e.g. "1" for latency-critical pools kept warm. The
+	// deactivator never scales down pools with a floor of one or more, and the pool is scaled up from zero to
+	// at least its floor.
+	MinReplicasKey = poolconfig.MinReplicasKey // Optional annotation
+
+	// DefaultInitialScale is the initial scale of pools without an initial scale annotation
+	DefaultInitialScale = 1
+)
+
+// initialScaleFor returns the number of replicas the given pool is scaled up to from zero, given the number
+// of requests held for it and the initial scale of pools without an initial scale annotation, and no less than
+// its min replicas.
+func initialScaleFor(pool *v1.InferencePool, held int32, defaultScale int32) int32 {
+	config := poolconfig.For(pool)
+	replicas := max(defaultScale, 1, config.MinReplicas)
+	if config.InitialScale > 0 {
+		replicas = max(config.InitialScale, config.MinReplicas)
+	}
+
+	perReplica := config.InitialScaleRequestsPerReplica
+	if perReplica == 0 {
+		return replicas
+	}
+	scaled := int32((int64(held) + int64(perReplica) - 1) / int64(perReplica))
+	if config.MaxReplicas > 0 {
+		scaled = min(scaled, config.MaxReplicas)
+	}
+	return max(replicas, scaled)
+}
+
+// minReplicasFor returns the floor of the replicas of the given pool, zero when it sets none.
+func minReplicasFor(pool *v1.InferencePool) int32 {
+	return poolconfig.For(pool).MinReplicas
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestInitialScaleFor(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		held         int32
+		defaultScale int32
+		want         int32
+	}{
+		{name: "default", defaultScale: 1, want: 1},
+		{name: "unset default", defaultScale: 0, want: 1},
+		{name: "configured default", defaultScale: 3, want: 3},
+		{name: "annotation overrides default", annotations: map[string]string{InitialScaleKey: "2"}, defaultScale: 3, want: 2},
+		{
+			name:        "scaled by held requests",
+			annotations: map[string]string{InitialScaleKey: "1", InitialScaleRequestsPerReplicaKey: "8"},
+			held:        20,
+			want:        3,
+		},
+		{
+			name:        "few held requests keep the initial scale",
+			annotations: map[string]string{InitialScaleKey: "2", InitialScaleRequestsPerReplicaKey: "8"},
+			held:        3,
+			want:        2,
+		},
+		{
+			name:         "scaled by held requests up to max replicas",
+			annotations:  map[string]string{InitialScaleRequestsPerReplicaKey: "1", MaxReplicasKey: "4"},
+			held:         50,
+			defaultScale: 1,
+			want:         4,
+		},
+		{name: "raised to min replicas", annotations: map[string]string{MinReplicasKey: "2"}, defaultScale: 1, want: 2},
+		{name: "annotation raised to min replicas", annotations: map[string]string{InitialScaleKey: "1", MinReplicasKey: "3"}, want: 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Annotations: test.annotations}}
+			if got := initialScaleFor(pool, test.held, test.defaultScale); got != test.want {
+				t.Errorf("initialScaleFor() = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateMinReplicas(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantErr     bool
+	}{
+		{name: "no floor"},
+		{name: "zero floor", annotations: map[string]string{MinReplicasKey: "0"}},
+		{name: "floor within max replicas", annotations: map[string]string{MinReplicasKey: "2", MaxReplicasKey: "4"}},
+		{name: "negative floor", annotations: map[string]string{MinReplicasKey: "-1"}, wantErr: true},
+		{name: "floor above max replicas", annotations: map[string]string{MinReplicasKey: "5", MaxReplicasKey: "4"}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Annotations: test.annotations}}
+			if err := poolconfig.Parse(pool).ErrOf(MinReplicasKey); (err != nil) != test.wantErr {
+				t.Errorf("poolconfig.Parse().ErrOf(MinReplicasKey) error = %v, wantErr %t", err, test.wantErr)
+			}
+		})
+	}
+}
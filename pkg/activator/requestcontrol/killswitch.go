@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// KillSwitchKey is the key of the kill switch ConfigMap turning the kill switch on fleet-wide when "true".
+	KillSwitchKey = "deactivation-disabled"
+
+	// DefaultKillSwitchInterval is the interval the kill switch ConfigMap is read at.
+	DefaultKillSwitchInterval = 5 * time.Second
+)
+
+// KillSwitchState is the state of the kill switch served by its admin API.
+type KillSwitchState struct {
+	// On reports whether the scale downs to zero are disabled, by either source.
+	On bool `json:"on"`
+	// Fleet reports whether the kill switch ConfigMap turned the kill switch on.
+	Fleet bool `json:"fleet"`
+	// Local reports whether the admin API of this activator turned the kill switch on.
+	Local bool `json:"local"`
+}
+
+// KillSwitch disables the scale downs to zero of the pool while it is on, leaving its activations alone, for
+// incident response when scale downs are suspected of causing an outage. It is turned on fleet-wide from the
+// KillSwitchKey of a ConfigMap read by every activator, or on a single activator through its admin API.
+type KillSwitch struct {
+	client    dynamic.Interface
+	configMap types.NamespacedName
+	fleet     atomic.Bool
+	local     atomic.Bool
+}
+
+// NewKillSwitch returns a kill switch read from the given ConfigMap, or only turned through its admin API when
+// the name of the ConfigMap is empty.
+func NewKillSwitch(client dynamic.Interface, configMap types.NamespacedName) *KillSwitch {
+	metrics.RecordDeactivationKillSwitch("fleet", false)
+	metrics.RecordDeactivationKillSwitch("local", false)
+	return &KillSwitch{client: client, configMap: configMap}
+}
+
+// On reports whether the scale downs to zero are disabled.
+func (s *KillSwitch) On() bool {
+	return s.fleet.Load() || s.local.Load()
+}
+
+// State returns the state of the kill switch.
+func (s *KillSwitch) State() KillSwitchState {
+	return KillSwitchState{On: s.On(), Fleet: s.fleet.Load(), Local: s.local.Load()}
+}
+
+// Run reads the kill switch ConfigMap at the given interval until the context is done.
+func (s *KillSwitch) Run(ctx context.Context, interval time.Duration) {
+	if s.configMap.Name == "" {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.refresh(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh reads the kill switch ConfigMap. A missing ConfigMap turns the kill switch off, while the last state
+// read is kept when the ConfigMap cannot be read.
+func (s *KillSwitch) refresh(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	configMap, err := s.client.Resource(configMapGVR).Namespace(s.configMap.Namespace).Get(ctx, s.configMap.Name, metav1.GetOptions{})
+	on := false
+	switch {
+	case errors.IsNotFound(err):
+	case err != nil:
+		logger.V(logutil.DEBUG).Info("Error reading the kill switch configMap, keeping its last state", "configMap", s.configMap.String(), "error", err.Error())
+		return
+	default:
+		value, _, _ := unstructured.NestedString(configMap.Object, "data", KillSwitchKey)
+		on, _ = strconv.ParseBool(value)
+	}
+	s.set(ctx, &s.fleet, "fleet", on)
+}
+
+// set turns the given source of the kill switch on or off, logging the changes.
+func (s *KillSwitch) set(ctx context.Context, source *atomic.Bool, name string, on bool) {
+	if source.Swap(on) == on {
+		return
+	}
+	metrics.RecordDeactivationKillSwitch(name, on)
+	if on {
+		log.FromContext(ctx).Info("Kill switch turned on, scale downs to zero are disabled", "source", name)
+	} else {
+		log.FromContext(ctx).Info("Kill switch turned off", "source", name)
+	}
+}
+
+// Handler serves the state of the kill switch, and turns it on or off on this activator with PUT ?on=true or
+// ?on=false. The kill switch ConfigMap turns it on regardless.
+func (s *KillSwitch) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			on, err := strconv.ParseBool(r.URL.Query().Get("on"))
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid on parameter: %v", err), http.StatusBadRequest)
+				return
+			}
+			s.set(r.Context(), &s.local, "local", on)
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(s.State())
+	})
+}
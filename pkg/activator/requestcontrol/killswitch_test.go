@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestKillSwitch(t *testing.T) {
+	configMap := func(data map[string]any) runtime.Object {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": "activator-kill-switch", "namespace": "activator-system"},
+			"data":       data,
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		local   string
+		want    KillSwitchState
+	}{
+		{name: "no configMap"},
+		{name: "turned on fleet-wide", objects: []runtime.Object{configMap(map[string]any{KillSwitchKey: "true"})}, want: KillSwitchState{On: true, Fleet: true}},
+		{name: "turned off fleet-wide", objects: []runtime.Object{configMap(map[string]any{KillSwitchKey: "false"})}},
+		{name: "turned on locally", local: "true", want: KillSwitchState{On: true, Local: true}},
+		{name: "turned off locally", objects: []runtime.Object{configMap(map[string]any{KillSwitchKey: "true"})}, local: "false", want: KillSwitchState{On: true, Fleet: true}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), test.objects...)
+			s := NewKillSwitch(client, types.NamespacedName{Namespace: "activator-system", Name: "activator-kill-switch"})
+			s.refresh(context.Background())
+
+			method := http.MethodGet
+			if test.local != "" {
+				method = http.MethodPut
+			}
+			w := httptest.NewRecorder()
+			s.Handler().ServeHTTP(w, httptest.NewRequest(method, "/admin/kill-switch?on="+test.local, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+			}
+			var got KillSwitchState
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatalf("invalid state: %v", err)
+			}
+			if got != test.want || s.On() != test.want.On {
+				t.Errorf("state = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
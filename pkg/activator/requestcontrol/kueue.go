@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// KueueQueueNameLabel marks workloads whose pods are admitted by Kueue
+	KueueQueueNameLabel = "kueue.x-k8s.io/queue-name"
+	// KueueAdmissionGate is the scheduling gate Kueue sets on pods until their workload is admitted
+	KueueAdmissionGate = "kueue.x-k8s.io/admission"
+
+	QueuedTimeoutKey = poolconfig.QueuedTimeoutKey // Optional annotation
+
+	// DefaultQueuedTimeout is the time we will wait for Kueue to admit the pods of a scale from zero
+	DefaultQueuedTimeout = poolconfig.DefaultQueuedTimeout
+)
+
+// kueueManaged reports whether the pods of the target workload are admitted by Kueue.
+func kueueManaged(target *unstructured.Unstructured) bool {
+	if _, ok := target.GetLabels()[KueueQueueNameLabel]; ok {
+		return true
+	}
+	templateLabels, _, _ := unstructured.NestedStringMap(target.Object, "spec", "template", "metadata", "labels")
+	_, ok := templateLabels[KueueQueueNameLabel]
+	return ok
+}
+
+// waitForKueueAdmission waits until Kueue admitted the given number of pods of the inferencePool, that is
+// until that many pods exist without the Kueue admission scheduling gate. It reports whether they were
+// admitted within the queued timeout of the pool, and before the given scale up context was cancelled.
+func (a *Activator) waitForKueueAdmission(ctx context.Context, logger logr.Logger, pool *v1.InferencePool, numReplicas int32) bool {
+	a.queuedForCapacity.Store(true)
+	defer a.queuedForCapacity.Store(false)
+
+	return kueueAdmitted(ctx, logger, a.DynamicClient, pool, numReplicas, poolconfig.For(pool).QueuedTimeout, func() {
+		a.datastore.ResetTicker(DefaultScaleDownDelay) // turn off the deactivator while queued for capacity
+	})
+}
+
+// kueueAdmitted polls the pods of the given inferencePool until Kueue admitted the given number of them, and
+// reports whether they were admitted within the given queued timeout, and before the given context was
+// cancelled. The given function is called on every poll.
+func kueueAdmitted(ctx context.Context, logger logr.Logger, client dynamic.Interface, pool *v1.InferencePool, numReplicas int32, queuedTimeout time.Duration, poll func()) bool {
+	err := wait.PollUntilContextTimeout(ctx, 1*time.Second, queuedTimeout, true, func(ctx context.Context) (bool, error) {
+		poll()
+
+		pods, err := client.Resource(podGVR).Namespace(pool.Namespace).List(ctx, metav1.ListOptions{LabelSelector: poolPodSelector(pool)})
+		if err != nil {
+			logger.V(logutil.DEBUG).Info("Error listing inferencePool pods", "error", err.Error())
+			return false, nil
+		}
+
+		var admitted int32
+		for _, pod := range pods.Items {
+			if pod.GetDeletionTimestamp() != nil || hasSchedulingGate(&pod, KueueAdmissionGate) {
+				continue
+			}
+			admitted++
+		}
+		logger.V(logutil.DEBUG).Info("Waiting for Kueue admission", "admitted", admitted, "desired", numReplicas)
+		return admitted >= numReplicas, nil
+	})
+	return err == nil
+}
+
+func hasSchedulingGate(pod *unstructured.Unstructured, name string) bool {
+	gates, _, _ := unstructured.NestedSlice(pod.Object, "spec", "schedulingGates")
+	for _, g := range gates {
+		if gate, ok := g.(map[string]any); ok && gate["name"] == name {
+			return true
+		}
+	}
+	return false
+}
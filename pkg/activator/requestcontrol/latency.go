@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"sync"
+	"time"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/handlers"
+)
+
+// Stages of the cold start of the requests held for a scale up from zero, in order.
+const (
+	// StageParse is the time the activator took to process the headers of the request
+	StageParse = "parse"
+	// StageQueue is the time the request waited for the pipeline of the pool and for the scale up to start,
+	// e.g. for an activation slot
+	StageQueue = "queue"
+	// StageScale is the time the scale API calls took
+	StageScale = "scale"
+	// StagePodReadiness is the time the pods took to be ready, warmed up included
+	StagePodReadiness = "pod_readiness"
+	// StageEndpointPickerSync is the time the Endpoint Picker took to report the ready pods
+	StageEndpointPickerSync = "epp_sync"
+	// StageRelease is the time the request took to be released once the pool was ready, e.g. paced or gated
+	StageRelease = "release"
+)
+
+// timelineMark is a step of the scale up from zero of the pool.
+type timelineMark int
+
+const (
+	scaleStarted timelineMark = iota
+	scaled
+	podsReady
+	endpointPickerSynced
+	timelineMarks
+)
+
+// activationTimeline records when the scale up from zero of the pool in progress, or the last one, went
+// through each of its steps, for the requests held for it to break their latency down by stage.
+type activationTimeline struct {
+	mu sync.Mutex
+	at [timelineMarks]time.Time
+}
+
+// begin starts the timeline of a new scale up from zero.
+func (t *activationTimeline) begin() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.at = [timelineMarks]time.Time{}
+}
+
+// mark records that the scale up from zero in progress went through the given step.
+func (t *activationTimeline) mark(step timelineMark, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.at[step] = now
+}
+
+// stages breaks the latency of a request received, entering the pipeline of the pool, held from start to end
+// down by stage. The steps of the scale up are clipped to the time the request was held, so that a request
+// joining a scale up in progress is only charged what it waited for. Unmarked steps charge the time to the pod
+// readiness, e.g. when the scale up was left to another activator or the pods were not ready in time.
+func (t *activationTimeline) stages(received, entered, start, end time.Time) []handlers.StageDuration {
+	t.mu.Lock()
+	at := t.at
+	t.mu.Unlock()
+
+	for step, defaults := range [timelineMarks]time.Time{scaleStarted: start, scaled: start, podsReady: end, endpointPickerSynced: end} {
+		if at[step].IsZero() {
+			at[step] = defaults
+		}
+	}
+	previous := start
+	for step := range at {
+		if at[step].Before(previous) {
+			at[step] = previous
+		}
+		if at[step].After(end) {
+			at[step] = end
+		}
+		previous = at[step]
+	}
+
+	parse := time.Duration(0)
+	if !received.IsZero() {
+		parse = max(entered.Sub(received), 0)
+	}
+	return []handlers.StageDuration{
+		{Stage: StageParse, Duration: parse},
+		{Stage: StageQueue, Duration: start.Sub(entered) + at[scaleStarted].Sub(start)},
+		{Stage: StageScale, Duration: at[scaled].Sub(at[scaleStarted])},
+		{Stage: StagePodReadiness, Duration: at[podsReady].Sub(at[scaled])},
+		{Stage: StageEndpointPickerSync, Duration: at[endpointPickerSynced].Sub(at[podsReady])},
+		{Stage: StageRelease, Duration: end.Sub(at[endpointPickerSynced])},
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActivationTimelineStages(t *testing.T) {
+	base := time.Now()
+	at := func(seconds int) time.Time { return base.Add(time.Duration(seconds) * time.Second) }
+
+	tests := []struct {
+		name  string
+		marks map[timelineMark]time.Time
+		start time.Time
+		want  map[string]time.Duration
+	}{
+		{
+			name:  "request triggering the scale up",
+			marks: map[timelineMark]time.Time{scaleStarted: at(3), scaled: at(4), podsReady: at(40), endpointPickerSynced: at(42)},
+			start: at(2),
+			want:  map[string]time.Duration{StageParse: time.Second, StageQueue: 2 * time.Second, StageScale: time.Second, StagePodReadiness: 36 * time.Second, StageEndpointPickerSync: 2 * time.Second, StageRelease: 3 * time.Second},
+		},
+		{
+			name:  "request joining the scale up in progress",
+			marks: map[timelineMark]time.Time{scaleStarted: at(-10), scaled: at(-9), podsReady: at(40), endpointPickerSynced: at(42)},
+			start: at(2),
+			want:  map[string]time.Duration{StageParse: time.Second, StageQueue: time.Second, StagePodReadiness: 38 * time.Second, StageEndpointPickerSync: 2 * time.Second, StageRelease: 3 * time.Second},
+		},
+		{
+			name:  "scale up left to another activator",
+			start: at(2),
+			want:  map[string]time.Duration{StageParse: time.Second, StageQueue: time.Second, StagePodReadiness: 43 * time.Second},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var timeline activationTimeline
+			timeline.begin()
+			for step, when := range test.marks {
+				timeline.mark(step, when)
+			}
+			stages := timeline.stages(at(0), at(1), test.start, at(45))
+			var total time.Duration
+			for _, stage := range stages {
+				total += stage.Duration
+				if stage.Duration != test.want[stage.Stage] {
+					t.Errorf("stage %s = %v, want %v", stage.Stage, stage.Duration, test.want[stage.Stage])
+				}
+			}
+			if total != 45*time.Second {
+				t.Errorf("stages add up to %v, want the 45s latency of the request", total)
+			}
+		})
+	}
+}
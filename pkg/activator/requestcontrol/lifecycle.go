@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"sync"
+	"time"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/audit"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+)
+
+// ActivationEventType is the kind of an activation lifecycle event.
+type ActivationEventType string
+
+const (
+	// ActivationStarted is emitted when this activator starts scaling a pool up from zero.
+	ActivationStarted ActivationEventType = "ActivationStarted"
+	// ActivationReady is emitted when a pool scaled up from zero by this activator is ready.
+	ActivationReady ActivationEventType = "ActivationReady"
+	// ActivationFailed is emitted when a scale up from zero by this activator failed or was abandoned.
+	ActivationFailed ActivationEventType = "ActivationFailed"
+	// ScaleDownExecuted is emitted when this activator scaled an idle pool down to zero.
+	ScaleDownExecuted ActivationEventType = "ScaleDownExecuted"
+)
+
+// ActivationEvent is a typed activation lifecycle event, mirroring the audit trail of the scale actions.
+type ActivationEvent struct {
+	Type      ActivationEventType
+	Timestamp time.Time
+	// Pool is the namespaced name of the InferencePool.
+	Pool string
+	// Target is the kind/name of the scaled workload, or the strategy/endpoint of external targets.
+	Target   string
+	Replicas int32
+	// RequestID is the gateway request ID of the request that triggered the activation, if any.
+	RequestID string
+	// ActivationKey identifies the activation across activator replicas and restarts, if claimed.
+	ActivationKey string
+	// Reason classifies why a failed activation failed, if known.
+	Reason  string
+	Message string
+	// Duration is how long the activation took, for ActivationReady and ActivationFailed events.
+	Duration time.Duration
+	// ResourceVersionBefore and ResourceVersionAfter are the resource versions of the target workload before
+	// and after it was scaled, if known.
+	ResourceVersionBefore string
+	ResourceVersionAfter  string
+}
+
+// EventBus fans the activation lifecycle events out to in-process subscribers, e.g. embedders and plugins.
+// Events are never waited on: a subscriber whose channel is full misses them.
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers map[chan<- ActivationEvent]struct{}
+}
+
+// NewEventBus creates an EventBus without subscribers.
+func NewEventBus() *EventBus {
+	return &EventBus{subscribers: make(map[chan<- ActivationEvent]struct{})}
+}
+
+// DefaultEventBus is the EventBus the activator publishes to. Embedders subscribe to it before starting
+// the activator.
+var DefaultEventBus = NewEventBus()
+
+// Subscribe delivers the events published from now on to the given channel, until the returned function
+// is called. The channel should be buffered.
+func (b *EventBus) Subscribe(ch chan<- ActivationEvent) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscribers[ch] = struct{}{}
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscribers, ch)
+	}
+}
+
+// publish delivers the given event to the subscribers ready to receive it. It is a no-op on a nil bus.
+func (b *EventBus) publish(event ActivationEvent) {
+	if b == nil {
+		return
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			metrics.RecordActivationEventDropped(string(event.Type))
+		}
+	}
+}
+
+// publishRecord publishes the event of the given type describing the scale action of the given audit record.
+func (b *EventBus) publishRecord(eventType ActivationEventType, record audit.Record, duration time.Duration) {
+	b.publish(ActivationEvent{
+		Type:                  eventType,
+		Timestamp:             record.Timestamp,
+		Pool:                  record.Pool,
+		Target:                record.Target,
+		Replicas:              record.Replicas,
+		RequestID:             record.RequestID,
+		ActivationKey:         record.ActivationKey,
+		Reason:                record.Reason,
+		Message:               record.Message,
+		Duration:              duration,
+		ResourceVersionBefore: record.ResourceVersionBefore,
+		ResourceVersionAfter:  record.ResourceVersionAfter,
+	})
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/audit"
+)
+
+func TestEventBus(t *testing.T) {
+	bus := NewEventBus()
+	ready := make(chan ActivationEvent, 1)
+	full := make(chan ActivationEvent)
+	unsubscribe := bus.Subscribe(ready)
+	bus.Subscribe(full)
+
+	// A subscriber not ready to receive must not block the others
+	bus.publishRecord(ActivationStarted, audit.Record{Pool: "default/pool", RequestID: "req"}, 0)
+	select {
+	case event := <-ready:
+		if event.Type != ActivationStarted || event.Pool != "default/pool" || event.RequestID != "req" || event.Timestamp.IsZero() {
+			t.Errorf("unexpected event %+v", event)
+		}
+	default:
+		t.Fatal("event not delivered to the subscriber")
+	}
+
+	unsubscribe()
+	bus.publishRecord(ScaleDownExecuted, audit.Record{Pool: "default/pool"}, 0)
+	select {
+	case event := <-ready:
+		t.Errorf("event %+v delivered after unsubscribing", event)
+	default:
+	}
+
+	// Publishing to a nil bus is a no-op
+	var none *EventBus
+	none.publishRecord(ActivationReady, audit.Record{}, 0)
+}
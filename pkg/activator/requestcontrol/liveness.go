@@ -0,0 +1,206 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/features"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// AbandonedLingerKey enables the cancellation of the scale ups from zero of the pool once every request held for
+// them disconnected, and reverts them to zero replicas after the given linger, e.g. "30s", unless new requests
+// arrive in the meantime.
+const AbandonedLingerKey = poolconfig.AbandonedLingerKey // Optional annotation
+
+// errRequestAbandoned is returned to held requests whose client disconnected. It is not a failure of
+// the pool, and does not count against its circuit breaker.
+var errRequestAbandoned = errutil.Error{Code: errutil.ServiceUnavailable, Msg: "client disconnected while the request was held"}
+
+// errRequestTimedOut is returned to held requests whose gateway timeout expired, as the gateway gave up on
+// them. Like abandoned requests, it is not a failure of the pool.
+var errRequestTimedOut = errutil.Error{Code: errutil.ServiceUnavailable, Msg: "gateway timeout expired while the request was held"}
+
+// errPoolDeleted is returned to held requests whose pool was deleted. It is not a failure of the pool's
+// activation either, and does not count against its circuit breaker.
+var errPoolDeleted = errutil.Error{Code: errutil.ServiceUnavailable, Msg: "inferencePool was deleted while the request was held"}
+
+// abandonedErr returns the error of a held request whose context is done.
+func abandonedErr(ctx context.Context) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return errRequestTimedOut
+	}
+	return errRequestAbandoned
+}
+
+// holdReady runs the readiness check of the pool, which may scale it up from zero, detached from the
+// request, and releases the request as soon as its client disconnects, that is once Envoy closed the
+// processing stream of the request, the pool gets deleted, or the wait of its priority class expires.
+func (a *Activator) holdReady(ctx context.Context, pool *v1.InferencePool, ready func(ctx context.Context) (bool, bool)) (bool, bool, error) {
+	type result struct {
+		ready, scaled bool
+		err           error
+	}
+
+	deleted := a.datastore.PoolDeleted()
+	a.held.Add(1)
+	defer a.trackHeld(ctx)()
+	done := make(chan result, 1)
+	go func() {
+		// A panic of the activation fails the request rather than the activator
+		r := result{scaled: true}
+		defer func() { done <- r }()
+		defer recoverPanic(ctx, pool.Namespace+"/"+pool.Name, &r.err)
+		r.ready, r.scaled = ready(context.WithoutCancel(ctx))
+	}()
+
+	var expired <-chan time.Time
+	wait, class := a.heldWait(ctx, pool)
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case r := <-done:
+		a.held.Add(-1)
+		return r.ready, r.scaled, r.err
+	case <-ctx.Done():
+		a.abandon(ctx)
+		return false, true, abandonedErr(ctx)
+	case <-deleted:
+		a.drainDeleted(ctx, pool)
+		return false, true, errPoolDeleted
+	case <-expired:
+		return false, true, a.expirePriorityWait(ctx, pool, class)
+	}
+}
+
+// holdOnGuard holds the request until the scale up in progress is done or the timeout is reached, and
+// releases it as soon as its client disconnects or the pool gets deleted. Requests of a priority class
+// setting a queue timeout fail once it expires, instead of being released to the pool.
+func (a *Activator) holdOnGuard(ctx context.Context, pool *v1.InferencePool, guard <-chan struct{}) error {
+	deleted := a.datastore.PoolDeleted()
+	a.held.Add(1)
+	defer a.trackHeld(ctx)()
+
+	timeout := poolconfig.For(pool).ScaleFromZeroGracePeriod
+	class := a.priorityClassOf(ctx, pool)
+	if class != nil && class.QueueTimeout != nil {
+		timeout = class.QueueTimeout.Duration
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		a.abandon(ctx)
+		return abandonedErr(ctx)
+	case <-deleted:
+		a.drainDeleted(ctx, pool)
+		return errPoolDeleted
+	case <-timer.C:
+		if class != nil && class.QueueTimeout != nil {
+			return a.expirePriorityWait(ctx, pool, class.Name)
+		}
+	case <-guard:
+	}
+	a.held.Add(-1)
+	return nil
+}
+
+// abandon removes the request whose client disconnected from the held requests, and cancels the scale up
+// in progress when it was the last one held and CancelAbandoned is set. Requests whose gateway timeout
+// expired never cancel the scale up.
+func (a *Activator) abandon(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	timedOut := ctx.Err() == context.DeadlineExceeded
+	if timedOut {
+		logger.V(logutil.DEBUG).Info("Gateway timeout expired while the request was held, releasing it")
+	} else {
+		logger.V(logutil.DEBUG).Info("Client disconnected while the request was held, releasing it")
+	}
+	if pool, err := a.datastore.PoolGet(); err == nil {
+		metrics.RecordAbandonedRequest(pool.Namespace + "/" + pool.Name)
+	}
+
+	// The gateway is likely to retry the requests it timed out, which still need the scale up
+	if a.held.Add(-1) > 0 || timedOut {
+		return
+	}
+	if pool, err := a.datastore.PoolGet(); err != nil || !a.cancelsAbandoned(pool) {
+		return
+	}
+	a.scalingUpAndGuardMu.Lock()
+	defer a.scalingUpAndGuardMu.Unlock()
+	if a.scalingUp && a.cancelScaleUp != nil {
+		logger.Info("Every request held for the scale up from zero disconnected, cancelling it")
+		a.cancelScaleUp()
+	}
+}
+
+// drainDeleted removes the request from the held requests once its pool was deleted, and cancels the scale
+// up in progress, if any, since there is no target left to wait for.
+func (a *Activator) drainDeleted(ctx context.Context, pool *v1.InferencePool) {
+	logger := log.FromContext(ctx)
+	logger.V(logutil.DEBUG).Info("InferencePool deleted while the request was held, failing it", "pool", pool.Name)
+	metrics.RecordPoolDeletedRequest(pool.Namespace + "/" + pool.Name)
+
+	a.held.Add(-1)
+	a.scalingUpAndGuardMu.Lock()
+	defer a.scalingUpAndGuardMu.Unlock()
+	if a.scalingUp && a.cancelScaleUp != nil {
+		logger.Info("InferencePool deleted during its scale up from zero, cancelling it", "pool", pool.Name)
+		a.cancelScaleUp()
+	}
+}
+
+// cancelsAbandoned reports whether the scale ups from zero of the given pool are cancelled once every request
+// held for them disconnected.
+func (a *Activator) cancelsAbandoned(pool *v1.InferencePool) bool {
+	_, revert := abandonedLingerFor(pool)
+	return a.CancelAbandoned || revert
+}
+
+// revertAbandoned hands the cancelled scale up of the given pool back to the Deactivator, which scales it
+// to zero after the abandoned activation linger of the pool unless new requests arrive in the meantime.
+func (a *Activator) revertAbandoned(ctx context.Context, pool *v1.InferencePool) {
+	linger, ok := abandonedLingerFor(pool)
+	if !ok {
+		return
+	}
+	log.FromContext(ctx).Info("Reverting the abandoned scale up from zero", "pool", pool.Name, "linger", linger)
+	a.datastore.ResetTicker(linger)
+}
+
+// abandonedLingerFor returns the abandoned activation linger of the given pool, if it reverts abandoned scale ups.
+func abandonedLingerFor(pool *v1.InferencePool) (time.Duration, bool) {
+	if !features.Enabled(features.AbandonedActivationCancellation) {
+		return 0, false
+	}
+	linger := poolconfig.For(pool).AbandonedLinger
+	return linger, linger > 0
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
+)
+
+func TestAbandonedRequests(t *testing.T) {
+	tests := []struct {
+		name            string
+		cancelAbandoned bool
+		annotations     map[string]string
+		held            int
+		wantCancelled   bool
+	}{
+		{name: "last held request abandoned", cancelAbandoned: true, held: 1, wantCancelled: true},
+		{name: "other requests still held", cancelAbandoned: true, held: 2, wantCancelled: false},
+		{name: "cancellation disabled", cancelAbandoned: false, held: 1, wantCancelled: false},
+		{name: "pool reverting abandoned scale ups", annotations: map[string]string{AbandonedLingerKey: "30s"}, held: 1, wantCancelled: true},
+		{name: "invalid linger", annotations: map[string]string{AbandonedLingerKey: "soon"}, held: 1, wantCancelled: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ds := datastore.NewDatastore(context.Background())
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Annotations: test.annotations}}
+			ds.PoolSet(pool)
+			a := &Activator{datastore: ds, CancelAbandoned: test.cancelAbandoned}
+			activation := a.beginScalingUp()
+			defer a.endScalingUp()
+			_, guard := a.isScalingUp()
+
+			// The other requests stay held on the guard of the scale up
+			for i := 1; i < test.held; i++ {
+				a.held.Add(1)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			if err := a.holdOnGuard(ctx, pool, guard); err != errRequestAbandoned {
+				t.Fatalf("holdOnGuard() error = %v, want %v", err, errRequestAbandoned)
+			}
+			if cancelled := activation.Err() != nil; cancelled != test.wantCancelled {
+				t.Errorf("scale up cancelled = %t, want %t", cancelled, test.wantCancelled)
+			}
+		})
+	}
+}
+
+func TestPoolDeletedWhileHeld(t *testing.T) {
+	pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"}}
+	ds := datastore.NewDatastore(context.Background())
+	ds.PoolSet(pool)
+	a := &Activator{datastore: ds}
+	activation := a.beginScalingUp()
+	defer a.endScalingUp()
+	_, guard := a.isScalingUp()
+
+	ds.Clear()
+	if err := a.holdOnGuard(context.Background(), pool, guard); err != errPoolDeleted {
+		t.Fatalf("holdOnGuard() error = %v, want %v", err, errPoolDeleted)
+	}
+	if _, _, err := a.holdReady(context.Background(), pool, func(ctx context.Context) (bool, bool) {
+		<-activation.Done()
+		return false, true
+	}); err != errPoolDeleted {
+		t.Fatalf("holdReady() error = %v, want %v", err, errPoolDeleted)
+	}
+	if activation.Err() == nil {
+		t.Error("scale up not cancelled after the pool was deleted")
+	}
+	if held := a.held.Load(); held != 0 {
+		t.Errorf("held requests = %d, want 0", held)
+	}
+}
+
+func TestRequestTimedOutWhileHeld(t *testing.T) {
+	pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"}}
+	ds := datastore.NewDatastore(context.Background())
+	ds.PoolSet(pool)
+	a := &Activator{datastore: ds, CancelAbandoned: true}
+	activation := a.beginScalingUp()
+	defer a.endScalingUp()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	if _, _, err := a.holdReady(ctx, pool, func(ctx context.Context) (bool, bool) {
+		<-activation.Done()
+		return false, true
+	}); err != errRequestTimedOut {
+		t.Fatalf("holdReady() error = %v, want %v", err, errRequestTimedOut)
+	}
+	if activation.Err() != nil {
+		t.Error("scale up cancelled after the gateway timeout of the last held request expired")
+	}
+	if held := a.held.Load(); held != 0 {
+		t.Errorf("held requests = %d, want 0", held)
+	}
+}
+
+func TestPanicWhileHeld(t *testing.T) {
+	pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"}}
+	ds := datastore.NewDatastore(context.Background())
+	ds.PoolSet(pool)
+	a := &Activator{datastore: ds}
+
+	ready, _, err := a.holdReady(context.Background(), pool, func(ctx context.Context) (bool, bool) {
+		panic("boom")
+	})
+	if ready || errutil.CanonicalCode(err) != errutil.Internal {
+		t.Fatalf("holdReady() = %t, %v, want an internal error", ready, err)
+	}
+	if held := a.held.Load(); held != 0 {
+		t.Errorf("held requests = %d, want 0", held)
+	}
+}
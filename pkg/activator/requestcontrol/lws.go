@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// The replicas of a LeaderWorkerSet are groups of a leader and its workers, e.g. the nodes of a multi-node
+// vLLM deployment, a group serving requests only once all of its pods are ready. Its status.readyReplicas
+// counts the groups whose pods are all ready.
+const (
+	lwsGroup = "leaderworkerset.x-k8s.io"
+	lwsKind  = "LeaderWorkerSet"
+)
+
+// lwsReadiness reports whether the readiness of the target workload of the given pool follows the semantics
+// of LeaderWorkerSets: the target workload is a LeaderWorkerSet and the pool sets neither a ready condition
+// nor a ready replicas JSONPath, which override them.
+func lwsReadiness(pool *v1.InferencePool) bool {
+	config := poolconfig.For(pool)
+	gv, err := schema.ParseGroupVersion(config.Target.APIVersion)
+	if err != nil || gv.Group != lwsGroup || config.Target.Kind != lwsKind {
+		return false
+	}
+	return config.ReadyCondition.Type == "" && config.ReadyReplicasJSONPath == ""
+}
+
+// releaseReplicasFor returns how many of the given replicas of the target workload of the given pool must be
+// ready for the requests held to be released: a single group of LeaderWorkerSets, whose groups take long to
+// come up together, a single one for Ray targets, seen as a single replica, and all of them otherwise.
+func releaseReplicasFor(pool *v1.InferencePool, numReplicas int32) int32 {
+	if _, ray := rayKind(pool); ray || lwsReadiness(pool) {
+		return min(numReplicas, 1)
+	}
+	return numReplicas
+}
+
+// lwsGroupReadyCheck passes once the LeaderWorkerSet has all the workers of at least one group ready.
+func lwsGroupReadyCheck(logger logr.Logger) func(target *unstructured.Unstructured) bool {
+	return func(target *unstructured.Unstructured) bool {
+		readyGroups, _, _ := unstructured.NestedInt64(target.Object, "status", "readyReplicas")
+		if readyGroups > 0 {
+			logger.V(logutil.DEBUG).Info("LeaderWorkerSet group is READY", "ready-groups", readyGroups)
+			return true
+		}
+		logger.V(logutil.DEBUG).Info("No LeaderWorkerSet group has all of its workers ready - candidate pods are NOT READY")
+		return false
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// ModelCacheCheckKey makes the activator verify that the model weights are cached before declaring the pool
+	// ready after a scale up from zero, so that the first requests do not time out while a large model downloads.
+	// "claim:<name>" waits for the given PersistentVolumeClaim holding the model cache to be bound and marked
+	// cached, e.g. by the job populating it, and "pods" waits for the scaled pods to be marked cached, e.g. by
+	// their init container or a sidecar once the weights are on disk.
+	ModelCacheCheckKey = poolconfig.ModelCacheCheckKey // Optional annotation
+
+	// ModelCachedKey marks the PersistentVolumeClaims or pods whose model weights are cached, with the value "true".
+	ModelCachedKey = "activator.llm-d.ai/model-cached"
+
+	modelCacheCheckPods        = poolconfig.ModelCacheCheckPods
+	modelCacheCheckClaimPrefix = poolconfig.ModelCacheCheckClaimPrefix
+
+	modelCacheCheckInterval = time.Second
+)
+
+// modelCacheReady waits until the model cache of the given pool is warm for the given number of replicas or
+// the timeout elapses. Pools without a model cache check annotation pass at once.
+func modelCacheReady(ctx context.Context, logger logr.Logger, client dynamic.Interface, pool *v1.InferencePool, numReplicas int32, timeout time.Duration) bool {
+	check := poolconfig.For(pool).ModelCacheCheck
+	if check == "" {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(modelCacheCheckInterval)
+	defer ticker.Stop()
+	for {
+		warm, err := modelCacheWarm(ctx, client, pool, check, numReplicas)
+		if err != nil {
+			logger.V(logutil.DEBUG).Info("Error checking the model cache", "check", check, "error", err.Error())
+		} else if warm {
+			logger.V(logutil.DEBUG).Info("Model cache is warm", "check", check)
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("Model cache did not warm up within the scale grace period", "check", check, "replicas", numReplicas)
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// modelCacheWarm reports whether the given model cache check of the pool passes for the given number of replicas.
+func modelCacheWarm(ctx context.Context, client dynamic.Interface, pool *v1.InferencePool, check string, numReplicas int32) (bool, error) {
+	if claimName, found := strings.CutPrefix(check, modelCacheCheckClaimPrefix); found {
+		claim, err := client.Resource(pvcGVR).Namespace(pool.Namespace).Get(ctx, claimName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		phase, _, _ := unstructured.NestedString(claim.Object, "status", "phase")
+		return phase == "Bound" && claim.GetAnnotations()[ModelCachedKey] == "true", nil
+	}
+
+	pods, err := client.Resource(podGVR).Namespace(pool.Namespace).List(ctx, metav1.ListOptions{LabelSelector: poolPodSelector(pool)})
+	if err != nil {
+		return false, err
+	}
+	cached := 0
+	for i := range pods.Items {
+		if pods.Items[i].GetDeletionTimestamp() == nil && pods.Items[i].GetAnnotations()[ModelCachedKey] == "true" {
+			cached++
+		}
+	}
+	return cached >= int(numReplicas), nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestModelCacheWarm(t *testing.T) {
+	claim := func(phase, cached string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "PersistentVolumeClaim",
+			"metadata":   map[string]any{"name": "models", "namespace": "default", "annotations": map[string]any{ModelCachedKey: cached}},
+			"status":     map[string]any{"phase": phase},
+		}}
+	}
+	pod := func(name, cached string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata":   map[string]any{"name": name, "namespace": "default", "labels": map[string]any{"app": "vllm"}, "annotations": map[string]any{ModelCachedKey: cached}},
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		check     string
+		objects   []runtime.Object
+		want      bool
+		wantError bool
+	}{
+		{name: "claim cached", check: "claim:models", objects: []runtime.Object{claim("Bound", "true")}, want: true},
+		{name: "claim populating", check: "claim:models", objects: []runtime.Object{claim("Bound", "false")}},
+		{name: "claim pending", check: "claim:models", objects: []runtime.Object{claim("Pending", "true")}},
+		{name: "claim missing", check: "claim:models", wantError: true},
+		{name: "pods cached", check: "pods", objects: []runtime.Object{pod("vllm-0", "true"), pod("vllm-1", "true")}, want: true},
+		{name: "pod downloading", check: "pods", objects: []runtime.Object{pod("vllm-0", "true"), pod("vllm-1", "")}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1.InferencePool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", Annotations: map[string]string{ModelCacheCheckKey: test.check}},
+				Spec:       v1.InferencePoolSpec{Selector: v1.LabelSelector{MatchLabels: map[v1.LabelKey]v1.LabelValue{"app": "vllm"}}},
+			}
+			if err := poolconfig.Parse(pool).ErrOf(ModelCacheCheckKey); err != nil {
+				t.Fatalf("poolconfig.Parse().ErrOf(ModelCacheCheckKey) error = %v", err)
+			}
+			client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{podGVR: "PodList", pvcGVR: "PersistentVolumeClaimList"}, test.objects...)
+			got, err := modelCacheWarm(context.Background(), client, pool, test.check, 2)
+			if (err != nil) != test.wantError || got != test.want {
+				t.Errorf("modelCacheWarm() = %t, %v, want %t, error %t", got, err, test.want, test.wantError)
+			}
+		})
+	}
+}
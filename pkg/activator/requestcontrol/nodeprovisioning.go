@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// NodeProvisioningTimeoutKey bounds how long the scale grace period of the pool is extended by for as long as
+	// the cluster-autoscaler or Karpenter provision nodes for its unschedulable pods, e.g. "15m". It also extends
+	// the wait for the capacity reservation of target workloads setting a provisioning class.
+	NodeProvisioningTimeoutKey = poolconfig.NodeProvisioningTimeoutKey // Optional annotation
+
+	// DefaultNodeProvisioningTimeout is the node provisioning timeout of pools without node provisioning timeout annotation
+	DefaultNodeProvisioningTimeout = poolconfig.DefaultNodeProvisioningTimeout
+
+	// clusterAutoscalerScaleUpReason is the reason of the events of the cluster-autoscaler on the pods it
+	// adds nodes for
+	clusterAutoscalerScaleUpReason = "TriggeredScaleUp"
+	// karpenterNominatedReason is the reason of the events of Karpenter on the pods it launches a NodeClaim for
+	karpenterNominatedReason = "Nominated"
+
+	// podUnschedulableReason is the reason of the PodScheduled condition of the pods no node fits
+	podUnschedulableReason = "Unschedulable"
+
+	// nodeProvisioningRecheck is how long the scale grace period is extended at a time while nodes are
+	// provisioned for the pods of the pool
+	nodeProvisioningRecheck = 30 * time.Second
+)
+
+var eventGVR = schema.GroupVersionResource{Version: "v1", Resource: "events"}
+
+// waitNodeProvisioning runs the given readiness wait for the scale grace period, then again for as long as
+// nodes are provisioned for the pods of the pool, up to its node provisioning timeout. It reports whether the
+// wait passed, and returns the scale grace period extended by the time waited for the nodes.
+func waitNodeProvisioning(ctx context.Context, logger logr.Logger, client dynamic.Interface, pool *v1.InferencePool, scaleGracePeriod time.Duration,
+	ready func(timeout time.Duration) bool) (bool, time.Duration) {
+	if ready(scaleGracePeriod) {
+		return true, scaleGracePeriod
+	}
+
+	extended, limit := scaleGracePeriod, scaleGracePeriod+poolconfig.For(pool).NodeProvisioningTimeout
+	for extended < limit && ctx.Err() == nil && nodeProvisioning(ctx, logger, client, pool) {
+		step := min(nodeProvisioningRecheck, limit-extended)
+		extended += step
+		logger.Info("Nodes are provisioned for the pods of the inferencePool, extending the scale grace period", "extension", step.String(), "gracePeriod", extended.String())
+		if ready(step) {
+			return true, extended
+		}
+	}
+	return false, extended
+}
+
+// nodeProvisioning reports whether the cluster-autoscaler or Karpenter are provisioning nodes for the
+// unschedulable pods of the pool, as told by their events on the pods.
+func nodeProvisioning(ctx context.Context, logger logr.Logger, client dynamic.Interface, pool *v1.InferencePool) bool {
+	pods, err := client.Resource(podGVR).Namespace(pool.Namespace).List(ctx, metav1.ListOptions{LabelSelector: poolPodSelector(pool)})
+	if err != nil {
+		logger.V(logutil.DEBUG).Info("Error listing inferencePool pods", "error", err.Error())
+		return false
+	}
+	unschedulable := map[string]bool{}
+	for i := range pods.Items {
+		if podUnschedulable(&pods.Items[i]) {
+			unschedulable[pods.Items[i].GetName()] = true
+		}
+	}
+	if len(unschedulable) == 0 {
+		return false
+	}
+
+	events, err := client.Resource(eventGVR).Namespace(pool.Namespace).List(ctx, metav1.ListOptions{FieldSelector: fields.OneTermEqualSelector("involvedObject.kind", "Pod").String()})
+	if err != nil {
+		logger.V(logutil.DEBUG).Info("Error listing pod events", "error", err.Error())
+		return false
+	}
+	for _, event := range events.Items {
+		reason, _, _ := unstructured.NestedString(event.Object, "reason")
+		pod, _, _ := unstructured.NestedString(event.Object, "involvedObject", "name")
+		if (reason == clusterAutoscalerScaleUpReason || reason == karpenterNominatedReason) && unschedulable[pod] {
+			return true
+		}
+	}
+	return false
+}
+
+// podUnschedulable reports whether the given pod is waiting for a node to be scheduled on, rather than e.g. for
+// the removal of its scheduling gates.
+func podUnschedulable(pod *unstructured.Unstructured) bool {
+	if pod.GetDeletionTimestamp() != nil {
+		return false
+	}
+	conditions, _, _ := unstructured.NestedSlice(pod.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, _ := c.(map[string]any)
+		if condition["type"] == "PodScheduled" && condition["status"] == string(metav1.ConditionFalse) && condition["reason"] == podUnschedulableReason {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestWaitNodeProvisioning(t *testing.T) {
+	pendingPod := func(scheduled, reason string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata":   map[string]any{"name": "vllm-0", "namespace": "default", "labels": map[string]any{"app": "vllm"}},
+			"status": map[string]any{
+				"conditions": []any{map[string]any{"type": "PodScheduled", "status": scheduled, "reason": reason}},
+			},
+		}}
+	}
+	event := func(reason string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion":     "v1",
+			"kind":           "Event",
+			"metadata":       map[string]any{"name": "vllm-0." + reason, "namespace": "default"},
+			"involvedObject": map[string]any{"kind": "Pod", "name": "vllm-0"},
+			"reason":         reason,
+		}}
+	}
+
+	tests := []struct {
+		name        string
+		timeout     string
+		objects     []runtime.Object
+		readyAfter  int
+		want        bool
+		wantWaits   int
+		wantExtends time.Duration
+	}{
+		{name: "ready within the grace period", timeout: "1m", readyAfter: 1, want: true, wantWaits: 1, wantExtends: time.Minute},
+		{
+			name: "no node provisioned", timeout: "1m", objects: []runtime.Object{pendingPod("False", podUnschedulableReason), event("FailedScheduling")},
+			readyAfter: 2, wantWaits: 1, wantExtends: time.Minute,
+		},
+		{
+			name: "cluster-autoscaler adding a node", timeout: "1m", objects: []runtime.Object{pendingPod("False", podUnschedulableReason), event(clusterAutoscalerScaleUpReason)},
+			readyAfter: 2, want: true, wantWaits: 2, wantExtends: time.Minute + nodeProvisioningRecheck,
+		},
+		{
+			name: "karpenter node claim up to the timeout", timeout: "45s", objects: []runtime.Object{pendingPod("False", podUnschedulableReason), event(karpenterNominatedReason)},
+			readyAfter: 4, wantWaits: 3, wantExtends: time.Minute + 45*time.Second,
+		},
+		{
+			name: "default node provisioning timeout", objects: []runtime.Object{pendingPod("False", podUnschedulableReason), event(clusterAutoscalerScaleUpReason)},
+			readyAfter: 5, want: true, wantWaits: 5, wantExtends: time.Minute + 4*nodeProvisioningRecheck,
+		},
+		{
+			name: "pod already scheduled", timeout: "1m", objects: []runtime.Object{pendingPod("True", ""), event(clusterAutoscalerScaleUpReason)},
+			readyAfter: 2, wantWaits: 1, wantExtends: time.Minute,
+		},
+		{
+			name: "pod gated", timeout: "1m", objects: []runtime.Object{pendingPod("False", "SchedulingGated"), event(karpenterNominatedReason)},
+			readyAfter: 2, wantWaits: 1, wantExtends: time.Minute,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1.InferencePool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", Annotations: map[string]string{}},
+				Spec:       v1.InferencePoolSpec{Selector: v1.LabelSelector{MatchLabels: map[v1.LabelKey]v1.LabelValue{"app": "vllm"}}},
+			}
+			if test.timeout != "" {
+				pool.Annotations[NodeProvisioningTimeoutKey] = test.timeout
+			}
+			client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{podGVR: "PodList", eventGVR: "EventList"}, test.objects...)
+			waits := 0
+			got, extended := waitNodeProvisioning(context.Background(), logr.Discard(), client, pool, time.Minute, func(time.Duration) bool {
+				waits++
+				return waits >= test.readyAfter
+			})
+			if got != test.want || waits != test.wantWaits || extended != test.wantExtends {
+				t.Errorf("waitNodeProvisioning() = %t after %d waits, grace period %s, want %t after %d waits, grace period %s",
+					got, waits, extended, test.want, test.wantWaits, test.wantExtends)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"runtime"
+	runtimemetrics "runtime/metrics"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// DefaultOverloadInterval is how often the activator checks its own resource usage
+	DefaultOverloadInterval = time.Duration(1 * time.Second)
+
+	heapBytesMetric = "/memory/classes/heap/objects:bytes"
+)
+
+// OverloadConfig defines the resource usage past which the activator sheds new requests. A zero
+// threshold is not enforced.
+type OverloadConfig struct {
+	// MaxGoroutines is the number of goroutines past which the activator is overloaded.
+	MaxGoroutines int
+	// MaxHeapBytes is the size of the live heap past which the activator is overloaded.
+	MaxHeapBytes uint64
+	// MaxCPU is the CPU usage, in cores, past which the activator is overloaded.
+	MaxCPU float64
+}
+
+// Enabled reports whether any threshold is enforced.
+func (c *OverloadConfig) Enabled() bool {
+	return c.MaxGoroutines > 0 || c.MaxHeapBytes > 0 || c.MaxCPU > 0
+}
+
+// overloadSample is the resource usage of the activator at a point in time.
+type overloadSample struct {
+	goroutines int
+	heapBytes  uint64
+	cpu        float64
+}
+
+// exceeds returns the name of the first threshold the sample exceeds, if any.
+func (c *OverloadConfig) exceeds(sample overloadSample) string {
+	switch {
+	case c.MaxGoroutines > 0 && sample.goroutines > c.MaxGoroutines:
+		return "goroutines"
+	case c.MaxHeapBytes > 0 && sample.heapBytes > c.MaxHeapBytes:
+		return "memory"
+	case c.MaxCPU > 0 && sample.cpu > c.MaxCPU:
+		return "cpu"
+	}
+	return ""
+}
+
+// OverloadMonitor watches the resource usage of the activator, so that new requests are shed with a
+// distinct error while it is overloaded, rather than silently degrading the activation latency of
+// every pool.
+type OverloadMonitor struct {
+	config     *OverloadConfig
+	overloaded atomic.Bool
+}
+
+func NewOverloadMonitor(config *OverloadConfig) *OverloadMonitor {
+	return &OverloadMonitor{config: config}
+}
+
+// Overloaded reports whether the activator was overloaded at the last check.
+func (m *OverloadMonitor) Overloaded() bool {
+	return m.overloaded.Load()
+}
+
+// Run checks the resource usage of the activator at the given interval until the context is done.
+func (m *OverloadMonitor) Run(ctx context.Context, interval time.Duration) {
+	logger := log.FromContext(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	samples := []runtimemetrics.Sample{{Name: heapBytesMetric}}
+	lastCPUSeconds, lastCheck := processCPUSeconds(), time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			runtimemetrics.Read(samples)
+			cpuSeconds := processCPUSeconds()
+			sample := overloadSample{
+				goroutines: runtime.NumGoroutine(),
+				heapBytes:  samples[0].Value.Uint64(),
+				cpu:        (cpuSeconds - lastCPUSeconds) / now.Sub(lastCheck).Seconds(),
+			}
+			lastCPUSeconds, lastCheck = cpuSeconds, now
+
+			resource := m.config.exceeds(sample)
+			if overloaded := resource != ""; m.overloaded.Swap(overloaded) != overloaded {
+				logger.V(logutil.DEFAULT).Info("Activator overload state changed", "overloaded", overloaded, "resource", resource,
+					"goroutines", sample.goroutines, "heapBytes", sample.heapBytes, "cpu", sample.cpu)
+				metrics.RecordOverloaded(overloaded)
+			}
+		}
+	}
+}
+
+// processCPUSeconds returns the CPU time consumed by the activator process so far.
+func processCPUSeconds() float64 {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0
+	}
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano()).Seconds()
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import "testing"
+
+func TestOverloadThresholds(t *testing.T) {
+	config := &OverloadConfig{MaxGoroutines: 1000, MaxHeapBytes: 1 << 30, MaxCPU: 2}
+
+	tests := []struct {
+		name   string
+		config *OverloadConfig
+		sample overloadSample
+		want   string
+	}{
+		{name: "Under every threshold", sample: overloadSample{goroutines: 100, heapBytes: 1 << 20, cpu: 0.5}},
+		{name: "Too many goroutines", sample: overloadSample{goroutines: 1001}, want: "goroutines"},
+		{name: "Heap too large", sample: overloadSample{heapBytes: 2 << 30}, want: "memory"},
+		{name: "CPU saturated", sample: overloadSample{cpu: 2.5}, want: "cpu"},
+		{name: "Disabled thresholds", config: &OverloadConfig{}, sample: overloadSample{goroutines: 1 << 20}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := config
+			if test.config != nil {
+				c = test.config
+			}
+			if got := c.exceeds(test.sample); got != test.want {
+				t.Errorf("exceeds() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+const (
+	TargetRequestRateKey = poolconfig.TargetRequestRateKey // Optional annotation, enables panic mode
+	MaxReplicasKey       = poolconfig.MaxReplicasKey       // Required when panic mode is enabled
+	PanicThresholdKey    = poolconfig.PanicThresholdKey    // Optional annotation
+
+	// DefaultPanicThreshold is the ratio of desired to current replicas that triggers panic mode
+	DefaultPanicThreshold = poolconfig.DefaultPanicThreshold
+
+	// DefaultPanicWindow is the window over which the request rate is measured to detect bursts
+	DefaultPanicWindow = time.Duration(6 * time.Second)
+
+	// DefaultStableWindow is how long panic mode lasts after the last burst was detected
+	DefaultStableWindow = time.Duration(60 * time.Second)
+)
+
+// panicConfig is the panic mode configuration of an InferencePool.
+type panicConfig struct {
+	// targetRate is the number of requests per second a single replica is expected to sustain.
+	targetRate float64
+	// maxReplicas bounds the scale ups applied in panic mode.
+	maxReplicas int32
+	// threshold is the ratio of desired to current replicas that triggers panic mode.
+	threshold float64
+}
+
+// panicConfigFor returns the panic mode configuration of the pool, or nil if panic mode is not enabled.
+func panicConfigFor(pool *v1.InferencePool) *panicConfig {
+	config := poolconfig.For(pool)
+	if config.TargetRequestRate == 0 || config.MaxReplicas == 0 {
+		return nil
+	}
+	return &panicConfig{targetRate: config.TargetRequestRate, maxReplicas: config.MaxReplicas, threshold: config.PanicThreshold}
+}
+
+// burstDetector measures the request rate of a warm pool over a short window, like Knative's panic
+// window, and decides when a burst requires scaling up ahead of the external autoscalers.
+type burstDetector struct {
+	mu sync.Mutex
+	// counts holds the number of requests received in each second of the panic window, and seconds
+	// the unix second each count belongs to.
+	counts     []int64
+	seconds    []int64
+	panicUntil time.Time
+	// scaling is held while a panic scale up is in progress so that concurrent requests don't repeat it.
+	scaling sync.Mutex
+}
+
+func newBurstDetector(window time.Duration) *burstDetector {
+	size := max(int(window/time.Second), 1)
+	return &burstDetector{counts: make([]int64, size), seconds: make([]int64, size)}
+}
+
+// observe records a request received at the given time.
+func (b *burstDetector) observe(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	second := now.Unix()
+	i := int(second % int64(len(b.counts)))
+	if b.seconds[i] != second {
+		b.seconds[i], b.counts[i] = second, 0
+	}
+	b.counts[i]++
+}
+
+// rate returns the average number of requests per second over the panic window ending at the given time.
+func (b *burstDetector) rate(now time.Time) float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.rateLocked(now)
+}
+
+func (b *burstDetector) rateLocked(now time.Time) float64 {
+	second := now.Unix()
+	var total int64
+	for i, s := range b.seconds {
+		if s > second-int64(len(b.seconds)) && s <= second {
+			total += b.counts[i]
+		}
+	}
+	return float64(total) / float64(len(b.counts))
+}
+
+// evaluate returns the number of replicas the pool needs for the current request rate, bounded by the
+// maximum replicas of the configuration, and whether the pool is in panic mode. Panic mode starts when
+// the desired replicas reach the threshold ratio of the current replicas, and lasts for the stable window.
+func (b *burstDetector) evaluate(now time.Time, current int32, config *panicConfig) (int32, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	desired := int32(math.Ceil(b.rateLocked(now) / config.targetRate))
+	if current > 0 && float64(desired) >= config.threshold*float64(current) {
+		b.panicUntil = now.Add(DefaultStableWindow)
+	}
+	return min(desired, config.maxReplicas), now.Before(b.panicUntil)
+}
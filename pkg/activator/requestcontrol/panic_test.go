@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBurstDetectorEvaluate(t *testing.T) {
+	config := &panicConfig{targetRate: 1, maxReplicas: 8, threshold: DefaultPanicThreshold}
+	now := time.Unix(1000, 0)
+
+	tests := []struct {
+		name          string
+		requests      int
+		current       int32
+		wantDesired   int32
+		wantPanicking bool
+	}{
+		{
+			name:          "Steady load",
+			requests:      12, // 2 requests per second over the panic window
+			current:       2,
+			wantDesired:   2,
+			wantPanicking: false,
+		},
+		{
+			name:          "Burst",
+			requests:      24,
+			current:       2,
+			wantDesired:   4,
+			wantPanicking: true,
+		},
+		{
+			name:          "Burst bounded by max replicas",
+			requests:      120,
+			current:       2,
+			wantDesired:   8,
+			wantPanicking: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := newBurstDetector(DefaultPanicWindow)
+			for i := 0; i < test.requests; i++ {
+				b.observe(now.Add(-time.Duration(i%6) * time.Second))
+			}
+
+			desired, panicking := b.evaluate(now, test.current, config)
+			if desired != test.wantDesired || panicking != test.wantPanicking {
+				t.Errorf("evaluate() = (%d, %t), want (%d, %t)", desired, panicking, test.wantDesired, test.wantPanicking)
+			}
+		})
+	}
+}
+
+func TestBurstDetectorStableWindow(t *testing.T) {
+	config := &panicConfig{targetRate: 1, maxReplicas: 8, threshold: DefaultPanicThreshold}
+	now := time.Unix(1000, 0)
+
+	b := newBurstDetector(DefaultPanicWindow)
+	for i := 0; i < 60; i++ {
+		b.observe(now)
+	}
+	if _, panicking := b.evaluate(now, 1, config); !panicking {
+		t.Fatal("expected burst to trigger panic mode")
+	}
+
+	// The burst is out of the panic window but panic mode lasts for the stable window
+	if _, panicking := b.evaluate(now.Add(DefaultStableWindow/2), 1, config); !panicking {
+		t.Error("expected panic mode to last for the stable window")
+	}
+	if _, panicking := b.evaluate(now.Add(DefaultStableWindow), 1, config); panicking {
+		t.Error("expected panic mode to end after the stable window")
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"time"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+// BenchmarkPassthroughKey overrides the benchmark passthrough mode of the activator for the pool at runtime,
+// "true" letting every request of the pool through without activation and "false" restoring the activation
+// logic, to benchmark the latency of the gateway with and without it.
+const BenchmarkPassthroughKey = poolconfig.BenchmarkPassthroughKey // Optional annotation
+
+const (
+	// SimulatedPass is the simulated decision for a request the activator would have let through
+	SimulatedPass = "pass"
+	// SimulatedHold is the simulated decision for a request the activator would have held for a scale up
+	SimulatedHold = "hold"
+)
+
+// benchmarkPassthrough reports whether the requests of the given pool pass through the activator, given
+// the benchmark passthrough mode of the activator.
+func benchmarkPassthrough(pool *v1.InferencePool, enabled bool) bool {
+	if override := poolconfig.For(pool).BenchmarkPassthrough; override != nil {
+		return *override
+	}
+	return enabled
+}
+
+// simulatedDecision returns the decision the activator would have made for a request passing through. It
+// only relies on the state of the pool in memory, to add no API call to the benchmarked requests.
+func (a *Activator) simulatedDecision(now time.Time) string {
+	if a.KnownWarm(now) || a.datastore.PoolState() == datastore.PoolActive {
+		return SimulatedPass
+	}
+	return SimulatedHold
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestBenchmarkPassthrough(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation string
+		enabled    bool
+		want       bool
+		wantErr    bool
+	}{
+		{name: "disabled", want: false},
+		{name: "enabled by the flag", enabled: true, want: true},
+		{name: "enabled at runtime by the pool", annotation: "true", want: true},
+		{name: "disabled at runtime by the pool", annotation: "false", enabled: true, want: false},
+		{name: "invalid annotation falls back to the flag", annotation: "yes please", enabled: true, want: true, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool"}}
+			if test.annotation != "" {
+				pool.Annotations = map[string]string{BenchmarkPassthroughKey: test.annotation}
+			}
+			if got := benchmarkPassthrough(pool, test.enabled); got != test.want {
+				t.Errorf("benchmarkPassthrough() = %t, want %t", got, test.want)
+			}
+			if err := poolconfig.Parse(pool).ErrOf(BenchmarkPassthroughKey); (err != nil) != test.wantErr {
+				t.Errorf("poolconfig.Parse().ErrOf(BenchmarkPassthroughKey) error = %v, wantErr %t", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// DefaultPipelineMaxConcurrency is the default number of requests a single pool pipeline admits concurrently
+	DefaultPipelineMaxConcurrency = 1000
+
+	// DefaultPipelineRetryReserve is the default number of additional requests a pool pipeline admits for client retries
+	DefaultPipelineRetryReserve = 100
+
+	// DefaultBreakerFailureThreshold is the default number of consecutive failures that opens the pool circuit breaker,
+	// zero leaving it disabled so that failing activations are retried by every request
+	DefaultBreakerFailureThreshold = 0
+
+	// DefaultBreakerCooldown is the default amount of time the pool circuit breaker stays open
+	DefaultBreakerCooldown = time.Duration(30 * time.Second)
+)
+
+// PipelineConfig holds the configuration of the pool pipeline.
+type PipelineConfig struct {
+	// MaxConcurrency bounds the number of requests admitted concurrently into a pool pipeline.
+	// Requests beyond this limit are rejected instead of queueing behind a stalled pool.
+	MaxConcurrency int
+	// RetryReserve is the number of requests admitted beyond MaxConcurrency when they are retries of
+	// previously failed requests, so that clients retrying do not keep failing behind first-time requests.
+	RetryReserve int
+	// BreakerFailureThreshold is the number of consecutive failures after which the pipeline fails fast.
+	// A value of zero disables the circuit breaker.
+	BreakerFailureThreshold int
+	// BreakerCooldown is the amount of time the pipeline fails fast before trying again.
+	BreakerCooldown time.Duration
+}
+
+// NewPipelineConfig creates a pipeline configuration with default values.
+func NewPipelineConfig() *PipelineConfig {
+	return &PipelineConfig{
+		MaxConcurrency:          DefaultPipelineMaxConcurrency,
+		RetryReserve:            DefaultPipelineRetryReserve,
+		BreakerFailureThreshold: DefaultBreakerFailureThreshold,
+		BreakerCooldown:         DefaultBreakerCooldown,
+	}
+}
+
+// pipeline is the request handling unit of the pool. It owns the concurrency limiter, circuit breaker and
+// metrics labels of the pool, and contains panics raised while serving it.
+type pipeline struct {
+	name    string
+	limiter *limiter
+	breaker *circuitBreaker
+}
+
+func newPipeline(name string, config *PipelineConfig) *pipeline {
+	return &pipeline{
+		name:    name,
+		limiter: &limiter{maxConcurrency: config.MaxConcurrency, retryReserve: config.RetryReserve},
+		breaker: &circuitBreaker{
+			failureThreshold: config.BreakerFailureThreshold,
+			cooldown:         config.BreakerCooldown,
+		},
+	}
+}
+
+// handle runs the given step within the pipeline isolation boundaries.
+func (p *pipeline) handle(ctx context.Context, step func(ctx context.Context) error) (err error) {
+	logger := log.FromContext(ctx).WithValues("pool", p.name)
+	gateway := gatewayFromContext(ctx)
+	metrics.RecordRequestCounter(p.name, gateway)
+	defer func() {
+		if err != nil {
+			metrics.RecordRequestErrCounter(p.name, gateway, errutil.CanonicalCode(err))
+		}
+	}()
+
+	if !p.breaker.allow() {
+		return errutil.Error{Code: errutil.ServiceUnavailable, Msg: fmt.Sprintf("activation of inferencePool %s is failing, rejecting request until the circuit breaker cools down", p.name)}
+	}
+
+	retry := isRetryFromContext(ctx)
+	if !p.limiter.acquire(retry) {
+		return errutil.Error{Code: errutil.InferencePoolResourceExhausted, Msg: fmt.Sprintf("too many requests waiting for inferencePool %s", p.name)}
+	}
+	if retry {
+		metrics.RecordRetryAdmitted(p.name)
+	}
+	metrics.IncPipelineInFlight(p.name)
+	defer func() {
+		p.limiter.release()
+		metrics.DecPipelineInFlight(p.name)
+	}()
+
+	defer func() {
+		// A failed activation shared by many requests counts as a single failure, recorded by the request
+		// that ran it
+		if state := activationStateFromContext(ctx); state != nil && state.shared {
+			return
+		}
+		if open := p.breaker.record(err == nil || err == errRequestAbandoned || err == errRequestTimedOut || err == errPoolDeleted || err == errPriorityWaitExpired); open {
+			logger.V(logutil.DEFAULT).Info("Circuit breaker opened for pool pipeline", "cooldown", p.breaker.cooldown)
+		}
+		metrics.RecordCircuitBreakerOpen(p.name, p.breaker.isOpen())
+	}()
+	defer recoverPanic(ctx, p.name, &err)
+
+	return step(ctx)
+}
+
+// recoverPanic, deferred, recovers from a panic raised while serving the given pool and reports it as an
+// internal error, for the goroutines serving the pool outside of its pipeline not to crash the activator.
+func recoverPanic(ctx context.Context, pool string, err *error) {
+	if r := recover(); r != nil {
+		log.FromContext(ctx).Error(fmt.Errorf("%v", r), "Recovered from panic in pool pipeline", "pool", pool)
+		metrics.RecordPipelinePanic(pool)
+		*err = errutil.Error{Code: errutil.Internal, Msg: fmt.Sprintf("internal error while activating inferencePool %s", pool)}
+	}
+}
+
+// limiter bounds the number of requests held in a pipeline, keeping a reserve for client retries.
+type limiter struct {
+	maxConcurrency int
+	retryReserve   int
+
+	mu       sync.Mutex
+	inFlight int
+}
+
+// acquire admits a request if the pipeline has room for it, and reports whether it was admitted.
+// Retries may use the retry reserve once the pipeline is full with first-time requests.
+func (l *limiter) acquire(retry bool) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limit := l.maxConcurrency
+	if retry {
+		limit += l.retryReserve
+	}
+	if l.inFlight >= limit {
+		return false
+	}
+	l.inFlight++
+	return true
+}
+
+func (l *limiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inFlight--
+}
+
+// circuitBreaker opens after a number of consecutive failures and rejects requests until the cooldown elapses.
+type circuitBreaker struct {
+	failureThreshold int
+	cooldown         time.Duration
+
+	mu                  sync.Mutex
+	consecutiveFailures int
+	openUntil           time.Time
+}
+
+// allow reports whether a request may go through the breaker.
+func (cb *circuitBreaker) allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return cb.failureThreshold <= 0 || time.Now().After(cb.openUntil)
+}
+
+// record registers the outcome of a request and reports whether the breaker just opened.
+func (cb *circuitBreaker) record(success bool) bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.failureThreshold <= 0 {
+		return false
+	}
+	if success {
+		cb.consecutiveFailures = 0
+		return false
+	}
+	cb.consecutiveFailures++
+	if cb.consecutiveFailures < cb.failureThreshold {
+		return false
+	}
+	cb.consecutiveFailures = 0
+	cb.openUntil = time.Now().Add(cb.cooldown)
+	return true
+}
+
+func (cb *circuitBreaker) isOpen() bool {
+	return !cb.allow()
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
+)
+
+func TestPipelineHandle(t *testing.T) {
+	failing := func(context.Context) error { return errors.New("activation failed") }
+	succeeding := func(context.Context) error { return nil }
+	panicking := func(context.Context) error { panic("boom") }
+
+	tests := []struct {
+		name     string
+		config   *PipelineConfig
+		steps    []func(context.Context) error
+		wantCode string
+	}{
+		{
+			name:   "Successful step",
+			config: NewPipelineConfig(),
+			steps:  []func(context.Context) error{succeeding},
+		},
+		{
+			name:     "Panic is recovered as internal error",
+			config:   NewPipelineConfig(),
+			steps:    []func(context.Context) error{panicking},
+			wantCode: errutil.Internal,
+		},
+		{
+			name:     "Circuit breaker opens after consecutive failures",
+			config:   &PipelineConfig{MaxConcurrency: 1, BreakerFailureThreshold: 2, BreakerCooldown: time.Minute},
+			steps:    []func(context.Context) error{failing, failing, succeeding},
+			wantCode: errutil.ServiceUnavailable,
+		},
+		{
+			name:   "Disabled circuit breaker never opens",
+			config: &PipelineConfig{MaxConcurrency: 1, BreakerFailureThreshold: 0},
+			steps:  []func(context.Context) error{failing, failing, succeeding},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPipeline("default/pool", tt.config)
+			var err error
+			for _, step := range tt.steps {
+				err = p.handle(context.Background(), step)
+			}
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if got := errutil.CanonicalCode(err); got != tt.wantCode {
+				t.Errorf("Unexpected error code, got %q want %q", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPipelineConcurrencyLimit(t *testing.T) {
+	p := newPipeline("default/pool", &PipelineConfig{MaxConcurrency: 1})
+
+	release := make(chan struct{})
+	admitted := make(chan struct{})
+	go func() {
+		_ = p.handle(context.Background(), func(context.Context) error {
+			close(admitted)
+			<-release
+			return nil
+		})
+	}()
+	<-admitted
+
+	err := p.handle(context.Background(), func(context.Context) error { return nil })
+	if got := errutil.CanonicalCode(err); got != errutil.InferencePoolResourceExhausted {
+		t.Errorf("Unexpected error code, got %q want %q", got, errutil.InferencePoolResourceExhausted)
+	}
+	close(release)
+}
+
+func TestPipelineRetryReserve(t *testing.T) {
+	p := newPipeline("default/pool", &PipelineConfig{MaxConcurrency: 1, RetryReserve: 1})
+
+	release := make(chan struct{})
+	admitted := make(chan struct{})
+	go func() {
+		_ = p.handle(context.Background(), func(context.Context) error {
+			close(admitted)
+			<-release
+			return nil
+		})
+	}()
+	<-admitted
+	defer close(release)
+
+	err := p.handle(context.Background(), func(context.Context) error { return nil })
+	if got := errutil.CanonicalCode(err); got != errutil.InferencePoolResourceExhausted {
+		t.Errorf("Unexpected error code for first-time request, got %q want %q", got, errutil.InferencePoolResourceExhausted)
+	}
+	if err := p.handle(withRetry(context.Background()), func(context.Context) error { return nil }); err != nil {
+		t.Errorf("Unexpected error for retried request: %v", err)
+	}
+}
+
+func TestPipelineSharedActivationFailure(t *testing.T) {
+	p := newPipeline("default/pool", &PipelineConfig{MaxConcurrency: 10, BreakerFailureThreshold: 2, BreakerCooldown: time.Minute})
+
+	// The request running the failed activation and the requests that joined it, as marked by sharedPoolReady
+	for i := range 5 {
+		ctx := withActivationState(context.Background(), &activationState{})
+		_ = p.handle(ctx, func(ctx context.Context) error {
+			activationStateFromContext(ctx).shared = i > 0
+			return errors.New("activation failed")
+		})
+	}
+
+	if p.breaker.isOpen() {
+		t.Errorf("circuit breaker opened after a single failed activation shared by 5 requests")
+	}
+}
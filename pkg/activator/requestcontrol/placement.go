@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	activatorv1alpha1 "github.com/llm-d-incubation/llm-d-activator/api/v1alpha1"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+const (
+	ActivationPolicyKey = poolconfig.ActivationPolicyKey // Optional annotation, name of an ActivationPolicy in the pool namespace
+
+	// PlacementFieldManager owns the placement overlay applied to the target workload
+	PlacementFieldManager = "llm-d-activator-placement"
+)
+
+var activationPolicyGVR = activatorv1alpha1.SchemeGroupVersion.WithResource("activationpolicies")
+
+// activationPolicyFor returns the ActivationPolicy referenced by the given inferencePool, or nil if it
+// does not reference any.
+func activationPolicyFor(ctx context.Context, client dynamic.Interface, pool *v1.InferencePool) (*activatorv1alpha1.ActivationPolicy, error) {
+	name := poolconfig.For(pool).ActivationPolicy
+	if name == "" {
+		return nil, nil
+	}
+
+	obj, err := client.Resource(activationPolicyGVR).Namespace(pool.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activation policy %s: %w", name, err)
+	}
+	policy := &activatorv1alpha1.ActivationPolicy{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse activation policy %s: %w", name, err)
+	}
+	return policy, nil
+}
+
+// applyPlacement overlays the placement of the given policy on the pod template of the target workload.
+// The overlay is owned by its own field manager, so that it only adds to the scheduling constraints
+// of the workload and can be removed without touching the fields owned by anybody else.
+func applyPlacement(ctx context.Context, client dynamic.Interface, gvr schema.GroupVersionResource, target *unstructured.Unstructured, placement *activatorv1alpha1.PlacementSpec) error {
+	podSpec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(placement)
+	if err != nil {
+		return fmt.Errorf("failed to convert placement of %s: %w", target.GetName(), err)
+	}
+
+	overlay := placementOverlay(target)
+	if err := unstructured.SetNestedField(overlay.Object, podSpec, "spec", "template", "spec"); err != nil {
+		return err
+	}
+	if _, err := client.Resource(gvr).Namespace(target.GetNamespace()).Apply(ctx, target.GetName(), overlay, metav1.ApplyOptions{FieldManager: PlacementFieldManager, Force: true}); err != nil {
+		return fmt.Errorf("failed to apply placement to %s: %w", target.GetName(), err)
+	}
+	return nil
+}
+
+// removePlacement gives up the placement overlay of the target workload, if any, by applying an empty
+// configuration with the placement field manager.
+func removePlacement(ctx context.Context, client dynamic.Interface, gvr schema.GroupVersionResource, target *unstructured.Unstructured) error {
+	if !ownsPlacement(target) {
+		return nil
+	}
+	if _, err := client.Resource(gvr).Namespace(target.GetNamespace()).Apply(ctx, target.GetName(), placementOverlay(target), metav1.ApplyOptions{FieldManager: PlacementFieldManager, Force: true}); err != nil {
+		return fmt.Errorf("failed to remove placement from %s: %w", target.GetName(), err)
+	}
+	return nil
+}
+
+// placementOverlay returns the apply configuration of the target workload without any field.
+func placementOverlay(target *unstructured.Unstructured) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": target.GetAPIVersion(),
+		"kind":       target.GetKind(),
+		"metadata":   map[string]any{"name": target.GetName(), "namespace": target.GetNamespace()},
+	}}
+}
+
+func ownsPlacement(target *unstructured.Unstructured) bool {
+	for _, entry := range target.GetManagedFields() {
+		if entry.Manager == PlacementFieldManager && entry.Operation == metav1.ManagedFieldsOperationApply {
+			return true
+		}
+	}
+	return false
+}
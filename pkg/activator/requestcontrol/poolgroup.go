@@ -0,0 +1,224 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// poolGroupTimeout bounds the scale ups of the other pools of a pool group
+const poolGroupTimeout = 30 * time.Second
+
+var inferencePoolGVR = v1.SchemeGroupVersion.WithResource("inferencepools")
+
+// poolGroup is the pool group of an InferencePool, as selected by its activation policy.
+type poolGroup struct {
+	policy string
+	// members are the pools of the group, the InferencePool itself included
+	members []*v1.InferencePool
+	// scaleDownDelay is the scale down delay of the group, zero for the scale down delay of each pool
+	scaleDownDelay time.Duration
+}
+
+// poolGroupFor returns the pool group of the given inferencePool, or nil if its activation policy defines
+// none or the pool does not match the selector of the group. The other pools of the group are listed rather
+// than reconciled, their activator annotations are filled in by the given defaulter, if any.
+func poolGroupFor(ctx context.Context, client dynamic.Interface, pool *v1.InferencePool, defaulter poolDefaulter) (*poolGroup, error) {
+	policy, err := activationPolicyFor(ctx, client, pool)
+	if err != nil || policy == nil || policy.Spec.Group == nil {
+		return nil, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.Group.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pool group selector of activation policy %s: %w", policy.Name, err)
+	}
+	if selector.Empty() || !selector.Matches(labels.Set(pool.Labels)) {
+		return nil, nil
+	}
+
+	list, err := client.Resource(inferencePoolGVR).Namespace(pool.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the pools of the pool group of activation policy %s: %w", policy.Name, err)
+	}
+	group := &poolGroup{policy: policy.Name}
+	if delay := policy.Spec.Group.ScaleDownDelay; delay != nil {
+		group.scaleDownDelay = delay.Duration
+	}
+	for i := range list.Items {
+		member := &v1.InferencePool{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, member); err != nil {
+			return nil, fmt.Errorf("failed to parse inferencePool %s: %w", list.Items[i].GetName(), err)
+		}
+		// The pool itself was defaulted by its reconciler already
+		if member.Name == pool.Name {
+			member = pool
+		} else if defaulter != nil {
+			if err := defaulter(ctx, member); err != nil {
+				return nil, fmt.Errorf("failed to fill in the activator annotations of inferencePool %s: %w", member.Name, err)
+			}
+		}
+		group.members = append(group.members, member)
+	}
+	return group, nil
+}
+
+// poolDefaulter fills in the activator annotations of an inferencePool in memory.
+type poolDefaulter func(ctx context.Context, pool *v1.InferencePool) error
+
+// memberDefaulter returns the defaulter of the other pools of a pool group, filling in their activator
+// annotations from their companion ConfigMap and target workload when the external configuration is
+// enabled, or nil otherwise.
+func memberDefaulter(externalConfig bool, client dynamic.Interface, mapper meta.RESTMapper) poolDefaulter {
+	if !externalConfig {
+		return nil
+	}
+	return func(ctx context.Context, pool *v1.InferencePool) error {
+		return applyExternalConfig(ctx, client, mapper, pool)
+	}
+}
+
+// activatePoolGroup scales up from zero the other pools of the pool group of the given inferencePool, if
+// any, without waiting for them to become ready. Their requests are held by their own activators.
+func (a *Activator) activatePoolGroup(logger logr.Logger, pool *v1.InferencePool) {
+	ctx, cancel := context.WithTimeout(context.Background(), poolGroupTimeout)
+	defer cancel()
+
+	group, err := poolGroupFor(ctx, a.DynamicClient, pool, memberDefaulter(a.ExternalConfig, a.DynamicClient, a.Mapper))
+	if err != nil {
+		logger.Error(err, "Error getting the pool group of the inferencePool")
+		return
+	}
+	if group == nil {
+		return
+	}
+	for _, member := range group.members {
+		if member.Name == pool.Name {
+			continue
+		}
+		if err := a.activateGroupMember(ctx, logger, member); err != nil {
+			logger.Error(err, "Error scaling up a pool of the pool group", "policy", group.policy, "member", member.Name)
+		}
+	}
+}
+
+// activateGroupMember scales up the target workload of the given pool of a pool group, if at zero replicas.
+// Like the scale ups from zero of the pool, it is claimed by a single activator replica and takes an
+// activation slot of the namespace, held until Kueue admitted the pods of a target queued for capacity.
+// The configuration of the member is parsed rather than cached, the cache holding the pool of the activator.
+func (a *Activator) activateGroupMember(ctx context.Context, logger logr.Logger, member *v1.InferencePool) error {
+	config := poolconfig.Parse(member)
+	if !config.HasTarget() {
+		return nil
+	}
+	strategy, err := strategyFor(a.strategies, member)
+	if err != nil {
+		return err
+	}
+	if _, ok := strategy.(ExternalTarget); ok {
+		return nil
+	}
+	gvr, err := targetResourceFor(a.Mapper, member)
+	if err != nil {
+		return err
+	}
+	scaleObject, err := scaleOf(ctx, a.ScaleClient, a.DynamicClient, member, gvr)
+	if err != nil {
+		return err
+	}
+	if scaleObject.Spec.Replicas > 0 {
+		return nil
+	}
+
+	if key, claimed := a.claimActivation(ctx, member, config.ScaleFromZeroGracePeriod); !claimed {
+		logger.Info("Activation of a pool of the pool group claimed by another activator", "member", member.Name, "activation-key", key)
+		return nil
+	}
+	releaseSlot, err := a.acquireActivationSlot(ctx, member, config.ScaleFromZeroGracePeriod)
+	if err != nil {
+		return err
+	}
+	defer releaseSlot()
+
+	replicas := initialScaleFor(member, 0, a.InitialScale)
+	if err := strategy.ScaleUp(ctx, &ScaleTarget{Pool: member, Resource: gvr, Scale: scaleObject}, replicas); err != nil {
+		return err
+	}
+	logger.Info("Scaled up a pool of the pool group", "member", member.Name, "replicas", replicas)
+
+	target, err := a.DynamicClient.Resource(gvr).Namespace(member.Namespace).Get(ctx, config.Target.Name, metav1.GetOptions{})
+	if err != nil || !kueueManaged(target) {
+		return nil
+	}
+	logger.Info("Pool of the pool group is queued for capacity, waiting for Kueue admission", "member", member.Name)
+	if !kueueAdmitted(ctx, logger, a.DynamicClient, member, replicas, config.QueuedTimeout, func() {}) {
+		return fmt.Errorf("pods of inferencePool %s were not admitted by Kueue within the queued timeout", member.Name)
+	}
+	return nil
+}
+
+// poolGroupBusy returns how long to wait before the given inferencePool may be scaled down, for pools of its
+// pool group still receiving requests, or zero if the whole group is idle.
+func (da *Deactivator) poolGroupBusy(ctx context.Context, logger logr.Logger, pool *v1.InferencePool, now time.Time) time.Duration {
+	group, err := poolGroupFor(ctx, da.DynamicClient, pool, memberDefaulter(da.ExternalConfig, da.DynamicClient, da.Mapper))
+	if err != nil {
+		logger.Error(err, "Error getting the pool group of the inferencePool, scaling it down alone")
+		return 0
+	}
+	return da.groupIdleWait(ctx, logger, group, now)
+}
+
+// groupIdleWait returns how long to wait for the given pool group, which may be nil, to be idle. The last
+// requests of the pools are those persisted by the idle clocks of their activators.
+func (da *Deactivator) groupIdleWait(ctx context.Context, logger logr.Logger, group *poolGroup, now time.Time) time.Duration {
+	if group == nil {
+		return 0
+	}
+	if da.IdleClock == nil {
+		logger.V(logutil.DEBUG).Info("Idle clocks are disabled, scaling the pool of a pool group down alone", "policy", group.policy)
+		return 0
+	}
+
+	var wait time.Duration
+	for _, member := range group.members {
+		lastRequest, ok := da.IdleClock.LastRequest(ctx, member)
+		if !ok {
+			continue
+		}
+		delay := group.scaleDownDelay
+		if delay == 0 {
+			delay = poolconfig.Parse(member).ScaleDownDelay
+		}
+		if remaining := delay - now.Sub(lastRequest); remaining > wait {
+			wait = remaining
+			logger.V(logutil.DEBUG).Info("Pool of the pool group is not idle", "policy", group.policy, "member", member.Name, "remaining", remaining)
+		}
+	}
+	return wait
+}
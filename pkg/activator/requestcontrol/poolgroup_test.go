@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestPoolGroupBusy(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	newPool := func(name string, grouped bool) *unstructured.Unstructured {
+		labels := map[string]any{}
+		if grouped {
+			labels["pipeline"] = "chat"
+		}
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "inference.networking.k8s.io/v1",
+			"kind":       "InferencePool",
+			"metadata": map[string]any{"name": name, "namespace": "default", "labels": labels,
+				"annotations": map[string]any{ActivationPolicyKey: "chat"}},
+		}}
+	}
+	newLease := func(pool string, lastRequest time.Time) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "coordination.k8s.io/v1",
+			"kind":       "Lease",
+			"metadata":   map[string]any{"name": IdleClockName(pool), "namespace": "default"},
+			"spec":       map[string]any{"renewTime": metav1.NewMicroTime(lastRequest).UTC().Format(metav1.RFC3339Micro)},
+		}}
+	}
+	policy := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "activator.llm-d.ai/v1alpha1",
+		"kind":       "ActivationPolicy",
+		"metadata":   map[string]any{"name": "chat", "namespace": "default"},
+		"spec": map[string]any{"group": map[string]any{
+			"selector":       map[string]any{"matchLabels": map[string]any{"pipeline": "chat"}},
+			"scaleDownDelay": "10m",
+		}},
+	}}
+
+	tests := []struct {
+		name     string
+		grouped  bool
+		leases   []*unstructured.Unstructured
+		wantWait time.Duration
+	}{
+		{name: "pool outside of the group", grouped: false, leases: []*unstructured.Unstructured{newLease("router", now.Add(-time.Minute))}},
+		{name: "whole group idle", grouped: true, leases: []*unstructured.Unstructured{newLease("router", now.Add(-time.Hour)), newLease("model", now.Add(-time.Hour))}},
+		{name: "other pool of the group busy", grouped: true, leases: []*unstructured.Unstructured{newLease("router", now.Add(-time.Minute)), newLease("model", now.Add(-time.Hour))}, wantWait: 9 * time.Minute},
+		{name: "no idle clock for the other pool", grouped: true, leases: []*unstructured.Unstructured{newLease("model", now.Add(-time.Hour))}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			objects := []runtime.Object{policy, newPool("router", true), newPool("model", test.grouped)}
+			for _, lease := range test.leases {
+				objects = append(objects, lease)
+			}
+			client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				inferencePoolGVR:    "InferencePoolList",
+				activationPolicyGVR: "ActivationPolicyList",
+				leaseGVR:            "LeaseList",
+			}, objects...)
+
+			pool := &v1.InferencePool{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(newPool("model", test.grouped).Object, pool); err != nil {
+				t.Fatal(err)
+			}
+			group, err := poolGroupFor(context.Background(), client, pool, nil)
+			if err != nil {
+				t.Fatalf("poolGroupFor() error = %v", err)
+			}
+			if (group != nil) != test.grouped {
+				t.Fatalf("poolGroupFor() = %v, want a group %t", group, test.grouped)
+			}
+
+			da := &Deactivator{IdleClock: NewIdleClock(client, nil)}
+			if got := da.groupIdleWait(context.Background(), logr.Discard(), group, now); got != test.wantWait {
+				t.Errorf("groupIdleWait() = %s, want %s", got, test.wantWait)
+			}
+		})
+	}
+}
+
+func TestPoolGroupMemberExternalConfig(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	newPool := func(name string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "inference.networking.k8s.io/v1",
+			"kind":       "InferencePool",
+			"metadata": map[string]any{"name": name, "namespace": "default", "labels": map[string]any{"pipeline": "chat"},
+				"annotations": map[string]any{ActivationPolicyKey: "chat"}},
+		}}
+	}
+	policy := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "activator.llm-d.ai/v1alpha1",
+		"kind":       "ActivationPolicy",
+		"metadata":   map[string]any{"name": "chat", "namespace": "default"},
+		"spec": map[string]any{"group": map[string]any{
+			"selector": map[string]any{"matchLabels": map[string]any{"pipeline": "chat"}},
+		}},
+	}}
+	// The router sets its scale down delay in its companion ConfigMap only
+	configMap := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "router" + configMapSuffix, "namespace": "default"},
+		"data":       map[string]any{"scale-down-delay": "30m"},
+	}}
+	lease := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "coordination.k8s.io/v1",
+		"kind":       "Lease",
+		"metadata":   map[string]any{"name": IdleClockName("router"), "namespace": "default"},
+		"spec":       map[string]any{"renewTime": metav1.NewMicroTime(now.Add(-time.Minute)).UTC().Format(metav1.RFC3339Micro)},
+	}}
+
+	tests := []struct {
+		name           string
+		externalConfig bool
+		wantWait       time.Duration
+	}{
+		{name: "annotations of the members only", externalConfig: false, wantWait: DefaultScaleDownDelay - time.Minute},
+		{name: "companion configMap of the members", externalConfig: true, wantWait: 29 * time.Minute},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				inferencePoolGVR:    "InferencePoolList",
+				activationPolicyGVR: "ActivationPolicyList",
+				leaseGVR:            "LeaseList",
+			}, policy, configMap, lease, newPool("router"), newPool("model"))
+
+			pool := &v1.InferencePool{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(newPool("model").Object, pool); err != nil {
+				t.Fatal(err)
+			}
+			da := &Deactivator{DynamicClient: client, IdleClock: NewIdleClock(client, nil), ExternalConfig: test.externalConfig}
+			if got := da.poolGroupBusy(context.Background(), logr.Discard(), pool, now); got != test.wantWait {
+				t.Errorf("poolGroupBusy() = %s, want %s", got, test.wantWait)
+			}
+		})
+	}
+}
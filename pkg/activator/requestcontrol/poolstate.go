@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/handlers"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// poolStateInterval is how often a scale from zero checks whether the scale down in progress is over.
+	poolStateInterval = 100 * time.Millisecond
+
+	// idleRecheckInterval is how often the target of a pool known to be scaled to zero is checked again, in
+	// case it was scaled up outside of the activator.
+	idleRecheckInterval = 5 * time.Minute
+)
+
+// activating runs the given scale from zero of the given pool in the Activating state, once the scale down in
+// progress, if any, is over. It leaves the pool Active when the scale up made it ready, and Idle otherwise.
+func (a *Activator) activating(ctx context.Context, pool *v1.InferencePool, scaleUp func() bool) bool {
+	logger := log.FromContext(ctx)
+	err := wait.PollUntilContextTimeout(ctx, poolStateInterval, poolconfig.For(pool).ScaleFromZeroGracePeriod, true, func(context.Context) (bool, error) {
+		if a.datastore.PoolTransition(datastore.PoolActivating, datastore.PoolIdle, datastore.PoolActive) {
+			return true, nil
+		}
+		logger.V(logutil.TRACE).Info("Waiting for the inferencePool to leave its state before scaling it up", "state", a.datastore.PoolState())
+		return false, nil
+	})
+	if err != nil {
+		logger.Error(err, "InferencePool did not leave its state in time to be scaled up", "state", a.datastore.PoolState())
+		return false
+	}
+
+	// Leave the Activating state even if the scale up panics, for the pool to be scaled down again
+	ready := false
+	defer func() {
+		if ready {
+			a.datastore.PoolTransition(datastore.PoolActive, datastore.PoolActivating)
+		} else {
+			a.datastore.PoolTransition(datastore.PoolIdle, datastore.PoolActivating)
+		}
+	}()
+	ready = scaleUp()
+	return ready
+}
+
+// deactivating moves the pool to the Deactivating state before its scale down, and reports false when the
+// pool is being scaled up from zero meanwhile, in which case it must not be scaled down.
+func (da *Deactivator) deactivating() bool {
+	return (*da.datastore).PoolTransition(datastore.PoolDeactivating, datastore.PoolIdle, datastore.PoolActive)
+}
+
+// deactivated leaves the Deactivating state of the pool after its scale down, for the Idle state when the
+// scale down succeeded and back to the Active state otherwise.
+func (da *Deactivator) deactivated(succeeded bool) {
+	to := datastore.PoolActive
+	if succeeded {
+		to = datastore.PoolIdle
+		da.idleCheckedAt = time.Now()
+	}
+	(*da.datastore).PoolTransition(to, datastore.PoolDeactivating)
+}
+
+// knownIdle reports whether the pool is known to be scaled to zero, and its target was checked recently
+// enough to be skipped by the scale down.
+func (da *Deactivator) knownIdle(now time.Time) bool {
+	return (*da.datastore).PoolState() == datastore.PoolIdle && now.Sub(da.idleCheckedAt) < idleRecheckInterval
+}
+
+// gracePeriodLeft returns how long the given active pool is still kept up for its scale to zero grace period,
+// zero or less once the grace period is over or when the pool sets none. Pools active since the activator
+// started get no grace period, for restarts and rollouts of the activator not to delay their scale down.
+func (da *Deactivator) gracePeriodLeft(pool *v1.InferencePool, now time.Time) time.Duration {
+	gracePeriod := poolconfig.For(pool).ScaleToZeroGracePeriod
+	if gracePeriod == 0 || (*da.datastore).PoolState() != datastore.PoolActive {
+		return 0
+	}
+	since := (*da.datastore).PoolActiveSince()
+	if since.IsZero() {
+		return 0
+	}
+	return gracePeriod - now.Sub(since)
+}
+
+// observedIdle records that the target of the pool was found at zero replicas.
+func (da *Deactivator) observedIdle(now time.Time) {
+	(*da.datastore).PoolTransition(datastore.PoolIdle, datastore.PoolActive)
+	da.idleCheckedAt = now
+}
+
+// poolStateSignal returns the activation state of the pool reported to Envoy, and the number of requests held
+// for its activation.
+func (a *Activator) poolStateSignal() (string, int32) {
+	state := handlers.PoolStateReady
+	switch a.datastore.PoolState() {
+	case datastore.PoolIdle, datastore.PoolDeactivating:
+		state = handlers.PoolStateCold
+	case datastore.PoolActivating:
+		state = handlers.PoolStateActivating
+	}
+	return state, a.held.Load()
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/handlers"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestKnownIdle(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name          string
+		state         datastore.PoolState
+		idleCheckedAt time.Time
+		want          bool
+	}{
+		{name: "active pool", state: datastore.PoolActive, idleCheckedAt: now, want: false},
+		{name: "idle pool checked recently", state: datastore.PoolIdle, idleCheckedAt: now.Add(-time.Minute), want: true},
+		{name: "idle pool due for a check", state: datastore.PoolIdle, idleCheckedAt: now.Add(-idleRecheckInterval), want: false},
+		{name: "activating pool", state: datastore.PoolActivating, idleCheckedAt: now, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ds := datastore.NewDatastore(context.Background())
+			if test.state != datastore.PoolActive {
+				ds.PoolTransition(test.state, datastore.PoolActive)
+			}
+			da := &Deactivator{datastore: &ds, idleCheckedAt: test.idleCheckedAt}
+			if got := da.knownIdle(now); got != test.want {
+				t.Errorf("knownIdle() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGracePeriodLeft(t *testing.T) {
+	tests := []struct {
+		name        string
+		state       datastore.PoolState
+		gracePeriod string
+		scaledUp    bool
+		wantLeft    bool
+	}{
+		{name: "no grace period", state: datastore.PoolActive, scaledUp: true},
+		{name: "pool scaled up from zero within its grace period", state: datastore.PoolActive, gracePeriod: "10m", scaledUp: true, wantLeft: true},
+		{name: "pool active since the activator started", state: datastore.PoolActive, gracePeriod: "10m"},
+		{name: "idle pool", state: datastore.PoolIdle, gracePeriod: "10m", scaledUp: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ds := datastore.NewDatastore(context.Background())
+			if test.scaledUp {
+				ds.PoolTransition(datastore.PoolIdle, datastore.PoolActive)
+				ds.PoolTransition(datastore.PoolActivating, datastore.PoolIdle)
+				ds.PoolTransition(datastore.PoolActive, datastore.PoolActivating)
+			}
+			if test.state != datastore.PoolActive {
+				ds.PoolTransition(test.state, datastore.PoolActive)
+			}
+			pool := &v1.InferencePool{}
+			pool.Name, pool.Annotations = "pool", map[string]string{}
+			if test.gracePeriod != "" {
+				pool.Annotations[poolconfig.ScaleToZeroGracePeriodKey] = test.gracePeriod
+			}
+			da := &Deactivator{datastore: &ds}
+			if left := da.gracePeriodLeft(pool, time.Now()); (left > 0) != test.wantLeft {
+				t.Errorf("gracePeriodLeft() = %v, want left %t", left, test.wantLeft)
+			}
+			if left := da.gracePeriodLeft(pool, time.Now().Add(time.Hour)); left > 0 {
+				t.Errorf("gracePeriodLeft() after the grace period = %v, want none left", left)
+			}
+		})
+	}
+}
+
+func TestPoolStateSignal(t *testing.T) {
+	tests := []struct {
+		name      string
+		state     datastore.PoolState
+		held      int32
+		wantState string
+	}{
+		{name: "active pool", state: datastore.PoolActive, wantState: handlers.PoolStateReady},
+		{name: "idle pool", state: datastore.PoolIdle, wantState: handlers.PoolStateCold},
+		{name: "deactivating pool", state: datastore.PoolDeactivating, wantState: handlers.PoolStateCold},
+		{name: "activating pool with requests held", state: datastore.PoolActivating, held: 3, wantState: handlers.PoolStateActivating},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ds := datastore.NewDatastore(context.Background())
+			if test.state != datastore.PoolActive {
+				ds.PoolTransition(test.state, datastore.PoolActive)
+			}
+			a := &Activator{datastore: ds}
+			a.held.Store(test.held)
+			if state, depth := a.poolStateSignal(); state != test.wantState || depth != test.held {
+				t.Errorf("poolStateSignal() = %s, %d, want %s, %d", state, depth, test.wantState, test.held)
+			}
+		})
+	}
+}
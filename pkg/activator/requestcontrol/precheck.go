@@ -0,0 +1,270 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// DefaultPrecheckTimeout bounds the time spent checking the target workload before scaling it up
+	DefaultPrecheckTimeout = time.Duration(3 * time.Second)
+
+	// dockerHubRegistry serves the images without registry in their reference
+	dockerHubRegistry = "registry-1.docker.io"
+)
+
+var pvcGVR = schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumeclaims"}
+
+// manifestMediaTypes are accepted when looking up an image manifest, so that registries answer for
+// multi-architecture images as well.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// Precheck verifies, before scaling a target workload up from zero, that its pods can become ready at
+// all: that the images of its containers exist in their registry and that the PersistentVolumeClaims it
+// mounts exist and are not lost. Checks that cannot be concluded, e.g. because a registry is unreachable
+// or requires credentials, do not fail the scale up.
+type Precheck struct {
+	httpClient *http.Client
+	timeout    time.Duration
+}
+
+// NewPrecheck creates a Precheck spending at most the given amount of time checking a workload.
+func NewPrecheck(timeout time.Duration) *Precheck {
+	return &Precheck{
+		httpClient: &http.Client{Timeout: timeout},
+		timeout:    timeout,
+	}
+}
+
+// Check returns a descriptive error when the given target workload will never become ready.
+func (p *Precheck) Check(ctx context.Context, client dynamic.Interface, target *unstructured.Unstructured) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	podSpec, found, err := unstructured.NestedMap(target.Object, "spec", "template", "spec")
+	if err != nil || !found {
+		return nil
+	}
+	if err := checkClaims(ctx, client, target.GetNamespace(), podSpec); err != nil {
+		// Pods mounting a missing or lost claim cannot be scheduled
+		return &failureError{reason: FailureScheduling, err: err}
+	}
+
+	logger := log.FromContext(ctx)
+	for _, image := range podImages(podSpec) {
+		exists, err := p.imageExists(ctx, image)
+		if err != nil {
+			logger.V(logutil.DEBUG).Info("Could not check image availability", "image", image, "error", err.Error())
+			continue
+		}
+		if !exists {
+			return &failureError{reason: FailureImagePull, err: fmt.Errorf("image %s of %s does not exist in its registry", image, target.GetName())}
+		}
+	}
+	return nil
+}
+
+// checkClaims fails when a PersistentVolumeClaim mounted by the pod spec is missing or lost. Pending claims
+// are accepted, as claims of storage classes binding on first consumer only bind once a pod is scheduled.
+func checkClaims(ctx context.Context, client dynamic.Interface, namespace string, podSpec map[string]any) error {
+	volumes, _, _ := unstructured.NestedSlice(podSpec, "volumes")
+	for _, v := range volumes {
+		volume, _ := v.(map[string]any)
+		claimName, found, _ := unstructured.NestedString(volume, "persistentVolumeClaim", "claimName")
+		if !found || claimName == "" {
+			continue
+		}
+		claim, err := client.Resource(pvcGVR).Namespace(namespace).Get(ctx, claimName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("persistentVolumeClaim %s mounted as volume %v does not exist", claimName, volume["name"])
+		}
+		if err != nil {
+			log.FromContext(ctx).V(logutil.DEBUG).Info("Could not check persistentVolumeClaim", "claim", claimName, "error", err.Error())
+			continue
+		}
+		if phase, _, _ := unstructured.NestedString(claim.Object, "status", "phase"); phase == "Lost" {
+			return fmt.Errorf("persistentVolumeClaim %s mounted as volume %v lost its volume", claimName, volume["name"])
+		}
+	}
+	return nil
+}
+
+// podImages returns the images of the init and regular containers of the pod spec.
+func podImages(podSpec map[string]any) []string {
+	var images []string
+	for _, field := range []string{"initContainers", "containers"} {
+		containers, _, _ := unstructured.NestedSlice(podSpec, field)
+		for _, c := range containers {
+			container, _ := c.(map[string]any)
+			if image, ok := container["image"].(string); ok && image != "" {
+				images = append(images, image)
+			}
+		}
+	}
+	return images
+}
+
+// imageReference is an image split into the registry host, repository and tag or digest.
+type imageReference struct {
+	registry   string
+	repository string
+	reference  string
+}
+
+// parseImageReference splits the given image the way container runtimes resolve it.
+func parseImageReference(image string) imageReference {
+	ref := imageReference{registry: dockerHubRegistry, reference: "latest"}
+
+	name := image
+	if at := strings.Index(name, "@"); at >= 0 {
+		name, ref.reference = name[:at], name[at+1:]
+	} else if colon := strings.LastIndex(name, ":"); colon > strings.LastIndex(name, "/") {
+		name, ref.reference = name[:colon], name[colon+1:]
+	}
+
+	if slash := strings.Index(name, "/"); slash >= 0 {
+		host := name[:slash]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			ref.registry, name = host, name[slash+1:]
+		}
+	}
+	if ref.registry == "docker.io" || ref.registry == "index.docker.io" {
+		ref.registry = dockerHubRegistry
+	}
+	if ref.registry == dockerHubRegistry && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	ref.repository = name
+	return ref
+}
+
+// imageExists looks the manifest of the image up in its registry. It returns an error when the registry
+// cannot tell, e.g. because it requires credentials the activator does not have.
+func (p *Precheck) imageExists(ctx context.Context, image string) (bool, error) {
+	ref := parseImageReference(image)
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", ref.registry, ref.repository, ref.reference)
+
+	resp, err := p.headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return false, err
+	}
+	// Registries commonly require an anonymous bearer token even for public images
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := p.anonymousToken(ctx, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return false, err
+		}
+		if resp, err = p.headManifest(ctx, manifestURL, token); err != nil {
+			return false, err
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("registry %s answered with status %d", ref.registry, resp.StatusCode)
+	}
+}
+
+func (p *Precheck) headManifest(ctx context.Context, manifestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ","))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// anonymousToken requests an anonymous token from the authorization server of a registry, as advertised
+// by the given Bearer challenge.
+func (p *Precheck) anonymousToken(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported registry authentication %q", scheme)
+	}
+	values := url.Values{}
+	var realm string
+	for _, param := range strings.Split(params, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		value = strings.Trim(value, `"`)
+		if key == "realm" {
+			realm = value
+		} else if key != "" {
+			values.Set(key, value)
+		}
+	}
+	if realm == "" {
+		return "", fmt.Errorf("registry authentication challenge has no realm")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+values.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry authorization server answered with status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
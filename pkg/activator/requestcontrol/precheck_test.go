@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		image string
+		want  imageReference
+	}{
+		{image: "nginx", want: imageReference{registry: dockerHubRegistry, repository: "library/nginx", reference: "latest"}},
+		{image: "vllm/vllm-openai:v0.9.0", want: imageReference{registry: dockerHubRegistry, repository: "vllm/vllm-openai", reference: "v0.9.0"}},
+		{image: "ghcr.io/llm-d/llm-d:v0.2", want: imageReference{registry: "ghcr.io", repository: "llm-d/llm-d", reference: "v0.2"}},
+		{image: "localhost:5000/model@sha256:abc", want: imageReference{registry: "localhost:5000", repository: "model", reference: "sha256:abc"}},
+		{image: "docker.io/library/busybox", want: imageReference{registry: dockerHubRegistry, repository: "library/busybox", reference: "latest"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.image, func(t *testing.T) {
+			if got := parseImageReference(test.image); got != test.want {
+				t.Errorf("parseImageReference() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestImageExists(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			_, _ = w.Write([]byte(`{"token":"anonymous"}`))
+		case r.Header.Get("Authorization") != "Bearer anonymous":
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+server.URL+`/token",service="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		case strings.HasSuffix(r.URL.Path, "/manifests/present"):
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	precheck := NewPrecheck(DefaultPrecheckTimeout)
+	precheck.httpClient = server.Client()
+	registry := strings.TrimPrefix(server.URL, "https://")
+
+	tests := []struct {
+		tag  string
+		want bool
+	}{
+		{tag: "present", want: true},
+		{tag: "missing", want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.tag, func(t *testing.T) {
+			got, err := precheck.imageExists(context.Background(), registry+"/model:"+test.tag)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("imageExists() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	activatorv1alpha1 "github.com/llm-d-incubation/llm-d-activator/api/v1alpha1"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	"sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
+	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// priorityRefresh is how long the priority classes of the pool and the priorities of the InferenceObjectives
+// of its requests are cached for
+const priorityRefresh = time.Duration(30 * time.Second)
+
+var inferenceObjectiveGVR = v1alpha2.SchemeGroupVersion.WithResource("inferenceobjectives")
+
+// errPriorityWaitExpired is returned to held requests whose priority class wait expired while the pool was
+// still scaling up from zero. It is not a failure of the pool, whose scale up carries on.
+var errPriorityWaitExpired = errutil.Error{Code: errutil.ServiceUnavailable, Msg: "inferencePool is still scaling up from zero after the wait of the priority class of the request, retry later"}
+
+// priorityClasses caches the priority classes of the activation policy of the pool and the priorities of the
+// InferenceObjectives of its requests, so that the held requests do not read them each.
+type priorityClasses struct {
+	mu sync.Mutex
+	// policy is the activation policy the classes were read from, at the given time
+	policy  string
+	classes *activatorv1alpha1.PrioritiesSpec
+	fetched time.Time
+	// objectives holds the priorities of the InferenceObjectives read so far
+	objectives map[string]objectivePriority
+}
+
+type objectivePriority struct {
+	priority int
+	fetched  time.Time
+}
+
+// spec returns the priority classes of the activation policy of the given pool, if any.
+func (c *priorityClasses) spec(ctx context.Context, client dynamic.Interface, pool *v1.InferencePool, now time.Time) *activatorv1alpha1.PrioritiesSpec {
+	name := poolconfig.For(pool).ActivationPolicy
+	if name == "" {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if name == c.policy && now.Sub(c.fetched) < priorityRefresh {
+		return c.classes
+	}
+	// A policy that cannot be read holds the requests as if it set no priority classes until the next refresh
+	c.policy, c.classes, c.fetched = name, nil, now
+	policy, err := activationPolicyFor(ctx, client, pool)
+	if err != nil {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Error getting the activation policy, ignoring its priority classes", "error", err.Error())
+		return nil
+	}
+	if policy != nil {
+		c.classes = policy.Spec.Priorities
+	}
+	return c.classes
+}
+
+// classFor returns the priority class of the requests of the given InferenceObjective, if the activation policy
+// of the given pool sets any they belong to.
+func (c *priorityClasses) classFor(ctx context.Context, client dynamic.Interface, pool *v1.InferencePool, objective string, now time.Time) *activatorv1alpha1.PriorityClass {
+	spec := c.spec(ctx, client, pool, now)
+	if spec == nil {
+		return nil
+	}
+	priority := c.priorityOf(ctx, client, pool.Namespace, objective, now)
+	for i := range spec.Classes {
+		if class := &spec.Classes[i]; class.MinPriority == nil || priority >= *class.MinPriority {
+			return class
+		}
+	}
+	return nil
+}
+
+// priorityOf returns the priority of the given InferenceObjective, 0 when it is unset or unknown.
+func (c *priorityClasses) priorityOf(ctx context.Context, client dynamic.Interface, namespace, objective string, now time.Time) int {
+	if objective == "" {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.objectives[objective]; ok && now.Sub(cached.fetched) < priorityRefresh {
+		return cached.priority
+	}
+	var priority int
+	obj, err := client.Resource(inferenceObjectiveGVR).Namespace(namespace).Get(ctx, objective, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		if value, found, _ := unstructured.NestedInt64(obj.Object, "spec", "priority"); found {
+			priority = int(value)
+		}
+	case !apierrors.IsNotFound(err):
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Error getting the InferenceObjective of the request, assuming the default priority", "objective", objective, "error", err.Error())
+	}
+	if c.objectives == nil {
+		c.objectives = map[string]objectivePriority{}
+	}
+	c.objectives[objective] = objectivePriority{priority: priority, fetched: now}
+	return priority
+}
+
+// priorityClassOf returns the priority class of the request being handled, if any.
+func (a *Activator) priorityClassOf(ctx context.Context, pool *v1.InferencePool) *activatorv1alpha1.PriorityClass {
+	if a.DynamicClient == nil {
+		return nil
+	}
+	return a.priorities.classFor(ctx, a.DynamicClient, pool, objectiveFromContext(ctx), time.Now())
+}
+
+// heldWait returns how long the request being handled is held for the scale up from zero it triggers or joins,
+// and its priority class, or zero to hold it for the whole scale up when the pool sets no priority classes.
+func (a *Activator) heldWait(ctx context.Context, pool *v1.InferencePool) (time.Duration, string) {
+	if a.DynamicClient == nil || a.priorities.spec(ctx, a.DynamicClient, pool, time.Now()) == nil {
+		return 0, ""
+	}
+	class := a.priorityClassOf(ctx, pool)
+	if class == nil {
+		return poolconfig.For(pool).ScaleFromZeroGracePeriod, ""
+	}
+	if class.GracePeriod == nil {
+		return poolconfig.For(pool).ScaleFromZeroGracePeriod, class.Name
+	}
+	return class.GracePeriod.Duration, class.Name
+}
+
+// scaleGracePeriodFor returns the scale from zero grace period of the given pool, extended to the longest grace
+// period of its priority classes, for the requests of the classes tolerating long waits.
+func (a *Activator) scaleGracePeriodFor(ctx context.Context, pool *v1.InferencePool) time.Duration {
+	gracePeriod := poolconfig.For(pool).ScaleFromZeroGracePeriod
+	if a.DynamicClient == nil {
+		return gracePeriod
+	}
+	if spec := a.priorities.spec(ctx, a.DynamicClient, pool, time.Now()); spec != nil {
+		for _, class := range spec.Classes {
+			if class.GracePeriod != nil && class.GracePeriod.Duration > gracePeriod {
+				gracePeriod = class.GracePeriod.Duration
+			}
+		}
+	}
+	return gracePeriod
+}
+
+// expirePriorityWait removes the request whose priority class wait expired from the held requests.
+func (a *Activator) expirePriorityWait(ctx context.Context, pool *v1.InferencePool, class string) error {
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Wait of the priority class of the request expired while the pool is scaling up, failing it", "class", class)
+	metrics.RecordPriorityWaitExpired(pool.Namespace+"/"+pool.Name, class)
+	a.held.Add(-1)
+	return errPriorityWaitExpired
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestPriorityClasses(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "activator.llm-d.ai/v1alpha1",
+		"kind":       "ActivationPolicy",
+		"metadata":   map[string]any{"name": "chat", "namespace": "default"},
+		"spec": map[string]any{"priorities": map[string]any{"classes": []any{
+			map[string]any{"name": "interactive", "minPriority": int64(10), "gracePeriod": "15s", "queueTimeout": "5s"},
+			map[string]any{"name": "batch", "gracePeriod": "10m"},
+		}}},
+	}}
+	newObjective := func(name string, priority int64) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "inference.networking.x-k8s.io/v1alpha2",
+			"kind":       "InferenceObjective",
+			"metadata":   map[string]any{"name": name, "namespace": "default"},
+			"spec":       map[string]any{"priority": priority},
+		}}
+	}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		activationPolicyGVR:   "ActivationPolicyList",
+		inferenceObjectiveGVR: "InferenceObjectiveList",
+	}, policy, newObjective("chat", 20), newObjective("summaries", -5))
+
+	tests := []struct {
+		name      string
+		policy    string
+		objective string
+		wantClass string
+	}{
+		{name: "pool without activation policy", objective: "chat"},
+		{name: "interactive objective", policy: "chat", objective: "chat", wantClass: "interactive"},
+		{name: "low priority objective", policy: "chat", objective: "summaries", wantClass: "batch"},
+		{name: "unknown objective has the default priority", policy: "chat", objective: "unknown", wantClass: "batch"},
+		{name: "request without objective", policy: "chat", wantClass: "batch"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", Annotations: map[string]string{}}}
+			if test.policy != "" {
+				pool.Annotations[ActivationPolicyKey] = test.policy
+			}
+			classes := &priorityClasses{}
+			class := classes.classFor(context.Background(), client, pool, test.objective, now)
+			if class == nil {
+				if test.wantClass != "" {
+					t.Fatalf("classFor() = nil, want class %s", test.wantClass)
+				}
+				return
+			}
+			if class.Name != test.wantClass {
+				t.Errorf("classFor() = %s, want %s", class.Name, test.wantClass)
+			}
+		})
+	}
+}
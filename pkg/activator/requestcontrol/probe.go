@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// ReadinessProbePathKey makes the activator confirm the readiness of the pool after a scale up from zero by
+// probing the given HTTP path on its pods, e.g. "/health" or "/v1/models", until the scaled replicas answer
+// it successfully, instead of trusting the ready replicas of the target workload alone, which may be ready
+// before the model is loaded.
+const ReadinessProbePathKey = poolconfig.ReadinessProbePathKey // Optional annotation
+
+const (
+	readinessProbeInterval = time.Second
+	readinessProbeTimeout  = 2 * time.Second
+)
+
+// probeReadiness probes the readiness probe path of the pods of the given pool until the given number of
+// them answer it successfully or the timeout elapses. Pools without a readiness probe path annotation pass
+// at once.
+func (a *Activator) probeReadiness(ctx context.Context, logger logr.Logger, pool *v1.InferencePool, numReplicas int32, timeout time.Duration) bool {
+	path := poolconfig.For(pool).ReadinessProbePath
+	if path == "" || len(pool.Spec.TargetPorts) == 0 {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(readinessProbeInterval)
+	defer ticker.Stop()
+	httpClient := &http.Client{Timeout: readinessProbeTimeout}
+	for {
+		pods, err := a.DynamicClient.Resource(podGVR).Namespace(pool.Namespace).List(ctx, metav1.ListOptions{LabelSelector: poolPodSelector(pool)})
+		if err != nil {
+			logger.V(logutil.DEBUG).Info("Error listing inferencePool pods to probe", "error", err.Error())
+		} else {
+			healthy := countHealthy(ctx, httpClient, probeURLs(pods.Items, pool.Spec.TargetPorts[0], path))
+			if healthy >= int(numReplicas) {
+				logger.V(logutil.DEBUG).Info("Pods passed the readiness probe", "path", path, "healthy", healthy)
+				return true
+			}
+			logger.V(logutil.DEBUG).Info("Pods did not pass the readiness probe yet", "path", path, "healthy", healthy, "replicas", numReplicas)
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("Pods did not pass the readiness probe within the scale grace period", "path", path, "replicas", numReplicas)
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// probeURLs returns the URLs of the given path on the given port of the running pods among the given ones.
+func probeURLs(pods []unstructured.Unstructured, port v1.Port, path string) []string {
+	var urls []string
+	for i := range pods {
+		pod := &pods[i]
+		podIP, _, _ := unstructured.NestedString(pod.Object, "status", "podIP")
+		if podIP == "" || pod.GetDeletionTimestamp() != nil {
+			continue
+		}
+		urls = append(urls, "http://"+net.JoinHostPort(podIP, strconv.Itoa(int(port.Number)))+path)
+	}
+	return urls
+}
+
+// countHealthy returns the number of the given URLs answering a GET successfully.
+func countHealthy(ctx context.Context, httpClient *http.Client, urls []string) int {
+	healthy := 0
+	for _, url := range urls {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			continue
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			continue
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			healthy++
+		}
+	}
+	return healthy
+}
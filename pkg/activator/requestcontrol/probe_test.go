@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestProbeURLs(t *testing.T) {
+	pods := []unstructured.Unstructured{
+		{Object: map[string]any{"metadata": map[string]any{"name": "running"}, "status": map[string]any{"podIP": "10.0.0.1"}}},
+		{Object: map[string]any{"metadata": map[string]any{"name": "pending"}, "status": map[string]any{}}},
+		{Object: map[string]any{"metadata": map[string]any{"name": "deleting", "deletionTimestamp": "2025-01-01T00:00:00Z"}, "status": map[string]any{"podIP": "10.0.0.2"}}},
+	}
+	urls := probeURLs(pods, v1.Port{Number: 8000}, "/health")
+	if len(urls) != 1 || urls[0] != "http://10.0.0.1:8000/health" {
+		t.Errorf("probeURLs() = %v, want [http://10.0.0.1:8000/health]", urls)
+	}
+}
+
+func TestCountHealthy(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer healthy.Close()
+	loading := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer loading.Close()
+
+	urls := []string{healthy.URL + "/health", loading.URL + "/health", "http://127.0.0.1:0/health"}
+	if got := countHealthy(context.Background(), http.DefaultClient, urls); got != 1 {
+		t.Errorf("countHealthy() = %d, want 1", got)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/handlers"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+// progressInterval is how often the progress of a scale up from zero is streamed to its subscribers.
+const progressInterval = time.Second
+
+// ActivationProgress is a snapshot of the scale up from zero of the pool.
+type ActivationProgress struct {
+	Pool       string `json:"pool,omitempty"`
+	Activating bool   `json:"activating"`
+	// RemainingSeconds is the wait budget left before the requests held for the scale up are failed.
+	RemainingSeconds float64 `json:"remainingSeconds"`
+	DesiredReplicas  int32   `json:"desiredReplicas"`
+	ReadyReplicas    int64   `json:"readyReplicas"`
+}
+
+// progressTracker tracks the scale up from zero in progress of the pool, if any.
+type progressTracker struct {
+	mu       sync.Mutex
+	current  ActivationProgress
+	deadline time.Time
+	// done is closed once the scale up in progress is over, ready tells how it went
+	done  chan struct{}
+	ready bool
+}
+
+// track runs the given scale up from zero of the pool, tracking its progress.
+func (t *progressTracker) track(pool string, desiredReplicas int32, gracePeriod time.Duration, scaleUp func() bool) bool {
+	t.mu.Lock()
+	t.current = ActivationProgress{Pool: pool, Activating: true, DesiredReplicas: desiredReplicas}
+	t.deadline = time.Now().Add(gracePeriod)
+	t.done, t.ready = make(chan struct{}), false
+	done := t.done
+	t.mu.Unlock()
+
+	ready := false
+	defer func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.current.Activating, t.ready = false, ready
+		close(done)
+	}()
+	ready = scaleUp()
+	return ready
+}
+
+// observeReady records the ready replicas of the target workload while it is scaled up from zero.
+func (t *progressTracker) observeReady(readyReplicas int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.current.Activating {
+		t.current.ReadyReplicas = readyReplicas
+	}
+}
+
+// snapshot returns the progress of the scale up from zero in progress, if any, and a channel closed once it
+// is over.
+func (t *progressTracker) snapshot(now time.Time) (ActivationProgress, <-chan struct{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	progress := t.current
+	if progress.Activating {
+		progress.RemainingSeconds = max(t.deadline.Sub(now), 0).Seconds()
+	}
+	return progress, t.done
+}
+
+// warmingUp returns the progress of the scale up from zero of the given pool reported to the requests not held
+// for it. The whole scale grace period of the pool is left when the scale up did not start yet.
+func (a *Activator) warmingUp(pool *v1.InferencePool, now time.Time) *handlers.WarmUp {
+	progress, _ := a.progress.snapshot(now)
+	if !progress.Activating {
+		return &handlers.WarmUp{Remaining: poolconfig.For(pool).ScaleFromZeroGracePeriod}
+	}
+	return &handlers.WarmUp{
+		Remaining:       time.Duration(progress.RemainingSeconds * float64(time.Second)),
+		DesiredReplicas: progress.DesiredReplicas,
+		ReadyReplicas:   progress.ReadyReplicas,
+	}
+}
+
+func (t *progressTracker) outcome() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.ready
+}
+
+// ProgressHandler streams the progress of the scale up from zero of the pool as server-sent events, for
+// interactive tools to show the remaining wait budget and the ready replicas while their requests are held.
+// Envoy relaying nothing from an external processor to the client before the request is released, the
+// progress is served beside the request rather than within it. A "progress" event is sent every second
+// until the scale up is over, followed by a "ready" or "failed" event. A single "progress" event is sent
+// when no scale up is in progress.
+func (a *Activator) ProgressHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			progress, done := a.progress.snapshot(time.Now())
+			if err := writeProgressEvent(w, "progress", progress); err != nil {
+				return
+			}
+			flusher.Flush()
+			if !progress.Activating {
+				return
+			}
+
+			select {
+			case <-r.Context().Done():
+				return
+			case <-ticker.C:
+			case <-done:
+				event := "failed"
+				if a.progress.outcome() {
+					event = "ready"
+				}
+				progress, _ := a.progress.snapshot(time.Now())
+				_ = writeProgressEvent(w, event, progress)
+				flusher.Flush()
+				return
+			}
+		}
+	})
+}
+
+func writeProgressEvent(w http.ResponseWriter, event string, progress ActivationProgress) error {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestProgressHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		activating bool
+		ready      bool
+		wantEvents []string
+	}{
+		{name: "no scale up in progress", wantEvents: []string{"progress"}},
+		{name: "scale up ready", activating: true, ready: true, wantEvents: []string{"progress", "ready"}},
+		{name: "scale up failed", activating: true, ready: false, wantEvents: []string{"progress", "failed"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := &Activator{}
+			release, finished := make(chan struct{}), make(chan struct{})
+			if test.activating {
+				started := make(chan struct{})
+				go func() {
+					defer close(finished)
+					a.progress.track("default/pool", 2, time.Minute, func() bool {
+						close(started)
+						<-release
+						return test.ready
+					})
+				}()
+				<-started
+				a.progress.observeReady(1)
+			} else {
+				close(finished)
+			}
+
+			recorder := httptest.NewRecorder()
+			served := make(chan struct{})
+			go func() {
+				defer close(served)
+				a.ProgressHandler().ServeHTTP(recorder, httptest.NewRequest("GET", "/activation/progress", nil))
+			}()
+			if test.activating {
+				// Let the handler send the progress before the scale up is over
+				time.Sleep(50 * time.Millisecond)
+			}
+			close(release)
+			<-finished
+			<-served
+
+			body := recorder.Body.String()
+			var events []string
+			for _, line := range strings.Split(body, "\n") {
+				if event, ok := strings.CutPrefix(line, "event: "); ok {
+					events = append(events, event)
+				}
+			}
+			if strings.Join(events, ",") != strings.Join(test.wantEvents, ",") {
+				t.Errorf("events = %v, want %v, body:\n%s", events, test.wantEvents, body)
+			}
+			if test.activating && !strings.Contains(body, `"desiredReplicas":2,"readyReplicas":1`) {
+				t.Errorf("progress does not report the replicas, body:\n%s", body)
+			}
+			if got := recorder.Header().Get("Content-Type"); got != "text/event-stream" {
+				t.Errorf("Content-Type = %q, want text/event-stream", got)
+			}
+		})
+	}
+}
+
+func TestWarmingUp(t *testing.T) {
+	pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", Annotations: map[string]string{
+		ScaleFromZeroGracePeriodKey: "90s",
+	}}}
+	a := &Activator{}
+	if got := a.warmingUp(pool, time.Now()); got.Remaining != 90*time.Second || got.DesiredReplicas != 0 {
+		t.Errorf("warmingUp() before the scale up = %+v, want the whole grace period left", got)
+	}
+
+	release, started := make(chan struct{}), make(chan struct{})
+	go a.progress.track("default/pool", 2, time.Minute, func() bool {
+		a.progress.observeReady(1)
+		close(started)
+		<-release
+		return true
+	})
+	defer close(release)
+	<-started
+	got := a.warmingUp(pool, time.Now())
+	if got.DesiredReplicas != 2 || got.ReadyReplicas != 1 || got.Remaining <= 0 || got.Remaining > time.Minute {
+		t.Errorf("warmingUp() during the scale up = %+v, want 1/2 replicas and at most a minute left", got)
+	}
+}
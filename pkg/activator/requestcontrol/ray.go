@@ -0,0 +1,157 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-logr/logr"
+	autoscaling "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// RayClusters and RayServices have no scale subresource: the activator scales them to zero by suspending
+// the Ray cluster, which deletes its head and worker pods, and back up by resuming it. They are seen as a
+// single replica, running unless the Ray cluster is suspended.
+const (
+	rayGroup          = "ray.io"
+	rayClusterKind    = "RayCluster"
+	rayServiceKind    = "RayService"
+	rayClusters       = "rayclusters"
+	rayServices       = "rayservices"
+	rayStateReady     = "ready"
+	rayStateSuspended = "suspended"
+
+	// rayClusterProvisioned and rayHeadPodReady are the conditions of RayClusters whose pods all became ready
+	// since they were last resumed, and whose head pod is ready
+	rayClusterProvisioned = "RayClusterProvisioned"
+	rayHeadPodReady       = "HeadPodReady"
+	// rayServiceRunning is the service status of RayServices whose Serve applications are healthy, for KubeRay
+	// versions without the Ready condition
+	rayServiceRunning = "Running"
+)
+
+// rayResource reports whether the given resource is a RayCluster or a RayService.
+func rayResource(gvr schema.GroupVersionResource) bool {
+	return gvr.Group == rayGroup && (gvr.Resource == rayClusters || gvr.Resource == rayServices)
+}
+
+// raySuspendField returns the path of the field suspending the Ray cluster of a target of the given resource:
+// RayServices suspend the Ray cluster they create through its spec.
+func raySuspendField(gvr schema.GroupVersionResource) []string {
+	if gvr.Resource == rayServices {
+		return []string{"spec", "rayClusterConfig", "suspend"}
+	}
+	return []string{"spec", "suspend"}
+}
+
+// rayScaleOf returns the scale of the given Ray target workload: one replica requested unless it is suspended,
+// and one running unless its Ray cluster is suspended or has not resumed yet.
+func rayScaleOf(ctx context.Context, dynamicClient dynamic.Interface, pool *v1.InferencePool, gvr schema.GroupVersionResource) (*autoscaling.Scale, error) {
+	target, err := dynamicClient.Resource(gvr).Namespace(pool.Namespace).Get(ctx, poolconfig.For(pool).Target.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var replicas, running int32
+	if suspended, _, _ := unstructured.NestedBool(target.Object, raySuspendField(gvr)...); !suspended {
+		replicas, running = 1, 1
+		if state, _, _ := unstructured.NestedString(target.Object, "status", "state"); state == rayStateSuspended {
+			running = 0
+		}
+	}
+	return &autoscaling.Scale{
+		ObjectMeta: metav1.ObjectMeta{Name: target.GetName(), Namespace: target.GetNamespace(), UID: target.GetUID(), ResourceVersion: target.GetResourceVersion()},
+		Spec:       autoscaling.ScaleSpec{Replicas: replicas},
+		Status:     autoscaling.ScaleStatus{Replicas: running},
+	}, nil
+}
+
+// suspendRay suspends the Ray cluster of the given target workload when scaled to zero replicas, and resumes
+// it otherwise.
+func suspendRay(ctx context.Context, dynamicClient dynamic.Interface, target *ScaleTarget, replicas int32) error {
+	field := raySuspendField(target.Resource)
+	var patch any = replicas == 0
+	for i := len(field) - 1; i >= 0; i-- {
+		patch = map[string]any{field[i]: patch}
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	updated, err := dynamicClient.Resource(target.Resource).Namespace(target.Pool.Namespace).
+		Patch(ctx, target.Scale.Name, types.MergePatchType, data, metav1.PatchOptions{FieldManager: ScaleFieldManager})
+	if err == nil {
+		target.Scale.Spec.Replicas = min(replicas, 1)
+		target.Scale.ResourceVersion = updated.GetResourceVersion()
+	}
+	return err
+}
+
+// rayKind returns the kind of the target workload of the given pool when its readiness follows the status of
+// Ray resources: the target workload is a RayCluster or a RayService and the pool sets neither a ready
+// condition nor a ready replicas JSONPath, which override it.
+func rayKind(pool *v1.InferencePool) (string, bool) {
+	config := poolconfig.For(pool)
+	gv, err := schema.ParseGroupVersion(config.Target.APIVersion)
+	kind := config.Target.Kind
+	if err != nil || gv.Group != rayGroup || (kind != rayClusterKind && kind != rayServiceKind) {
+		return "", false
+	}
+	return kind, config.ReadyCondition.Type == "" && config.ReadyReplicasJSONPath == ""
+}
+
+// rayReadyCheck passes once the Ray target workload of the given kind is ready: once a RayCluster is provisioned
+// and its head pod ready, or reports the ready state for KubeRay versions without conditions, and once a
+// RayService is Ready or running.
+func rayReadyCheck(logger logr.Logger, kind string) func(target *unstructured.Unstructured) bool {
+	return func(target *unstructured.Unstructured) bool {
+		var ready bool
+		if kind == rayServiceKind {
+			status, _, _ := unstructured.NestedString(target.Object, "status", "serviceStatus")
+			ready = conditionTrue(target, "Ready") || status == rayServiceRunning
+		} else {
+			state, _, _ := unstructured.NestedString(target.Object, "status", "state")
+			ready = (conditionTrue(target, rayClusterProvisioned) && conditionTrue(target, rayHeadPodReady)) || state == rayStateReady
+		}
+		if ready {
+			logger.V(logutil.DEBUG).Info("Ray target is READY", "kind", kind)
+			return true
+		}
+		logger.V(logutil.DEBUG).Info("Ray target is not ready yet - candidate pods are NOT READY", "kind", kind)
+		return false
+	}
+}
+
+// conditionTrue reports whether the given target object reports the given condition as True.
+func conditionTrue(target *unstructured.Unstructured, conditionType string) bool {
+	conditions, _, _ := unstructured.NestedSlice(target.Object, "status", "conditions")
+	for _, c := range conditions {
+		if condition, _ := c.(map[string]any); condition["type"] == conditionType {
+			return condition["status"] == string(metav1.ConditionTrue)
+		}
+	}
+	return false
+}
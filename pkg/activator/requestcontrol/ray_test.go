@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	fakescale "k8s.io/client-go/scale/fake"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestRaySuspend(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     string
+		resource string
+		field    []string
+	}{
+		{name: "ray cluster", kind: "RayCluster", resource: "rayclusters", field: []string{"spec", "suspend"}},
+		{name: "ray service", kind: "RayService", resource: "rayservices", field: []string{"spec", "rayClusterConfig", "suspend"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gvr := schema.GroupVersionResource{Group: "ray.io", Version: "v1", Resource: test.resource}
+			target := &unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "ray.io/v1",
+				"kind":       test.kind,
+				"metadata":   map[string]any{"name": "ray", "namespace": "default"},
+			}}
+			if err := unstructured.SetNestedField(target.Object, true, test.field...); err != nil {
+				t.Fatal(err)
+			}
+			dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{gvr: test.kind + "List"}, target)
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", Annotations: map[string]string{ObjectNameKey: "ray"}}}
+
+			ctx := context.Background()
+			scaleObject, err := scaleOf(ctx, &fakescale.FakeScaleClient{}, dynamicClient, pool, gvr)
+			if err != nil {
+				t.Fatalf("scaleOf() error = %v", err)
+			}
+			if scaleObject.Spec.Replicas != 0 || scaleObject.Status.Replicas != 0 {
+				t.Errorf("scaleOf() = %d replicas, %d running, want none of a suspended Ray cluster", scaleObject.Spec.Replicas, scaleObject.Status.Replicas)
+			}
+
+			strategy := &scaleStrategy{clients: StrategyClients{DynamicClient: dynamicClient}}
+			if err := strategy.ScaleUp(ctx, &ScaleTarget{Pool: pool, Resource: gvr, Scale: scaleObject}, 2); err != nil {
+				t.Fatalf("ScaleUp() error = %v", err)
+			}
+			if scaleObject, err = scaleOf(ctx, &fakescale.FakeScaleClient{}, dynamicClient, pool, gvr); err != nil {
+				t.Fatalf("scaleOf() error = %v", err)
+			}
+			if scaleObject.Spec.Replicas != 1 {
+				t.Errorf("scaleOf() = %d replicas after the scale up, want 1 of a resumed Ray cluster", scaleObject.Spec.Replicas)
+			}
+		})
+	}
+}
+
+func TestRayReadiness(t *testing.T) {
+	condition := func(conditionType, status string) map[string]any {
+		return map[string]any{"type": conditionType, "status": status}
+	}
+
+	tests := []struct {
+		name      string
+		kind      string
+		status    map[string]any
+		wantReady bool
+	}{
+		{name: "ray cluster provisioned", kind: "RayCluster", wantReady: true,
+			status: map[string]any{"conditions": []any{condition("RayClusterProvisioned", "True"), condition("HeadPodReady", "True")}}},
+		{name: "ray cluster head pod not ready", kind: "RayCluster",
+			status: map[string]any{"conditions": []any{condition("RayClusterProvisioned", "True"), condition("HeadPodReady", "False")}}},
+		{name: "ray cluster ready state", kind: "RayCluster", status: map[string]any{"state": "ready"}, wantReady: true},
+		{name: "ray cluster suspended", kind: "RayCluster", status: map[string]any{"state": "suspended"}},
+		{name: "ray service ready", kind: "RayService", status: map[string]any{"conditions": []any{condition("Ready", "True")}}, wantReady: true},
+		{name: "ray service running", kind: "RayService", status: map[string]any{"serviceStatus": "Running"}, wantReady: true},
+		{name: "ray service not ready", kind: "RayService", status: map[string]any{"conditions": []any{condition("Ready", "False")}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", Annotations: map[string]string{
+				ObjectApiVersionKey: "ray.io/v1", ObjectkindKey: test.kind, ObjectNameKey: "ray",
+			}}}
+			target := &unstructured.Unstructured{Object: map[string]any{"status": test.status}}
+			if ready := readinessCheckFor(logr.Discard(), pool, 2)(target); ready != test.wantReady {
+				t.Errorf("readinessCheckFor() = %t, want %t", ready, test.wantReady)
+			}
+			if released := releaseReplicasFor(pool, 2); released != 1 {
+				t.Errorf("releaseReplicasFor() = %d, want 1", released)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// ReadyReplicasJSONPathKey is the JSONPath of the ready replicas in the target object, for workloads not
+	// reporting them in status.readyReplicas, e.g. "{.status.readyWorkerReplicas}" for a RayCluster.
+	ReadyReplicasJSONPathKey = poolconfig.ReadyReplicasJSONPathKey // Optional annotation
+
+	// DefaultReadyReplicasJSONPath is the JSONPath of the ready replicas of pools without a ready replicas annotation
+	DefaultReadyReplicasJSONPath = "{.status.readyReplicas}"
+
+	// ReadyConditionKey derives the readiness of the target object from a status condition rather than from
+	// its ready replicas, for workloads not reporting any, e.g. "Available" or "Ready=True". The status of the
+	// condition defaults to "True".
+	ReadyConditionKey = poolconfig.ReadyConditionKey // Optional annotation
+
+	// readinessResync is how often the target object is read again while waiting for its readiness, in case
+	// its watch missed a change or could not be established.
+	readinessResync = 10 * time.Second
+)
+
+// watchReadiness waits until the given check passes on the target object, the timeout elapsed or the given
+// context is done. Rather than polling, it watches the target object and checks it as soon as it changes,
+// so that the requests held are released the instant its ready replicas are. It calls observe, when set,
+// each time it reads or is notified about the target object.
+func watchReadiness(ctx context.Context, logger logr.Logger, client dynamic.Interface, gvr schema.GroupVersionResource, namespace, name string,
+	timeout time.Duration, check func(target *unstructured.Unstructured) bool, observe func()) bool {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resources := client.Resource(gvr).Namespace(namespace)
+	for ctx.Err() == nil {
+		if observe != nil {
+			observe()
+		}
+		resourceVersion := ""
+		if target, err := resources.Get(ctx, name, metav1.GetOptions{}); err != nil {
+			logger.V(logutil.DEBUG).Info("Error getting target object", "error", err.Error())
+		} else if check(target) {
+			return true
+		} else {
+			resourceVersion = target.GetResourceVersion()
+		}
+		if watchTarget(ctx, logger, resources, name, resourceVersion, check, observe) {
+			return true
+		}
+	}
+	return false
+}
+
+// watchTarget watches the target object from the given resource version until the check passes on it,
+// the watch ends, the readiness resync is due or the context is done.
+func watchTarget(ctx context.Context, logger logr.Logger, resources dynamic.ResourceInterface, name, resourceVersion string,
+	check func(target *unstructured.Unstructured) bool, observe func()) bool {
+	resync := time.NewTimer(readinessResync)
+	defer resync.Stop()
+
+	var events <-chan watch.Event
+	w, err := resources.Watch(ctx, metav1.ListOptions{
+		FieldSelector:   fields.OneTermEqualSelector("metadata.name", name).String(),
+		ResourceVersion: resourceVersion,
+	})
+	if err != nil {
+		logger.V(logutil.DEBUG).Info("Error watching target object, falling back to resyncs", "error", err.Error())
+	} else {
+		defer w.Stop()
+		events = w.ResultChan()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-resync.C:
+			return false
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			target, isTarget := event.Object.(*unstructured.Unstructured)
+			if !isTarget || target.GetName() != name || (event.Type != watch.Added && event.Type != watch.Modified) {
+				continue
+			}
+			if observe != nil {
+				observe()
+			}
+			if check(target) {
+				return true
+			}
+		}
+	}
+}
+
+// readyReplicasCheck passes once the target object has the given number of ready replicas at the given JSONPath.
+func readyReplicasCheck(logger logr.Logger, path *jsonpath.JSONPath, numReplicas int32) func(target *unstructured.Unstructured) bool {
+	return func(target *unstructured.Unstructured) bool {
+		readyReplicas, found := readyReplicasOf(target, path)
+		if !found {
+			logger.V(logutil.DEBUG).Info("Object ready replicas field is not set yet - candidate pods for serving the request are NOT READY ")
+			return false
+		}
+		if numReplicas == int32(readyReplicas) {
+			logger.V(logutil.DEBUG).Info("Candidate pods are READY")
+			return true
+		}
+		logger.V(logutil.DEBUG).Info("Candidate pods are NOT READY")
+		return false
+	}
+}
+
+// readinessCheckFor returns the check passing once the target object of the given pool is ready, that is once
+// its ready condition is met if the pool sets one, once a group of the LeaderWorkerSet is ready for targets of
+// that kind, once the Ray cluster or service is ready for Ray targets, or once it has the given number of ready
+// replicas otherwise.
+func readinessCheckFor(logger logr.Logger, pool *v1.InferencePool, numReplicas int32) func(target *unstructured.Unstructured) bool {
+	if conditionType, status, ok := readyConditionFor(pool); ok {
+		return readyConditionCheck(logger, conditionType, status)
+	}
+	if lwsReadiness(pool) {
+		return lwsGroupReadyCheck(logger)
+	}
+	if kind, ok := rayKind(pool); ok {
+		return rayReadyCheck(logger, kind)
+	}
+	return readyReplicasCheck(logger, readyReplicasPathFor(pool), numReplicas)
+}
+
+// readyConditionCheck passes once the target object reports the given condition with the given status.
+func readyConditionCheck(logger logr.Logger, conditionType, status string) func(target *unstructured.Unstructured) bool {
+	return func(target *unstructured.Unstructured) bool {
+		conditions, _, _ := unstructured.NestedSlice(target.Object, "status", "conditions")
+		for _, c := range conditions {
+			condition, _ := c.(map[string]any)
+			if condition["type"] == conditionType {
+				ready := condition["status"] == status
+				logger.V(logutil.DEBUG).Info("Object ready condition observed", "condition", conditionType, "status", condition["status"], "ready", ready)
+				return ready
+			}
+		}
+		logger.V(logutil.DEBUG).Info("Object ready condition is not set yet - candidate pods for serving the request are NOT READY", "condition", conditionType)
+		return false
+	}
+}
+
+// readyConditionFor returns the type and status of the ready condition of the given pool, if it sets a valid one.
+func readyConditionFor(pool *v1.InferencePool) (string, string, bool) {
+	condition := poolconfig.For(pool).ReadyCondition
+	return condition.Type, condition.Status, condition.Type != ""
+}
+
+// readyReplicasPathFor returns the JSONPath of the ready replicas in the target object of the given pool,
+// falling back to status.readyReplicas if the pool sets an invalid one.
+func readyReplicasPathFor(pool *v1.InferencePool) *jsonpath.JSONPath {
+	expression := poolconfig.For(pool).ReadyReplicasJSONPath
+	if expression == "" {
+		expression = DefaultReadyReplicasJSONPath
+	}
+	path, _ := parseReadyReplicasPath(expression)
+	return path
+}
+
+// parseReadyReplicasPath parses the given JSONPath, with or without its surrounding braces.
+func parseReadyReplicasPath(expression string) (*jsonpath.JSONPath, error) {
+	if !strings.HasPrefix(expression, "{") {
+		expression = "{" + expression + "}"
+	}
+	path := jsonpath.New("ready-replicas").AllowMissingKeys(true)
+	if err := path.Parse(expression); err != nil {
+		return nil, err
+	}
+	return path, nil
+}
+
+// readyReplicasOf returns the ready replicas of the given target object at the given JSONPath, if it reports
+// them as a number.
+func readyReplicasOf(target *unstructured.Unstructured, path *jsonpath.JSONPath) (int64, bool) {
+	results, err := path.FindResults(target.Object)
+	if err != nil || len(results) == 0 || len(results[0]) == 0 {
+		return 0, false
+	}
+	value := results[0][0]
+	if value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+	switch {
+	case value.CanInt():
+		return value.Int(), true
+	case value.CanUint():
+		return int64(value.Uint()), true
+	case value.CanFloat():
+		return int64(value.Float()), true
+	case value.Kind() == reflect.String:
+		readyReplicas, err := strconv.ParseInt(value.String(), 10, 64)
+		return readyReplicas, err == nil
+	}
+	return 0, false
+}
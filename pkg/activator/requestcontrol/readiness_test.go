@@ -0,0 +1,235 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestWatchReadiness(t *testing.T) {
+	deploymentGVR := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	newTarget := func(readyReplicas int64) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]any{"name": "model", "namespace": "default"},
+			"status":     map[string]any{"readyReplicas": readyReplicas},
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		ready     int64
+		update    int64
+		timeout   time.Duration
+		wantReady bool
+	}{
+		{name: "already ready", ready: 1, timeout: time.Second, wantReady: true},
+		{name: "ready once the target changes", ready: 0, update: 1, timeout: readinessResync / 2, wantReady: true},
+		{name: "never ready", ready: 0, timeout: 100 * time.Millisecond, wantReady: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{deploymentGVR: "DeploymentList"}, newTarget(test.ready))
+			if test.update > 0 {
+				go func() {
+					time.Sleep(100 * time.Millisecond)
+					_, _ = client.Resource(deploymentGVR).Namespace("default").Update(context.Background(), newTarget(test.update), metav1.UpdateOptions{})
+				}()
+			}
+
+			ready := watchReadiness(context.Background(), logr.Discard(), client, deploymentGVR, "default", "model", test.timeout, readyReplicasCheck(logr.Discard(), readyReplicasPathFor(&v1.InferencePool{}), 1), nil)
+			if ready != test.wantReady {
+				t.Errorf("watchReadiness() = %t, want %t", ready, test.wantReady)
+			}
+		})
+	}
+}
+
+func TestSharedPoolReady(t *testing.T) {
+	a := &Activator{}
+	pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"}}
+	release := make(chan struct{})
+	var checks atomic.Int32
+	check := func(ctx context.Context, _ *v1.InferencePool) (bool, bool) {
+		checks.Add(1)
+		activationStateFromContext(ctx).reason = FailureScheduling
+		<-release
+		return false, true
+	}
+
+	const requests = 10
+	states := make([]*activationState, requests)
+	var started, done sync.WaitGroup
+	for i := range states {
+		states[i] = &activationState{}
+		started.Add(1)
+		done.Add(1)
+		go func(state *activationState) {
+			defer done.Done()
+			started.Done()
+			if ready, scaled := a.sharedPoolReady(withActivationState(context.Background(), state), pool, check); ready || !scaled {
+				t.Errorf("sharedPoolReady() = (%t, %t), want (false, true)", ready, scaled)
+			}
+		}(states[i])
+	}
+	started.Wait()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	done.Wait()
+
+	if n := checks.Load(); n != 1 {
+		t.Errorf("readiness checks = %d, want 1", n)
+	}
+	for _, state := range states {
+		if state.reason != FailureScheduling {
+			t.Errorf("shared failure reason = %q, want %q", state.reason, FailureScheduling)
+		}
+	}
+}
+
+func TestReadyReplicasOf(t *testing.T) {
+	target := &unstructured.Unstructured{Object: map[string]any{
+		"status": map[string]any{
+			"readyReplicas":       int64(2),
+			"readyWorkerReplicas": int64(3),
+			"replicaStatus":       map[string]any{"ready": "4"},
+		},
+	}}
+
+	tests := []struct {
+		name      string
+		path      string
+		wantReady int64
+		wantFound bool
+		wantErr   bool
+	}{
+		{name: "default path", path: "", wantReady: 2, wantFound: true},
+		{name: "custom path", path: "{.status.readyWorkerReplicas}", wantReady: 3, wantFound: true},
+		{name: "custom path without braces", path: ".status.readyWorkerReplicas", wantReady: 3, wantFound: true},
+		{name: "string value", path: ".status.replicaStatus.ready", wantReady: 4, wantFound: true},
+		{name: "missing field", path: ".status.availableReplicas", wantFound: false},
+		{name: "invalid path", path: "{.status[", wantReady: 2, wantFound: true, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"}}
+			if test.path != "" {
+				pool.Annotations = map[string]string{ReadyReplicasJSONPathKey: test.path}
+			}
+			if err := poolconfig.Parse(pool).ErrOf(ReadyReplicasJSONPathKey); (err != nil) != test.wantErr {
+				t.Errorf("poolconfig.Parse().ErrOf(ReadyReplicasJSONPathKey) error = %v, wantErr %t", err, test.wantErr)
+			}
+
+			ready, found := readyReplicasOf(target, readyReplicasPathFor(pool))
+			if ready != test.wantReady || found != test.wantFound {
+				t.Errorf("readyReplicasOf() = (%d, %t), want (%d, %t)", ready, found, test.wantReady, test.wantFound)
+			}
+		})
+	}
+}
+
+func TestReadyConditionCheck(t *testing.T) {
+	target := &unstructured.Unstructured{Object: map[string]any{
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Available", "status": "True"},
+				map[string]any{"type": "Progressing", "status": "False"},
+			},
+		},
+	}}
+
+	tests := []struct {
+		name      string
+		condition string
+		wantReady bool
+		wantErr   bool
+	}{
+		{name: "condition met", condition: "Available", wantReady: true},
+		{name: "condition met with status", condition: "Progressing=False", wantReady: true},
+		{name: "condition not met", condition: "Available=False", wantReady: false},
+		{name: "condition missing", condition: "Ready", wantReady: false},
+		{name: "invalid status", condition: "Ready=Yes", wantErr: true},
+		{name: "missing type", condition: "=True", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default",
+				Annotations: map[string]string{ReadyConditionKey: test.condition}}}
+			if err := poolconfig.Parse(pool).ErrOf(ReadyConditionKey); (err != nil) != test.wantErr {
+				t.Errorf("poolconfig.Parse().ErrOf(ReadyConditionKey) error = %v, wantErr %t", err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+
+			if ready := readinessCheckFor(logr.Discard(), pool, 1)(target); ready != test.wantReady {
+				t.Errorf("readinessCheckFor() = %t, want %t", ready, test.wantReady)
+			}
+		})
+	}
+}
+
+func TestLeaderWorkerSetReadiness(t *testing.T) {
+	lws := map[string]string{ObjectApiVersionKey: "leaderworkerset.x-k8s.io/v1", ObjectkindKey: "LeaderWorkerSet", ObjectNameKey: "vllm"}
+
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		readyGroups  int64
+		wantReady    bool
+		wantReleased int32
+	}{
+		{name: "no group ready", annotations: lws, readyGroups: 0, wantReady: false, wantReleased: 1},
+		{name: "one of the groups ready", annotations: lws, readyGroups: 1, wantReady: true, wantReleased: 1},
+		{name: "every group ready", annotations: lws, readyGroups: 3, wantReady: true, wantReleased: 1},
+		{name: "deployment waits for all replicas", annotations: map[string]string{ObjectApiVersionKey: "apps/v1", ObjectkindKey: "Deployment", ObjectNameKey: "vllm"},
+			readyGroups: 1, wantReady: false, wantReleased: 3},
+		{name: "ready replicas path overrides the groups", annotations: map[string]string{ObjectApiVersionKey: "leaderworkerset.x-k8s.io/v1", ObjectkindKey: "LeaderWorkerSet",
+			ObjectNameKey: "vllm", ReadyReplicasJSONPathKey: "{.status.readyReplicas}"}, readyGroups: 1, wantReady: false, wantReleased: 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", Annotations: test.annotations}}
+			target := &unstructured.Unstructured{Object: map[string]any{"status": map[string]any{"readyReplicas": test.readyGroups}}}
+			if ready := readinessCheckFor(logr.Discard(), pool, 3)(target); ready != test.wantReady {
+				t.Errorf("readinessCheckFor() = %t, want %t", ready, test.wantReady)
+			}
+			if released := releaseReplicasFor(pool, 3); released != test.wantReleased {
+				t.Errorf("releaseReplicasFor() = %d, want %d", released, test.wantReleased)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	MeanRequestDurationKey = poolconfig.MeanRequestDurationKey // Optional annotation
+
+	// DefaultMeanRequestDuration is the mean request duration assumed when estimating the concurrency of a pool
+	DefaultMeanRequestDuration = poolconfig.DefaultMeanRequestDuration
+
+	// DefaultRecommendationWindow is the amount of traffic history recommendations are computed from
+	DefaultRecommendationWindow = time.Duration(1 * time.Hour)
+
+	// DefaultRecommendationInterval is how often recommendations are recomputed and exported
+	DefaultRecommendationInterval = time.Duration(1 * time.Minute)
+)
+
+// Recommendation is the right-sizing recommendation of an InferencePool.
+type Recommendation struct {
+	// AverageConcurrency is the average estimated number of concurrent requests over the window.
+	AverageConcurrency float64
+	// P99Concurrency is the 99th percentile of the estimated number of concurrent requests over the window.
+	P99Concurrency float64
+	// SuggestedReplicas is the steady-state number of replicas sustaining the p99 concurrency,
+	// or -1 when the pool has no target request rate annotation to derive it from.
+	SuggestedReplicas int32
+}
+
+// Recommender computes right-sizing recommendations for the baseline replicas of the InferencePool from
+// the traffic observed by the activator. The activator only sees request arrivals, so concurrency is
+// estimated with Little's law from the per-second arrival rate and the mean request duration of the pool.
+type Recommender struct {
+	datastore datastore.Datastore
+
+	mu sync.Mutex
+	// counts holds the number of requests received in each second of the window, and seconds the unix
+	// second each count belongs to.
+	counts  []int64
+	seconds []int64
+	started time.Time
+}
+
+func NewRecommender(datastore datastore.Datastore, window time.Duration) *Recommender {
+	size := max(int(window/time.Second), 1)
+	return &Recommender{
+		datastore: datastore,
+		counts:    make([]int64, size),
+		seconds:   make([]int64, size),
+		started:   time.Now(),
+	}
+}
+
+// Observe records a request received at the given time.
+func (r *Recommender) Observe(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	second := now.Unix()
+	i := int(second % int64(len(r.counts)))
+	if r.seconds[i] != second {
+		r.seconds[i], r.counts[i] = second, 0
+	}
+	r.counts[i]++
+}
+
+// Recommend computes the recommendation of the given pool from the traffic observed over the window ending at now.
+func (r *Recommender) Recommend(now time.Time, pool *v1.InferencePool) Recommendation {
+	config := poolconfig.For(pool)
+	meanDuration := config.MeanRequestDuration
+
+	rates := r.rates(now)
+	recommendation := Recommendation{SuggestedReplicas: -1}
+	if len(rates) == 0 {
+		return recommendation
+	}
+
+	var total float64
+	for _, rate := range rates {
+		total += rate
+	}
+	slices.Sort(rates)
+	p99Rate := rates[int(math.Ceil(0.99*float64(len(rates))))-1]
+
+	recommendation.AverageConcurrency = total / float64(len(rates)) * meanDuration.Seconds()
+	recommendation.P99Concurrency = p99Rate * meanDuration.Seconds()
+	if config.TargetRequestRate > 0 {
+		recommendation.SuggestedReplicas = int32(math.Ceil(p99Rate / config.TargetRequestRate))
+	}
+	return recommendation
+}
+
+// rates returns the number of requests received in each second of the window elapsed so far.
+func (r *Recommender) rates(now time.Time) []float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	second := now.Unix()
+	elapsed := min(int64(now.Sub(r.started)/time.Second), int64(len(r.counts)))
+	rates := make([]float64, elapsed)
+	for i, s := range r.seconds {
+		if age := second - s; age >= 0 && age < elapsed {
+			rates[age] = float64(r.counts[i])
+		}
+	}
+	return rates
+}
+
+// snapshot returns the number of requests received in each second of the window elapsed so far, keyed by
+// Unix second.
+func (r *Recommender) snapshot(now time.Time) map[int64]int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	second := now.Unix()
+	counts := map[int64]int64{}
+	for i, s := range r.seconds {
+		if age := second - s; age >= 0 && age < int64(len(r.counts)) && r.counts[i] > 0 {
+			counts[s] = r.counts[i]
+		}
+	}
+	return counts
+}
+
+// restore merges the given numbers of requests received per Unix second, observed by another activator, into
+// the window ending at now. The larger count of a second is kept, so that importing twice counts nothing twice.
+func (r *Recommender) restore(counts map[int64]int64, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	second := now.Unix()
+	for s, count := range counts {
+		if age := second - s; age < 0 || age >= int64(len(r.counts)) || count <= 0 {
+			continue
+		}
+		i := int(s % int64(len(r.counts)))
+		if r.seconds[i] != s {
+			r.seconds[i], r.counts[i] = s, 0
+		}
+		r.counts[i] = max(r.counts[i], count)
+		if at := time.Unix(s, 0); at.Before(r.started) {
+			r.started = at
+		}
+	}
+}
+
+// Run periodically exports the recommendation of the InferencePool until the context is cancelled.
+func (r *Recommender) Run(ctx context.Context, interval time.Duration) {
+	logger := log.FromContext(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			pool, err := r.datastore.PoolGet()
+			if err != nil {
+				continue
+			}
+			recommendation := r.Recommend(now, pool)
+			poolName := fmt.Sprintf("%s/%s", pool.Namespace, pool.Name)
+			logger.V(logutil.DEBUG).Info("Right-sizing recommendation", "pool", poolName,
+				"averageConcurrency", recommendation.AverageConcurrency,
+				"p99Concurrency", recommendation.P99Concurrency,
+				"suggestedReplicas", recommendation.SuggestedReplicas)
+			metrics.RecordRecommendation(poolName, recommendation.AverageConcurrency, recommendation.P99Concurrency, recommendation.SuggestedReplicas)
+		}
+	}
+}
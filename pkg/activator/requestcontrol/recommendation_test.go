@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestRecommenderRecommend(t *testing.T) {
+	now := time.Unix(10000, 0)
+	// 100 seconds of traffic: one request per second, except a single second with 10 requests
+	observe := func(r *Recommender) {
+		r.started = now.Add(-100 * time.Second)
+		for i := 0; i < 100; i++ {
+			r.Observe(now.Add(-time.Duration(i) * time.Second))
+		}
+		for i := 0; i < 9; i++ {
+			r.Observe(now)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        Recommendation
+	}{
+		{
+			name: "No target request rate",
+			want: Recommendation{AverageConcurrency: 1.09, P99Concurrency: 1, SuggestedReplicas: -1},
+		},
+		{
+			name:        "Mean request duration and target request rate",
+			annotations: map[string]string{MeanRequestDurationKey: "4s", TargetRequestRateKey: "0.5"},
+			want:        Recommendation{AverageConcurrency: 4.36, P99Concurrency: 4, SuggestedReplicas: 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := NewRecommender(nil, time.Hour)
+			observe(r)
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Annotations: test.annotations}}
+
+			got := r.Recommend(now, pool)
+			if diff := cmp.Diff(test.want, got, cmp.Comparer(func(a, b float64) bool { return a-b < 1e-9 && b-a < 1e-9 })); diff != "" {
+				t.Errorf("Unexpected recommendation (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+// StartupState summarizes the state of the pool found by the startup reconciliation.
+type StartupState string
+
+const (
+	// StartupScaledToZero is reported for pools found at zero replicas, whose leftover capacity reservation
+	// and activation placement were cleaned up.
+	StartupScaledToZero StartupState = "ScaledToZero"
+	// StartupActive is reported for pools found with replicas and no evidence of idleness.
+	StartupActive StartupState = "Active"
+	// StartupStale is reported for pools found with replicas despite being idle for their whole scale down
+	// delay, e.g. left behind by a crashed activator. They are scaled down on the first deactivator check.
+	StartupStale StartupState = "Stale"
+	// StartupExternal is reported for pools activating targets outside of Kubernetes.
+	StartupExternal StartupState = "External"
+	// StartupUnresolved is reported for pools whose target could not be resolved.
+	StartupUnresolved StartupState = "Unresolved"
+)
+
+// reconcileOnStartup waits for the pool to be synced, then reconciles the state left behind by previous
+// activators before traffic or the next deactivator check do: the idle timer of the pool is rebuilt from
+// its persisted idle clock, and the leftovers of interrupted activations of a pool at zero replicas are
+// cleaned up. The outcome is summarized in an event on the pool and a metric.
+func (da *Deactivator) reconcileOnStartup(ctx context.Context) {
+	pool := da.waitForPool(ctx)
+	if pool == nil {
+		return
+	}
+
+	// Wait for the scale down delay of the pool rather than the default one, unless its idle clock tells better
+	(*da.datastore).ResetTicker(scaleDownDelayFor(pool))
+	stale := false
+	if da.IdleClock != nil {
+		stale = da.rebuildIdleTimer(ctx, pool)
+	}
+	state, replicas := da.reconcileTarget(ctx, pool, stale)
+	if state == StartupScaledToZero {
+		da.observedIdle(time.Now())
+	}
+
+	message := fmt.Sprintf("Startup reconciliation: inferencePool is %s", state)
+	if state != StartupExternal && state != StartupUnresolved {
+		message = fmt.Sprintf("%s with %d replicas", message, replicas)
+	}
+	log.FromContext(ctx).Info(message, "pool", pool.Name)
+	metrics.RecordStartupReconciliation(fmt.Sprintf("%s/%s", pool.Namespace, pool.Name), string(state))
+	if da.Recorder != nil {
+		da.Recorder.Event(pool, corev1.EventTypeNormal, "StartupReconciled", message)
+	}
+}
+
+// reconcileTarget resolves the target workload of the given pool and cleans up the leftovers of interrupted
+// activations when it is at zero replicas.
+func (da *Deactivator) reconcileTarget(ctx context.Context, pool *v1.InferencePool, stale bool) (StartupState, int32) {
+	logger := log.FromContext(ctx)
+
+	if strategy, err := strategyFor(da.strategies, pool); err == nil {
+		if _, ok := strategy.(ExternalTarget); ok {
+			return StartupExternal, 0
+		}
+	}
+	if !VerifyPoolObjectAnnotations(logger, pool) {
+		return StartupUnresolved, 0
+	}
+	gvr, err := targetResourceFor(da.Mapper, pool)
+	if err != nil {
+		return StartupUnresolved, 0
+	}
+	scaleObject, err := scaleOf(ctx, da.ScaleClient, da.DynamicClient, pool, gvr)
+	if err != nil {
+		logger.Error(err, "Error getting scale subresource object")
+		return StartupUnresolved, 0
+	}
+
+	replicas := scaleObject.Spec.Replicas
+	if replicas > 0 {
+		if stale {
+			return StartupStale, replicas
+		}
+		return StartupActive, replicas
+	}
+
+	if target, err := da.DynamicClient.Resource(gvr).Namespace(pool.Namespace).Get(ctx, poolconfig.For(pool).Target.Name, metav1.GetOptions{}); err == nil {
+		if err := releaseCapacity(ctx, da.DynamicClient, target); err != nil {
+			logger.Error(err, "Error releasing leftover capacity reservation")
+		}
+		if err := removePlacement(ctx, da.DynamicClient, gvr, target); err != nil {
+			logger.Error(err, "Error removing leftover activation placement")
+		}
+	}
+	return StartupScaledToZero, replicas
+}
+
+// waitForPool waits until the pool is synced, and returns nil if the context is done first.
+func (da *Deactivator) waitForPool(ctx context.Context) *v1.InferencePool {
+	for {
+		if pool, err := (*da.datastore).PoolGet(); err == nil {
+			return pool
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// readyBeforeRelease double-checks that the given pool still has a ready pod right before the requests held
+// for its scale up from zero are released, in case its pods crashed right after becoming ready. The pods are
+// read from the cache of the manager, and the pool is assumed ready when the check cannot tell.
+func (a *Activator) readyBeforeRelease(ctx context.Context, pool *v1.InferencePool) bool {
+	if a.Pods == nil || len(pool.Spec.Selector.MatchLabels) == 0 {
+		return true
+	}
+	// Targets outside of Kubernetes have no pods to check
+	if strategy, err := strategyFor(a.strategies, pool); err == nil {
+		if _, ok := strategy.(ExternalTarget); ok {
+			return true
+		}
+	}
+
+	selector := make(client.MatchingLabels, len(pool.Spec.Selector.MatchLabels))
+	for k, v := range pool.Spec.Selector.MatchLabels {
+		selector[string(k)] = string(v)
+	}
+	pods := &corev1.PodList{}
+	if err := a.Pods.List(ctx, pods, client.InNamespace(pool.Namespace), selector); err != nil {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Error listing the pods of the inferencePool, releasing the request", "error", err.Error())
+		return true
+	}
+	for i := range pods.Items {
+		if typedPodReady(&pods.Items[i]) {
+			return true
+		}
+	}
+
+	log.FromContext(ctx).Info("InferencePool lost its ready pods right before the release of the held request, waiting for its activation again", "pool", pool.Name)
+	metrics.RecordReleaseRecheckFailed(pool.Namespace + "/" + pool.Name)
+	return false
+}
+
+// typedPodReady reports whether the given pod is running and ready.
+func typedPodReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestReadyBeforeRelease(t *testing.T) {
+	newPod := func(name string, app string, phase corev1.PodPhase, ready corev1.ConditionStatus) runtime.Object {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": app}},
+			Status: corev1.PodStatus{
+				Phase:      phase,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		pods []runtime.Object
+		want bool
+	}{
+		{name: "ready pod", pods: []runtime.Object{newPod("a", "llama", corev1.PodRunning, corev1.ConditionFalse), newPod("b", "llama", corev1.PodRunning, corev1.ConditionTrue)}, want: true},
+		{name: "pod crashed right after readiness", pods: []runtime.Object{newPod("a", "llama", corev1.PodRunning, corev1.ConditionFalse)}},
+		{name: "failed pod", pods: []runtime.Object{newPod("a", "llama", corev1.PodFailed, corev1.ConditionTrue)}},
+		{name: "ready pod of another pool", pods: []runtime.Object{newPod("a", "mistral", corev1.PodRunning, corev1.ConditionTrue)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := &Activator{Pods: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithRuntimeObjects(test.pods...).Build()}
+			pool := &v1.InferencePool{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+				Spec:       v1.InferencePoolSpec{Selector: v1.LabelSelector{MatchLabels: map[v1.LabelKey]v1.LabelValue{"app": "llama"}}},
+			}
+			if got := a.readyBeforeRelease(context.Background(), pool); got != test.want {
+				t.Errorf("readyBeforeRelease() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
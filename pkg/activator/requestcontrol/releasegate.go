@@ -0,0 +1,264 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/features"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// ReleaseGatesKey selects the comma separated release gates the requests of the pool go through once
+	// the pool is active, e.g. "model-listed,external". Every gate must release the request for it to proceed.
+	ReleaseGatesKey = poolconfig.ReleaseGatesKey // Optional annotation
+
+	// ReleaseGateURLKey is the endpoint called by the external release gate.
+	ReleaseGateURLKey = poolconfig.ReleaseGateURLKey // Required by the external release gate
+
+	// ReleaseGatesFailOpenKey releases the requests the release gates of the pool fail to decide on when set
+	// to "true". They are rejected by default, for a gate that cannot be reached not to bypass its check.
+	ReleaseGatesFailOpenKey = poolconfig.ReleaseGatesFailOpenKey // Optional annotation
+
+	ExternalReleaseGateName = "external"
+
+	releaseGateTimeout = 5 * time.Second
+)
+
+// ReleaseRequest describes the request a release gate decides on.
+type ReleaseRequest struct {
+	RequestID string `json:"requestId,omitempty"`
+	Model     string `json:"model,omitempty"`
+	Gateway   string `json:"gateway,omitempty"`
+	// ColdStart is set when the request was held while the pool scaled up from zero.
+	ColdStart bool `json:"coldStart"`
+	// Delayed is the amount of time the release gates already delayed the request.
+	Delayed time.Duration `json:"delayed"`
+}
+
+// ReleaseDecision is the decision of a release gate. The zero value releases the request.
+type ReleaseDecision struct {
+	// Delay holds the request for the given duration before the gate decides on it again.
+	Delay time.Duration
+	// Veto rejects the request with the given reason.
+	Veto string
+}
+
+// ReleaseGate is a final check on the requests of an active InferencePool before they are released to
+// it, letting operators enforce their own safety checks. Gates failing to decide reject the request, unless
+// the pool sets the release gates fail open annotation.
+type ReleaseGate interface {
+	Release(ctx context.Context, pool *v1.InferencePool, request ReleaseRequest) (ReleaseDecision, error)
+}
+
+// ReleaseGateFactory creates a ReleaseGate with the given clients.
+type ReleaseGateFactory func(clients StrategyClients) ReleaseGate
+
+var (
+	releaseGatesMu sync.RWMutex
+	releaseGates   = map[string]ReleaseGateFactory{
+		ExternalReleaseGateName: func(StrategyClients) ReleaseGate {
+			return &externalReleaseGate{httpClient: &http.Client{Timeout: releaseGateTimeout}}
+		},
+	}
+)
+
+// RegisterReleaseGate registers a release gate selectable with the release gates annotation.
+// It is meant to be called before the activator starts, e.g. for out-of-tree gates.
+func RegisterReleaseGate(name string, factory ReleaseGateFactory) {
+	releaseGatesMu.Lock()
+	defer releaseGatesMu.Unlock()
+
+	releaseGates[name] = factory
+}
+
+// RegisteredReleaseGates returns the sorted names of the registered release gates.
+func RegisteredReleaseGates() []string {
+	releaseGatesMu.RLock()
+	defer releaseGatesMu.RUnlock()
+
+	names := make([]string, 0, len(releaseGates))
+	for name := range releaseGates {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// newReleaseGates instantiates all registered release gates with the given clients.
+func newReleaseGates(clients StrategyClients) map[string]ReleaseGate {
+	releaseGatesMu.RLock()
+	defer releaseGatesMu.RUnlock()
+
+	instances := make(map[string]ReleaseGate, len(releaseGates))
+	for name, factory := range releaseGates {
+		instances[name] = factory(clients)
+	}
+	return instances
+}
+
+// releaseGatesFor returns the names of the release gates selected by the pool.
+func releaseGatesFor(pool *v1.InferencePool) []string {
+	if !features.Enabled(features.ReleaseGates) {
+		return nil
+	}
+	return poolconfig.For(pool).ReleaseGates
+}
+
+// validateReleaseGates checks that the release gates selected by the pool are registered and configured.
+func validateReleaseGates(pool *v1.InferencePool) error {
+	registered := RegisteredReleaseGates()
+	for _, name := range releaseGatesFor(pool) {
+		if !slices.Contains(registered, name) {
+			return fmt.Errorf("invalid annotation '%s' on pool '%s': unknown release gate %q, registered gates: %s",
+				ReleaseGatesKey, pool.Name, name, strings.Join(registered, ", "))
+		}
+		if name == ExternalReleaseGateName && poolconfig.For(pool).ReleaseGateURL == "" {
+			return fmt.Errorf("annotation '%s' is required by the external release gate on pool '%s'", ReleaseGateURLKey, pool.Name)
+		}
+	}
+	return nil
+}
+
+// awaitRelease runs the request through the release gates of the pool. It holds the request while a gate
+// delays it, and rejects it when a gate vetoes it, keeps delaying it beyond the scale from zero grace period
+// or fails to decide, unless the gates of the pool fail open.
+func (a *Activator) awaitRelease(ctx context.Context, pool *v1.InferencePool, coldStart bool) error {
+	names := releaseGatesFor(pool)
+	if len(names) == 0 {
+		return nil
+	}
+	logger := log.FromContext(ctx)
+	poolName := pool.Namespace + "/" + pool.Name
+	request := ReleaseRequest{
+		RequestID: requestIDFromContext(ctx),
+		Model:     modelNameFromContext(ctx),
+		Gateway:   gatewayFromContext(ctx),
+		ColdStart: coldStart,
+	}
+
+	for _, name := range names {
+		gate, ok := a.releaseGates[name]
+		if !ok {
+			continue
+		}
+		for {
+			decision, err := gate.Release(ctx, pool, request)
+			if err != nil {
+				metrics.RecordReleaseGateDecision(poolName, name, "error")
+				if poolconfig.For(pool).ReleaseGatesFailOpen {
+					logger.V(logutil.DEFAULT).Info("Release gate failed to decide, releasing the request", "gate", name, "error", err.Error())
+					break
+				}
+				logger.V(logutil.DEFAULT).Info("Release gate failed to decide, rejecting the request", "gate", name, "error", err.Error())
+				return errutil.Error{Code: errutil.ServiceUnavailable, Msg: fmt.Sprintf("release gate %s of inferencePool %s failed to decide on the request: %v", name, pool.Name, err)}
+			}
+			if decision.Veto != "" {
+				logger.V(logutil.DEBUG).Info("Release gate vetoed the request", "gate", name, "reason", decision.Veto)
+				metrics.RecordReleaseGateDecision(poolName, name, "veto")
+				return errutil.Error{Code: errutil.ServiceUnavailable, Msg: fmt.Sprintf("release gate %s of inferencePool %s rejected the request: %s", name, pool.Name, decision.Veto)}
+			}
+			if decision.Delay <= 0 {
+				metrics.RecordReleaseGateDecision(poolName, name, "release")
+				break
+			}
+
+			metrics.RecordReleaseGateDecision(poolName, name, "delay")
+			if request.Delayed+decision.Delay > poolconfig.For(pool).ScaleFromZeroGracePeriod {
+				return errutil.Error{Code: errutil.ServiceUnavailable, Msg: fmt.Sprintf("release gate %s of inferencePool %s held the request for too long", name, pool.Name)}
+			}
+			logger.V(logutil.TRACE).Info("Release gate delayed the request", "gate", name, "delay", decision.Delay)
+			select {
+			case <-ctx.Done():
+				return abandonedErr(ctx)
+			case <-time.After(decision.Delay):
+			}
+			request.Delayed += decision.Delay
+		}
+	}
+	return nil
+}
+
+// externalReleaseGate delegates the release decision to an operator provided HTTP(S) endpoint. The endpoint
+// is posted the namespace and name of the pool along with the request metadata, and answers a JSON object
+// holding the decision, e.g. {"release": false, "delay": "2s"} or {"release": false, "reason": "frozen"}.
+type externalReleaseGate struct {
+	httpClient *http.Client
+}
+
+func (g *externalReleaseGate) Release(ctx context.Context, pool *v1.InferencePool, request ReleaseRequest) (ReleaseDecision, error) {
+	payload, err := json.Marshal(struct {
+		Namespace string `json:"namespace"`
+		Pool      string `json:"pool"`
+		ReleaseRequest
+	}{Namespace: pool.Namespace, Pool: pool.Name, ReleaseRequest: request})
+	if err != nil {
+		return ReleaseDecision{}, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, poolconfig.For(pool).ReleaseGateURL, bytes.NewReader(payload))
+	if err != nil {
+		return ReleaseDecision{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return ReleaseDecision{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ReleaseDecision{}, fmt.Errorf("release gate endpoint returned status %d", resp.StatusCode)
+	}
+
+	var answer struct {
+		Release bool   `json:"release"`
+		Delay   string `json:"delay"`
+		Reason  string `json:"reason"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&answer); err != nil {
+		return ReleaseDecision{}, fmt.Errorf("failed to decode release decision: %w", err)
+	}
+	if answer.Release {
+		return ReleaseDecision{}, nil
+	}
+	if answer.Delay != "" {
+		delay, err := time.ParseDuration(answer.Delay)
+		if err != nil || delay <= 0 {
+			return ReleaseDecision{}, fmt.Errorf("invalid release delay %q", answer.Delay)
+		}
+		return ReleaseDecision{Delay: delay}, nil
+	}
+	if answer.Reason == "" {
+		answer.Reason = "vetoed by " + poolconfig.For(pool).ReleaseGateURL
+	}
+	return ReleaseDecision{Veto: answer.Reason}, nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+const (
+	ModelListedReleaseGateName = "model-listed"
+
+	// ModelsPath is the OpenAI compatible endpoint of the model servers listing the models they serve,
+	// including their loaded LoRA adapters
+	ModelsPath = "/v1/models"
+
+	// modelListedRetryInterval is how long a request whose model is not listed yet is held before the
+	// model servers are queried again, and modelListedTTL how long a model found listed is trusted to be
+	modelListedRetryInterval = time.Second
+	modelListedTTL           = 10 * time.Second
+)
+
+func init() {
+	RegisterReleaseGate(ModelListedReleaseGateName, func(c StrategyClients) ReleaseGate {
+		return &modelListedGate{clients: c, httpClient: &http.Client{Timeout: releaseGateTimeout}, listed: map[string]time.Time{}}
+	})
+}
+
+// modelListedGate holds the requests of the pool until a ready pod of the pool lists their target model on
+// its models endpoint. Model servers are ready once their base model is loaded, while the LoRA adapters
+// requests target may still be downloading; the Endpoint Picker routes the requests released to the pods
+// serving their adapter. Requests without a target model are released at once.
+type modelListedGate struct {
+	clients    StrategyClients
+	httpClient *http.Client
+
+	mu sync.Mutex
+	// listed holds when the models were last found listed.
+	listed map[string]time.Time
+}
+
+func (g *modelListedGate) Release(ctx context.Context, pool *v1.InferencePool, request ReleaseRequest) (ReleaseDecision, error) {
+	if request.Model == "" || g.recentlyListed(request.Model, time.Now()) {
+		return ReleaseDecision{}, nil
+	}
+	if len(pool.Spec.TargetPorts) == 0 {
+		return ReleaseDecision{}, fmt.Errorf("pool '%s' has no target port to list the models of", pool.Name)
+	}
+
+	pods, err := g.clients.DynamicClient.Resource(podGVR).Namespace(pool.Namespace).List(ctx, metav1.ListOptions{LabelSelector: poolPodSelector(pool)})
+	if err != nil {
+		return ReleaseDecision{}, err
+	}
+	endpoints, _ := readyEndpoints(pods.Items, pool.Spec.TargetPorts[:1])
+	for _, endpoint := range endpoints {
+		models, err := g.models(ctx, "http://"+endpoint+ModelsPath)
+		if err == nil && slices.Contains(models, request.Model) {
+			g.mu.Lock()
+			g.listed[request.Model] = time.Now()
+			g.mu.Unlock()
+			return ReleaseDecision{}, nil
+		}
+	}
+	return ReleaseDecision{Delay: modelListedRetryInterval}, nil
+}
+
+// recentlyListed reports whether the given model was found listed within the model listed TTL.
+func (g *modelListedGate) recentlyListed(model string, now time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	listedAt, ok := g.listed[model]
+	return ok && now.Sub(listedAt) < modelListedTTL
+}
+
+// models returns the identifiers of the models listed at the given models endpoint.
+func (g *modelListedGate) models(ctx context.Context, url string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("models endpoint returned status %d", resp.StatusCode)
+	}
+
+	var list struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to decode models: %w", err)
+	}
+	models := make([]string, 0, len(list.Data))
+	for _, model := range list.Data {
+		models = append(models, model.ID)
+	}
+	return models, nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestModelListedGate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != ModelsPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"object":"list","data":[{"id":"meta-llama/Llama-3.1-8B"},{"id":"sql-lora"}]}`)
+	}))
+	defer server.Close()
+
+	host, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	pod := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]any{"name": "vllm", "namespace": "default", "labels": map[string]any{"app": "vllm"}},
+		"status": map[string]any{
+			"podIP":      host,
+			"conditions": []any{map[string]any{"type": "Ready", "status": "True"}},
+		},
+	}}
+	pool := &v1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"},
+		Spec: v1.InferencePoolSpec{
+			Selector:    v1.LabelSelector{MatchLabels: map[v1.LabelKey]v1.LabelValue{"app": "vllm"}},
+			TargetPorts: []v1.Port{{Number: v1.PortNumber(portNumber)}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		model     string
+		wantDelay time.Duration
+	}{
+		{name: "adapter loaded", model: "sql-lora"},
+		{name: "base model loaded", model: "meta-llama/Llama-3.1-8B"},
+		{name: "adapter still loading", model: "chat-lora", wantDelay: modelListedRetryInterval},
+		{name: "no target model"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gate := &modelListedGate{
+				clients:    StrategyClients{DynamicClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), pod)},
+				httpClient: server.Client(),
+				listed:     map[string]time.Time{},
+			}
+			decision, err := gate.Release(context.Background(), pool, ReleaseRequest{Model: test.model})
+			if err != nil {
+				t.Fatalf("Release() error = %v", err)
+			}
+			if decision.Delay != test.wantDelay || decision.Veto != "" {
+				t.Errorf("Release() = %+v, want a delay of %s", decision, test.wantDelay)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/features"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+// scriptedReleaseGate answers the given decisions in turn, then releases the request.
+type scriptedReleaseGate struct {
+	decisions []ReleaseDecision
+	err       error
+	calls     int
+}
+
+func (g *scriptedReleaseGate) Release(context.Context, *v1.InferencePool, ReleaseRequest) (ReleaseDecision, error) {
+	g.calls++
+	if g.err != nil {
+		return ReleaseDecision{}, g.err
+	}
+	if g.calls > len(g.decisions) {
+		return ReleaseDecision{}, nil
+	}
+	return g.decisions[g.calls-1], nil
+}
+
+func TestAwaitRelease(t *testing.T) {
+	tests := []struct {
+		name        string
+		enabled     bool
+		annotations map[string]string
+		gate        *scriptedReleaseGate
+		wantErr     bool
+		wantCalls   int
+	}{
+		{name: "feature disabled", gate: &scriptedReleaseGate{decisions: []ReleaseDecision{{Veto: "frozen"}}}, wantCalls: 0},
+		{name: "released", enabled: true, gate: &scriptedReleaseGate{}, wantCalls: 1},
+		{name: "vetoed", enabled: true, gate: &scriptedReleaseGate{decisions: []ReleaseDecision{{Veto: "frozen"}}}, wantErr: true, wantCalls: 1},
+		{name: "delayed then released", enabled: true, gate: &scriptedReleaseGate{decisions: []ReleaseDecision{{Delay: time.Millisecond}}}, wantCalls: 2},
+		{name: "delayed beyond the grace period", enabled: true, gate: &scriptedReleaseGate{decisions: []ReleaseDecision{{Delay: DefaultScaleFromZeroGracePeriod + time.Second}}}, wantErr: true, wantCalls: 1},
+		{name: "gate failing closed", enabled: true, gate: &scriptedReleaseGate{err: errors.New("unreachable")}, wantErr: true, wantCalls: 1},
+		{name: "gate failing open", enabled: true, annotations: map[string]string{ReleaseGatesFailOpenKey: "true"}, gate: &scriptedReleaseGate{err: errors.New("unreachable")}, wantCalls: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defaults := features.Gate.DeepCopy()
+			t.Cleanup(func() { features.Gate = defaults })
+			if err := features.Gate.SetFromMap(map[string]bool{string(features.ReleaseGates): test.enabled}); err != nil {
+				t.Fatal(err)
+			}
+
+			annotations := map[string]string{ReleaseGatesKey: "scripted"}
+			maps.Copy(annotations, test.annotations)
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Annotations: annotations}}
+			a := &Activator{releaseGates: map[string]ReleaseGate{"scripted": test.gate}}
+			if err := a.awaitRelease(context.Background(), pool, true); (err != nil) != test.wantErr {
+				t.Errorf("awaitRelease() error = %v, wantErr %t", err, test.wantErr)
+			}
+			if test.gate.calls != test.wantCalls {
+				t.Errorf("release gate calls = %d, want %d", test.gate.calls, test.wantCalls)
+			}
+		})
+	}
+}
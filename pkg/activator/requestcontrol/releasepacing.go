@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// ReleaseStrategyKey selects how the requests held while the pool scaled up from zero are released once it
+	// is ready, so that they do not saturate the first replicas at once: "all-at-once", the default,
+	// "rate-limited" at the release rate of the pool, or "jittered" over its release jitter.
+	ReleaseStrategyKey = poolconfig.ReleaseStrategyKey // Optional annotation
+
+	// ReleaseRateKey is the number of held requests released per second by the rate-limited release strategy.
+	ReleaseRateKey = poolconfig.ReleaseRateKey // Optional annotation
+
+	// ReleaseJitterKey is the maximum random delay of the held requests released by the jittered release
+	// strategy, e.g. "2s".
+	ReleaseJitterKey = poolconfig.ReleaseJitterKey // Optional annotation
+
+	ReleaseAllAtOnce   = poolconfig.ReleaseAllAtOnce
+	ReleaseRateLimited = poolconfig.ReleaseRateLimited
+	ReleaseJittered    = poolconfig.ReleaseJittered
+
+	// DefaultReleaseRate is the release rate of pools without release rate annotation
+	DefaultReleaseRate = poolconfig.DefaultReleaseRate
+
+	// DefaultReleaseJitter is the release jitter of pools without release jitter annotation
+	DefaultReleaseJitter = poolconfig.DefaultReleaseJitter
+)
+
+// releasePacer spaces the releases of the held requests of the rate-limited release strategy.
+type releasePacer struct {
+	mu sync.Mutex
+	// next is the earliest time the next held request may be released
+	next time.Time
+}
+
+// reserve returns the delay before a held request may be released at the given rate, reserving its release.
+func (p *releasePacer) reserve(rate int, now time.Time) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	at := now
+	if p.next.After(now) {
+		at = p.next
+	}
+	p.next = at.Add(time.Second / time.Duration(rate))
+	return at.Sub(now)
+}
+
+// release paces the requests held while the pool scaled up from zero with the release strategy of the pool,
+// then runs them through its release gates.
+func (a *Activator) release(ctx context.Context, pool *v1.InferencePool, coldStart bool) error {
+	if coldStart {
+		if delay := a.releaseDelay(pool, time.Now()); delay > 0 {
+			log.FromContext(ctx).V(logutil.TRACE).Info("Pacing the release of the held request", "strategy", poolconfig.For(pool).ReleaseStrategy, "delay", delay)
+			select {
+			case <-ctx.Done():
+				return abandonedErr(ctx)
+			case <-time.After(delay):
+			}
+		}
+	}
+	return a.awaitRelease(ctx, pool, coldStart)
+}
+
+// releaseDelay returns the delay of a held request of the given pool under its release strategy.
+func (a *Activator) releaseDelay(pool *v1.InferencePool, now time.Time) time.Duration {
+	config := poolconfig.For(pool)
+	switch config.ReleaseStrategy {
+	case ReleaseRateLimited:
+		return a.releases.reserve(config.ReleaseRate, now)
+	case ReleaseJittered:
+		return randomJitter(config.ReleaseJitter)
+	default:
+		return 0
+	}
+}
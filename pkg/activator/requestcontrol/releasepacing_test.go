@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestReleaseDelay(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		requests    int
+		wantMax     []time.Duration
+		wantMin     []time.Duration
+		wantErr     bool
+	}{
+		{
+			name: "all at once by default", requests: 3,
+			wantMin: []time.Duration{0, 0, 0}, wantMax: []time.Duration{0, 0, 0},
+		},
+		{
+			name: "rate limited", annotations: map[string]string{ReleaseStrategyKey: ReleaseRateLimited, ReleaseRateKey: "4"}, requests: 3,
+			wantMin: []time.Duration{0, 250 * time.Millisecond, 500 * time.Millisecond}, wantMax: []time.Duration{0, 250 * time.Millisecond, 500 * time.Millisecond},
+		},
+		{
+			name: "jittered", annotations: map[string]string{ReleaseStrategyKey: ReleaseJittered, ReleaseJitterKey: "1s"}, requests: 3,
+			wantMin: []time.Duration{0, 0, 0}, wantMax: []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{name: "unknown strategy", annotations: map[string]string{ReleaseStrategyKey: "burst"}, wantErr: true},
+		{name: "invalid rate", annotations: map[string]string{ReleaseStrategyKey: ReleaseRateLimited, ReleaseRateKey: "0"}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Annotations: test.annotations}}
+			if err := poolconfig.Parse(pool).Err; (err != nil) != test.wantErr {
+				t.Fatalf("poolconfig.Parse().Err error = %v, wantErr %t", err, test.wantErr)
+			}
+
+			a := &Activator{}
+			for i := range test.requests {
+				delay := a.releaseDelay(pool, now)
+				if delay < test.wantMin[i] || delay > test.wantMax[i] {
+					t.Errorf("releaseDelay() of request %d = %s, want between %s and %s", i, delay, test.wantMin[i], test.wantMax[i])
+				}
+			}
+		})
+	}
+}
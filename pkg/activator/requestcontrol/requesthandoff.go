@@ -0,0 +1,346 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// HandoffServiceName is the gRPC service the activator replicas receive the requests held by a peer on,
+	// served along the ext_proc service.
+	HandoffServiceName = "llmd.activator.v1.RequestHandoff"
+
+	JSONHeldRequestCodecName = "json"
+
+	// DefaultHandoffTimeout bounds the handoff of the held requests to a peer replica on shutdown
+	DefaultHandoffTimeout = 5 * time.Second
+
+	// handoffTransferMethod is the full name of the gRPC method the held requests are handed off with. Its
+	// request is the batch of held requests serialized by the codec named by the codec metadata.
+	handoffTransferMethod = "/" + HandoffServiceName + "/Transfer"
+
+	// handoffCodecMetadataKey is the gRPC metadata naming the codec the handed off requests are serialized with
+	handoffCodecMetadataKey = "x-activator-handoff-codec"
+)
+
+// HeldRequest is the metadata of a request held for the activation of the pool. Requests are held at their
+// headers, before Envoy sends their body, so the body of a held request is never known to the activator.
+type HeldRequest struct {
+	RequestID string    `json:"requestId,omitempty"`
+	Model     string    `json:"model,omitempty"`
+	Gateway   string    `json:"gateway,omitempty"`
+	HeldSince time.Time `json:"heldSince"`
+}
+
+// HeldRequestBatch is the requests held for the activation of a pool, handed off by an activator replica
+// restarting during the activation to a peer replica of the same pool.
+type HeldRequestBatch struct {
+	// Pool is the namespace/name of the InferencePool the requests are held for.
+	Pool     string        `json:"pool"`
+	Requests []HeldRequest `json:"requests"`
+}
+
+// HeldRequestCodec serializes the batches of held requests handed off between activator replicas. Both
+// replicas must register the codec.
+type HeldRequestCodec interface {
+	Name() string
+	Marshal(batch HeldRequestBatch) ([]byte, error)
+	Unmarshal(data []byte) (HeldRequestBatch, error)
+}
+
+var (
+	heldRequestCodecsMu sync.RWMutex
+	heldRequestCodecs   = map[string]HeldRequestCodec{JSONHeldRequestCodecName: jsonHeldRequestCodec{}}
+)
+
+// RegisterHeldRequestCodec registers a codec the held requests can be handed off with. It is meant to be
+// called before the activator starts, e.g. for out-of-tree codecs.
+func RegisterHeldRequestCodec(codec HeldRequestCodec) {
+	heldRequestCodecsMu.Lock()
+	defer heldRequestCodecsMu.Unlock()
+
+	heldRequestCodecs[codec.Name()] = codec
+}
+
+// RegisteredHeldRequestCodecs returns the sorted names of the registered held request codecs.
+func RegisteredHeldRequestCodecs() []string {
+	heldRequestCodecsMu.RLock()
+	defer heldRequestCodecsMu.RUnlock()
+
+	names := make([]string, 0, len(heldRequestCodecs))
+	for name := range heldRequestCodecs {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// heldRequestCodecFor returns the registered held request codec of the given name, if any.
+func heldRequestCodecFor(name string) (HeldRequestCodec, bool) {
+	heldRequestCodecsMu.RLock()
+	defer heldRequestCodecsMu.RUnlock()
+
+	codec, ok := heldRequestCodecs[name]
+	return codec, ok
+}
+
+type jsonHeldRequestCodec struct{}
+
+func (jsonHeldRequestCodec) Name() string { return JSONHeldRequestCodecName }
+
+func (jsonHeldRequestCodec) Marshal(batch HeldRequestBatch) ([]byte, error) {
+	return json.Marshal(batch)
+}
+
+func (jsonHeldRequestCodec) Unmarshal(data []byte) (HeldRequestBatch, error) {
+	var batch HeldRequestBatch
+	err := json.Unmarshal(data, &batch)
+	return batch, err
+}
+
+// RequestHandoff hands the requests held for the activation of the pool off to a peer activator replica when
+// this one shuts down during the activation, and receives those of its peers. The receiving replica starts the
+// activation at once, so that the requests retried by Envoy or the clients after the connection to the
+// restarting replica is reset find the pool activating, and reports them resumed when they arrive.
+type RequestHandoff struct {
+	activator *Activator
+	codec     HeldRequestCodec
+	// activate starts the activation of the pool for the requests handed off by a peer
+	activate func(ctx context.Context, pool *v1.InferencePool)
+
+	mu sync.Mutex
+	// held holds the requests held by this replica, by hold sequence number
+	held    map[uint64]HeldRequest
+	nextSeq uint64
+	// expected holds until when the requests handed off by a peer are expected to be retried, by request ID
+	expected map[string]time.Time
+}
+
+// NewRequestHandoff returns the handoff of the held requests of the given activator, serialized with the
+// registered codec of the given name.
+func NewRequestHandoff(activator *Activator, codecName string) (*RequestHandoff, error) {
+	codec, ok := heldRequestCodecFor(codecName)
+	if !ok {
+		return nil, fmt.Errorf("unknown held request codec %q, registered codecs: %v", codecName, RegisteredHeldRequestCodecs())
+	}
+	h := &RequestHandoff{activator: activator, codec: codec, held: map[uint64]HeldRequest{}, expected: map[string]time.Time{}}
+	h.activate = func(ctx context.Context, pool *v1.InferencePool) {
+		activator.sharedPoolReady(ctx, pool, activator.InferencePoolReady)
+	}
+	return h, nil
+}
+
+// track records the request of the given context as held until the returned function is called.
+func (h *RequestHandoff) track(ctx context.Context) func() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	seq := h.nextSeq
+	h.nextSeq++
+	h.held[seq] = HeldRequest{
+		RequestID: requestIDFromContext(ctx),
+		Model:     modelNameFromContext(ctx),
+		Gateway:   gatewayFromContext(ctx),
+		HeldSince: time.Now(),
+	}
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.held, seq)
+	}
+}
+
+// heldRequests returns the requests currently held, oldest first.
+func (h *RequestHandoff) heldRequests() []HeldRequest {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	requests := make([]HeldRequest, 0, len(h.held))
+	for _, request := range h.held {
+		requests = append(requests, request)
+	}
+	slices.SortFunc(requests, func(a, b HeldRequest) int { return a.HeldSince.Compare(b.HeldSince) })
+	return requests
+}
+
+// resume reports whether the request of the given context was handed off by a peer, forgetting it.
+func (h *RequestHandoff) resume(ctx context.Context, now time.Time) bool {
+	requestID := requestIDFromContext(ctx)
+	if requestID == "" {
+		return false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	until, ok := h.expected[requestID]
+	delete(h.expected, requestID)
+	return ok && now.Before(until)
+}
+
+// expect records the given requests handed off by a peer as expected to be retried until the given time,
+// forgetting the ones expected no longer.
+func (h *RequestHandoff) expect(requests []HeldRequest, now, until time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for requestID, expiry := range h.expected {
+		if !now.Before(expiry) {
+			delete(h.expected, requestID)
+		}
+	}
+	for _, request := range requests {
+		if request.RequestID != "" {
+			h.expected[request.RequestID] = until
+		}
+	}
+}
+
+// Send hands the requests currently held off to the peer activator replica at the given address, connecting
+// with the given credentials. It is a no-op when no request is held.
+func (h *RequestHandoff) Send(ctx context.Context, peer string, creds credentials.TransportCredentials) error {
+	requests := h.heldRequests()
+	if len(requests) == 0 {
+		return nil
+	}
+	pool, err := h.activator.datastore.PoolGet()
+	if err != nil {
+		return err
+	}
+	poolName := pool.Namespace + "/" + pool.Name
+	payload, err := h.codec.Marshal(HeldRequestBatch{Pool: poolName, Requests: requests})
+	if err != nil {
+		return fmt.Errorf("failed to serialize the held requests: %w", err)
+	}
+
+	conn, err := grpc.NewClient(peer, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx = metadata.AppendToOutgoingContext(ctx, handoffCodecMetadataKey, h.codec.Name())
+	if err := conn.Invoke(ctx, handoffTransferMethod, wrapperspb.Bytes(payload), &emptypb.Empty{}); err != nil {
+		return fmt.Errorf("failed to hand the held requests off to %s: %w", peer, err)
+	}
+
+	metrics.RecordHandedOffRequests(poolName, "sent", len(requests))
+	log.FromContext(ctx).Info("Handed the held requests off to a peer activator", "peer", peer, "requests", len(requests))
+	return nil
+}
+
+// Transfer receives the requests held by a peer replica, and starts the activation of the pool for them.
+func (h *RequestHandoff) Transfer(ctx context.Context, in *wrapperspb.BytesValue) (*emptypb.Empty, error) {
+	logger := log.FromContext(ctx)
+	codecName := JSONHeldRequestCodecName
+	if values := metadata.ValueFromIncomingContext(ctx, handoffCodecMetadataKey); len(values) > 0 {
+		codecName = values[0]
+	}
+	codec, ok := heldRequestCodecFor(codecName)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown held request codec %q", codecName)
+	}
+	batch, err := codec.Unmarshal(in.GetValue())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid held requests: %v", err)
+	}
+	pool, err := h.activator.datastore.PoolGet()
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if poolName := pool.Namespace + "/" + pool.Name; batch.Pool != poolName {
+		return nil, status.Errorf(codes.FailedPrecondition, "requests held for inferencePool %q cannot be handed off to the activator of inferencePool %q", batch.Pool, poolName)
+	}
+
+	now := time.Now()
+	h.expect(batch.Requests, now, now.Add(poolconfig.For(pool).ScaleFromZeroGracePeriod))
+	metrics.RecordHandedOffRequests(batch.Pool, "received", len(batch.Requests))
+	logger.Info("Received the requests held by a peer activator, activating the inferencePool", "requests", len(batch.Requests))
+	if len(batch.Requests) > 0 {
+		activationCtx := log.IntoContext(context.Background(), logger)
+		go h.activate(activationCtx, pool)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// Register registers the handoff service on the given gRPC server.
+func (h *RequestHandoff) Register(srv *grpc.Server) {
+	srv.RegisterService(&handoffServiceDesc, h)
+}
+
+// trackHeld records the request of the given context as held for the handoff, if any, until the returned
+// function is called.
+func (a *Activator) trackHeld(ctx context.Context) func() {
+	if a.Handoff == nil {
+		return func() {}
+	}
+	return a.Handoff.track(ctx)
+}
+
+// resumeHandedOff records the request of the given context resumed when a peer replica handed it off.
+func (a *Activator) resumeHandedOff(ctx context.Context, poolName string) {
+	if a.Handoff == nil || !a.Handoff.resume(ctx, time.Now()) {
+		return
+	}
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Resuming the request handed off by a peer activator")
+	metrics.RecordHandedOffRequests(poolName, "resumed", 1)
+}
+
+// handoffServer is the server side of the handoff service.
+type handoffServer interface {
+	Transfer(ctx context.Context, in *wrapperspb.BytesValue) (*emptypb.Empty, error)
+}
+
+var handoffServiceDesc = grpc.ServiceDesc{
+	ServiceName: HandoffServiceName,
+	HandlerType: (*handoffServer)(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "Transfer",
+		Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			in := &wrapperspb.BytesValue{}
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+			if interceptor == nil {
+				return srv.(handoffServer).Transfer(ctx, in)
+			}
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: handoffTransferMethod}
+			return interceptor(ctx, in, info, func(ctx context.Context, req any) (any, error) {
+				return srv.(handoffServer).Transfer(ctx, req.(*wrapperspb.BytesValue))
+			})
+		},
+	}},
+	Streams: []grpc.StreamDesc{},
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func newRequestHandoff(t *testing.T, pool *v1.InferencePool) *RequestHandoff {
+	ds := datastore.NewDatastore(context.Background())
+	ds.PoolSet(pool)
+	handoff, err := NewRequestHandoff(&Activator{datastore: ds}, JSONHeldRequestCodecName)
+	if err != nil {
+		t.Fatalf("NewRequestHandoff() error = %v", err)
+	}
+	return handoff
+}
+
+func TestRequestHandoff(t *testing.T) {
+	pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"}}
+
+	peer := newRequestHandoff(t, pool)
+	activated := make(chan string, 1)
+	peer.activate = func(_ context.Context, pool *v1.InferencePool) { activated <- pool.Name }
+	srv := grpc.NewServer()
+	peer.Register(srv)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	restarting := newRequestHandoff(t, pool)
+	ctx := context.Background()
+	release := restarting.track(withModelName(withRequestID(ctx, "req-1"), "llama"))
+	restarting.track(withRequestID(ctx, "req-2"))
+	release()
+	restarting.track(withRequestID(ctx, "req-3"))
+
+	if err := restarting.Send(ctx, lis.Addr().String(), insecure.NewCredentials()); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	select {
+	case name := <-activated:
+		if name != pool.Name {
+			t.Errorf("activated inferencePool %s, want %s", name, pool.Name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("peer did not activate the inferencePool for the handed off requests")
+	}
+
+	now := time.Now()
+	for _, step := range []struct {
+		requestID string
+		want      bool
+	}{
+		{requestID: "req-1"},
+		{requestID: "req-2", want: true},
+		{requestID: "req-3", want: true},
+		{requestID: "req-3"},
+	} {
+		if got := peer.resume(withRequestID(ctx, step.requestID), now); got != step.want {
+			t.Errorf("resume(%s) = %t, want %t", step.requestID, got, step.want)
+		}
+	}
+
+	other := newRequestHandoff(t, &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"}})
+	other.track(withRequestID(ctx, "req-4"))
+	if err := other.Send(ctx, lis.Addr().String(), insecure.NewCredentials()); err == nil {
+		t.Error("Send() handed off the requests of another inferencePool")
+	}
+}
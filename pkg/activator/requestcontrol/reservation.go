@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// ProvisioningClassKey is set on the target workload to reserve capacity with a cluster-autoscaler
+	// ProvisioningRequest of the given class before scaling it up from zero, e.g.
+	// "best-effort-atomic-scale-up.autoscaling.x-k8s.io". The pod template of the workload should carry the
+	// "autoscaling.x-k8s.io/consume-provisioning-request" annotation set to the reservation name, so that
+	// its pods land on the reserved capacity.
+	ProvisioningClassKey = "activator.llm-d.ai/provisioning-class" // Optional target workload annotation
+
+	// reservationSuffix is appended to the name of the target workload to name its reservation
+	reservationSuffix = "-activator"
+)
+
+var (
+	provisioningRequestGVR = schema.GroupVersionResource{Group: "autoscaling.x-k8s.io", Version: "v1", Resource: "provisioningrequests"}
+	podTemplateGVR         = schema.GroupVersionResource{Version: "v1", Resource: "podtemplates"}
+)
+
+// ReservationName returns the name of the capacity reservation of the given target workload.
+func ReservationName(targetName string) string {
+	return targetName + reservationSuffix
+}
+
+// reserveCapacity claims capacity for the given number of replicas of the target workload with a
+// ProvisioningRequest, and waits until the capacity is provisioned. It does nothing for target workloads
+// without provisioning class annotation.
+func reserveCapacity(ctx context.Context, client dynamic.Interface, target *unstructured.Unstructured, replicas int32, timeout time.Duration) error {
+	class, ok := target.GetAnnotations()[ProvisioningClassKey]
+	if !ok || class == "" {
+		return nil
+	}
+
+	// A reservation left over from a previous activation may have expired, always start from a fresh one
+	if err := releaseCapacity(ctx, client, target); err != nil {
+		return err
+	}
+
+	namespace, name := target.GetNamespace(), ReservationName(target.GetName())
+	template, found, err := unstructured.NestedMap(target.Object, "spec", "template")
+	if err != nil || !found {
+		return fmt.Errorf("target workload %s has no pod template to reserve capacity for", target.GetName())
+	}
+	ownerReferences := []any{map[string]any{
+		"apiVersion": target.GetAPIVersion(),
+		"kind":       target.GetKind(),
+		"name":       target.GetName(),
+		"uid":        string(target.GetUID()),
+	}}
+
+	podTemplate := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "PodTemplate",
+		"metadata":   map[string]any{"name": name, "namespace": namespace, "ownerReferences": ownerReferences},
+		"template":   template,
+	}}
+	if _, err := client.Resource(podTemplateGVR).Namespace(namespace).Create(ctx, podTemplate, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create pod template for capacity reservation %s: %w", name, err)
+	}
+
+	provisioningRequest := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "autoscaling.x-k8s.io/v1",
+		"kind":       "ProvisioningRequest",
+		"metadata":   map[string]any{"name": name, "namespace": namespace, "ownerReferences": ownerReferences},
+		"spec": map[string]any{
+			"provisioningClassName": class,
+			"podSets": []any{map[string]any{
+				"podTemplateRef": map[string]any{"name": name},
+				"count":          int64(replicas),
+			}},
+		},
+	}}
+	if _, err := client.Resource(provisioningRequestGVR).Namespace(namespace).Create(ctx, provisioningRequest, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create capacity reservation %s: %w", name, err)
+	}
+
+	var failure error
+	// Don't inherit the parent context to avoid cancellation
+	err = wait.PollUntilContextTimeout(context.Background(), 1*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		obj, err := client.Resource(provisioningRequestGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, nil // continue polling
+		}
+		conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		for _, c := range conditions {
+			condition, _ := c.(map[string]any)
+			if condition["status"] != string(metav1.ConditionTrue) {
+				continue
+			}
+			switch condition["type"] {
+			case "Provisioned":
+				return true, nil
+			case "Failed":
+				failure = fmt.Errorf("capacity reservation %s failed: %v", name, condition["message"])
+				return false, failure
+			}
+		}
+		return false, nil
+	})
+	if failure != nil {
+		return failure
+	}
+	if err != nil {
+		return fmt.Errorf("capacity reservation %s was not provisioned within %s", name, timeout)
+	}
+	return nil
+}
+
+// releaseCapacity deletes the capacity reservation of the target workload, if any.
+func releaseCapacity(ctx context.Context, client dynamic.Interface, target *unstructured.Unstructured) error {
+	if _, ok := target.GetAnnotations()[ProvisioningClassKey]; !ok {
+		return nil
+	}
+
+	namespace, name := target.GetNamespace(), ReservationName(target.GetName())
+	for _, gvr := range []schema.GroupVersionResource{provisioningRequestGVR, podTemplateGVR} {
+		err := client.Resource(gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return fmt.Errorf("failed to release capacity reservation %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"sync/atomic"
+)
+
+// ResponseTracker keeps track of the requests released to the pool whose response is not complete yet, so
+// that the Deactivator does not scale the pool down in the middle of long-running generations. The idle
+// period of the pool starts once the last response completed, streamed responses included. The requests
+// received but not released yet are tracked as well, so that none is released to a pool being scaled down.
+type ResponseTracker struct {
+	inFlight atomic.Int32
+	arriving atomic.Int32
+	// completed counts the responses completed, each starting a new idle period of the pool
+	completed atomic.Uint64
+}
+
+func NewResponseTracker() *ResponseTracker {
+	return &ResponseTracker{}
+}
+
+// Begin records a request released to the pool.
+func (t *ResponseTracker) Begin() {
+	t.inFlight.Add(1)
+}
+
+// End records the completion of the response to a request released to the pool.
+func (t *ResponseTracker) End() {
+	t.inFlight.Add(-1)
+	t.completed.Add(1)
+}
+
+// IdlePeriod identifies the current idle period of the pool, which changes whenever a response completes.
+func (t *ResponseTracker) IdlePeriod() uint64 {
+	return t.completed.Load()
+}
+
+// InFlight returns the number of requests released to the pool whose response is not complete yet.
+func (t *ResponseTracker) InFlight() int32 {
+	return t.inFlight.Load()
+}
+
+// Arrive records a request received for the pool, until it is released to the pool or rejected.
+func (t *ResponseTracker) Arrive() {
+	t.arriving.Add(1)
+}
+
+// Depart records the release to the pool, or the rejection, of a request received for the pool. Requests
+// released are recorded by Begin first, so that they are tracked throughout.
+func (t *ResponseTracker) Depart() {
+	t.arriving.Add(-1)
+}
+
+// Active returns the number of requests received for the pool whose response is not complete yet, released
+// to the pool or not.
+func (t *ResponseTracker) Active() int32 {
+	return t.arriving.Load() + t.inFlight.Load()
+}
+
+// storeLatest stores the given Unix nanoseconds unless a later time is stored already, so that concurrent
+// requests completing out of order never move the time of the last request back.
+func storeLatest(v *atomic.Int64, unixNano int64) {
+	for {
+		current := v.Load()
+		if current >= unixNano || v.CompareAndSwap(current, unixNano) {
+			return
+		}
+	}
+}
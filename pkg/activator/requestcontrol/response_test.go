@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/handlers"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestResponseCompletion(t *testing.T) {
+	ds := datastore.NewDatastore(context.Background())
+	ds.PoolSet(&v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"}})
+	a := &Activator{datastore: ds}
+	d := &Director{datastore: ds, activator: a, Responses: NewResponseTracker()}
+
+	admitted, rejected := &handlers.RequestContext{}, &handlers.RequestContext{}
+	d.admit(admitted)
+	if inFlight := d.Responses.InFlight(); inFlight != 1 {
+		t.Fatalf("responses in flight = %d, want 1", inFlight)
+	}
+
+	before := time.Now()
+	d.HandleRequestCompletion(context.Background(), rejected)
+	if inFlight := d.Responses.InFlight(); inFlight != 1 {
+		t.Errorf("responses in flight after a rejected request completed = %d, want 1", inFlight)
+	}
+	if a.lastRequest.Load() != 0 {
+		t.Error("rejected request completion recorded as the last request of the pool")
+	}
+
+	d.HandleRequestCompletion(context.Background(), admitted)
+	if inFlight := d.Responses.InFlight(); inFlight != 0 {
+		t.Errorf("responses in flight = %d, want 0", inFlight)
+	}
+	if lastRequest := time.Unix(0, a.lastRequest.Load()); lastRequest.Before(before) {
+		t.Errorf("last request = %v, want the response completion", lastRequest)
+	}
+}
+
+func TestResponseTrackerActive(t *testing.T) {
+	tracker := NewResponseTracker()
+	tracker.Arrive()
+	if active, inFlight := tracker.Active(), tracker.InFlight(); active != 1 || inFlight != 0 {
+		t.Fatalf("held request: active = %d, in flight = %d, want 1 and 0", active, inFlight)
+	}
+	// Released requests are tracked throughout
+	tracker.Begin()
+	tracker.Depart()
+	if active, inFlight := tracker.Active(), tracker.InFlight(); active != 1 || inFlight != 1 {
+		t.Fatalf("released request: active = %d, in flight = %d, want 1 and 1", active, inFlight)
+	}
+	tracker.End()
+	if active := tracker.Active(); active != 0 {
+		t.Errorf("completed request: active = %d, want 0", active)
+	}
+}
+
+func TestStoreLatest(t *testing.T) {
+	var v atomic.Int64
+	var wg sync.WaitGroup
+	for i := int64(1); i <= 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			storeLatest(&v, i)
+		}()
+	}
+	wg.Wait()
+	if got := v.Load(); got != 100 {
+		t.Errorf("storeLatest() = %d, want the latest time 100", got)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	requtil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/request"
+)
+
+const (
+	// DefaultIdempotencyKeyHeader is the request header identifying the attempts of the same logical request
+	DefaultIdempotencyKeyHeader = "idempotency-key"
+
+	// EnvoyAttemptCountHeaderKey is set by Envoy to the attempt number of requests retried by the gateway
+	EnvoyAttemptCountHeaderKey = "x-envoy-attempt-count"
+
+	// DefaultRetryMemory is how long failed requests are remembered to recognize their retries
+	DefaultRetryMemory = time.Duration(10 * time.Minute)
+
+	// maxFailedRequests bounds the number of failed requests remembered
+	maxFailedRequests = 10000
+)
+
+// RetryTracker remembers the requests that failed or timed out in the activator, so that their retries,
+// recognized by request ID or idempotency key, are prioritized over first-time requests.
+type RetryTracker struct {
+	idempotencyKeyHeader string
+	memory               time.Duration
+
+	mu     sync.Mutex
+	failed map[string]time.Time
+}
+
+// NewRetryTracker creates a RetryTracker identifying requests by the given lower-cased idempotency key
+// header in addition to their request ID.
+func NewRetryTracker(idempotencyKeyHeader string, memory time.Duration) *RetryTracker {
+	return &RetryTracker{
+		idempotencyKeyHeader: idempotencyKeyHeader,
+		memory:               memory,
+		failed:               make(map[string]time.Time),
+	}
+}
+
+// IsRetry reports whether the request with the given headers is a retry of a failed request.
+func (t *RetryTracker) IsRetry(headers map[string]string, now time.Time) bool {
+	if attempt, err := strconv.Atoi(headers[EnvoyAttemptCountHeaderKey]); err == nil && attempt > 1 {
+		return true
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, key := range t.keys(headers) {
+		if failedAt, ok := t.failed[key]; ok && now.Sub(failedAt) < t.memory {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordFailure remembers the request with the given headers as failed.
+func (t *RetryTracker) RecordFailure(headers map[string]string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	keys := t.keys(headers)
+	if len(keys) == 0 {
+		return
+	}
+	if len(t.failed)+len(keys) > maxFailedRequests {
+		t.expireLocked(now)
+	}
+	for _, key := range keys {
+		if len(t.failed) >= maxFailedRequests {
+			return
+		}
+		t.failed[key] = now
+	}
+}
+
+// keys returns the identifiers of the request, prefixed by their kind so they cannot collide.
+func (t *RetryTracker) keys(headers map[string]string) []string {
+	var keys []string
+	if requestID := headers[requtil.RequestIdHeaderKey]; requestID != "" {
+		keys = append(keys, "id/"+requestID)
+	}
+	if t.idempotencyKeyHeader != "" {
+		if key := headers[t.idempotencyKeyHeader]; key != "" {
+			keys = append(keys, "key/"+key)
+		}
+	}
+	return keys
+}
+
+func (t *RetryTracker) expireLocked(now time.Time) {
+	for key, failedAt := range t.failed {
+		if now.Sub(failedAt) >= t.memory {
+			delete(t.failed, key)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/features"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// rolloutInProgress reports whether the target workload is rolling out a new revision of its pods, that is
+// whether pods of a previous revision are still around, as reported in the status of Deployments,
+// StatefulSets and workloads following the same conventions.
+func rolloutInProgress(target *unstructured.Unstructured) bool {
+	currentRevision, _, _ := unstructured.NestedString(target.Object, "status", "currentRevision")
+	updateRevision, _, _ := unstructured.NestedString(target.Object, "status", "updateRevision")
+	if currentRevision != "" && updateRevision != "" && currentRevision != updateRevision {
+		return true
+	}
+
+	replicas, _, _ := unstructured.NestedInt64(target.Object, "status", "replicas")
+	updatedReplicas, found, _ := unstructured.NestedInt64(target.Object, "status", "updatedReplicas")
+	return (found || replicas > 0) && replicas > updatedReplicas
+}
+
+// targetRollingOut reports whether the target workload of the given name is rolling out, when rollout aware
+// activation is enabled.
+func targetRollingOut(ctx context.Context, client dynamic.Interface, gvr schema.GroupVersionResource, namespace, name string) bool {
+	if !features.Enabled(features.RolloutAwareActivation) {
+		return false
+	}
+	target, err := client.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	return err == nil && rolloutInProgress(target)
+}
+
+// rolloutPodsReady waits until the target workload rolling out has at least one ready replica, or meets its
+// ready condition, or the scale grace period elapsed. Requests are released on the replicas available during
+// the rollout, leaving the replicas of the workload to its deployment strategy.
+func (a *Activator) rolloutPodsReady(logger logr.Logger, pool *v1.InferencePool, scaleGracePeriod time.Duration, gvr schema.GroupVersionResource) bool {
+	path := readyReplicasPathFor(pool)
+	check := func(target *unstructured.Unstructured) bool {
+		readyReplicas, _ := readyReplicasOf(target, path)
+		logger.V(logutil.DEBUG).Info("Waiting for a ready replica of the target object rolling out", "ready", readyReplicas)
+		return readyReplicas > 0
+	}
+	if conditionType, status, ok := readyConditionFor(pool); ok {
+		check = readyConditionCheck(logger, conditionType, status)
+	}
+	// Don't inherit the parent context to avoid cancellation
+	return watchReadiness(context.Background(), logger, a.DynamicClient, gvr, pool.Namespace, poolconfig.For(pool).Target.Name, scaleGracePeriod, check, nil)
+}
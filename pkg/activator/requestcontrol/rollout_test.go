@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestRolloutInProgress(t *testing.T) {
+	tests := []struct {
+		name   string
+		status map[string]any
+		want   bool
+	}{
+		{name: "scaled to zero", status: map[string]any{}, want: false},
+		{name: "all replicas updated", status: map[string]any{"replicas": int64(2), "updatedReplicas": int64(2)}, want: false},
+		{name: "scaling up", status: map[string]any{"replicas": int64(3), "updatedReplicas": int64(3), "readyReplicas": int64(1)}, want: false},
+		{name: "surging new replicas", status: map[string]any{"replicas": int64(3), "updatedReplicas": int64(1)}, want: true},
+		{name: "rollout not started", status: map[string]any{"replicas": int64(2)}, want: true},
+		{
+			name:   "statefulset rolling update",
+			status: map[string]any{"replicas": int64(2), "updatedReplicas": int64(2), "currentRevision": "web-1", "updateRevision": "web-2"},
+			want:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target := &unstructured.Unstructured{Object: map[string]any{"status": test.status}}
+			if got := rolloutInProgress(target); got != test.want {
+				t.Errorf("rolloutInProgress() = %t, want %t", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/audit"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// RolloverTargetKey is the name of the workload the pool rolls over to, e.g. the Deployment of a new version
+// of its model, with the apiVersion and kind of its target workload. While the target workload is scaled to
+// zero, the pool is woken on the new workload at once. While it serves requests, it keeps serving them while
+// the new workload is woken in the background, and the pool cuts over to the new workload, scaling the target
+// workload down to zero, once the new workload is ready and passed the warm-up or readiness probe of the pool,
+// if any. The cutover lasts until the target annotations of the pool are updated to the new workload.
+const RolloverTargetKey = poolconfig.RolloverTargetKey // Optional annotation
+
+// rolloverRetryInterval spaces the attempts to roll a pool over to a new workload that failed its checks.
+const rolloverRetryInterval = 5 * time.Minute
+
+// rollover tracks the roll over of the pool to a new workload.
+type rollover struct {
+	mu sync.Mutex
+	// from and to are the target workload and the new workload of the last cutover
+	from, to string
+	running  bool
+	failedAt time.Time
+}
+
+// cutOver records the cutover of the pool from the given target workload to the given new one.
+func (r *rollover) cutOver(from, to string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.from, r.to = from, to
+}
+
+// cutsOver reports whether the pool was cut over from the given target workload to the given new one.
+func (r *rollover) cutsOver(from, to string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.from == from && r.to == to
+}
+
+// begin reports whether a roll over in the background may begin at the given time, marking it running if so.
+func (r *rollover) begin(now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running || now.Sub(r.failedAt) < rolloverRetryInterval {
+		return false
+	}
+	r.running = true
+	return true
+}
+
+// end marks the roll over in the background over, failed or not, at the given time.
+func (r *rollover) end(failed bool, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.running = false
+	if failed {
+		r.failedAt = now
+	}
+}
+
+// rolledOver returns a copy of the given pool cut over to the given new workload.
+func rolledOver(pool *v1.InferencePool, to string) *v1.InferencePool {
+	pool = pool.DeepCopy()
+	pool.Annotations[ObjectNameKey] = to
+	delete(pool.Annotations, RolloverTargetKey)
+	return pool
+}
+
+// ApplyRollover points the target annotations of the given pool to its rollover target once the pool was cut
+// over to it, either by this activator or, as seen from the replicas of both workloads, by another one. The
+// annotations are only changed in memory.
+func (a *Activator) ApplyRollover(ctx context.Context, pool *v1.InferencePool) error {
+	config := poolconfig.For(pool)
+	to, from := config.RolloverTarget, config.Target.Name
+	if to == "" {
+		return nil
+	}
+	if !a.rollover.cutsOver(from, to) {
+		gvr, err := targetResourceFor(a.Mapper, pool)
+		if err != nil {
+			return nil
+		}
+		old, err := a.ScaleClient.Scales(pool.Namespace).Get(ctx, gvr.GroupResource(), from, metav1.GetOptions{})
+		if err != nil || old.Spec.Replicas > 0 {
+			return nil
+		}
+		next, err := a.ScaleClient.Scales(pool.Namespace).Get(ctx, gvr.GroupResource(), to, metav1.GetOptions{})
+		if err != nil || next.Spec.Replicas == 0 {
+			return nil
+		}
+		a.rollover.cutOver(from, to)
+	}
+	*pool = *rolledOver(pool, to)
+	log.FromContext(ctx).V(logutil.DEBUG).Info("InferencePool was cut over to its rollover target", "from", from, "to", to)
+	return nil
+}
+
+// rollOverIdle cuts the given pool over to its rollover target, if any, while its target workload is scaled to
+// zero, and returns the pool cut over. Otherwise, when its target workload serves requests, it starts rolling
+// the pool over in the background.
+func (a *Activator) rollOverIdle(logger logr.Logger, pool *v1.InferencePool, replicas int32, gvr schema.GroupVersionResource) (*v1.InferencePool, bool) {
+	to := poolconfig.For(pool).RolloverTarget
+	if to == "" {
+		return pool, false
+	}
+	if replicas > 0 {
+		if a.rollover.begin(time.Now()) {
+			go a.rollOver(logger, pool, to, replicas, gvr)
+		}
+		return pool, false
+	}
+	return a.completeRollover(logger, pool, to), true
+}
+
+// rollOver wakes the given new workload of the pool to the given replicas while the target workload keeps
+// serving the requests, checks it and cuts the pool over to it, or scales it back down if it failed its checks.
+func (a *Activator) rollOver(logger logr.Logger, pool *v1.InferencePool, to string, replicas int32, gvr schema.GroupVersionResource) {
+	ctx := context.Background()
+	logger = logger.WithValues("rollover-target", to)
+	gracePeriod := poolconfig.For(pool).ScaleFromZeroGracePeriod
+
+	failed := true
+	defer func() { a.rollover.end(failed, time.Now()) }()
+
+	scaleObject, err := a.ScaleClient.Scales(pool.Namespace).Get(ctx, gvr.GroupResource(), to, metav1.GetOptions{})
+	if err != nil {
+		a.rolloverFailed(logger, pool, to, err.Error())
+		return
+	}
+	previous := scaleObject.Spec.Replicas
+	target := &ScaleTarget{Pool: pool, Resource: gvr, Scale: scaleObject}
+	scaler := &scaleStrategy{clients: StrategyClients{ScaleClient: a.ScaleClient, DynamicClient: a.DynamicClient}}
+	if previous < replicas {
+		if err := scaler.setReplicas(ctx, target, replicas); err != nil {
+			a.rolloverFailed(logger, pool, to, err.Error())
+			return
+		}
+	}
+	logger.Info("Waking the rollover target while the target workload serves the requests", "replicas", replicas)
+
+	if !watchReadiness(ctx, logger, a.DynamicClient, gvr, pool.Namespace, to, gracePeriod, readinessCheckFor(logger, pool, releaseReplicasFor(pool, replicas)), nil) {
+		a.rolloverFailed(logger, pool, to, "rollover target did not become ready within the scale grace period")
+		a.restoreRolloverTarget(ctx, logger, scaler, target, previous)
+		return
+	}
+	if err := a.rolloverCanary(ctx, pool, to, gvr); err != nil {
+		a.rolloverFailed(logger, pool, to, err.Error())
+		a.restoreRolloverTarget(ctx, logger, scaler, target, previous)
+		return
+	}
+	failed = false
+
+	from := poolconfig.For(pool).Target.Name
+	a.completeRollover(logger, pool, to)
+	if old, err := a.ScaleClient.Scales(pool.Namespace).Get(ctx, gvr.GroupResource(), from, metav1.GetOptions{}); err != nil {
+		logger.Error(err, "Error getting the scale subresource of the workload rolled over from")
+	} else if err := scaler.setReplicas(ctx, &ScaleTarget{Pool: pool, Resource: gvr, Scale: old}, 0); err != nil {
+		logger.Error(err, "Error scaling down the workload rolled over from")
+	}
+}
+
+// rolloverCanary sends the warm-up request of the pool, or else probes its readiness probe path, on the ready
+// pods of the given new workload. Pools setting neither pass at once.
+func (a *Activator) rolloverCanary(ctx context.Context, pool *v1.InferencePool, to string, gvr schema.GroupVersionResource) error {
+	config := poolconfig.For(pool)
+	if (config.WarmUpPath == "" && config.ReadinessProbePath == "") || len(pool.Spec.TargetPorts) == 0 {
+		return nil
+	}
+	workload, err := a.DynamicClient.Resource(gvr).Namespace(pool.Namespace).Get(ctx, to, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	selector, _, _ := unstructured.NestedStringMap(workload.Object, "spec", "selector", "matchLabels")
+	if len(selector) == 0 {
+		return fmt.Errorf("rollover target %s selects no pods to check", to)
+	}
+	pods, err := a.DynamicClient.Resource(podGVR).Namespace(pool.Namespace).List(ctx, metav1.ListOptions{LabelSelector: labels.SelectorFromSet(selector).String()})
+	if err != nil {
+		return err
+	}
+	endpoints, _ := readyEndpoints(pods.Items, pool.Spec.TargetPorts[:1])
+	if len(endpoints) == 0 {
+		return fmt.Errorf("rollover target %s has no ready pod to check", to)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultWarmUpTimeout)
+	defer cancel()
+	for _, endpoint := range endpoints {
+		if config.WarmUpPath != "" {
+			if err := warmUpEndpoint(ctx, &http.Client{}, "http://"+endpoint+config.WarmUpPath, config.WarmUpBody); err != nil {
+				return fmt.Errorf("rollover target pod %s failed the warm-up request: %w", endpoint, err)
+			}
+			continue
+		}
+		if countHealthy(ctx, &http.Client{Timeout: readinessProbeTimeout}, []string{"http://" + endpoint + config.ReadinessProbePath}) == 0 {
+			return fmt.Errorf("rollover target pod %s failed the readiness probe", endpoint)
+		}
+	}
+	return nil
+}
+
+// completeRollover records the cutover of the given pool to the given new workload, updates the pool known to
+// the activator and reports the cutover. It returns the pool cut over.
+func (a *Activator) completeRollover(logger logr.Logger, pool *v1.InferencePool, to string) *v1.InferencePool {
+	from := poolconfig.For(pool).Target.Name
+	a.rollover.cutOver(from, to)
+	next := rolledOver(pool, to)
+	a.datastore.PoolSet(next)
+
+	message := fmt.Sprintf("InferencePool cut over from %s to %s, update its target annotations", from, to)
+	logger.Info("InferencePool cut over to its rollover target", "from", from, "to", to)
+	audit.Log(audit.Record{
+		Action:  audit.ActionRollover,
+		Outcome: audit.OutcomeSucceeded,
+		Pool:    pool.Namespace + "/" + pool.Name,
+		Target:  fmt.Sprintf("%s/%s", poolconfig.For(pool).Target.Kind, to),
+		Message: message,
+	})
+	if a.Recorder != nil {
+		a.Recorder.Event(pool, corev1.EventTypeNormal, "RolloverCompleted", message)
+	}
+	return next
+}
+
+// rolloverFailed reports the failure of the roll over of the given pool to the given new workload.
+func (a *Activator) rolloverFailed(logger logr.Logger, pool *v1.InferencePool, to, message string) {
+	logger.Info("Rollover target failed its checks, keeping the target workload", "reason", message)
+	audit.Log(audit.Record{
+		Action:  audit.ActionRollover,
+		Outcome: audit.OutcomeFailed,
+		Pool:    pool.Namespace + "/" + pool.Name,
+		Target:  fmt.Sprintf("%s/%s", poolconfig.For(pool).Target.Kind, to),
+		Message: message,
+	})
+	if a.Recorder != nil {
+		a.Recorder.Event(pool, corev1.EventTypeWarning, "RolloverFailed", message)
+	}
+}
+
+// restoreRolloverTarget brings the new workload that failed its checks back to the replicas it had before.
+func (a *Activator) restoreRolloverTarget(ctx context.Context, logger logr.Logger, scaler *scaleStrategy, target *ScaleTarget, previous int32) {
+	if err := scaler.setReplicas(ctx, target, previous); err != nil {
+		logger.Error(err, "Error restoring the replicas of the rollover target", "replicas", previous)
+	}
+}
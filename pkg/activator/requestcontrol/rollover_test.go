@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	autoscaling "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakescale "k8s.io/client-go/scale/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestApplyRollover(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+	tests := []struct {
+		name        string
+		rollover    string
+		replicas    map[string]int32
+		cutOver     bool
+		wantTarget  string
+		wantPending bool
+	}{
+		{name: "no rollover target", replicas: map[string]int32{"v1": 1}, wantTarget: "v1"},
+		{name: "target serving", rollover: "v2", replicas: map[string]int32{"v1": 1, "v2": 1}, wantTarget: "v1", wantPending: true},
+		{name: "both scaled to zero", rollover: "v2", replicas: map[string]int32{"v1": 0, "v2": 0}, wantTarget: "v1", wantPending: true},
+		{name: "rollover target serving alone", rollover: "v2", replicas: map[string]int32{"v1": 0, "v2": 2}, wantTarget: "v2"},
+		{name: "cut over by this activator", rollover: "v2", replicas: map[string]int32{"v1": 0, "v2": 0}, cutOver: true, wantTarget: "v2"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &fakescale.FakeScaleClient{}
+			client.AddReactor("get", "deployments", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				name := action.(clienttesting.GetAction).GetName()
+				return true, &autoscaling.Scale{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: autoscaling.ScaleSpec{Replicas: test.replicas[name]}}, nil
+			})
+			activator := &Activator{ScaleClient: client, Mapper: mapper}
+			if test.cutOver {
+				activator.rollover.cutOver("v1", "v2")
+			}
+
+			annotations := map[string]string{ObjectApiVersionKey: "apps/v1", ObjectkindKey: "Deployment", ObjectNameKey: "v1"}
+			if test.rollover != "" {
+				annotations[RolloverTargetKey] = test.rollover
+			}
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", UID: types.UID(test.name), Annotations: annotations}}
+			if err := activator.ApplyRollover(context.Background(), pool); err != nil {
+				t.Fatalf("ApplyRollover() error = %v", err)
+			}
+			if target := pool.Annotations[ObjectNameKey]; target != test.wantTarget {
+				t.Errorf("target = %s, want %s", target, test.wantTarget)
+			}
+			if _, pending := pool.Annotations[RolloverTargetKey]; pending != test.wantPending {
+				t.Errorf("rollover pending = %t, want %t", pending, test.wantPending)
+			}
+		})
+	}
+}
+
+func TestRolloverRetries(t *testing.T) {
+	var r rollover
+	now := time.Now()
+	if !r.begin(now) {
+		t.Fatal("begin() = false, want a first rollover to begin")
+	}
+	if r.begin(now) {
+		t.Error("begin() = true while a rollover is running, want false")
+	}
+	r.end(true, now)
+	if r.begin(now.Add(time.Minute)) {
+		t.Error("begin() = true right after a failed rollover, want false")
+	}
+	if !r.begin(now.Add(rolloverRetryInterval)) {
+		t.Error("begin() = false after the rollover retry interval, want true")
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+
+	autoscaling "k8s.io/api/autoscaling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/scale"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+// ReplicasFallbackKey makes the activator read and patch the spec.replicas field of the target workload
+// when set to "true" and the target workload has no scale subresource, e.g. bespoke inference CRDs.
+const ReplicasFallbackKey = poolconfig.ReplicasFallbackKey // Optional annotation
+
+// replicasFallback reports whether the given pool falls back to the spec.replicas field of its target
+// workload when it has no scale subresource.
+func replicasFallback(pool *v1.InferencePool) bool {
+	return poolconfig.For(pool).ReplicasFallback
+}
+
+// scaleOf returns the scale subresource of the target workload of the given pool. When the target workload
+// has none and the pool sets the replicas fallback annotation, the scale is read from the workload itself:
+// its spec.replicas, and its status.replicas or else its ready replicas. Ray target workloads, which have
+// none either, are seen through whether they are suspended.
+func scaleOf(ctx context.Context, scaleClient scale.ScalesGetter, dynamicClient dynamic.Interface, pool *v1.InferencePool, gvr schema.GroupVersionResource) (*autoscaling.Scale, error) {
+	if rayResource(gvr) {
+		return rayScaleOf(ctx, dynamicClient, pool, gvr)
+	}
+	name := poolconfig.For(pool).Target.Name
+	scaleObject, err := scaleClient.Scales(pool.Namespace).Get(ctx, gvr.GroupResource(), name, metav1.GetOptions{})
+	if err == nil || !apierrors.IsNotFound(err) || !replicasFallback(pool) {
+		return scaleObject, err
+	}
+
+	target, err := dynamicClient.Resource(gvr).Namespace(pool.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	specReplicas, found, err := unstructured.NestedInt64(target.Object, "spec", "replicas")
+	if err != nil || !found {
+		return nil, fmt.Errorf("target %s of inferencePool %s has neither a scale subresource nor an integer spec.replicas field", name, pool.Name)
+	}
+	statusReplicas, found, _ := unstructured.NestedInt64(target.Object, "status", "replicas")
+	if !found {
+		statusReplicas, _ = readyReplicasOf(target, readyReplicasPathFor(pool))
+	}
+	return &autoscaling.Scale{
+		ObjectMeta: metav1.ObjectMeta{Name: target.GetName(), Namespace: target.GetNamespace(), UID: target.GetUID(), ResourceVersion: target.GetResourceVersion()},
+		Spec:       autoscaling.ScaleSpec{Replicas: int32(specReplicas)},
+		Status:     autoscaling.ScaleStatus{Replicas: int32(statusReplicas)},
+	}, nil
+}
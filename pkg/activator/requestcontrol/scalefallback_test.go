@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	fakescale "k8s.io/client-go/scale/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestReplicasFallback(t *testing.T) {
+	modelGVR := schema.GroupVersionResource{Group: "serving.example.com", Version: "v1", Resource: "models"}
+	newTarget := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "serving.example.com/v1",
+			"kind":       "Model",
+			"metadata":   map[string]any{"name": "model", "namespace": "default"},
+			"spec":       map[string]any{"replicas": int64(0)},
+			"status":     map[string]any{"readyReplicas": int64(0)},
+		}}
+	}
+
+	tests := []struct {
+		name     string
+		fallback string
+		wantErr  bool
+	}{
+		{name: "fallback enabled", fallback: "true"},
+		{name: "fallback disabled", fallback: "false", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scaleClient := &fakescale.FakeScaleClient{}
+			scaleClient.AddReactor("*", "models", func(clienttesting.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewNotFound(modelGVR.GroupResource(), "model")
+			})
+			dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{modelGVR: "ModelList"}, newTarget())
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", Annotations: map[string]string{
+				ObjectNameKey:       "model",
+				ReplicasFallbackKey: test.fallback,
+			}}}
+
+			ctx := context.Background()
+			scaleObject, err := scaleOf(ctx, scaleClient, dynamicClient, pool, modelGVR)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("scaleOf() error = %v, wantErr %t", err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+			if scaleObject.Name != "model" || scaleObject.Spec.Replicas != 0 {
+				t.Errorf("scaleOf() = %s with %d replicas, want model with 0 replicas", scaleObject.Name, scaleObject.Spec.Replicas)
+			}
+
+			strategy := &scaleStrategy{clients: StrategyClients{ScaleClient: scaleClient, DynamicClient: dynamicClient}}
+			if err := strategy.ScaleUp(ctx, &ScaleTarget{Pool: pool, Resource: modelGVR, Scale: scaleObject}, 2); err != nil {
+				t.Fatalf("ScaleUp() error = %v", err)
+			}
+			target, err := dynamicClient.Resource(modelGVR).Namespace("default").Get(ctx, "model", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if replicas, _, _ := unstructured.NestedInt64(target.Object, "spec", "replicas"); replicas != 2 {
+				t.Errorf("spec.replicas = %d, want 2", replicas)
+			}
+		})
+	}
+}
@@ -0,0 +1,354 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/datastore"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// SoakPoolLabel labels the target workloads of the synthetic pools of the soak test mode, and selects their pods
+	SoakPoolLabel = "activator.llm-d.ai/soak-pool"
+
+	DefaultSoakPools          = 3
+	DefaultSoakRequests       = 5
+	DefaultSoakInterval       = time.Duration(30 * time.Second)
+	DefaultSoakGoroutineSlack = 100
+
+	// soakImage is the image of the pods of the synthetic pools, ready as soon as they run
+	soakImage = "registry.k8s.io/pause:3.10"
+	// soakPort is the target port of the synthetic pools
+	soakPort = 8000
+	// soakTimeout bounds each activation and deactivation of a synthetic pool
+	soakTimeout = 5 * time.Minute
+)
+
+var deploymentGVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+// SoakConfig configures the soak test mode.
+type SoakConfig struct {
+	// Namespace is the test namespace of the target workloads of the synthetic pools.
+	Namespace string
+	// Pools is the number of synthetic pools activated and deactivated concurrently.
+	Pools int
+	// Requests is the number of requests held for each activation of a synthetic pool.
+	Requests int
+	// Interval separates the activate/deactivate cycles.
+	Interval time.Duration
+	// GoroutineSlack is how many goroutines more than after the first cycle the activator may run after
+	// a cycle before it is considered leaking goroutines.
+	GoroutineSlack int
+}
+
+// Soak continuously activates and deactivates synthetic pools, whose target workloads it creates in a test
+// namespace, through the scale up and scale down paths of the activator, and checks after each cycle that no
+// goroutine leaked, no request or scale up is stuck, and that no counter decreased. Violations are kept for
+// the health checks of the activator to fail.
+type Soak struct {
+	config   SoakConfig
+	gatherer prometheus.Gatherer
+	pools    []*soakPool
+
+	// goroutines is the number of goroutines after the first cycle, 0 until then
+	goroutines int
+	// counters holds the last values of the counters, and the sample counts of the histograms and summaries
+	counters map[string]float64
+
+	mu         sync.Mutex
+	violations []string
+}
+
+// soakPool is a synthetic pool, with the activator and deactivator of its own datastore.
+type soakPool struct {
+	pool        *v1.InferencePool
+	activator   *Activator
+	deactivator *Deactivator
+}
+
+// NewSoak returns the soak test harness of the given configuration, whose synthetic pools are activated
+// with the clients and strategies of the given activator, and whose counters are gathered from the given
+// gatherer.
+func NewSoak(ctx context.Context, a *Activator, gatherer prometheus.Gatherer, config SoakConfig) *Soak {
+	s := &Soak{config: config, gatherer: gatherer, counters: map[string]float64{}}
+	for i := range config.Pools {
+		pool := soakPoolFor(config.Namespace, fmt.Sprintf("soak-%d", i))
+		ds := datastore.NewDatastore(ctx)
+		ds.PoolSet(pool)
+		s.pools = append(s.pools, &soakPool{
+			pool: pool,
+			activator: &Activator{
+				DynamicClient: a.DynamicClient,
+				ScaleClient:   a.ScaleClient,
+				Mapper:        a.Mapper,
+				InitialScale:  1,
+				datastore:     ds,
+				strategies:    a.strategies,
+				releaseGates:  a.releaseGates,
+				burst:         newBurstDetector(DefaultPanicWindow),
+			},
+			deactivator: &Deactivator{
+				DynamicClient: a.DynamicClient,
+				ScaleClient:   a.ScaleClient,
+				Mapper:        a.Mapper,
+				datastore:     &ds,
+				strategies:    a.strategies,
+			},
+		})
+	}
+	return s
+}
+
+// soakPoolFor returns the synthetic pool of the given name, targeting the Deployment of the same name.
+func soakPoolFor(namespace, name string) *v1.InferencePool {
+	return &v1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			UID:             types.UID(namespace + "/" + name),
+			ResourceVersion: "1",
+			Annotations: map[string]string{
+				ObjectApiVersionKey: "apps/v1",
+				ObjectkindKey:       "Deployment",
+				ObjectNameKey:       name,
+			},
+		},
+		Spec: v1.InferencePoolSpec{
+			Selector:    v1.LabelSelector{MatchLabels: map[v1.LabelKey]v1.LabelValue{SoakPoolLabel: v1.LabelValue(name)}},
+			TargetPorts: []v1.Port{{Number: soakPort}},
+		},
+	}
+}
+
+// soakDeployment returns the target workload of the given synthetic pool, at zero replicas.
+func soakDeployment(pool *v1.InferencePool) *unstructured.Unstructured {
+	labels := map[string]any{SoakPoolLabel: pool.Name}
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": pool.Name, "namespace": pool.Namespace, "labels": labels},
+		"spec": map[string]any{
+			"replicas": int64(0),
+			"selector": map[string]any{"matchLabels": labels},
+			"template": map[string]any{
+				"metadata": map[string]any{"labels": labels},
+				"spec": map[string]any{
+					"containers": []any{map[string]any{
+						"name":  "model-server",
+						"image": soakImage,
+						"ports": []any{map[string]any{"containerPort": int64(soakPort)}},
+					}},
+				},
+			},
+		},
+	}}
+}
+
+// Run creates the target workloads of the synthetic pools, then runs activate/deactivate cycles until the
+// given context is done.
+func (s *Soak) Run(ctx context.Context) {
+	logger := log.FromContext(ctx).WithName("soak")
+	for _, p := range s.pools {
+		_, err := p.activator.DynamicClient.Resource(deploymentGVR).Namespace(p.pool.Namespace).Create(ctx, soakDeployment(p.pool), metav1.CreateOptions{})
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			s.violate(logger, fmt.Sprintf("failed to create the target workload of synthetic pool %s: %v", p.pool.Name, err))
+			return
+		}
+	}
+
+	for cycle := 1; ; cycle++ {
+		s.cycle(log.IntoContext(ctx, logger.WithValues("cycle", cycle)))
+		if ctx.Err() != nil {
+			return
+		}
+		s.check(logger, runtime.NumGoroutine())
+		logger.V(logutil.DEFAULT).Info("Soak cycle done", "cycle", cycle, "goroutines", runtime.NumGoroutine(), "healthy", s.Healthy())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.config.Interval):
+		}
+	}
+}
+
+// cycle activates the synthetic pools concurrently, each for the configured number of concurrent requests,
+// then deactivates them.
+func (s *Soak) cycle(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	var wg sync.WaitGroup
+	for _, p := range s.pools {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.activate(ctx, p); err != nil {
+				s.violate(logger, fmt.Sprintf("synthetic pool %s failed to activate: %v", p.pool.Name, err))
+			}
+			if err := s.deactivate(ctx, p); err != nil {
+				s.violate(logger, fmt.Sprintf("synthetic pool %s failed to deactivate: %v", p.pool.Name, err))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// activate holds the configured number of requests on the given synthetic pool until it is ready.
+func (s *Soak) activate(ctx context.Context, p *soakPool) error {
+	ctx, cancel := context.WithTimeout(ctx, soakTimeout)
+	defer cancel()
+
+	errs := make([]error, s.config.Requests)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = p.activator.MayActivate(ctx)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deactivate scales the target workload of the given synthetic pool down to zero, as the Deactivator does
+// once the pool is idle, and waits for its replicas to be gone.
+func (s *Soak) deactivate(ctx context.Context, p *soakPool) error {
+	ctx, cancel := context.WithTimeout(ctx, soakTimeout)
+	defer cancel()
+
+	da := p.deactivator
+	if !da.deactivating() {
+		return fmt.Errorf("pool is still scaling up from zero")
+	}
+	target := &ScaleTarget{Pool: p.pool, Resource: deploymentGVR}
+	scaleObject, err := scaleOf(ctx, da.ScaleClient, da.DynamicClient, p.pool, deploymentGVR)
+	if err == nil {
+		target.Scale = scaleObject
+		err = (&scaleStrategy{clients: StrategyClients{ScaleClient: da.ScaleClient, DynamicClient: da.DynamicClient}}).ScaleDown(ctx, target)
+	}
+	da.deactivated(err == nil)
+	if err != nil {
+		return err
+	}
+
+	return wait.PollUntilContextCancel(ctx, time.Second, true, func(ctx context.Context) (bool, error) {
+		scaleObject, err := scaleOf(ctx, da.ScaleClient, da.DynamicClient, p.pool, deploymentGVR)
+		return err == nil && scaleObject.Status.Replicas == 0, nil
+	})
+}
+
+// check records the invariants violated after a cycle, given the number of goroutines running then.
+func (s *Soak) check(logger logr.Logger, goroutines int) {
+	if s.goroutines == 0 {
+		s.goroutines = goroutines
+	} else if goroutines > s.goroutines+s.config.GoroutineSlack {
+		s.violate(logger, fmt.Sprintf("goroutine leak: %d goroutines running, %d after the first cycle", goroutines, s.goroutines))
+	}
+
+	for _, p := range s.pools {
+		if held := p.activator.held.Load(); held != 0 {
+			s.violate(logger, fmt.Sprintf("stuck queue: %d requests still held on synthetic pool %s", held, p.pool.Name))
+		}
+		if scalingUp, _ := p.activator.isScalingUp(); scalingUp {
+			s.violate(logger, fmt.Sprintf("stuck scale up: synthetic pool %s is still scaling up from zero", p.pool.Name))
+		}
+	}
+
+	families, err := s.gatherer.Gather()
+	if err != nil {
+		logger.V(logutil.DEBUG).Info("Error gathering the metrics, skipping their monotonicity check", "error", err.Error())
+		return
+	}
+	for key, value := range counterValues(families) {
+		if last, ok := s.counters[key]; ok && value < last {
+			s.violate(logger, fmt.Sprintf("non monotonic metric: %s decreased from %g to %g", key, last, value))
+		}
+		s.counters[key] = value
+	}
+}
+
+// counterValues returns the values of the counters of the given metric families, and the sample counts of
+// their histograms and summaries, by metric name and labels.
+func counterValues(families []*dto.MetricFamily) map[string]float64 {
+	values := map[string]float64{}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			labels := make([]string, 0, len(metric.GetLabel()))
+			for _, label := range metric.GetLabel() {
+				labels = append(labels, label.GetName()+"="+label.GetValue())
+			}
+			slices.Sort(labels)
+			key := family.GetName() + "{" + strings.Join(labels, ",") + "}"
+
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				values[key] = metric.GetCounter().GetValue()
+			case dto.MetricType_HISTOGRAM:
+				values[key] = float64(metric.GetHistogram().GetSampleCount())
+			case dto.MetricType_SUMMARY:
+				values[key] = float64(metric.GetSummary().GetSampleCount())
+			}
+		}
+	}
+	return values
+}
+
+func (s *Soak) violate(logger logr.Logger, violation string) {
+	logger.Error(nil, "Soak invariant violated", "violation", violation)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.violations = append(s.violations, violation)
+}
+
+// Healthy reports whether no invariant was violated so far.
+func (s *Soak) Healthy() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.violations) == 0
+}
+
+// Violations returns the invariants violated so far, in order.
+func (s *Soak) Violations() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.violations)
+}
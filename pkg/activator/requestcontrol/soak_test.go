@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestSoakCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		goroutines int
+		held       int32
+		decrease   bool
+		want       string
+	}{
+		{name: "invariants hold", goroutines: 105},
+		{name: "goroutine leak", goroutines: 111, want: "goroutine leak"},
+		{name: "stuck queue", goroutines: 100, held: 2, want: "stuck queue"},
+		{name: "counter decreased", goroutines: 100, decrease: true, want: "non monotonic metric"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			registry := prometheus.NewRegistry()
+			counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "soak_test_total"}, []string{"pool"})
+			registry.MustRegister(counter)
+			counter.WithLabelValues("soak-0").Add(3)
+
+			s := NewSoak(context.Background(), &Activator{}, registry, SoakConfig{Namespace: "soak", Pools: 1, GoroutineSlack: 10})
+			s.check(logr.Discard(), 100)
+			if !s.Healthy() {
+				t.Fatalf("first check found violations: %v", s.Violations())
+			}
+
+			s.pools[0].activator.held.Store(test.held)
+			if test.decrease {
+				counter.Reset()
+				counter.WithLabelValues("soak-0").Add(1)
+			}
+			s.check(logr.Discard(), test.goroutines)
+
+			violations := s.Violations()
+			if test.want == "" {
+				if len(violations) != 0 {
+					t.Errorf("unexpected violations: %v", violations)
+				}
+				return
+			}
+			if len(violations) != 1 || !strings.HasPrefix(violations[0], test.want) {
+				t.Errorf("violations = %v, want one %q", violations, test.want)
+			}
+			if s.Healthy() {
+				t.Error("soak with violations reported healthy")
+			}
+		})
+	}
+}
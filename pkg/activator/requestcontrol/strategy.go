@@ -0,0 +1,341 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	autoscaling "k8s.io/api/autoscaling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/scale"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/audit"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+// scaleUpdateBackoff spaces the retries of the patches of a scale subresource, for about 3s overall.
+var scaleUpdateBackoff = wait.Backoff{Steps: 5, Duration: 100 * time.Millisecond, Factor: 2, Jitter: 0.1}
+
+const (
+	StrategyKey = poolconfig.StrategyKey // Optional annotation
+
+	// Strategy specific annotations
+	HPANameKey              = poolconfig.HPANameKey              // Required by the hpa-min strategy
+	KEDAScaledObjectNameKey = poolconfig.KEDAScaledObjectNameKey // Required by the keda-pause strategy
+
+	// ScaleFieldManager is the field manager of the replicas the activator sets on the scale subresource of
+	// target workloads
+	ScaleFieldManager = "llm-d-activator"
+
+	// KEDAPausedReplicasAnnotation is the KEDA annotation pausing a ScaledObject at a fixed number of replicas
+	KEDAPausedReplicasAnnotation = "autoscaling.keda.sh/paused-replicas"
+
+	ScaleStrategyName     = "scale"
+	HPAMinStrategyName    = "hpa-min"
+	KEDAPauseStrategyName = "keda-pause"
+
+	// DefaultStrategyName is the strategy used by pools without a strategy annotation
+	DefaultStrategyName = ScaleStrategyName
+)
+
+var (
+	hpaGVR          = schema.GroupVersionResource{Group: "autoscaling", Version: "v2", Resource: "horizontalpodautoscalers"}
+	scaledObjectGVR = schema.GroupVersionResource{Group: "keda.sh", Version: "v1alpha1", Resource: "scaledobjects"}
+)
+
+// ScaleTarget describes the workload activated on behalf of an InferencePool.
+type ScaleTarget struct {
+	Pool *v1.InferencePool
+	// Resource is the resource of the target workload.
+	Resource schema.GroupVersionResource
+	// Scale is the current scale subresource of the target workload.
+	Scale *autoscaling.Scale
+}
+
+// Strategy is the mechanism used to bring the target workload of an InferencePool up from and down to zero replicas.
+type Strategy interface {
+	// Validate checks the strategy specific annotations of the pool.
+	Validate(pool *v1.InferencePool) error
+	// ScaleUp brings the target workload to the given number of replicas.
+	ScaleUp(ctx context.Context, target *ScaleTarget, replicas int32) error
+	// ScaleDown brings the target workload to zero replicas.
+	ScaleDown(ctx context.Context, target *ScaleTarget) error
+}
+
+// StrategyClients are the clients available to strategies.
+type StrategyClients struct {
+	ScaleClient   scale.ScalesGetter
+	DynamicClient dynamic.Interface
+}
+
+// StrategyFactory creates a Strategy with the given clients.
+type StrategyFactory func(clients StrategyClients) Strategy
+
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[string]StrategyFactory{
+		ScaleStrategyName:     func(c StrategyClients) Strategy { return &scaleStrategy{clients: c} },
+		HPAMinStrategyName:    func(c StrategyClients) Strategy { return &hpaMinStrategy{clients: c} },
+		KEDAPauseStrategyName: func(c StrategyClients) Strategy { return &kedaPauseStrategy{clients: c} },
+	}
+)
+
+// RegisterStrategy registers an activation strategy selectable with the strategy annotation.
+// It is meant to be called before the activator starts, e.g. for out-of-tree strategies.
+func RegisterStrategy(name string, factory StrategyFactory) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+
+	strategies[name] = factory
+}
+
+// RegisteredStrategies returns the sorted names of the registered strategies.
+func RegisteredStrategies() []string {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// newStrategies instantiates all registered strategies with the given clients.
+func newStrategies(clients StrategyClients) map[string]Strategy {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+
+	instances := make(map[string]Strategy, len(strategies))
+	for name, factory := range strategies {
+		instances[name] = factory(clients)
+	}
+	return instances
+}
+
+// strategyFor returns the strategy selected by the pool annotation among the given instances.
+func strategyFor(instances map[string]Strategy, pool *v1.InferencePool) (Strategy, error) {
+	name := DefaultStrategyName
+	if value := poolconfig.For(pool).Strategy; value != "" {
+		name = value
+	}
+	strategy, ok := instances[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown activation strategy %q on pool '%s', registered strategies: %s", name, pool.Name, strings.Join(RegisteredStrategies(), ", "))
+	}
+	return strategy, nil
+}
+
+// validatePoolStrategy checks that the strategy selected by the pool exists and that its options are valid.
+func validatePoolStrategy(instances map[string]Strategy, pool *v1.InferencePool) error {
+	strategy, err := strategyFor(instances, pool)
+	if err != nil {
+		return err
+	}
+	return strategy.Validate(pool)
+}
+
+// requireAnnotation checks that the given annotation of the pool, parsed to the given value, is set.
+func requireAnnotation(pool *v1.InferencePool, key, value string) error {
+	if value == "" {
+		return fmt.Errorf("annotation '%s' is required by activation strategy %q on pool '%s'", key, poolconfig.For(pool).Strategy, pool.Name)
+	}
+	return nil
+}
+
+// scaleStrategy updates the scale subresource of the target workload. This is the default strategy.
+type scaleStrategy struct {
+	clients StrategyClients
+}
+
+func (s *scaleStrategy) Validate(*v1.InferencePool) error {
+	return nil
+}
+
+func (s *scaleStrategy) ScaleUp(ctx context.Context, target *ScaleTarget, replicas int32) error {
+	return s.setReplicas(ctx, target, replicas)
+}
+
+// setReplicas merge patches the replicas of the target workload, whatever they are, and suspends or resumes
+// Ray target workloads.
+func (s *scaleStrategy) setReplicas(ctx context.Context, target *ScaleTarget, replicas int32) error {
+	if rayResource(target.Resource) {
+		return s.retryPatch(target, func() error {
+			return suspendRay(ctx, s.clients.DynamicClient, target, replicas)
+		})
+	}
+	patch, err := json.Marshal(map[string]any{"spec": map[string]any{"replicas": replicas}})
+	if err != nil {
+		return err
+	}
+	return s.patchReplicas(ctx, target, types.MergePatchType, patch, replicas)
+}
+
+// ScaleDown scales the target workload to zero with a JSON patch testing that its replicas are still those
+// found when the pool was deemed idle, so that a scale up by the HPA or another controller meanwhile is not
+// reverted: the patch is then rejected as invalid and the pool is checked again on the next tick.
+func (s *scaleStrategy) ScaleDown(ctx context.Context, target *ScaleTarget) error {
+	if rayResource(target.Resource) {
+		return s.setReplicas(ctx, target, 0)
+	}
+	patch, err := json.Marshal([]map[string]any{
+		{"op": "test", "path": "/spec/replicas", "value": target.Scale.Spec.Replicas},
+		{"op": "replace", "path": "/spec/replicas", "value": 0},
+	})
+	if err != nil {
+		return err
+	}
+	err = s.patchReplicas(ctx, target, types.JSONPatchType, patch, 0)
+	if apierrors.IsInvalid(err) {
+		return fmt.Errorf("replicas of %s changed from %d since the pool was found idle, not scaling it down: %w", target.Scale.Name, target.Scale.Spec.Replicas, err)
+	}
+	return err
+}
+
+// patchReplicas applies the given patch of the replicas to the scale subresource of the target workload.
+// Patching spec.replicas alone, rather than updating the scale subresource read earlier, neither conflicts
+// with nor reverts the changes of the HPA or other controllers owning the workload meanwhile. Target
+// workloads without a scale subresource are patched directly when their pool sets the replicas fallback
+// annotation. Transient API server errors are retried with exponential backoff.
+func (s *scaleStrategy) patchReplicas(ctx context.Context, target *ScaleTarget, patchType types.PatchType, patch []byte, replicas int32) error {
+	err := s.retryPatch(target, func() error {
+		updated, err := s.clients.ScaleClient.Scales(target.Pool.Namespace).
+			Patch(ctx, target.Resource, target.Scale.Name, patchType, patch, metav1.PatchOptions{FieldManager: ScaleFieldManager})
+		if err == nil {
+			target.Scale = updated
+		}
+		return err
+	})
+	if !apierrors.IsNotFound(err) || !replicasFallback(target.Pool) {
+		return err
+	}
+	return s.retryPatch(target, func() error {
+		updated, err := s.clients.DynamicClient.Resource(target.Resource).Namespace(target.Pool.Namespace).
+			Patch(ctx, target.Scale.Name, patchType, patch, metav1.PatchOptions{FieldManager: ScaleFieldManager})
+		if err == nil {
+			target.Scale.Spec.Replicas = replicas
+			target.Scale.ResourceVersion = updated.GetResourceVersion()
+		}
+		return err
+	})
+}
+
+// recordResourceVersionAfter records on the given audit record the resource version of the target workload
+// after its scale, the version before being already recorded. It is the version of the patch of the target
+// workload when the strategy patched it, and is read back otherwise, e.g. for strategies patching the
+// autoscaler of the target workload.
+func recordResourceVersionAfter(ctx context.Context, clients StrategyClients, record *audit.Record, target *ScaleTarget) {
+	if target.Scale != nil && target.Scale.ResourceVersion != record.ResourceVersionBefore {
+		record.ResourceVersionAfter = target.Scale.ResourceVersion
+		return
+	}
+	if scaleObject, err := scaleOf(ctx, clients.ScaleClient, clients.DynamicClient, target.Pool, target.Resource); err == nil {
+		record.ResourceVersionAfter = scaleObject.ResourceVersion
+	}
+}
+
+// retryPatch runs the given patch of the target workload, retrying it on transient API server errors.
+func (s *scaleStrategy) retryPatch(target *ScaleTarget, patch func() error) error {
+	attempt := 0
+	return retry.OnError(scaleUpdateBackoff, retriableScaleError, func() error {
+		if attempt++; attempt > 1 {
+			metrics.RecordScaleUpdateRetry(target.Pool.Namespace + "/" + target.Pool.Name)
+		}
+		return patch()
+	})
+}
+
+// retriableScaleError reports whether a patch of a scale subresource failing with the given error may
+// succeed when retried. Patches carry no resource version, so they never fail on conflict: the API server
+// retries them against the latest version of the workload itself.
+func retriableScaleError(err error) bool {
+	return apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) || apierrors.IsServiceUnavailable(err)
+}
+
+// hpaMinStrategy activates the target workload by raising the minReplicas of the HorizontalPodAutoscaler
+// managing it, and deactivates it by lowering minReplicas back to zero. Scaling an HPA to zero requires
+// the HPAScaleToZero feature gate on the cluster.
+type hpaMinStrategy struct {
+	clients StrategyClients
+}
+
+func (s *hpaMinStrategy) Validate(pool *v1.InferencePool) error {
+	return requireAnnotation(pool, HPANameKey, poolconfig.For(pool).HPAName)
+}
+
+func (s *hpaMinStrategy) ScaleUp(ctx context.Context, target *ScaleTarget, replicas int32) error {
+	return s.setMinReplicas(ctx, target, replicas)
+}
+
+func (s *hpaMinStrategy) ScaleDown(ctx context.Context, target *ScaleTarget) error {
+	return s.setMinReplicas(ctx, target, 0)
+}
+
+func (s *hpaMinStrategy) setMinReplicas(ctx context.Context, target *ScaleTarget, replicas int32) error {
+	patch, err := json.Marshal(map[string]any{"spec": map[string]any{"minReplicas": replicas}})
+	if err != nil {
+		return err
+	}
+	_, err = s.clients.DynamicClient.Resource(hpaGVR).Namespace(target.Pool.Namespace).
+		Patch(ctx, poolconfig.For(target.Pool).HPAName, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// kedaPauseStrategy deactivates the target workload by pausing its KEDA ScaledObject at zero replicas,
+// and activates it by removing the pause so KEDA resumes scaling.
+type kedaPauseStrategy struct {
+	clients StrategyClients
+}
+
+func (s *kedaPauseStrategy) Validate(pool *v1.InferencePool) error {
+	return requireAnnotation(pool, KEDAScaledObjectNameKey, poolconfig.For(pool).KEDAScaledObjectName)
+}
+
+func (s *kedaPauseStrategy) ScaleUp(ctx context.Context, target *ScaleTarget, _ int32) error {
+	return s.setPausedReplicas(ctx, target, nil)
+}
+
+func (s *kedaPauseStrategy) ScaleDown(ctx context.Context, target *ScaleTarget) error {
+	zero := "0"
+	return s.setPausedReplicas(ctx, target, &zero)
+}
+
+func (s *kedaPauseStrategy) setPausedReplicas(ctx context.Context, target *ScaleTarget, value *string) error {
+	patch, err := json.Marshal(map[string]any{"metadata": map[string]any{"annotations": map[string]any{KEDAPausedReplicasAnnotation: value}}})
+	if err != nil {
+		return err
+	}
+	_, err = s.clients.DynamicClient.Resource(scaledObjectGVR).Namespace(target.Pool.Namespace).
+		Patch(ctx, poolconfig.For(target.Pool).KEDAScaledObjectName, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
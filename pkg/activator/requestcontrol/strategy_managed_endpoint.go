@@ -0,0 +1,254 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+const (
+	ManagedEndpointStrategyName = "managed-endpoint"
+
+	// Managed endpoint strategy annotations
+	ManagedEndpointProviderKey = poolconfig.ManagedEndpointProviderKey // Required by the managed-endpoint strategy
+	ManagedEndpointKey         = poolconfig.ManagedEndpointKey         // Required by the managed-endpoint strategy
+	ManagedEndpointModelsKey   = poolconfig.ManagedEndpointModelsKey   // Optional, comma separated list of models served by the endpoint
+	ManagedEndpointSecretKey   = poolconfig.ManagedEndpointSecretKey   // Optional, Secret holding the credentials of the endpoint
+
+	// ManagedEndpointSecretDataKey is the key holding the bearer token in the managed endpoint secret
+	ManagedEndpointSecretDataKey = "token"
+
+	// RESTProviderName is the name of the reference managed endpoint provider
+	RESTProviderName = "rest"
+)
+
+// ExternalTarget is implemented by strategies whose target is not a Kubernetes workload, or is not activated
+// through its replicas. The Activator and Deactivator skip the scale subresource of such pools and rely on
+// the strategy for readiness.
+type ExternalTarget interface {
+	Strategy
+	// Ready reports whether the external target of the pool can serve requests.
+	Ready(ctx context.Context, pool *v1.InferencePool) (bool, error)
+	// Matches reports whether a request for the given model should activate the external target.
+	Matches(pool *v1.InferencePool, modelName string) bool
+}
+
+// ManagedEndpoint is the managed endpoint of a pool, with the credentials to reach it.
+type ManagedEndpoint struct {
+	// Name identifies the endpoint to its provider, e.g. the URL of the endpoint resource for the rest provider.
+	Name string
+	// Token is read from the Secret named by the managed endpoint secret annotation of the pool, so that
+	// credentials are bound to the endpoint they were issued for. Empty when the pool names no Secret.
+	Token string
+}
+
+// ManagedEndpointProvider is the plugin interface to a cloud provider serving managed inference endpoints.
+type ManagedEndpointProvider interface {
+	// Resume brings a paused endpoint back into service.
+	Resume(ctx context.Context, endpoint ManagedEndpoint) error
+	// Pause takes the endpoint out of service, releasing its accelerators.
+	Pause(ctx context.Context, endpoint ManagedEndpoint) error
+	// Ready reports whether the endpoint is in service.
+	Ready(ctx context.Context, endpoint ManagedEndpoint) (bool, error)
+}
+
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]ManagedEndpointProvider{
+		RESTProviderName: &RESTProvider{HTTPClient: &http.Client{Timeout: 10 * time.Second}},
+	}
+)
+
+// RegisterManagedEndpointProvider registers a provider selectable with the managed endpoint provider annotation.
+func RegisterManagedEndpointProvider(name string, provider ManagedEndpointProvider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	providers[name] = provider
+}
+
+func managedEndpointProvider(name string) (ManagedEndpointProvider, bool) {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	provider, ok := providers[name]
+	return provider, ok
+}
+
+func init() {
+	RegisterStrategy(ManagedEndpointStrategyName, func(c StrategyClients) Strategy {
+		return &managedEndpointStrategy{clients: c}
+	})
+}
+
+// managedEndpointStrategy activates a cloud managed inference endpoint through its provider, letting
+// hybrid deployments use the activator as a single activation front door.
+type managedEndpointStrategy struct {
+	clients StrategyClients
+}
+
+func (s *managedEndpointStrategy) Validate(pool *v1.InferencePool) error {
+	config := poolconfig.For(pool)
+	if err := requireAnnotation(pool, ManagedEndpointProviderKey, config.ManagedEndpointProvider); err != nil {
+		return err
+	}
+	if err := requireAnnotation(pool, ManagedEndpointKey, config.ManagedEndpoint); err != nil {
+		return err
+	}
+	if _, ok := managedEndpointProvider(config.ManagedEndpointProvider); !ok {
+		return fmt.Errorf("unknown managed endpoint provider %q on pool '%s'", config.ManagedEndpointProvider, pool.Name)
+	}
+	return config.ErrOf(ManagedEndpointSecretKey)
+}
+
+func (s *managedEndpointStrategy) ScaleUp(ctx context.Context, target *ScaleTarget, _ int32) error {
+	provider, endpoint, err := s.resolve(ctx, target.Pool)
+	if err != nil {
+		return err
+	}
+	return provider.Resume(ctx, endpoint)
+}
+
+func (s *managedEndpointStrategy) ScaleDown(ctx context.Context, target *ScaleTarget) error {
+	provider, endpoint, err := s.resolve(ctx, target.Pool)
+	if err != nil {
+		return err
+	}
+	return provider.Pause(ctx, endpoint)
+}
+
+func (s *managedEndpointStrategy) Ready(ctx context.Context, pool *v1.InferencePool) (bool, error) {
+	provider, endpoint, err := s.resolve(ctx, pool)
+	if err != nil {
+		return false, err
+	}
+	return provider.Ready(ctx, endpoint)
+}
+
+func (s *managedEndpointStrategy) Matches(pool *v1.InferencePool, modelName string) bool {
+	models := poolconfig.For(pool).ManagedEndpointModels
+	return len(models) == 0 || slices.Contains(models, modelName)
+}
+
+func (s *managedEndpointStrategy) resolve(ctx context.Context, pool *v1.InferencePool) (ManagedEndpointProvider, ManagedEndpoint, error) {
+	if err := s.Validate(pool); err != nil {
+		return nil, ManagedEndpoint{}, err
+	}
+	config := poolconfig.For(pool)
+	provider, _ := managedEndpointProvider(config.ManagedEndpointProvider)
+	endpoint := ManagedEndpoint{Name: config.ManagedEndpoint}
+	if secretName := config.ManagedEndpointSecretName; secretName != "" {
+		token, err := s.token(ctx, pool.Namespace, secretName)
+		if err != nil {
+			return nil, ManagedEndpoint{}, err
+		}
+		endpoint.Token = token
+	}
+	return provider, endpoint, nil
+}
+
+// token reads the bearer token of the managed endpoint from the given Secret.
+func (s *managedEndpointStrategy) token(ctx context.Context, namespace, name string) (string, error) {
+	secret, err := s.clients.DynamicClient.Resource(secretGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get managed endpoint secret %s/%s: %w", namespace, name, err)
+	}
+	encoded, found, err := unstructured.NestedString(secret.Object, "data", ManagedEndpointSecretDataKey)
+	if err != nil || !found {
+		return "", fmt.Errorf("managed endpoint secret %s/%s has no '%s' key", namespace, name, ManagedEndpointSecretDataKey)
+	}
+	token, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("invalid '%s' key in managed endpoint secret %s/%s: %w", ManagedEndpointSecretDataKey, namespace, name, err)
+	}
+	return string(token), nil
+}
+
+// RESTProvider is the reference ManagedEndpointProvider. The endpoint is the URL of the endpoint resource:
+// it is resumed with a POST to <endpoint>/resume, paused with a POST to <endpoint>/pause, and considered
+// ready once a GET of <endpoint> returns a JSON object whose "state" is Ready, Running or InService. The token
+// of the endpoint, if any, is sent as a bearer token.
+type RESTProvider struct {
+	HTTPClient *http.Client
+}
+
+func (p *RESTProvider) Resume(ctx context.Context, endpoint ManagedEndpoint) error {
+	_, err := p.do(ctx, http.MethodPost, strings.TrimSuffix(endpoint.Name, "/")+"/resume", endpoint.Token)
+	return err
+}
+
+func (p *RESTProvider) Pause(ctx context.Context, endpoint ManagedEndpoint) error {
+	_, err := p.do(ctx, http.MethodPost, strings.TrimSuffix(endpoint.Name, "/")+"/pause", endpoint.Token)
+	return err
+}
+
+func (p *RESTProvider) Ready(ctx context.Context, endpoint ManagedEndpoint) (bool, error) {
+	body, err := p.do(ctx, http.MethodGet, endpoint.Name, endpoint.Token)
+	if err != nil {
+		return false, err
+	}
+	var status struct {
+		State string `json:"state"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return false, fmt.Errorf("failed to decode managed endpoint status: %w", err)
+	}
+	switch strings.ToLower(status.State) {
+	case "ready", "running", "inservice":
+		return true, nil
+	}
+	return false, nil
+}
+
+func (p *RESTProvider) do(ctx context.Context, method, url, token string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(nil))
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("managed endpoint %s %s returned status %d", method, url, resp.StatusCode)
+	}
+	return body, nil
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func managedEndpointPool(annotations map[string]string) *v1.InferencePool {
+	all := map[string]string{StrategyKey: ManagedEndpointStrategyName, ManagedEndpointProviderKey: RESTProviderName}
+	for key, value := range annotations {
+		all[key] = value
+	}
+	return &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", Annotations: all}}
+}
+
+func TestManagedEndpointStrategyMatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		models string
+		model  string
+		want   bool
+	}{
+		{name: "no models", model: "llama", want: true},
+		{name: "single model", models: "llama", model: "llama", want: true},
+		{name: "listed model", models: "llama,mistral", model: "mistral", want: true},
+		{name: "listed model after a space", models: "llama, mistral", model: "mistral", want: true},
+		{name: "unlisted model", models: "llama, mistral", model: "qwen"},
+		{name: "empty model", models: "llama, mistral", model: ""},
+	}
+
+	strategy := &managedEndpointStrategy{}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			annotations := map[string]string{ManagedEndpointKey: "https://endpoint"}
+			if test.models != "" {
+				annotations[ManagedEndpointModelsKey] = test.models
+			}
+			if got := strategy.Matches(managedEndpointPool(annotations), test.model); got != test.want {
+				t.Errorf("Matches(%q) = %t, want %t", test.model, got, test.want)
+			}
+		})
+	}
+}
+
+func TestManagedEndpointStrategy(t *testing.T) {
+	secret := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]any{"name": "endpoint", "namespace": "default"},
+		"data":       map[string]any{ManagedEndpointSecretDataKey: base64.StdEncoding.EncodeToString([]byte("endpoint-token"))},
+	}}
+
+	var mu sync.Mutex
+	var calls []string
+	var authorization atomic.Value
+	var state atomic.Value
+	state.Store("Paused")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		authorization.Store(r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/endpoints/llama/resume":
+			state.Store("InService")
+		case "/endpoints/llama/pause":
+			state.Store("Paused")
+		case "/endpoints/llama":
+			_ = json.NewEncoder(w).Encode(map[string]string{"state": state.Load().(string)})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name              string
+		annotations       map[string]string
+		objects           []runtime.Object
+		wantAuthorization string
+		wantErr           bool
+	}{
+		{name: "without credentials", annotations: map[string]string{ManagedEndpointKey: server.URL + "/endpoints/llama"}},
+		{
+			name:              "with the token of the endpoint secret",
+			annotations:       map[string]string{ManagedEndpointKey: server.URL + "/endpoints/llama", ManagedEndpointSecretKey: "endpoint"},
+			objects:           []runtime.Object{secret},
+			wantAuthorization: "Bearer endpoint-token",
+		},
+		{
+			name:        "missing secret",
+			annotations: map[string]string{ManagedEndpointKey: server.URL + "/endpoints/llama", ManagedEndpointSecretKey: "endpoint"},
+			wantErr:     true,
+		},
+		{
+			name:        "unknown provider",
+			annotations: map[string]string{ManagedEndpointKey: server.URL + "/endpoints/llama", ManagedEndpointProviderKey: "unknown"},
+			wantErr:     true,
+		},
+		{
+			name:        "missing endpoint",
+			annotations: map[string]string{},
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mu.Lock()
+			calls = nil
+			mu.Unlock()
+			authorization.Store("")
+			state.Store("Paused")
+
+			strategy := &managedEndpointStrategy{clients: StrategyClients{DynamicClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), test.objects...)}}
+			var _ ExternalTarget = strategy
+			ctx := context.Background()
+			pool := managedEndpointPool(test.annotations)
+			target := &ScaleTarget{Pool: pool}
+
+			err := strategy.ScaleUp(ctx, target, 1)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ScaleUp() error = %v, wantErr %t", err, test.wantErr)
+			}
+			if test.wantErr {
+				mu.Lock()
+				defer mu.Unlock()
+				if len(calls) > 0 {
+					t.Errorf("calls = %v, want none", calls)
+				}
+				return
+			}
+			if ready, err := strategy.Ready(ctx, pool); err != nil || !ready {
+				t.Errorf("Ready() after ScaleUp() = %t, %v, want true", ready, err)
+			}
+			if err := strategy.ScaleDown(ctx, target); err != nil {
+				t.Fatalf("ScaleDown() error = %v", err)
+			}
+			if ready, err := strategy.Ready(ctx, pool); err != nil || ready {
+				t.Errorf("Ready() after ScaleDown() = %t, %v, want false", ready, err)
+			}
+
+			want := []string{"POST /endpoints/llama/resume", "GET /endpoints/llama", "POST /endpoints/llama/pause", "GET /endpoints/llama"}
+			mu.Lock()
+			defer mu.Unlock()
+			if len(calls) != len(want) {
+				t.Fatalf("calls = %v, want %v", calls, want)
+			}
+			for i := range want {
+				if calls[i] != want[i] {
+					t.Errorf("calls = %v, want %v", calls, want)
+					break
+				}
+			}
+			if got := authorization.Load().(string); got != test.wantAuthorization {
+				t.Errorf("Authorization = %q, want %q", got, test.wantAuthorization)
+			}
+		})
+	}
+}
+
+func TestRESTProviderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr bool
+	}{
+		{name: "ready", status: http.StatusOK, body: `{"state":"running"}`},
+		{name: "error status", status: http.StatusForbidden, wantErr: true},
+		{name: "invalid status", status: http.StatusOK, body: "not json", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.status)
+				_, _ = w.Write([]byte(test.body))
+			}))
+			defer server.Close()
+
+			provider := &RESTProvider{HTTPClient: server.Client()}
+			ready, err := provider.Ready(context.Background(), ManagedEndpoint{Name: server.URL})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Ready() error = %v, wantErr %t", err, test.wantErr)
+			}
+			if !test.wantErr && !ready {
+				t.Errorf("Ready() = false, want true")
+			}
+		})
+	}
+}
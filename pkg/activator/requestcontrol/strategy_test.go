@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	autoscaling "k8s.io/api/autoscaling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakescale "k8s.io/client-go/scale/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/audit"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestScaleStrategyRetries(t *testing.T) {
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	unavailable := apierrors.NewServiceUnavailable("etcd leader changed")
+
+	tests := []struct {
+		name        string
+		failures    []error
+		wantErr     bool
+		wantPatches int
+	}{
+		{name: "success", wantPatches: 1},
+		{name: "transient errors then success", failures: []error{unavailable, unavailable}, wantPatches: 3},
+		{name: "throttled then success", failures: []error{apierrors.NewTooManyRequests("slow down", 1)}, wantPatches: 2},
+		{name: "permanent error", failures: []error{apierrors.NewForbidden(deployments.GroupResource(), "model", errors.New("denied"))}, wantErr: true, wantPatches: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &fakescale.FakeScaleClient{}
+			patches := 0
+			client.AddReactor("patch", "deployments", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				patches++
+				if patches <= len(test.failures) {
+					return true, nil, test.failures[patches-1]
+				}
+				patch := action.(clienttesting.PatchAction)
+				if patch.GetPatchType() != types.MergePatchType || string(patch.GetPatch()) != `{"spec":{"replicas":2}}` {
+					t.Errorf("patch = %s %s, want a merge patch of the replicas", patch.GetPatchType(), patch.GetPatch())
+				}
+				return true, &autoscaling.Scale{ObjectMeta: metav1.ObjectMeta{Name: "model"}, Spec: autoscaling.ScaleSpec{Replicas: 2}}, nil
+			})
+
+			strategy := &scaleStrategy{clients: StrategyClients{ScaleClient: client}}
+			target := &ScaleTarget{
+				Pool:     &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"}},
+				Resource: deployments,
+				Scale:    &autoscaling.Scale{ObjectMeta: metav1.ObjectMeta{Name: "model"}},
+			}
+			err := strategy.ScaleUp(context.Background(), target, 2)
+			if (err != nil) != test.wantErr {
+				t.Errorf("ScaleUp() error = %v, wantErr %t", err, test.wantErr)
+			}
+			if patches != test.wantPatches {
+				t.Errorf("patches = %d, want %d", patches, test.wantPatches)
+			}
+			if !test.wantErr && target.Scale.Spec.Replicas != 2 {
+				t.Errorf("replicas = %d, want 2", target.Scale.Spec.Replicas)
+			}
+		})
+	}
+}
+
+func TestScaleStrategyScaleDown(t *testing.T) {
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	// The API server rejects a JSON patch whose test operation fails as invalid
+	testFailed := apierrors.NewGenericServerResponse(http.StatusUnprocessableEntity, "", schema.GroupResource{}, "", "testing value /spec/replicas failed", 0, false)
+
+	tests := []struct {
+		name         string
+		failure      error
+		wantErr      bool
+		wantReplicas int32
+	}{
+		{name: "replicas unchanged", wantReplicas: 0},
+		{name: "replicas changed meanwhile", failure: testFailed, wantErr: true, wantReplicas: 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &fakescale.FakeScaleClient{}
+			patches := 0
+			client.AddReactor("patch", "deployments", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				patches++
+				patch := action.(clienttesting.PatchAction)
+				want := `[{"op":"test","path":"/spec/replicas","value":3},{"op":"replace","path":"/spec/replicas","value":0}]`
+				if patch.GetPatchType() != types.JSONPatchType || string(patch.GetPatch()) != want {
+					t.Errorf("patch = %s %s, want a JSON patch testing the replicas found idle", patch.GetPatchType(), patch.GetPatch())
+				}
+				if test.failure != nil {
+					return true, nil, test.failure
+				}
+				return true, &autoscaling.Scale{ObjectMeta: metav1.ObjectMeta{Name: "model"}}, nil
+			})
+
+			strategy := &scaleStrategy{clients: StrategyClients{ScaleClient: client}}
+			target := &ScaleTarget{
+				Pool:     &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"}},
+				Resource: deployments,
+				Scale:    &autoscaling.Scale{ObjectMeta: metav1.ObjectMeta{Name: "model"}, Spec: autoscaling.ScaleSpec{Replicas: 3}},
+			}
+			err := strategy.ScaleDown(context.Background(), target)
+			if (err != nil) != test.wantErr {
+				t.Errorf("ScaleDown() error = %v, wantErr %t", err, test.wantErr)
+			}
+			if patches != 1 {
+				t.Errorf("patches = %d, want 1", patches)
+			}
+			if target.Scale.Spec.Replicas != test.wantReplicas {
+				t.Errorf("replicas = %d, want %d", target.Scale.Spec.Replicas, test.wantReplicas)
+			}
+		})
+	}
+}
+
+func TestRecordResourceVersionAfter(t *testing.T) {
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+	tests := []struct {
+		name      string
+		strategy  string
+		wantAfter string
+	}{
+		{name: "version of the patch of the scale subresource", strategy: ScaleStrategyName, wantAfter: "12"},
+		{name: "version read back after patching the autoscaler", strategy: HPAMinStrategyName, wantAfter: "11"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &fakescale.FakeScaleClient{}
+			client.AddReactor("patch", "deployments", func(clienttesting.Action) (bool, runtime.Object, error) {
+				return true, &autoscaling.Scale{ObjectMeta: metav1.ObjectMeta{Name: "model", ResourceVersion: "12"}, Spec: autoscaling.ScaleSpec{Replicas: 1}}, nil
+			})
+			client.AddReactor("get", "deployments", func(clienttesting.Action) (bool, runtime.Object, error) {
+				return true, &autoscaling.Scale{ObjectMeta: metav1.ObjectMeta{Name: "model", ResourceVersion: "11"}}, nil
+			})
+
+			clients := StrategyClients{ScaleClient: client}
+			target := &ScaleTarget{
+				Pool: &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default",
+					Annotations: map[string]string{ObjectNameKey: "model"}}},
+				Resource: deployments,
+				Scale:    &autoscaling.Scale{ObjectMeta: metav1.ObjectMeta{Name: "model", ResourceVersion: "10"}},
+			}
+			record := audit.Record{ResourceVersionBefore: target.Scale.ResourceVersion}
+			if test.strategy == ScaleStrategyName {
+				if err := (&scaleStrategy{clients: clients}).ScaleUp(context.Background(), target, 1); err != nil {
+					t.Fatalf("ScaleUp() error = %v", err)
+				}
+			}
+
+			recordResourceVersionAfter(context.Background(), clients, &record, target)
+			if record.ResourceVersionBefore != "10" || record.ResourceVersionAfter != test.wantAfter {
+				t.Errorf("resource versions = %q to %q, want %q to %q", record.ResourceVersionBefore, record.ResourceVersionAfter, "10", test.wantAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+const (
+	VLLMSleepStrategyName = "vllm-sleep"
+
+	// vLLM sleep strategy annotations
+	VLLMSleepLevelKey = poolconfig.VLLMSleepLevelKey // Optional, used by the vllm-sleep strategy
+
+	// DefaultVLLMSleepLevel offloads the model weights to CPU memory while sleeping, level 2 discarding them
+	DefaultVLLMSleepLevel = poolconfig.DefaultVLLMSleepLevel
+
+	// vllmSleepTimeout bounds the calls to the sleep API of a pod, which offload or reload the model weights
+	vllmSleepTimeout = 2 * time.Minute
+)
+
+func init() {
+	RegisterStrategy(VLLMSleepStrategyName, func(c StrategyClients) Strategy {
+		return &vllmSleepStrategy{clients: c, httpClient: &http.Client{Timeout: vllmSleepTimeout}}
+	})
+}
+
+// vllmSleepStrategy puts the vLLM model servers of the pool to sleep rather than scaling them to zero replicas,
+// releasing the accelerator memory of the model while keeping the pods, and wakes them up on activation. It
+// saves very large models the load time of a scale from zero, at the cost of the pods and their CPU memory.
+// The pool is ready once every ready pod of the pool confirms it is awake. The model servers must run with
+// VLLM_SERVER_DEV_MODE=1 and --enable-sleep-mode for vLLM to serve its sleep API on the target port of the
+// pool, and the target workload keeps its replicas.
+type vllmSleepStrategy struct {
+	clients    StrategyClients
+	httpClient *http.Client
+}
+
+func (s *vllmSleepStrategy) Validate(pool *v1.InferencePool) error {
+	if len(pool.Spec.TargetPorts) == 0 {
+		return fmt.Errorf("activation strategy %q requires a target port on pool '%s'", VLLMSleepStrategyName, pool.Name)
+	}
+	return poolconfig.For(pool).ErrOf(VLLMSleepLevelKey)
+}
+
+func (s *vllmSleepStrategy) ScaleUp(ctx context.Context, target *ScaleTarget, _ int32) error {
+	return s.forEachPod(ctx, target.Pool, func(ctx context.Context, endpoint string) error {
+		_, err := s.call(ctx, http.MethodPost, "http://"+endpoint+"/wake_up")
+		return err
+	})
+}
+
+func (s *vllmSleepStrategy) ScaleDown(ctx context.Context, target *ScaleTarget) error {
+	level := poolconfig.For(target.Pool).VLLMSleepLevel
+	return s.forEachPod(ctx, target.Pool, func(ctx context.Context, endpoint string) error {
+		_, err := s.call(ctx, http.MethodPost, "http://"+endpoint+"/sleep?level="+level)
+		return err
+	})
+}
+
+// Ready is the wake confirmation probe of the pool: it passes once every ready pod of the pool reports it is
+// not sleeping.
+func (s *vllmSleepStrategy) Ready(ctx context.Context, pool *v1.InferencePool) (bool, error) {
+	var mu sync.Mutex
+	awake := true
+	err := s.forEachPod(ctx, pool, func(ctx context.Context, endpoint string) error {
+		body, err := s.call(ctx, http.MethodGet, "http://"+endpoint+"/is_sleeping")
+		if err != nil {
+			return err
+		}
+		var status struct {
+			IsSleeping bool `json:"is_sleeping"`
+		}
+		if err := json.Unmarshal(body, &status); err != nil {
+			return fmt.Errorf("failed to decode vLLM sleep status: %w", err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		awake = awake && !status.IsSleeping
+		return nil
+	})
+	return err == nil && awake, err
+}
+
+func (s *vllmSleepStrategy) Matches(*v1.InferencePool, string) bool {
+	return true
+}
+
+// forEachPod calls the given function on the endpoint of each ready pod of the pool concurrently, and fails if
+// the pool has no ready pod or any of the calls failed.
+func (s *vllmSleepStrategy) forEachPod(ctx context.Context, pool *v1.InferencePool, call func(ctx context.Context, endpoint string) error) error {
+	if err := s.Validate(pool); err != nil {
+		return err
+	}
+	pods, err := s.clients.DynamicClient.Resource(podGVR).Namespace(pool.Namespace).List(ctx, metav1.ListOptions{LabelSelector: poolPodSelector(pool)})
+	if err != nil {
+		return err
+	}
+	endpoints, _ := readyEndpoints(pods.Items, pool.Spec.TargetPorts[:1])
+	if len(endpoints) == 0 {
+		return fmt.Errorf("pool '%s' has no ready pod to call the vLLM sleep API of", pool.Name)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(endpoints))
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := call(ctx, endpoint); err != nil {
+				errs[i] = fmt.Errorf("pod %s: %w", endpoint, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (s *vllmSleepStrategy) call(ctx context.Context, method, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("vLLM %s %s returned status %d", method, url, resp.StatusCode)
+	}
+	return body, nil
+}
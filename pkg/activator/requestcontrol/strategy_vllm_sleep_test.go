@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestVLLMSleepStrategy(t *testing.T) {
+	var sleeping atomic.Bool
+	var level atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sleep":
+			level.Store(r.URL.Query().Get("level"))
+			sleeping.Store(true)
+		case "/wake_up":
+			sleeping.Store(false)
+		case "/is_sleeping":
+			fmt.Fprintf(w, `{"is_sleeping": %t}`, sleeping.Load())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	host, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	pod := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]any{"name": "vllm", "namespace": "default", "labels": map[string]any{"app": "vllm"}},
+		"status": map[string]any{
+			"podIP":      host,
+			"conditions": []any{map[string]any{"type": "Ready", "status": "True"}},
+		},
+	}}
+	pool := &v1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", Annotations: map[string]string{
+			StrategyKey:       VLLMSleepStrategyName,
+			VLLMSleepLevelKey: "2",
+		}},
+		Spec: v1.InferencePoolSpec{
+			Selector:    v1.LabelSelector{MatchLabels: map[v1.LabelKey]v1.LabelValue{"app": "vllm"}},
+			TargetPorts: []v1.Port{{Number: v1.PortNumber(portNumber)}},
+		},
+	}
+
+	strategy := &vllmSleepStrategy{
+		clients:    StrategyClients{DynamicClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), pod)},
+		httpClient: server.Client(),
+	}
+	if err := strategy.Validate(pool); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if err := strategy.ScaleDown(ctx, &ScaleTarget{Pool: pool}); err != nil {
+		t.Fatalf("ScaleDown() error = %v", err)
+	}
+	if ready, err := strategy.Ready(ctx, pool); err != nil || ready {
+		t.Errorf("Ready() = %t, %v after the pods were put to sleep, want false", ready, err)
+	}
+	if got := level.Load(); got != "2" {
+		t.Errorf("sleep level = %v, want 2", got)
+	}
+
+	if err := strategy.ScaleUp(ctx, &ScaleTarget{Pool: pool}, 1); err != nil {
+		t.Fatalf("ScaleUp() error = %v", err)
+	}
+	if ready, err := strategy.Ready(ctx, pool); err != nil || !ready {
+		t.Errorf("Ready() = %t, %v after the pods were woken up, want true", ready, err)
+	}
+}
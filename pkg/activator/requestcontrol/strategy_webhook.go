@@ -0,0 +1,260 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+const (
+	WebhookStrategyName = "webhook"
+
+	// Webhook strategy annotations
+	WebhookURLKey        = poolconfig.WebhookURLKey        // Required by the webhook strategy
+	WebhookSecretNameKey = poolconfig.WebhookSecretNameKey // Optional, enables HMAC signing of the webhook payload
+	WebhookHealthURLKey  = poolconfig.WebhookHealthURLKey  // Optional, checks the readiness of the target instead of the webhook
+
+	// WebhookSecretDataKey is the key holding the HMAC signing key in the webhook secret
+	WebhookSecretDataKey = "hmac-key"
+
+	// WebhookSignatureHeader carries the hex encoded HMAC-SHA256 of the timestamp and the payload
+	WebhookSignatureHeader = "X-Activator-Signature"
+	// WebhookTimestampHeader carries the unix timestamp the payload was signed at
+	WebhookTimestampHeader = "X-Activator-Timestamp"
+
+	webhookTimeout = 10 * time.Second
+)
+
+var (
+	secretGVR = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+
+	// webhookBackoff retries failed webhook calls 4 times over roughly 7 seconds.
+	webhookBackoff = wait.Backoff{
+		Duration: 500 * time.Millisecond,
+		Factor:   2.0,
+		Jitter:   0.1,
+		Steps:    4,
+	}
+)
+
+func init() {
+	RegisterStrategy(WebhookStrategyName, func(c StrategyClients) Strategy {
+		return &webhookStrategy{clients: c, httpClient: &http.Client{Timeout: webhookTimeout}}
+	})
+}
+
+// WebhookPayload is the JSON body sent to the activation webhook.
+type WebhookPayload struct {
+	// Action is either "scale-up", "scale-down" or "status".
+	Action    string `json:"action"`
+	Namespace string `json:"namespace"`
+	Pool      string `json:"pool"`
+	// Target is the name of the target workload as annotated on the pool.
+	Target   string `json:"target,omitempty"`
+	Replicas int32  `json:"replicas"`
+}
+
+// WebhookStatus is the JSON body the activation webhook answers a "status" action with.
+type WebhookStatus struct {
+	// Ready reports whether the target serves requests.
+	Ready bool `json:"ready"`
+}
+
+// webhookStrategy delegates scaling to an operator provided HTTP(S) endpoint, for targets managed by
+// systems that Kubernetes scale subresources cannot reach, e.g. Slurm or a cloud provider. Requests are
+// optionally signed with an HMAC-SHA256 key read from a Secret, and scale actions are retried with
+// exponential backoff on transient failures. The target is ready once a GET of its health URL succeeds, or
+// when it has none, once the webhook answers a "status" action with a ready status.
+type webhookStrategy struct {
+	clients    StrategyClients
+	httpClient *http.Client
+}
+
+func (s *webhookStrategy) Validate(pool *v1.InferencePool) error {
+	config := poolconfig.For(pool)
+	if err := errors.Join(config.ErrOf(WebhookURLKey), config.ErrOf(WebhookHealthURLKey), config.ErrOf(WebhookSecretNameKey)); err != nil {
+		return err
+	}
+	return requireAnnotation(pool, WebhookURLKey, config.WebhookURL)
+}
+
+func (s *webhookStrategy) ScaleUp(ctx context.Context, target *ScaleTarget, replicas int32) error {
+	return s.call(ctx, target.Pool, "scale-up", replicas)
+}
+
+func (s *webhookStrategy) ScaleDown(ctx context.Context, target *ScaleTarget) error {
+	return s.call(ctx, target.Pool, "scale-down", 0)
+}
+
+// Ready checks the health URL of the target of the pool, or asks the webhook for its status when it has none.
+// Failed checks report the target as not ready, to be checked again, rather than retried.
+func (s *webhookStrategy) Ready(ctx context.Context, pool *v1.InferencePool) (bool, error) {
+	if err := s.Validate(pool); err != nil {
+		return false, err
+	}
+	if healthURL := poolconfig.For(pool).WebhookHealthURL; healthURL != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthURL, nil)
+		if err != nil {
+			return false, err
+		}
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return false, err
+		}
+		defer resp.Body.Close()
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode >= 200 && resp.StatusCode < 300, nil
+	}
+
+	payload, key, err := s.request(ctx, pool, "status", 0)
+	if err != nil {
+		return false, err
+	}
+	body, _, err := s.post(ctx, poolconfig.For(pool).WebhookURL, payload, key)
+	if err != nil {
+		return false, err
+	}
+	var status WebhookStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		return false, fmt.Errorf("failed to decode the activation webhook status of pool '%s': %w", pool.Name, err)
+	}
+	return status.Ready, nil
+}
+
+// Matches activates the target of the pool for every model it serves.
+func (s *webhookStrategy) Matches(*v1.InferencePool, string) bool {
+	return true
+}
+
+// request returns the payload of the given action on the pool, and the key to sign it with, if any.
+func (s *webhookStrategy) request(ctx context.Context, pool *v1.InferencePool, action string, replicas int32) ([]byte, []byte, error) {
+	payload, err := json.Marshal(WebhookPayload{
+		Action:    action,
+		Namespace: pool.Namespace,
+		Pool:      pool.Name,
+		Target:    poolconfig.For(pool).Target.Name,
+		Replicas:  replicas,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var key []byte
+	if secretName := poolconfig.For(pool).WebhookSecretName; secretName != "" {
+		if key, err = s.signingKey(ctx, pool.Namespace, secretName); err != nil {
+			return nil, nil, err
+		}
+	}
+	return payload, key, nil
+}
+
+func (s *webhookStrategy) call(ctx context.Context, pool *v1.InferencePool, action string, replicas int32) error {
+	payload, key, err := s.request(ctx, pool, action, replicas)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	err = wait.ExponentialBackoffWithContext(ctx, webhookBackoff, func(ctx context.Context) (bool, error) {
+		_, retry, err := s.post(ctx, poolconfig.For(pool).WebhookURL, payload, key)
+		if err == nil {
+			return true, nil
+		}
+		lastErr = err
+		if !retry {
+			return false, err
+		}
+		return false, nil
+	})
+	if wait.Interrupted(err) && lastErr != nil {
+		return fmt.Errorf("activation webhook for pool '%s' failed after retries: %w", pool.Name, lastErr)
+	}
+	return err
+}
+
+// post sends the payload to the webhook, and returns the body of its response and whether a failure is worth
+// retrying.
+func (s *webhookStrategy) post(ctx context.Context, webhookURL string, payload, key []byte) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if key != nil {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(WebhookTimestampHeader, timestamp)
+		req.Header.Set(WebhookSignatureHeader, "sha256="+signWebhookPayload(key, timestamp, payload))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, err
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return body, false, nil
+	}
+	err = fmt.Errorf("activation webhook returned status %d", resp.StatusCode)
+	return nil, resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests, err
+}
+
+// signingKey reads the HMAC signing key from the given Secret.
+func (s *webhookStrategy) signingKey(ctx context.Context, namespace, name string) ([]byte, error) {
+	secret, err := s.clients.DynamicClient.Resource(secretGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook secret %s/%s: %w", namespace, name, err)
+	}
+	encoded, found, err := unstructured.NestedString(secret.Object, "data", WebhookSecretDataKey)
+	if err != nil || !found {
+		return nil, fmt.Errorf("webhook secret %s/%s has no '%s' key", namespace, name, WebhookSecretDataKey)
+	}
+	return base64.StdEncoding.DecodeString(encoded)
+}
+
+// signWebhookPayload returns the hex encoded HMAC-SHA256 of "<timestamp>.<payload>".
+func signWebhookPayload(key []byte, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
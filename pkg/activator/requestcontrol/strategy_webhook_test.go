@@ -0,0 +1,198 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func newWebhookStrategy(server *httptest.Server, objects ...runtime.Object) *webhookStrategy {
+	return &webhookStrategy{
+		clients:    StrategyClients{DynamicClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)},
+		httpClient: server.Client(),
+	}
+}
+
+func webhookPool(annotations map[string]string) *v1.InferencePool {
+	all := map[string]string{StrategyKey: WebhookStrategyName}
+	for key, value := range annotations {
+		all[key] = value
+	}
+	return &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", Annotations: all}}
+}
+
+func TestWebhookStrategySignature(t *testing.T) {
+	key := []byte("signing-key")
+	secret := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]any{"name": "webhook", "namespace": "default"},
+		"data":       map[string]any{WebhookSecretDataKey: base64.StdEncoding.EncodeToString(key)},
+	}}
+
+	var signature, timestamp atomic.Value
+	var payload atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		payload.Store(body)
+		signature.Store(r.Header.Get(WebhookSignatureHeader))
+		timestamp.Store(r.Header.Get(WebhookTimestampHeader))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		objects     []runtime.Object
+		wantSigned  bool
+		wantErr     bool
+	}{
+		{name: "unsigned", annotations: map[string]string{WebhookURLKey: server.URL}},
+		{name: "signed with the secret key", annotations: map[string]string{WebhookURLKey: server.URL, WebhookSecretNameKey: "webhook"}, objects: []runtime.Object{secret}, wantSigned: true},
+		{name: "missing secret", annotations: map[string]string{WebhookURLKey: server.URL, WebhookSecretNameKey: "webhook"}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signature.Store("")
+			timestamp.Store("")
+			strategy := newWebhookStrategy(server, test.objects...)
+			pool := webhookPool(test.annotations)
+			err := strategy.ScaleUp(context.Background(), &ScaleTarget{Pool: pool}, 2)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ScaleUp() error = %v, wantErr %t", err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+
+			var sent WebhookPayload
+			if err := json.Unmarshal(payload.Load().([]byte), &sent); err != nil {
+				t.Fatalf("invalid payload: %v", err)
+			}
+			if sent.Action != "scale-up" || sent.Pool != "pool" || sent.Replicas != 2 {
+				t.Errorf("payload = %+v, want a scale-up of pool to 2 replicas", sent)
+			}
+			got := signature.Load().(string)
+			if !test.wantSigned {
+				if got != "" {
+					t.Errorf("signature = %q, want none", got)
+				}
+				return
+			}
+			want := "sha256=" + signWebhookPayload(key, timestamp.Load().(string), payload.Load().([]byte))
+			if got != want {
+				t.Errorf("signature = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestWebhookStrategyRetries(t *testing.T) {
+	defer func(backoff wait.Backoff) { webhookBackoff = backoff }(webhookBackoff)
+	webhookBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 4}
+
+	tests := []struct {
+		name      string
+		failures  int32
+		status    int
+		wantCalls int32
+		wantErr   bool
+	}{
+		{name: "success", wantCalls: 1},
+		{name: "retried on 5xx", failures: 2, status: http.StatusServiceUnavailable, wantCalls: 3},
+		{name: "retried on 429", failures: 2, status: http.StatusTooManyRequests, wantCalls: 3},
+		{name: "gives up after retries", failures: 10, status: http.StatusInternalServerError, wantCalls: 4, wantErr: true},
+		{name: "not retried on 4xx", failures: 10, status: http.StatusBadRequest, wantCalls: 1, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) <= test.failures {
+					w.WriteHeader(test.status)
+				}
+			}))
+			defer server.Close()
+
+			strategy := newWebhookStrategy(server)
+			err := strategy.ScaleDown(context.Background(), &ScaleTarget{Pool: webhookPool(map[string]string{WebhookURLKey: server.URL})})
+			if (err != nil) != test.wantErr {
+				t.Errorf("ScaleDown() error = %v, wantErr %t", err, test.wantErr)
+			}
+			if got := calls.Load(); got != test.wantCalls {
+				t.Errorf("calls = %d, want %d", got, test.wantCalls)
+			}
+		})
+	}
+}
+
+func TestWebhookStrategyReady(t *testing.T) {
+	var ready atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			if !ready.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		default:
+			var payload WebhookPayload
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			if payload.Action != "status" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(WebhookStatus{Ready: ready.Load()})
+		}
+	}))
+	defer server.Close()
+
+	strategy := newWebhookStrategy(server)
+	var _ ExternalTarget = strategy
+	for _, pool := range []*v1.InferencePool{
+		webhookPool(map[string]string{WebhookURLKey: server.URL}),
+		webhookPool(map[string]string{WebhookURLKey: server.URL, WebhookHealthURLKey: server.URL + "/healthz"}),
+	} {
+		for _, want := range []bool{false, true} {
+			ready.Store(want)
+			if got, err := strategy.Ready(context.Background(), pool); err != nil || got != want {
+				t.Errorf("Ready() = %t, %v, want %t with annotations %v", got, err, want, pool.Annotations)
+			}
+		}
+	}
+
+	if err := strategy.Validate(webhookPool(map[string]string{WebhookURLKey: server.URL, WebhookHealthURLKey: "/healthz"})); err == nil {
+		t.Errorf("Validate() accepted a relative health URL")
+	}
+}
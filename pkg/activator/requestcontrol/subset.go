@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"net"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// hintEndpointSubset restricts the requests released after a scale from zero to the endpoints of the
+// inferencePool that are ready, while other replicas are still starting. The Endpoint Picker then sends
+// the early backlog to the replicas that became ready first, instead of rediscovering their readiness.
+func (a *Activator) hintEndpointSubset(ctx context.Context, pool *v1.InferencePool) {
+	state := activationStateFromContext(ctx)
+	if state == nil {
+		return
+	}
+
+	pods, err := a.DynamicClient.Resource(podGVR).Namespace(pool.Namespace).List(ctx, metav1.ListOptions{LabelSelector: poolPodSelector(pool)})
+	if err != nil {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("Error listing inferencePool pods, skipping endpoint subset hint", "error", err.Error())
+		return
+	}
+
+	endpoints, starting := readyEndpoints(pods.Items, pool.Spec.TargetPorts)
+	if len(endpoints) == 0 || starting == 0 {
+		// Either nothing to point at, or every replica is ready and the Endpoint Picker may use them all
+		return
+	}
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Hinting ready endpoints of the inferencePool", "endpoints", endpoints, "starting", starting)
+	state.endpointSubset = endpoints
+}
+
+// readyEndpoints returns the address:port endpoints of the ready pods among the given ones, along with the
+// number of pods that are not ready yet.
+func readyEndpoints(pods []unstructured.Unstructured, ports []v1.Port) ([]string, int) {
+	var endpoints []string
+	var starting int
+	for i := range pods {
+		pod := &pods[i]
+		if pod.GetDeletionTimestamp() != nil {
+			continue
+		}
+		podIP, _, _ := unstructured.NestedString(pod.Object, "status", "podIP")
+		if podIP == "" || !podReady(pod) {
+			starting++
+			continue
+		}
+		for _, port := range ports {
+			endpoints = append(endpoints, net.JoinHostPort(podIP, strconv.Itoa(int(port.Number))))
+		}
+	}
+	return endpoints, starting
+}
+
+func podReady(pod *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(pod.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, _ := c.(map[string]any)
+		if condition["type"] == "Ready" {
+			return condition["status"] == string(metav1.ConditionTrue)
+		}
+	}
+	return false
+}
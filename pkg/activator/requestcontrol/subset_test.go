@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"slices"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func podWithReadiness(podIP string, ready bool) unstructured.Unstructured {
+	status := "False"
+	if ready {
+		status = "True"
+	}
+	return podWithStatus(map[string]any{
+		"podIP":      podIP,
+		"conditions": []any{map[string]any{"type": "Ready", "status": status}},
+	})
+}
+
+func TestReadyEndpoints(t *testing.T) {
+	ports := []v1.Port{{Number: 8000}}
+
+	tests := []struct {
+		name         string
+		pods         []unstructured.Unstructured
+		want         []string
+		wantStarting int
+	}{
+		{name: "No pods"},
+		{name: "All ready", pods: []unstructured.Unstructured{podWithReadiness("10.0.0.1", true), podWithReadiness("10.0.0.2", true)}, want: []string{"10.0.0.1:8000", "10.0.0.2:8000"}},
+		{name: "First ready", pods: []unstructured.Unstructured{podWithReadiness("10.0.0.1", false), podWithReadiness("10.0.0.2", true)}, want: []string{"10.0.0.2:8000"}, wantStarting: 1},
+		{name: "Not scheduled yet", pods: []unstructured.Unstructured{podWithReadiness("", false)}, wantStarting: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, starting := readyEndpoints(test.pods, ports)
+			if !slices.Equal(got, test.want) || starting != test.wantStarting {
+				t.Errorf("readyEndpoints() = (%v, %d), want (%v, %d)", got, starting, test.want, test.wantStarting)
+			}
+		})
+	}
+}
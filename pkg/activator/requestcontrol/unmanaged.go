@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/handlers"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// ActivatorEnabledKey takes the pool out of activator control when set to "false": its requests are let
+// through without activation check and the deactivator never scales it down, e.g. while operators manage
+// its replicas by hand.
+const ActivatorEnabledKey = poolconfig.ActivatorEnabledKey // Optional annotation
+
+// NamespaceEnabledLabel opts the namespace of the pool in to the activator when set to "true", in the namespace
+// opt-in mode, where the pools of the other namespaces are out of activator control.
+const NamespaceEnabledLabel = "activator.llm-d.ai/enabled"
+
+var namespaceGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+
+const (
+	// UnmanagedDisabled is the reason of the requests of pools taken out of activator control
+	UnmanagedDisabled = "disabled"
+	// UnmanagedDebugBypass is the reason of the requests carrying the debug bypass header
+	UnmanagedDebugBypass = "debug-bypass"
+)
+
+// activatorEnabled reports whether the given pool is under activator control.
+func activatorEnabled(pool *v1.InferencePool) bool {
+	return poolconfig.For(pool).Enabled
+}
+
+// unmanagedReason returns why the request with the given headers skips the activation check entirely, if it
+// does: its pool is out of activator control, or it carries the debug bypass header while debugBypass is set.
+func unmanagedReason(pool *v1.InferencePool, headers map[string]string, debugBypass bool) (string, bool) {
+	if !activatorEnabled(pool) {
+		return UnmanagedDisabled, true
+	}
+	if _, ok := headers[handlers.DebugBypassHeaderKey]; ok && debugBypass {
+		return UnmanagedDebugBypass, true
+	}
+	return "", false
+}
+
+// ApplyNamespaceOptIn takes the given pool out of activator control, as the enabled annotation set to "false"
+// does, unless its namespace is labeled activator.llm-d.ai/enabled=true, so that operators can roll the
+// activator out tenant by tenant without changing the pools. Pools whose namespace cannot be read are taken
+// out of activator control as well. The annotation is only changed in memory.
+func (a *Activator) ApplyNamespaceOptIn(ctx context.Context, pool *v1.InferencePool) error {
+	optedIn, err := namespaceOptedIn(ctx, a.DynamicClient, pool.Namespace)
+	if optedIn {
+		return nil
+	}
+	if pool.Annotations == nil {
+		pool.Annotations = map[string]string{}
+	}
+	pool.Annotations[ActivatorEnabledKey] = "false"
+	log.FromContext(ctx).V(logutil.DEBUG).Info("Namespace of the inferencePool is not opted in to the activator, leaving the pool alone", "namespace", pool.Namespace)
+	return err
+}
+
+// namespaceOptedIn reports whether the given namespace is labeled activator.llm-d.ai/enabled=true.
+func namespaceOptedIn(ctx context.Context, client dynamic.Interface, name string) (bool, error) {
+	namespace, err := client.Resource(namespaceGVR).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to get namespace %s to check its opt-in to the activator: %w", name, err)
+	}
+	enabled, err := strconv.ParseBool(namespace.GetLabels()[NamespaceEnabledLabel])
+	return err == nil && enabled, nil
+}
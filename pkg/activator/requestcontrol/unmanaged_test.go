@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/handlers"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestUnmanagedReason(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		headers     map[string]string
+		debugBypass bool
+		wantReason  string
+		wantErr     bool
+	}{
+		{name: "managed pool"},
+		{name: "explicitly enabled pool", annotations: map[string]string{ActivatorEnabledKey: "true"}},
+		{name: "disabled pool", annotations: map[string]string{ActivatorEnabledKey: "false"}, wantReason: UnmanagedDisabled},
+		{name: "debug bypass header ignored when not enabled", headers: map[string]string{handlers.DebugBypassHeaderKey: "1"}},
+		{name: "debug bypass header", headers: map[string]string{handlers.DebugBypassHeaderKey: "1"}, debugBypass: true, wantReason: UnmanagedDebugBypass},
+		{name: "invalid annotation", annotations: map[string]string{ActivatorEnabledKey: "no way"}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Annotations: test.annotations}}
+			if err := poolconfig.Parse(pool).ErrOf(ActivatorEnabledKey); (err != nil) != test.wantErr {
+				t.Fatalf("poolconfig.Parse().ErrOf(ActivatorEnabledKey) error = %v, wantErr %t", err, test.wantErr)
+			}
+			reason, ok := unmanagedReason(pool, test.headers, test.debugBypass)
+			if reason != test.wantReason || ok != (test.wantReason != "") {
+				t.Errorf("unmanagedReason() = %q, %t, want %q", reason, ok, test.wantReason)
+			}
+		})
+	}
+}
+
+func TestNamespaceOptedIn(t *testing.T) {
+	namespace := func(name string, labels map[string]any) runtime.Object {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata":   map[string]any{"name": name, "labels": labels},
+		}}
+	}
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(),
+		namespace("tenant-a", map[string]any{NamespaceEnabledLabel: "true"}),
+		namespace("tenant-b", map[string]any{NamespaceEnabledLabel: "false"}),
+		namespace("tenant-c", nil),
+	)
+
+	for name, want := range map[string]bool{"tenant-a": true, "tenant-b": false, "tenant-c": false} {
+		if got, err := namespaceOptedIn(context.Background(), client, name); err != nil || got != want {
+			t.Errorf("namespaceOptedIn(%s) = %t, %v, want %t", name, got, err, want)
+		}
+	}
+	if got, err := namespaceOptedIn(context.Background(), client, "missing"); err == nil || got {
+		t.Errorf("namespaceOptedIn(missing) = %t, %v, want an error", got, err)
+	}
+}
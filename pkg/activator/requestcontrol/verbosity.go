@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// LogVerbosityKey raises the verbosity of the activator logs while set on the pool, e.g. "5" for the trace
+// logs of its activations. An activator serving a single pool, it debugs one pool of a large fleet without
+// restarting its activator nor flooding the logs of the others.
+const LogVerbosityKey = poolconfig.LogVerbosityKey // Optional annotation
+
+// LogVerbosity applies the log verbosity annotation of the pool to the level of the activator logs.
+type LogVerbosity struct {
+	level uberzap.AtomicLevel
+	base  zapcore.Level
+}
+
+// NewLogVerbosity creates a LogVerbosity adjusting the given level, the level the activator was started
+// with being restored once the pool no longer sets a log verbosity.
+func NewLogVerbosity(level uberzap.AtomicLevel) *LogVerbosity {
+	return &LogVerbosity{level: level, base: level.Level()}
+}
+
+// Apply sets the level of the activator logs to the log verbosity of the given pool, or back to the level
+// the activator was started with if the pool sets none, a lower one, or was deleted.
+func (v *LogVerbosity) Apply(ctx context.Context, pool *v1.InferencePool) {
+	level := v.base
+	if verbosity, ok := logVerbosityFor(pool); ok && zapcore.Level(-verbosity) < level {
+		level = zapcore.Level(-verbosity)
+	}
+	if level == v.level.Level() {
+		return
+	}
+	v.level.SetLevel(level)
+	log.FromContext(ctx).V(logutil.DEFAULT).Info("Log verbosity changed", "verbosity", -int(level))
+}
+
+// logVerbosityFor returns the log verbosity of the given pool, if it sets a valid one.
+func logVerbosityFor(pool *v1.InferencePool) (int8, bool) {
+	if pool == nil {
+		return 0, false
+	}
+	if verbosity := poolconfig.For(pool).LogVerbosity; verbosity != nil {
+		return *verbosity, true
+	}
+	return 0, false
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"testing"
+
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestLogVerbosity(t *testing.T) {
+	newPool := func(verbosity string) *v1.InferencePool {
+		pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"}}
+		if verbosity != "" {
+			pool.Annotations = map[string]string{LogVerbosityKey: verbosity}
+		}
+		return pool
+	}
+
+	tests := []struct {
+		name      string
+		pool      *v1.InferencePool
+		wantLevel zapcore.Level
+		wantErr   bool
+	}{
+		{name: "no annotation", pool: newPool(""), wantLevel: -2},
+		{name: "raised verbosity", pool: newPool("5"), wantLevel: -5},
+		{name: "lower verbosity than the activator", pool: newPool("1"), wantLevel: -2},
+		{name: "invalid verbosity", pool: newPool("debug"), wantLevel: -2, wantErr: true},
+		{name: "negative verbosity", pool: newPool("-1"), wantLevel: -2, wantErr: true},
+		{name: "pool deleted", pool: nil, wantLevel: -2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			level := uberzap.NewAtomicLevelAt(-2)
+			verbosity := NewLogVerbosity(level)
+			// Start from a raised verbosity, to check it is restored
+			level.SetLevel(-7)
+
+			verbosity.Apply(context.Background(), test.pool)
+			if got := level.Level(); got != test.wantLevel {
+				t.Errorf("Apply() level = %v, want %v", got, test.wantLevel)
+			}
+			if test.pool == nil {
+				return
+			}
+			if err := poolconfig.Parse(test.pool).ErrOf(LogVerbosityKey); (err != nil) != test.wantErr {
+				t.Errorf("poolconfig.Parse().ErrOf(LogVerbosityKey) error = %v, wantErr %t", err, test.wantErr)
+			}
+		})
+	}
+}
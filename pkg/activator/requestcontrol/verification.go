@@ -0,0 +1,257 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// ActivationSLOKey is the longest a scale up from zero of the pool may take for the first activation after
+	// a rollout of its target workload to pass its verification, e.g. "90s". Defaults to the scale from zero
+	// grace period, any activation ready in time passing.
+	ActivationSLOKey = poolconfig.ActivationSLOKey // Optional annotation
+
+	// VerificationWebhookURLKey is the URL the verification of the first activation after each rollout of the
+	// target workload of the pool is posted to as JSON, e.g. to resume a deployment pipeline.
+	VerificationWebhookURLKey = poolconfig.VerificationWebhookURLKey // Optional annotation
+
+	// MaxVerificationWait bounds the wait of the requests to the verification endpoint
+	MaxVerificationWait = 10 * time.Minute
+
+	verificationWebhookTimeout = 10 * time.Second
+)
+
+// VerificationStatus is the status of the verification of the first activation after a rollout.
+type VerificationStatus string
+
+const (
+	// VerificationPending is reported until the pool is activated for the first time after the rollout.
+	VerificationPending VerificationStatus = "Pending"
+	// VerificationPassed is reported once the first activation after the rollout was ready within the SLO.
+	VerificationPassed VerificationStatus = "Passed"
+	// VerificationFailed is reported once the first activation after the rollout failed or exceeded the SLO.
+	VerificationFailed VerificationStatus = "Failed"
+)
+
+// ActivationVerification is the verification of the first scale up from zero of the pool after a rollout
+// of its target workload, for deployment pipelines to confirm that a newly shipped revision still wakes up.
+type ActivationVerification struct {
+	Pool string `json:"pool"`
+	// Revision identifies the revision of the target workload, see targetRevision.
+	Revision        string             `json:"revision"`
+	Status          VerificationStatus `json:"status"`
+	DurationSeconds float64            `json:"durationSeconds,omitempty"`
+	SLOSeconds      float64            `json:"sloSeconds,omitempty"`
+	Message         string             `json:"message,omitempty"`
+	Timestamp       *time.Time         `json:"timestamp,omitempty"`
+}
+
+// ActivationVerifier keeps the verification of the first scale up from zero after the latest rollout of
+// the target workload of the pool.
+type ActivationVerifier struct {
+	mu     sync.Mutex
+	latest ActivationVerification
+	// changed is closed and replaced each time a verification is recorded
+	changed    chan struct{}
+	httpClient *http.Client
+}
+
+// NewActivationVerifier creates an ActivationVerifier without verification.
+func NewActivationVerifier() *ActivationVerifier {
+	return &ActivationVerifier{changed: make(chan struct{}), httpClient: &http.Client{Timeout: verificationWebhookTimeout}}
+}
+
+// activationSLOFor returns the activation SLO of the given pool.
+func activationSLOFor(pool *v1.InferencePool) time.Duration {
+	return poolconfig.For(pool).ActivationSLO
+}
+
+// targetRevision returns the revision of the given target workload: a hash of its spec without its replicas
+// and the scheduling constraints of its pods, which changes with its pod template but neither when it is
+// scaled nor when the activation placement is overlaid on it.
+func targetRevision(target *unstructured.Unstructured) string {
+	spec, _, _ := unstructured.NestedMap(target.Object, "spec")
+	delete(spec, "replicas")
+	for _, field := range []string{"nodeSelector", "affinity", "tolerations"} {
+		unstructured.RemoveNestedField(spec, "template", "spec", field)
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// observe records the outcome of a scale up from zero of the given pool at the given target revision, if it
+// is the first one since the target workload rolled out that revision. A nil verifier records nothing.
+func (v *ActivationVerifier) observe(logger logr.Logger, pool *v1.InferencePool, revision string, ready bool, duration time.Duration, now time.Time) {
+	if v == nil || revision == "" {
+		return
+	}
+	slo := activationSLOFor(pool)
+	verification := ActivationVerification{
+		Pool:            pool.Namespace + "/" + pool.Name,
+		Revision:        revision,
+		Status:          VerificationPassed,
+		DurationSeconds: duration.Seconds(),
+		SLOSeconds:      slo.Seconds(),
+		Message:         "candidate pods were ready within the activation SLO",
+		Timestamp:       &now,
+	}
+	switch {
+	case !ready:
+		verification.Status, verification.Message = VerificationFailed, "candidate pods did not become ready"
+	case duration > slo:
+		verification.Status, verification.Message = VerificationFailed, fmt.Sprintf("candidate pods were ready after %s, beyond the activation SLO of %s", duration.Round(time.Millisecond), slo)
+	}
+
+	v.mu.Lock()
+	if v.latest.Revision == revision && v.latest.Pool == verification.Pool {
+		v.mu.Unlock()
+		return
+	}
+	v.latest = verification
+	close(v.changed)
+	v.changed = make(chan struct{})
+	v.mu.Unlock()
+
+	logger.Info("Verified the first activation after a rollout", "revision", revision, "status", verification.Status, "duration", duration)
+	if webhookURL := poolconfig.For(pool).VerificationWebhookURL; webhookURL != "" {
+		go v.notify(logger, webhookURL, verification)
+	}
+}
+
+// verification returns the verification of the given revision of the target workload of the given pool, and
+// a channel closed once another verification is recorded.
+func (v *ActivationVerifier) verification(pool, revision string) (ActivationVerification, <-chan struct{}) {
+	if v == nil {
+		return ActivationVerification{Pool: pool, Revision: revision, Status: VerificationPending}, nil
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.latest.Pool == pool && v.latest.Revision == revision {
+		return v.latest, v.changed
+	}
+	return ActivationVerification{Pool: pool, Revision: revision, Status: VerificationPending}, v.changed
+}
+
+// notify posts the given verification to the given webhook URL.
+func (v *ActivationVerifier) notify(logger logr.Logger, webhookURL string, verification ActivationVerification) {
+	payload, err := json.Marshal(verification)
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), verificationWebhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		logger.Error(err, "Error creating the verification webhook request")
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		logger.Error(err, "Error posting the verification to its webhook")
+		return
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		logger.Info("Verification webhook did not accept the verification", "status", resp.StatusCode)
+		return
+	}
+	logger.V(logutil.DEBUG).Info("Posted the verification to its webhook")
+}
+
+// VerificationHandler serves the verification of the first scale up from zero after the rollout of the
+// current revision of the target workload of the pool, as JSON, for deployment pipelines to confirm that a
+// newly shipped revision still wakes up within its SLO. The status code tells the status of the
+// verification: 200 once passed, 202 while pending and 409 once failed. A "wait" query parameter, e.g.
+// "?wait=5m", waits for the verification to be passed or failed for at most the given time.
+func (a *Activator) VerificationHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var wait time.Duration
+		if value := r.URL.Query().Get("wait"); value != "" {
+			var err error
+			if wait, err = time.ParseDuration(value); err != nil || wait < 0 {
+				http.Error(w, fmt.Sprintf("invalid wait %q", value), http.StatusBadRequest)
+				return
+			}
+		}
+		pool, err := a.datastore.PoolGet()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		gvr, err := targetResourceFor(a.Mapper, pool)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		target, err := a.DynamicClient.Resource(gvr).Namespace(pool.Namespace).Get(r.Context(), poolconfig.For(pool).Target.Name, metav1.GetOptions{})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), min(wait, MaxVerificationWait))
+		defer cancel()
+		poolName, revision := pool.Namespace+"/"+pool.Name, targetRevision(target)
+		verification, changed := a.Verifier.verification(poolName, revision)
+		for verification.Status == VerificationPending && ctx.Err() == nil {
+			select {
+			case <-ctx.Done():
+			case <-changed:
+				verification, changed = a.Verifier.verification(poolName, revision)
+			}
+		}
+
+		status := http.StatusOK
+		switch verification.Status {
+		case VerificationPending:
+			status = http.StatusAccepted
+		case VerificationFailed:
+			status = http.StatusConflict
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(verification)
+	})
+}
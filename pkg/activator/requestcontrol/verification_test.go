@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestActivationVerifier(t *testing.T) {
+	pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default", Annotations: map[string]string{ActivationSLOKey: "1m"}}}
+
+	tests := []struct {
+		name       string
+		revision   string
+		ready      bool
+		duration   time.Duration
+		wantStatus VerificationStatus
+		wantChange bool
+	}{
+		{name: "first activation within the SLO", revision: "a", ready: true, duration: 30 * time.Second, wantStatus: VerificationPassed, wantChange: true},
+		{name: "later activation of the same revision", revision: "a", ready: false, wantStatus: VerificationPassed},
+		{name: "first activation beyond the SLO", revision: "b", ready: true, duration: 2 * time.Minute, wantStatus: VerificationFailed, wantChange: true},
+		{name: "first activation not ready", revision: "c", ready: false, duration: time.Minute, wantStatus: VerificationFailed, wantChange: true},
+	}
+
+	verifier := NewActivationVerifier()
+	if verification, _ := verifier.verification("default/pool", "a"); verification.Status != VerificationPending {
+		t.Errorf("status before any activation = %s, want %s", verification.Status, VerificationPending)
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, changed := verifier.verification("default/pool", test.revision)
+			verifier.observe(logr.Discard(), pool, test.revision, test.ready, test.duration, time.Now())
+			verification, _ := verifier.verification("default/pool", test.revision)
+			if verification.Status != test.wantStatus {
+				t.Errorf("status = %s, want %s", verification.Status, test.wantStatus)
+			}
+			notified := false
+			select {
+			case <-changed:
+				notified = true
+			default:
+			}
+			if notified != test.wantChange {
+				t.Errorf("waiters notified = %t, want %t", notified, test.wantChange)
+			}
+		})
+	}
+}
+
+func TestTargetRevision(t *testing.T) {
+	target := func(replicas int64, image string, nodeSelector map[string]any) *unstructured.Unstructured {
+		podSpec := map[string]any{"containers": []any{map[string]any{"name": "vllm", "image": image}}}
+		if nodeSelector != nil {
+			podSpec["nodeSelector"] = nodeSelector
+		}
+		return &unstructured.Unstructured{Object: map[string]any{
+			"spec": map[string]any{"replicas": replicas, "template": map[string]any{"spec": podSpec}},
+		}}
+	}
+
+	revision := targetRevision(target(0, "vllm:v1", nil))
+	if scaled := targetRevision(target(3, "vllm:v1", nil)); scaled != revision {
+		t.Errorf("revision of the scaled target = %s, want %s", scaled, revision)
+	}
+	if placed := targetRevision(target(1, "vllm:v1", map[string]any{"zone": "a"})); placed != revision {
+		t.Errorf("revision of the placed target = %s, want %s", placed, revision)
+	}
+	if rolledOut := targetRevision(target(0, "vllm:v2", nil)); rolledOut == revision {
+		t.Errorf("revision of the rolled out target = %s, want another revision", rolledOut)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// WarmUpPathKey enables the warm-up of the pods woken by the scale ups from zero of the pool: once they are
+	// ready, a small synthetic completion request is sent to each of them on the given path, e.g.
+	// "/v1/completions", and the requests held are only released after it succeeded, so that the first real
+	// request does not pay for the model loading or compilation.
+	WarmUpPathKey = poolconfig.WarmUpPathKey // Optional annotation
+
+	// WarmUpBodyKey is the JSON body of the warm-up requests of the pool. Defaults to DefaultWarmUpBody.
+	WarmUpBodyKey = poolconfig.WarmUpBodyKey // Optional annotation
+
+	// DefaultWarmUpBody is the body of the warm-up requests of pools without a warm-up body annotation
+	DefaultWarmUpBody = poolconfig.DefaultWarmUpBody
+
+	// DefaultWarmUpTimeout bounds the warm-up of the pods, the requests held being released anyway afterwards
+	DefaultWarmUpTimeout = time.Duration(2 * time.Minute)
+
+	warmUpRetryInterval = time.Second
+)
+
+// warmUp sends a warm-up request to each ready pod of the given pool after a scale up from zero, and waits
+// until every one of them succeeded. It fails open: the requests are released once the warm-up timeout
+// elapsed even if some pods never answered. Pools without a warm-up path annotation are not warmed up.
+func (a *Activator) warmUp(ctx context.Context, logger logr.Logger, pool *v1.InferencePool) {
+	config := poolconfig.For(pool)
+	path, body := config.WarmUpPath, config.WarmUpBody
+	if path == "" || len(pool.Spec.TargetPorts) == 0 {
+		return
+	}
+	poolName := pool.Namespace + "/" + pool.Name
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultWarmUpTimeout)
+	defer cancel()
+	pods, err := a.DynamicClient.Resource(podGVR).Namespace(pool.Namespace).List(ctx, metav1.ListOptions{LabelSelector: poolPodSelector(pool)})
+	if err != nil {
+		logger.V(logutil.DEBUG).Info("Error listing inferencePool pods, skipping warm-up", "error", err.Error())
+		return
+	}
+	endpoints, _ := readyEndpoints(pods.Items, pool.Spec.TargetPorts[:1])
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	errs := make([]error, len(endpoints))
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = warmUpEndpoint(ctx, &http.Client{}, "http://"+endpoint+path, body)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			logger.Info("Warm-up request did not succeed within the warm-up timeout, releasing requests", "endpoint", endpoints[i], "error", err.Error())
+			metrics.RecordWarmUp(poolName, "failed")
+			return
+		}
+	}
+	if len(endpoints) > 0 {
+		logger.V(logutil.DEBUG).Info("Warmed up the ready pods of the inferencePool", "endpoints", endpoints, "duration", time.Since(start))
+		metrics.RecordWarmUp(poolName, "succeeded")
+	}
+}
+
+// warmUpEndpoint posts the given warm-up body to the given URL until it succeeds or the context is done.
+func warmUpEndpoint(ctx context.Context, httpClient *http.Client, url, body string) error {
+	ticker := time.NewTicker(warmUpRetryInterval)
+	defer ticker.Stop()
+	for {
+		err := postWarmUp(ctx, httpClient, url, body)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-ticker.C:
+		}
+	}
+}
+
+func postWarmUp(ctx context.Context, httpClient *http.Client, url, body string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
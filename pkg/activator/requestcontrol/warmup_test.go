@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+)
+
+func TestWarmUpEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int32
+		timeout  time.Duration
+		wantWarm bool
+	}{
+		{name: "warm at once", failures: 0, timeout: time.Second, wantWarm: true},
+		{name: "warm after a retry", failures: 1, timeout: 5 * time.Second, wantWarm: true},
+		{name: "never warm", failures: 1000, timeout: 300 * time.Millisecond, wantWarm: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if body, _ := io.ReadAll(r.Body); string(body) != DefaultWarmUpBody || r.URL.Path != "/v1/completions" {
+					t.Errorf("warm-up request = %s %s, want the default body on /v1/completions", r.URL.Path, body)
+				}
+				if requests.Add(1) <= test.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			}))
+			defer server.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), test.timeout)
+			defer cancel()
+			err := warmUpEndpoint(ctx, server.Client(), server.URL+"/v1/completions", DefaultWarmUpBody)
+			if warm := err == nil; warm != test.wantWarm {
+				t.Errorf("warmUpEndpoint() error = %v, want warm %t", err, test.wantWarm)
+			}
+		})
+	}
+}
+
+func TestValidateWarmUp(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantErr     bool
+	}{
+		{name: "no warm-up"},
+		{name: "default body", annotations: map[string]string{WarmUpPathKey: "/v1/completions"}},
+		{name: "custom body", annotations: map[string]string{WarmUpPathKey: "/v1/chat/completions", WarmUpBodyKey: `{"messages":[{"role":"user","content":"Hi"}],"max_tokens":1}`}},
+		{name: "relative path", annotations: map[string]string{WarmUpPathKey: "v1/completions"}, wantErr: true},
+		{name: "invalid body", annotations: map[string]string{WarmUpPathKey: "/v1/completions", WarmUpBodyKey: "Hello"}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &v1.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Annotations: test.annotations}}
+			if err := poolconfig.Parse(pool).Err; (err != nil) != test.wantErr {
+				t.Errorf("poolconfig.Parse().Err error = %v, wantErr %t", err, test.wantErr)
+			}
+		})
+	}
+}
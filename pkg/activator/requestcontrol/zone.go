@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	activatorv1alpha1 "github.com/llm-d-incubation/llm-d-activator/api/v1alpha1"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/metrics"
+	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/poolconfig"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// ZoneAwareActivationKey makes the scale ups from zero of the pool prefer waking replicas in the zone of
+	// the activator, that is of the gateway traffic it serves, when set to "true". The preference is applied
+	// with the placement overlay, on top of the placement of the activation policy of the pool if any.
+	ZoneAwareActivationKey = poolconfig.ZoneAwareActivationKey // Optional annotation
+
+	// zoneLabel is the well-known label of the zone of the nodes
+	zoneLabel = "topology.kubernetes.io/zone"
+
+	// zoneReportTimeout bounds the lookup of the zones of the replicas woken by a scale up from zero
+	zoneReportTimeout = 10 * time.Second
+)
+
+var nodeGVR = schema.GroupVersionResource{Version: "v1", Resource: "nodes"}
+
+// zoneAware reports whether the scale ups from zero of the given pool prefer the zone of the activator.
+func zoneAware(pool *v1.InferencePool) bool {
+	return poolconfig.For(pool).ZoneAwareActivation
+}
+
+// withZonePreference returns a copy of the given placement, which may be nil, preferring the nodes of the
+// given zone.
+func withZonePreference(placement *activatorv1alpha1.PlacementSpec, zone string) *activatorv1alpha1.PlacementSpec {
+	preferred := &activatorv1alpha1.PlacementSpec{}
+	if placement != nil {
+		preferred = placement.DeepCopy()
+	}
+	if preferred.Affinity == nil {
+		preferred.Affinity = &corev1.Affinity{}
+	}
+	if preferred.Affinity.NodeAffinity == nil {
+		preferred.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := preferred.Affinity.NodeAffinity
+	nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+		corev1.PreferredSchedulingTerm{
+			Weight: 100,
+			Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+				{Key: zoneLabel, Operator: corev1.NodeSelectorOpIn, Values: []string{zone}},
+			}},
+		})
+	return preferred
+}
+
+// reportActivationZone records whether the scale up from zero of the given pool woke a ready replica in the
+// zone of the activator. It does nothing when the zone of the activator is unknown.
+func (a *Activator) reportActivationZone(logger logr.Logger, pool *v1.InferencePool) {
+	if a.Zone == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), zoneReportTimeout)
+	defer cancel()
+
+	zones, err := a.readyReplicaZones(ctx, pool)
+	if err != nil {
+		logger.V(logutil.DEBUG).Info("Error looking up the zones of the replicas woken up, skipping zone report", "error", err.Error())
+		return
+	}
+	if len(zones) == 0 {
+		return
+	}
+	sameZone := zones[a.Zone]
+	if !sameZone {
+		logger.V(logutil.DEBUG).Info("No replica woken up in the zone of the traffic", "zone", a.Zone, "replicaZones", zones)
+	}
+	metrics.RecordActivationZonePlacement(pool.Namespace+"/"+pool.Name, a.Zone, sameZone)
+}
+
+// readyReplicaZones returns the zones of the nodes running the ready pods of the given pool.
+func (a *Activator) readyReplicaZones(ctx context.Context, pool *v1.InferencePool) (map[string]bool, error) {
+	pods, err := a.DynamicClient.Resource(podGVR).Namespace(pool.Namespace).List(ctx, metav1.ListOptions{LabelSelector: poolPodSelector(pool)})
+	if err != nil {
+		return nil, err
+	}
+
+	zones := map[string]bool{}
+	nodes := map[string]bool{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		nodeName, _, _ := unstructured.NestedString(pod.Object, "spec", "nodeName")
+		if nodeName == "" || nodes[nodeName] || !podReady(pod) {
+			continue
+		}
+		nodes[nodeName] = true
+		node, err := a.DynamicClient.Resource(nodeGVR).Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		if zone, ok := node.GetLabels()[zoneLabel]; ok {
+			zones[zone] = true
+		}
+	}
+	return zones, nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requestcontrol
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	activatorv1alpha1 "github.com/llm-d-incubation/llm-d-activator/api/v1alpha1"
+)
+
+func TestWithZonePreference(t *testing.T) {
+	policyTerm := corev1.PreferredSchedulingTerm{Weight: 10, Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+		{Key: "gpu", Operator: corev1.NodeSelectorOpExists},
+	}}}
+
+	tests := []struct {
+		name      string
+		placement *activatorv1alpha1.PlacementSpec
+		wantTerms int
+	}{
+		{name: "no policy placement", placement: nil, wantTerms: 1},
+		{name: "policy placement without affinity", placement: &activatorv1alpha1.PlacementSpec{NodeSelector: map[string]string{"gpu": "a100"}}, wantTerms: 1},
+		{
+			name: "policy node affinity is kept",
+			placement: &activatorv1alpha1.PlacementSpec{Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{policyTerm},
+			}}},
+			wantTerms: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var original *activatorv1alpha1.PlacementSpec
+			if test.placement != nil {
+				original = test.placement.DeepCopy()
+			}
+
+			got := withZonePreference(test.placement, "us-east1-b")
+			terms := got.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+			if len(terms) != test.wantTerms {
+				t.Fatalf("preferred terms = %d, want %d", len(terms), test.wantTerms)
+			}
+			zoneTerm := terms[len(terms)-1].Preference.MatchExpressions[0]
+			if zoneTerm.Key != zoneLabel || len(zoneTerm.Values) != 1 || zoneTerm.Values[0] != "us-east1-b" {
+				t.Errorf("zone preference = %+v, want %s in us-east1-b", zoneTerm, zoneLabel)
+			}
+			if test.placement != nil && test.placement.NodeSelector != nil && got.NodeSelector["gpu"] != "a100" {
+				t.Errorf("node selector of the policy placement was dropped")
+			}
+			if original != nil && (original.Affinity == nil) != (test.placement.Affinity == nil) {
+				t.Errorf("withZonePreference() modified the policy placement")
+			}
+		})
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	v1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/common"
 
 	"github.com/llm-d-incubation/llm-d-activator/pkg/activator/controller"
@@ -53,7 +54,21 @@ type ExtProcServerRunner struct {
 	CertPath                         string
 	RefreshPrometheusMetricsInterval time.Duration
 	MetricsStalenessThreshold        time.Duration
-	Activator                        *requestcontrol.Activator
+	Director                         *requestcontrol.Director
+	// PoolDefaulter fills in the activator configuration the pool does not set on every reconcile, before it
+	// is validated. Optional.
+	PoolDefaulter func(ctx context.Context, pool *v1.InferencePool) error
+	// PoolValidator checks the activator configuration of the pool on every reconcile. Optional.
+	PoolValidator func(pool *v1.InferencePool) error
+	// PoolChecker verifies the activator configuration of the pool against the cluster on every reconcile. Optional.
+	PoolChecker func(ctx context.Context, pool *v1.InferencePool) error
+	// PoolObserver is called with the pool after every reconcile, or with nil once it is deleted. Optional.
+	PoolObserver func(ctx context.Context, pool *v1.InferencePool)
+	// PoolResync is the interval the pool is reconciled again at, e.g. to pick up the activator configuration
+	// its defaulter fills in from other objects. Optional.
+	PoolResync time.Duration
+	// Handoff is served along the ext_proc service, receiving the requests held by peer activator replicas. Optional.
+	Handoff *requestcontrol.RequestHandoff
 }
 
 // Default values for CLI flags in main
@@ -71,6 +86,10 @@ const (
 	DefaultCertPath                         = ""                            // default for --cert-path
 	DefaultPoolGroup                        = "inference.networking.k8s.io" // default for --pool-group
 	DefaultMetricsStalenessThreshold        = 2 * time.Second
+	DefaultPipelineMaxConcurrency           = requestcontrol.DefaultPipelineMaxConcurrency  // default for --pipeline-max-concurrency
+	DefaultPipelineRetryReserve             = requestcontrol.DefaultPipelineRetryReserve    // default for --pipeline-retry-reserve
+	DefaultBreakerFailureThreshold          = requestcontrol.DefaultBreakerFailureThreshold // default for --breaker-failure-threshold
+	DefaultBreakerCooldown                  = requestcontrol.DefaultBreakerCooldown         // default for --breaker-cooldown
 )
 
 // NewDefaultExtProcServerRunner creates a runner with default values.
@@ -102,6 +121,12 @@ func (r *ExtProcServerRunner) SetupWithManager(ctx context.Context, mgr ctrl.Man
 		Datastore: r.Datastore,
 		Reader:    mgr.GetClient(),
 		PoolGKNN:  r.PoolGKNN,
+		Default:   r.PoolDefaulter,
+		Validate:  r.PoolValidator,
+		Check:     r.PoolChecker,
+		Observe:   r.PoolObserver,
+		Resync:    r.PoolResync,
+		Recorder:  mgr.GetEventRecorderFor("activator"),
 	}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("failed setting up InferencePoolReconciler: %w", err)
 	}
@@ -137,8 +162,11 @@ func (r *ExtProcServerRunner) AsRunnable(logger logr.Logger) manager.Runnable {
 			srv = grpc.NewServer()
 		}
 
-		extProcServer := handlers.NewStreamingServer(r.Datastore, r.Activator)
+		extProcServer := handlers.NewStreamingServer(r.Datastore, r.Director)
 		extProcPb.RegisterExternalProcessorServer(srv, extProcServer)
+		if r.Handoff != nil {
+			r.Handoff.Register(srv)
+		}
 
 		if r.HealthChecking {
 			healthcheck := health.NewServer()